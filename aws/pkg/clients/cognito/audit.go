@@ -0,0 +1,64 @@
+package cognito
+
+import (
+	"context"
+	"errors"
+
+	"github.com/skolldire/go-engine/pkg/utilities/audit"
+)
+
+// Tipos de evento de auditoría emitidos por el cliente
+const (
+	EventAuthenticate             = "cognito.authenticate"
+	EventMFAChallenge             = "cognito.mfa_challenge"
+	EventForgotPassword           = "cognito.forgot_password"
+	EventPasswordChange           = "cognito.password_change"
+	EventAdminAddUserToGroup      = "cognito.admin_add_user_to_group"
+	EventAdminRemoveUserFromGroup = "cognito.admin_remove_user_from_group"
+)
+
+// Option configura dependencias opcionales del Client
+type Option func(*Client)
+
+// WithAuditor registra los eventos de seguridad (login, cambio de contraseña,
+// operaciones Admin) en el sink de auditoría, separado del logger de la aplicación.
+// Para operaciones Admin el actor se toma del contexto (audit.WithActor).
+func WithAuditor(auditor audit.Service) Option {
+	return func(c *Client) {
+		c.auditor = auditor
+	}
+}
+
+// audit emite el evento de auditoría de una operación.
+// CRÍTICO: nunca incluir passwords, códigos ni tokens; Reason es solo el código de error.
+func (c *Client) audit(ctx context.Context, eventType, actor, target string, err error, metadata map[string]interface{}) {
+	if c.auditor == nil {
+		return
+	}
+
+	event := audit.AuditEvent{
+		Type:     eventType,
+		Actor:    actor,
+		Target:   target,
+		Outcome:  audit.OutcomeSuccess,
+		Metadata: metadata,
+	}
+
+	if err != nil {
+		var mfaErr *MFARequiredError
+		var cognitoErr *CognitoError
+		switch {
+		case errors.As(err, &mfaErr):
+			event.Outcome = audit.OutcomeChallenge
+			event.Reason = string(mfaErr.ChallengeType)
+		case errors.As(err, &cognitoErr):
+			event.Outcome = audit.OutcomeFailure
+			event.Reason = cognitoErr.Code
+		default:
+			event.Outcome = audit.OutcomeFailure
+			event.Reason = "ClientError"
+		}
+	}
+
+	c.auditor.Audit(ctx, event)
+}
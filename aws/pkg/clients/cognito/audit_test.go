@@ -0,0 +1,84 @@
+package cognito
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/skolldire/go-engine/pkg/utilities/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditStubAPI overrides the calls exercised by the audit tests.
+type auditStubAPI struct {
+	cognitoAPI
+	initiateAuthErr error
+}
+
+func (s *auditStubAPI) InitiateAuth(_ context.Context, _ *cognitoidentityprovider.InitiateAuthInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.InitiateAuthOutput, error) {
+	return nil, s.initiateAuthErr
+}
+
+func (s *auditStubAPI) AdminAddUserToGroup(_ context.Context, _ *cognitoidentityprovider.AdminAddUserToGroupInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.AdminAddUserToGroupOutput, error) {
+	return &cognitoidentityprovider.AdminAddUserToGroupOutput{}, nil
+}
+
+func newAuditStubClient(api cognitoAPI, sink *bytes.Buffer) *Client {
+	client := newGroupsStubClient(api)
+	WithAuditor(audit.NewService(audit.Config{Sink: sink}))(client)
+	return client
+}
+
+func decodeAuditEvent(t *testing.T, sink *bytes.Buffer) audit.AuditEvent {
+	t.Helper()
+	var event audit.AuditEvent
+	require.NoError(t, json.Unmarshal(sink.Bytes(), &event))
+	return event
+}
+
+func TestAuthenticate_AuditsFailureWithoutSecrets(t *testing.T) {
+	var sink bytes.Buffer
+	api := &auditStubAPI{initiateAuthErr: &types.NotAuthorizedException{Message: aws.String("Incorrect username or password.")}}
+	client := newAuditStubClient(api, &sink)
+
+	_, err := client.Authenticate(context.Background(), AuthenticateRequest{
+		Username: "john",
+		Password: "SuperSecret1!",
+	})
+	require.Error(t, err)
+
+	event := decodeAuditEvent(t, &sink)
+	assert.Equal(t, EventAuthenticate, event.Type)
+	assert.Equal(t, "john", event.Actor)
+	assert.Equal(t, audit.OutcomeFailure, event.Outcome)
+	assert.Equal(t, "NotAuthorized", event.Reason)
+	assert.NotContains(t, sink.String(), "SuperSecret1!")
+}
+
+func TestAddUserToGroup_AuditsActorFromContext(t *testing.T) {
+	var sink bytes.Buffer
+	client := newAuditStubClient(&auditStubAPI{}, &sink)
+
+	ctx := audit.WithActor(context.Background(), "admin-1")
+	require.NoError(t, client.AddUserToGroup(ctx, "john", "admins"))
+
+	event := decodeAuditEvent(t, &sink)
+	assert.Equal(t, EventAdminAddUserToGroup, event.Type)
+	assert.Equal(t, "admin-1", event.Actor)
+	assert.Equal(t, "john", event.Target)
+	assert.Equal(t, audit.OutcomeSuccess, event.Outcome)
+	assert.Equal(t, "admins", event.Metadata["group"])
+}
+
+func TestAudit_WithoutAuditorIsNoop(t *testing.T) {
+	client := newGroupsStubClient(&auditStubAPI{})
+	assert.NotPanics(t, func() {
+		client.audit(context.Background(), EventAuthenticate, "john", "", errors.New("boom"), nil)
+	})
+}
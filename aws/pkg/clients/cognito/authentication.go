@@ -15,7 +15,7 @@ func (c *Client) RegisterUser(ctx context.Context, req RegisterUserRequest) (*Us
 		return nil, err
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "RegisterUser")
 	defer cancel()
 
 	attributes := []types.AttributeType{
@@ -47,7 +47,7 @@ func (c *Client) RegisterUser(ctx context.Context, req RegisterUserRequest) (*Us
 	}
 
 	var result *cognitoidentityprovider.SignUpOutput
-	_, err := c.executeOperation(ctx, "RegisterUser", func() (interface{}, error) {
+	_, err := c.executeOperation(ctx, "RegisterUser", func(ctx context.Context) (interface{}, error) {
 		var err error
 		result, err = c.cognitoClient.SignUp(ctx, input)
 		return result, err
@@ -90,7 +90,7 @@ func (c *Client) ConfirmSignUp(ctx context.Context, req ConfirmSignUpRequest) er
 		return ErrMissingRequiredField
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "ConfirmSignUp")
 	defer cancel()
 
 	input := &cognitoidentityprovider.ConfirmSignUpInput{
@@ -104,7 +104,7 @@ func (c *Client) ConfirmSignUp(ctx context.Context, req ConfirmSignUpRequest) er
 		input.SecretHash = aws.String(secretHash)
 	}
 
-	_, err := c.executeOperation(ctx, "ConfirmSignUp", func() (interface{}, error) {
+	_, err := c.executeOperation(ctx, "ConfirmSignUp", func(ctx context.Context) (interface{}, error) {
 		return c.cognitoClient.ConfirmSignUp(ctx, input)
 	})
 
@@ -125,11 +125,18 @@ func (c *Client) ConfirmSignUp(ctx context.Context, req ConfirmSignUpRequest) er
 // Authenticate autentica un usuario y obtiene tokens JWT.
 // Si MFA está activado, retorna MFARequiredError (usar RespondToMFAChallenge)
 func (c *Client) Authenticate(ctx context.Context, req AuthenticateRequest) (*AuthTokens, error) {
+	tokens, err := c.authenticate(ctx, req)
+	c.logChallengeIssued(ctx, req.Username, err)
+	c.audit(ctx, EventAuthenticate, req.Username, "", err, nil)
+	return tokens, err
+}
+
+func (c *Client) authenticate(ctx context.Context, req AuthenticateRequest) (*AuthTokens, error) {
 	if err := validateAuthenticateRequest(req); err != nil {
 		return nil, err
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "Authenticate")
 	defer cancel()
 
 	authParams := map[string]string{
@@ -149,7 +156,7 @@ func (c *Client) Authenticate(ctx context.Context, req AuthenticateRequest) (*Au
 	}
 
 	var result *cognitoidentityprovider.InitiateAuthOutput
-	_, err := c.executeOperation(ctx, "Authenticate", func() (interface{}, error) {
+	_, err := c.executeOperation(ctx, "Authenticate", func(ctx context.Context) (interface{}, error) {
 		var err error
 		result, err = c.cognitoClient.InitiateAuth(ctx, input)
 		return result, err
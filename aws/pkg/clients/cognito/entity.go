@@ -3,8 +3,10 @@ package cognito
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/skolldire/go-engine/pkg/core/auth"
 	"github.com/skolldire/go-engine/pkg/utilities/resilience"
 )
 
@@ -14,6 +16,10 @@ import (
 // usando sintaxis ${COGNITO_CLIENT_SECRET} en YAML. El secret se encapsula
 // en el cliente y nunca se expone públicamente.
 type Config struct {
+	// Enabled en false deja el cliente configurado pero sin construir ni validar;
+	// nil (ausente en el YAML) equivale a true
+	Enabled *bool `mapstructure:"enabled" json:"enabled,omitempty"`
+
 	// AWS Configuration
 	Region       string `mapstructure:"region" json:"region"`
 	UserPoolID   string `mapstructure:"user_pool_id" json:"user_pool_id"`
@@ -31,12 +37,33 @@ type Config struct {
 	Timeout      time.Duration `mapstructure:"timeout" json:"timeout"`
 	MaxRetries   int           `mapstructure:"max_retries" json:"max_retries"`
 	RetryBackoff time.Duration `mapstructure:"retry_backoff" json:"retry_backoff"`
+	// OperationTimeouts sobrescribe Timeout por operación, con la clave del nombre de
+	// la operación (ej. "ValidateToken", "RegisterUser", "Authenticate")
+	OperationTimeouts map[string]time.Duration `mapstructure:"operation_timeouts" json:"operation_timeouts"`
 
 	// Feature Flags
 	EnableLogging  bool `mapstructure:"enable_logging" json:"enable_logging"`
 	WithResilience bool `mapstructure:"with_resilience" json:"with_resilience"`
 }
 
+// IsEnabled indica si el cliente debe construirse; true salvo enabled: false explícito
+func (c Config) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// WithDefaults retorna una copia de c con los valores por defecto aplicados:
+// Timeout (DefaultTimeout) y JWKSUrl (endpoint JWKS del user pool en la región).
+func (c Config) WithDefaults() Config {
+	if c.Timeout == 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.JWKSUrl == "" && c.Region != "" && c.UserPoolID != "" {
+		c.JWKSUrl = fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s/.well-known/jwks.json",
+			c.Region, c.UserPoolID)
+	}
+	return c
+}
+
 // User representa un usuario de Cognito
 type User struct {
 	ID            string            `json:"id"`
@@ -93,6 +120,23 @@ type TokenClaims struct {
 	TokenUse string `json:"token_use"` // "id", "access", "refresh"
 }
 
+// AuthClaims convierte los claims de Cognito a los claims neutrales de auth
+func (t *TokenClaims) AuthClaims() *auth.Claims {
+	return &auth.Claims{
+		Sub:           t.Sub,
+		Email:         t.Email,
+		EmailVerified: t.EmailVerified,
+		Username:      t.Username,
+		Groups:        t.Groups,
+		CustomClaims:  t.CustomClaims,
+		Iss:           t.Iss,
+		Aud:           t.Aud,
+		Exp:           t.Exp,
+		Iat:           t.Iat,
+		TokenUse:      t.TokenUse,
+	}
+}
+
 // MFAChallengeType representa el tipo de desafío MFA
 type MFAChallengeType string
 
@@ -12,6 +12,12 @@ import (
 // Mapea AdminAddUserToGroup; el UserPoolID se toma de la Config del cliente.
 // El grupo de Cognito modela el rol del usuario.
 func (c *Client) AddUserToGroup(ctx context.Context, username, group string) error {
+	err := c.addUserToGroup(ctx, username, group)
+	c.audit(ctx, EventAdminAddUserToGroup, "", username, err, map[string]interface{}{"group": group})
+	return err
+}
+
+func (c *Client) addUserToGroup(ctx context.Context, username, group string) error {
 	if username == "" {
 		return ErrMissingRequiredField
 	}
@@ -19,7 +25,7 @@ func (c *Client) AddUserToGroup(ctx context.Context, username, group string) err
 		return ErrMissingRequiredField
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "AddUserToGroup")
 	defer cancel()
 
 	input := &cognitoidentityprovider.AdminAddUserToGroupInput{
@@ -28,7 +34,7 @@ func (c *Client) AddUserToGroup(ctx context.Context, username, group string) err
 		GroupName:  aws.String(group),
 	}
 
-	_, err := c.executeOperation(ctx, "AddUserToGroup", func() (interface{}, error) {
+	_, err := c.executeOperation(ctx, "AddUserToGroup", func(ctx context.Context) (interface{}, error) {
 		return c.cognitoClient.AdminAddUserToGroup(ctx, input)
 	})
 
@@ -51,6 +57,12 @@ func (c *Client) AddUserToGroup(ctx context.Context, username, group string) err
 // RemoveUserFromGroup quita un usuario de un grupo del User Pool.
 // Mapea AdminRemoveUserFromGroup; el UserPoolID se toma de la Config del cliente.
 func (c *Client) RemoveUserFromGroup(ctx context.Context, username, group string) error {
+	err := c.removeUserFromGroup(ctx, username, group)
+	c.audit(ctx, EventAdminRemoveUserFromGroup, "", username, err, map[string]interface{}{"group": group})
+	return err
+}
+
+func (c *Client) removeUserFromGroup(ctx context.Context, username, group string) error {
 	if username == "" {
 		return ErrMissingRequiredField
 	}
@@ -58,7 +70,7 @@ func (c *Client) RemoveUserFromGroup(ctx context.Context, username, group string
 		return ErrMissingRequiredField
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "RemoveUserFromGroup")
 	defer cancel()
 
 	input := &cognitoidentityprovider.AdminRemoveUserFromGroupInput{
@@ -67,7 +79,7 @@ func (c *Client) RemoveUserFromGroup(ctx context.Context, username, group string
 		GroupName:  aws.String(group),
 	}
 
-	_, err := c.executeOperation(ctx, "RemoveUserFromGroup", func() (interface{}, error) {
+	_, err := c.executeOperation(ctx, "RemoveUserFromGroup", func(ctx context.Context) (interface{}, error) {
 		return c.cognitoClient.AdminRemoveUserFromGroup(ctx, input)
 	})
 
@@ -94,7 +106,7 @@ func (c *Client) ListGroupsForUser(ctx context.Context, username string) ([]stri
 		return nil, ErrMissingRequiredField
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "ListGroupsForUser")
 	defer cancel()
 
 	var groups []string
@@ -107,7 +119,7 @@ func (c *Client) ListGroupsForUser(ctx context.Context, username string) ([]stri
 			NextToken:  nextToken,
 		}
 
-		result, err := c.executeOperation(ctx, "ListGroupsForUser", func() (interface{}, error) {
+		result, err := c.executeOperation(ctx, "ListGroupsForUser", func(ctx context.Context) (interface{}, error) {
 			return c.cognitoClient.AdminListGroupsForUser(ctx, input)
 		})
 
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/skolldire/go-engine/pkg/utilities/cache"
 )
 
 const (
@@ -17,69 +18,80 @@ const (
 	DefaultJWKSRefreshThreshold = 10 * time.Minute
 )
 
+// jwksCacheKey es la única entrada del cache: el set completo de claves del endpoint
+const jwksCacheKey = "jwks"
+
 // JWKSClient maneja la obtención y cache de claves públicas JWKS de Cognito
 type JWKSClient struct {
 	url              string
-	cache            map[string]*rsa.PublicKey
+	cache            *cache.Cache[string, map[string]*rsa.PublicKey]
 	cacheTTL         time.Duration
-	lastFetch        time.Time
-	mu               sync.RWMutex
 	refreshThreshold time.Duration
+
+	// lastKeys conserva el último set obtenido para usarlo si el endpoint falla
+	mu       sync.RWMutex
+	lastKeys map[string]*rsa.PublicKey
 }
 
 // NewJWKSClient crea un nuevo cliente JWKS
 func NewJWKSClient(url string) *JWKSClient {
 	return &JWKSClient{
 		url:              url,
-		cache:            make(map[string]*rsa.PublicKey),
+		cache:            cache.New[string, map[string]*rsa.PublicKey](cache.Config{}),
 		cacheTTL:         DefaultJWKSCacheTTL,
 		refreshThreshold: DefaultJWKSRefreshThreshold,
 	}
 }
 
 // GetKey obtiene una clave pública por su Key ID (kid)
-// Implementa cache con refresh automático antes de expirar
+// Implementa cache con refresh automático antes de expirar; las obtenciones concurrentes
+// del endpoint se deduplican en una sola petición.
 func (c *JWKSClient) GetKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
-	c.mu.RLock()
-	key, exists := c.cache[kid]
-	needsRefresh := c.shouldRefresh()
-	c.mu.RUnlock()
-
-	// Si la clave existe y no necesita refresh, retornarla
-	if exists && !needsRefresh {
-		return key, nil
+	keys, err := c.keys(ctx)
+	if err != nil {
+		return nil, err
 	}
-
-	// Si necesita refresh o no existe, obtener desde el endpoint
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	// Double-check: otro goroutine pudo haber actualizado el cache
-	if key, exists := c.cache[kid]; exists && !c.shouldRefresh() {
+	if key, exists := keys[kid]; exists {
 		return key, nil
 	}
 
-	// Obtener claves desde el endpoint JWKS
-	keys, err := c.fetchKeys(ctx)
+	// kid desconocido: las claves pudieron rotar, forzar una nueva obtención
+	c.cache.Delete(jwksCacheKey)
+	keys, err = c.keys(ctx)
 	if err != nil {
-		// Si falla pero tenemos una clave en cache, usar la cacheada
-		if cachedKey, exists := c.cache[kid]; exists {
-			return cachedKey, nil
-		}
-		return nil, fmt.Errorf("failed to fetch JWKS keys: %w", err)
+		return nil, err
+	}
+	if key, exists := keys[kid]; exists {
+		return key, nil
 	}
+	return nil, fmt.Errorf("key with kid '%s' not found in JWKS", kid)
+}
 
-	// Actualizar cache
-	c.cache = keys
-	c.lastFetch = time.Now()
+// keys retorna el set de claves cacheado, obteniéndolo del endpoint si expiró
+func (c *JWKSClient) keys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	keys, err := c.cache.GetOrLoad(ctx, jwksCacheKey, c.cacheTTL-c.refreshThreshold, c.loadKeys)
+	if err == nil {
+		return keys, nil
+	}
 
-	// Retornar la clave solicitada
-	foundKey, exists := c.cache[kid]
-	if !exists {
-		return nil, fmt.Errorf("key with kid '%s' not found in JWKS", kid)
+	// Si falla pero tenemos claves previas, usar las cacheadas
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.lastKeys != nil {
+		return c.lastKeys, nil
 	}
+	return nil, fmt.Errorf("failed to fetch JWKS keys: %w", err)
+}
 
-	return foundKey, nil
+func (c *JWKSClient) loadKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	keys, err := c.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.lastKeys = keys
+	c.mu.Unlock()
+	return keys, nil
 }
 
 // fetchKeys obtiene las claves desde el endpoint JWKS de Cognito
@@ -121,19 +133,10 @@ func (c *JWKSClient) fetchKeys(ctx context.Context) (map[string]*rsa.PublicKey,
 	return keys, nil
 }
 
-// shouldRefresh determina si el cache necesita ser refrescado
-func (c *JWKSClient) shouldRefresh() bool {
-	if c.lastFetch.IsZero() {
-		return true
-	}
-	elapsed := time.Since(c.lastFetch)
-	return elapsed >= (c.cacheTTL - c.refreshThreshold)
-}
-
 // ClearCache limpia el cache de claves (útil para testing)
 func (c *JWKSClient) ClearCache() {
+	c.cache.Clear()
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.cache = make(map[string]*rsa.PublicKey)
-	c.lastFetch = time.Time{}
+	c.lastKeys = nil
 }
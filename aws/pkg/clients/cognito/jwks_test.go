@@ -2,10 +2,18 @@ package cognito
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
-	"time"
 
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewJWKSClient(t *testing.T) {
@@ -23,36 +31,62 @@ func TestJWKSClient_ClearCache(t *testing.T) {
 	client := NewJWKSClient("https://test.com/jwks.json")
 
 	// Agregar algo al cache manualmente (para testing)
-	client.mu.Lock()
-	client.cache["test-kid"] = nil
-	client.lastFetch = time.Now()
-	client.mu.Unlock()
+	client.cache.Set(jwksCacheKey, map[string]*rsa.PublicKey{"test-kid": nil}, 0)
+	client.lastKeys = map[string]*rsa.PublicKey{"test-kid": nil}
 
 	client.ClearCache()
 
-	client.mu.RLock()
-	assert.Empty(t, client.cache)
-	assert.True(t, client.lastFetch.IsZero())
-	client.mu.RUnlock()
+	assert.Equal(t, 0, client.cache.Len())
+	assert.Nil(t, client.lastKeys)
 }
 
-func TestJWKSClient_shouldRefresh(t *testing.T) {
-	client := NewJWKSClient("https://test.com/jwks.json")
+func TestJWKSClient_GetKey_DeduplicatesConcurrentFetches(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pub, err := jwk.FromRaw(&priv.PublicKey)
+	require.NoError(t, err)
+	require.NoError(t, pub.Set(jwk.KeyIDKey, "kid-1"))
+	set := jwk.NewSet()
+	require.NoError(t, set.AddKey(pub))
+	body, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
 
-	// Sin fetch previo, debe necesitar refresh
-	assert.True(t, client.shouldRefresh())
+	client := NewJWKSClient(server.URL)
 
-	// Con fetch reciente, no debe necesitar refresh
-	client.mu.Lock()
-	client.lastFetch = time.Now()
-	client.mu.Unlock()
-	assert.False(t, client.shouldRefresh())
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key, err := client.GetKey(context.Background(), "kid-1")
+			assert.NoError(t, err)
+			if assert.NotNil(t, key) {
+				assert.Equal(t, priv.PublicKey.N, key.N)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	// Un kid desconocido fuerza una nueva obtención (rotación de claves)
+	_, err = client.GetKey(context.Background(), "kid-unknown")
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
 
-	// Con fetch antiguo (más allá del threshold), debe necesitar refresh
-	client.mu.Lock()
-	client.lastFetch = time.Now().Add(-(DefaultJWKSCacheTTL - DefaultJWKSRefreshThreshold + time.Minute))
-	client.mu.Unlock()
-	assert.True(t, client.shouldRefresh())
+	// Si el endpoint falla, se usan las últimas claves obtenidas
+	server.Close()
+	client.cache.Clear()
+	key, err := client.GetKey(context.Background(), "kid-1")
+	assert.NoError(t, err)
+	assert.NotNil(t, key)
 }
 
 func TestJWKSClient_GetKey_WithoutRealEndpoint(t *testing.T) {
@@ -14,11 +14,56 @@ import (
 )
 
 func (c *Client) RespondToMFAChallenge(ctx context.Context, req MFAChallengeRequest) (*AuthTokens, error) {
+	tokens, err := c.respondToMFAChallenge(ctx, req)
+	c.logChallengeResponse(ctx, req, err)
+	c.audit(ctx, EventMFAChallenge, req.Username, "", err, nil)
+	return tokens, err
+}
+
+// logChallengeIssued registra en Info que Authenticate devolvió un challenge MFA.
+// CRÍTICO: solo username y tipo de challenge; nunca el session token.
+func (c *Client) logChallengeIssued(ctx context.Context, username string, err error) {
+	var mfaErr *MFARequiredError
+	if !c.logging || !errors.As(err, &mfaErr) {
+		return
+	}
+
+	c.logger.Info(ctx, "MFA challenge issued", map[string]interface{}{
+		"username":       username,
+		"challenge_type": string(mfaErr.ChallengeType),
+	})
+}
+
+// logChallengeResponse registra en Info el resultado de RespondToMFAChallenge.
+// CRÍTICO: nunca incluir el código MFA ni el session token; en error solo el código Cognito.
+func (c *Client) logChallengeResponse(ctx context.Context, req MFAChallengeRequest, err error) {
+	if !c.logging {
+		return
+	}
+
+	fields := map[string]interface{}{
+		"username":       req.Username,
+		"challenge_type": string(req.ChallengeType),
+	}
+	if err == nil {
+		c.logger.Info(ctx, "MFA challenge completed successfully", fields)
+		return
+	}
+
+	fields["reason"] = "ClientError"
+	var cognitoErr *CognitoError
+	if errors.As(err, &cognitoErr) {
+		fields["reason"] = cognitoErr.Code
+	}
+	c.logger.Info(ctx, "MFA challenge failed", fields)
+}
+
+func (c *Client) respondToMFAChallenge(ctx context.Context, req MFAChallengeRequest) (*AuthTokens, error) {
 	if err := validateMFAChallengeRequest(req); err != nil {
 		return nil, err
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "RespondToMFAChallenge")
 	defer cancel()
 
 	challengeParams := map[string]string{
@@ -46,7 +91,7 @@ func (c *Client) RespondToMFAChallenge(ctx context.Context, req MFAChallengeRequ
 	}
 
 	var result *cognitoidentityprovider.RespondToAuthChallengeOutput
-	_, err := c.executeOperation(ctx, "RespondToMFAChallenge", func() (interface{}, error) {
+	_, err := c.executeOperation(ctx, "RespondToMFAChallenge", func(ctx context.Context) (interface{}, error) {
 		var err error
 		result, err = c.cognitoClient.RespondToAuthChallenge(ctx, input)
 		return result, err
@@ -82,13 +127,6 @@ func (c *Client) RespondToMFAChallenge(ctx context.Context, req MFAChallengeRequ
 		ExpiresIn:    int64(result.AuthenticationResult.ExpiresIn),
 	}
 
-	if c.logging {
-		c.logger.Info(ctx, "MFA challenge completed successfully",
-			map[string]interface{}{
-				"challenge_type": req.ChallengeType,
-			})
-	}
-
 	return tokens, nil
 }
 
@@ -102,7 +140,7 @@ func (c *Client) AssociateSoftwareToken(ctx context.Context, accessToken string)
 		return nil, ErrInvalidToken
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "AssociateSoftwareToken")
 	defer cancel()
 
 	input := &cognitoidentityprovider.AssociateSoftwareTokenInput{
@@ -110,7 +148,7 @@ func (c *Client) AssociateSoftwareToken(ctx context.Context, accessToken string)
 	}
 
 	var result *cognitoidentityprovider.AssociateSoftwareTokenOutput
-	_, err = c.executeOperation(ctx, "AssociateSoftwareToken", func() (interface{}, error) {
+	_, err = c.executeOperation(ctx, "AssociateSoftwareToken", func(ctx context.Context) (interface{}, error) {
 		var err error
 		result, err = c.cognitoClient.AssociateSoftwareToken(ctx, input)
 		return result, err
@@ -184,7 +222,7 @@ func (c *Client) VerifySoftwareToken(ctx context.Context, accessToken, userCode,
 		}
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "VerifySoftwareToken")
 	defer cancel()
 
 	input := &cognitoidentityprovider.VerifySoftwareTokenInput{
@@ -198,7 +236,7 @@ func (c *Client) VerifySoftwareToken(ctx context.Context, accessToken, userCode,
 		input.Session = aws.String(session)
 	}
 
-	_, err := c.executeOperation(ctx, "VerifySoftwareToken", func() (interface{}, error) {
+	_, err := c.executeOperation(ctx, "VerifySoftwareToken", func(ctx context.Context) (interface{}, error) {
 		return c.cognitoClient.VerifySoftwareToken(ctx, input)
 	})
 
@@ -240,7 +278,7 @@ func (c *Client) SetUserMFAPreference(ctx context.Context, accessToken string, s
 		return ErrInvalidToken
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "SetUserMFAPreference")
 	defer cancel()
 
 	preferredSMS := smsEnabled && !totpEnabled
@@ -262,7 +300,7 @@ func (c *Client) SetUserMFAPreference(ctx context.Context, accessToken string, s
 		},
 	}
 
-	_, err = c.executeOperation(ctx, "SetUserMFAPreference", func() (interface{}, error) {
+	_, err = c.executeOperation(ctx, "SetUserMFAPreference", func(ctx context.Context) (interface{}, error) {
 		return c.cognitoClient.SetUserMFAPreference(ctx, input)
 	})
 
@@ -297,7 +335,7 @@ func (c *Client) GetUserMFAStatus(ctx context.Context, accessToken string) (*MFA
 		return nil, ErrInvalidToken
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "GetUserMFAStatus")
 	defer cancel()
 
 	input := &cognitoidentityprovider.GetUserInput{
@@ -305,7 +343,7 @@ func (c *Client) GetUserMFAStatus(ctx context.Context, accessToken string) (*MFA
 	}
 
 	var result *cognitoidentityprovider.GetUserOutput
-	_, err = c.executeOperation(ctx, "GetUserMFAStatus", func() (interface{}, error) {
+	_, err = c.executeOperation(ctx, "GetUserMFAStatus", func(ctx context.Context) (interface{}, error) {
 		var err error
 		result, err = c.cognitoClient.GetUser(ctx, input)
 		return result, err
@@ -2,11 +2,98 @@ package cognito
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
+// challengeStubAPI issues a software-token challenge on InitiateAuth and answers
+// RespondToAuthChallenge with respondErr
+type challengeStubAPI struct {
+	cognitoAPI
+	respondErr error
+}
+
+func (s *challengeStubAPI) InitiateAuth(_ context.Context, _ *cognitoidentityprovider.InitiateAuthInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.InitiateAuthOutput, error) {
+	return &cognitoidentityprovider.InitiateAuthOutput{
+		ChallengeName: types.ChallengeNameTypeSoftwareTokenMfa,
+		Session:       aws.String("secret-session-token"),
+	}, nil
+}
+
+func (s *challengeStubAPI) RespondToAuthChallenge(_ context.Context, _ *cognitoidentityprovider.RespondToAuthChallengeInput, _ ...func(*cognitoidentityprovider.Options)) (*cognitoidentityprovider.RespondToAuthChallengeOutput, error) {
+	return nil, s.respondErr
+}
+
+// newChallengeLogClient returns a client with logging enabled and the Info entries it emits
+func newChallengeLogClient(api cognitoAPI) (*Client, *[]map[string]interface{}, *[]string) {
+	log := &mockLogger{}
+	var fields []map[string]interface{}
+	var messages []string
+	log.On("Debug", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	log.On("Error", mock.Anything, mock.Anything, mock.Anything).Maybe()
+	log.On("Info", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		messages = append(messages, args.String(1))
+		fields = append(fields, args.Get(2).(map[string]interface{}))
+	}).Maybe()
+
+	client := newGroupsStubClient(api)
+	client.logger = log
+	client.logging = true
+	return client, &fields, &messages
+}
+
+func TestClient_Authenticate_LogsChallengeIssued(t *testing.T) {
+	client, fields, messages := newChallengeLogClient(&challengeStubAPI{})
+
+	_, err := client.Authenticate(context.Background(), AuthenticateRequest{Username: "john", Password: "SuperSecret1!"})
+
+	var mfaErr *MFARequiredError
+	require.ErrorAs(t, err, &mfaErr)
+	require.Equal(t, []string{"MFA challenge issued"}, *messages)
+	assert.Equal(t, map[string]interface{}{
+		"username":       "john",
+		"challenge_type": string(MFAChallengeTypeSoftwareToken),
+	}, (*fields)[0])
+	assert.NotContains(t, fmt.Sprint(*fields), "secret-session-token")
+}
+
+func TestClient_RespondToMFAChallenge_LogsFailureWithoutSecrets(t *testing.T) {
+	api := &challengeStubAPI{respondErr: &types.CodeMismatchException{Message: aws.String("Invalid code received for user")}}
+	client, fields, messages := newChallengeLogClient(api)
+
+	_, err := client.RespondToMFAChallenge(context.Background(), MFAChallengeRequest{
+		Username:      "john",
+		SessionToken:  "secret-session-token",
+		MFACode:       "123456",
+		ChallengeType: MFAChallengeTypeSoftwareToken,
+	})
+
+	require.Error(t, err)
+	require.Equal(t, []string{"MFA challenge failed"}, *messages)
+	assert.Equal(t, "john", (*fields)[0]["username"])
+	assert.Equal(t, string(MFAChallengeTypeSoftwareToken), (*fields)[0]["challenge_type"])
+	assert.NotEmpty(t, (*fields)[0]["reason"])
+	assert.NotContains(t, fmt.Sprint(*fields), "secret-session-token")
+	assert.NotContains(t, fmt.Sprint(*fields), "123456")
+}
+
+func TestClient_Authenticate_NoChallengeLogWhenLoggingDisabled(t *testing.T) {
+	client, _, messages := newChallengeLogClient(&challengeStubAPI{})
+	client.logging = false
+
+	_, err := client.Authenticate(context.Background(), AuthenticateRequest{Username: "john", Password: "SuperSecret1!"})
+
+	require.Error(t, err)
+	assert.Empty(t, *messages)
+}
+
 func TestClient_AssociateSoftwareToken_InvalidToken(t *testing.T) {
 	cfg := Config{
 		Region:        "us-east-1",
@@ -8,11 +8,17 @@ import (
 )
 
 func (c *Client) ForgotPassword(ctx context.Context, req ForgotPasswordRequest) error {
+	err := c.forgotPassword(ctx, req)
+	c.audit(ctx, EventForgotPassword, req.Username, "", err, nil)
+	return err
+}
+
+func (c *Client) forgotPassword(ctx context.Context, req ForgotPasswordRequest) error {
 	if req.Username == "" {
 		return ErrMissingRequiredField
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "ForgotPassword")
 	defer cancel()
 
 	input := &cognitoidentityprovider.ForgotPasswordInput{
@@ -25,7 +31,7 @@ func (c *Client) ForgotPassword(ctx context.Context, req ForgotPasswordRequest)
 		input.SecretHash = aws.String(secretHash)
 	}
 
-	_, err := c.executeOperation(ctx, "ForgotPassword", func() (interface{}, error) {
+	_, err := c.executeOperation(ctx, "ForgotPassword", func(ctx context.Context) (interface{}, error) {
 		return c.cognitoClient.ForgotPassword(ctx, input)
 	})
 
@@ -44,11 +50,17 @@ func (c *Client) ForgotPassword(ctx context.Context, req ForgotPasswordRequest)
 }
 
 func (c *Client) ConfirmForgotPassword(ctx context.Context, req ConfirmForgotPasswordRequest) error {
+	err := c.confirmForgotPassword(ctx, req)
+	c.audit(ctx, EventPasswordChange, req.Username, "", err, nil)
+	return err
+}
+
+func (c *Client) confirmForgotPassword(ctx context.Context, req ConfirmForgotPasswordRequest) error {
 	if req.Username == "" || req.ConfirmationCode == "" || req.NewPassword == "" {
 		return ErrMissingRequiredField
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "ConfirmForgotPassword")
 	defer cancel()
 
 	input := &cognitoidentityprovider.ConfirmForgotPasswordInput{
@@ -63,7 +75,7 @@ func (c *Client) ConfirmForgotPassword(ctx context.Context, req ConfirmForgotPas
 		input.SecretHash = aws.String(secretHash)
 	}
 
-	_, err := c.executeOperation(ctx, "ConfirmForgotPassword", func() (interface{}, error) {
+	_, err := c.executeOperation(ctx, "ConfirmForgotPassword", func(ctx context.Context) (interface{}, error) {
 		return c.cognitoClient.ConfirmForgotPassword(ctx, input)
 	})
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/skolldire/go-engine/pkg/utilities/audit"
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
 	"github.com/skolldire/go-engine/pkg/utilities/resilience"
 )
@@ -46,16 +47,20 @@ type Client struct {
 	jwksClient    *JWKSClient
 	logger        logger.Service
 	resilience    *resilience.Service
+	auditor       audit.Service
 	logging       bool
 }
 
 // NewClient crea una nueva instancia del cliente Cognito
 // CRÍTICO: Manejo seguro del secret - se copia a campo privado y se limpia de Config
-func NewClient(cfg Config, log logger.Service) (Service, error) {
+func NewClient(cfg Config, log logger.Service, opts ...Option) (Service, error) {
+	log = logger.OrNoop(log)
+
 	if err := validateConfig(cfg); err != nil {
 		return nil, fmt.Errorf("invalid cognito config: %w", err)
 	}
 
+	cfg = cfg.WithDefaults()
 	clientSecret := cfg.ClientSecret
 	cfg.ClientSecret = ""
 
@@ -68,13 +73,7 @@ func NewClient(cfg Config, log logger.Service) (Service, error) {
 
 	cognitoClient := cognitoidentityprovider.NewFromConfig(awsCfg)
 
-	jwksURL := cfg.JWKSUrl
-	if jwksURL == "" {
-		jwksURL = fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s/.well-known/jwks.json",
-			cfg.Region, cfg.UserPoolID)
-	}
-
-	jwksClient := NewJWKSClient(jwksURL)
+	jwksClient := NewJWKSClient(cfg.JWKSUrl)
 
 	var resilienceSvc *resilience.Service
 	if cfg.WithResilience {
@@ -91,6 +90,10 @@ func NewClient(cfg Config, log logger.Service) (Service, error) {
 		logging:       cfg.EnableLogging,
 	}
 
+	for _, opt := range opts {
+		opt(client)
+	}
+
 	if client.logging {
 		logFields := map[string]interface{}{
 			"user_pool_id": cfg.UserPoolID,
@@ -108,20 +111,29 @@ func NewClient(cfg Config, log logger.Service) (Service, error) {
 	return client, nil
 }
 
-func (c *Client) ensureContextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
-	timeout := c.config.Timeout
-	if timeout == 0 {
-		timeout = DefaultTimeout
-	}
-
+// ensureContextWithTimeout aplica el timeout de operationName (ver operationTimeout)
+// cuando ctx no tiene deadline; un deadline existente se respeta tal cual.
+func (c *Client) ensureContextWithTimeout(ctx context.Context, operationName string) (context.Context, context.CancelFunc) {
 	if _, hasDeadline := ctx.Deadline(); !hasDeadline {
-		return context.WithTimeout(ctx, timeout)
+		return context.WithTimeout(ctx, c.operationTimeout(operationName))
 	}
 	return context.WithCancel(ctx)
 }
 
+// operationTimeout retorna Config.OperationTimeouts[operationName] si está definido,
+// o Config.Timeout (DefaultTimeout si es cero) en caso contrario.
+func (c *Client) operationTimeout(operationName string) time.Duration {
+	if timeout, ok := c.config.OperationTimeouts[operationName]; ok && timeout > 0 {
+		return timeout
+	}
+	if c.config.Timeout == 0 {
+		return DefaultTimeout
+	}
+	return c.config.Timeout
+}
+
 func (c *Client) executeOperation(ctx context.Context, operationName string,
-	operation func() (interface{}, error)) (interface{}, error) {
+	operation func(ctx context.Context) (interface{}, error)) (interface{}, error) {
 	logFields := map[string]interface{}{
 		"operation": operationName,
 		"service":   "Cognito",
@@ -135,12 +147,12 @@ func (c *Client) executeOperation(ctx context.Context, operationName string,
 }
 
 func (c *Client) executeWithResilience(ctx context.Context, operationName string,
-	operation func() (interface{}, error), logFields map[string]interface{}) (interface{}, error) {
+	operation func(ctx context.Context) (interface{}, error), logFields map[string]interface{}) (interface{}, error) {
 	if c.logging {
 		c.logger.Debug(ctx, fmt.Sprintf("starting Cognito operation with resilience: %s", operationName), logFields)
 	}
 
-	result, err := c.resilience.Execute(ctx, operation)
+	result, err := c.resilience.ExecuteNamedContext(ctx, operationName, operation)
 
 	if err != nil && c.logging {
 		c.logger.Error(ctx, err, logFields)
@@ -152,12 +164,12 @@ func (c *Client) executeWithResilience(ctx context.Context, operationName string
 }
 
 func (c *Client) executeWithLogging(ctx context.Context, operationName string,
-	operation func() (interface{}, error), logFields map[string]interface{}) (interface{}, error) {
+	operation func(ctx context.Context) (interface{}, error), logFields map[string]interface{}) (interface{}, error) {
 	if c.logging {
 		c.logger.Debug(ctx, fmt.Sprintf("starting Cognito operation: %s", operationName), logFields)
 	}
 
-	result, err := operation()
+	result, err := operation(ctx)
 
 	if err != nil && c.logging {
 		c.logger.Error(ctx, err, logFields)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
@@ -62,6 +63,55 @@ func TestNewClient(t *testing.T) {
 	assert.IsType(t, &Client{}, client)
 }
 
+func TestConfig_WithDefaults(t *testing.T) {
+	cfg := Config{Region: "us-east-1", UserPoolID: "us-east-1_TestPool123"}.WithDefaults()
+
+	assert.Equal(t, DefaultTimeout, cfg.Timeout)
+	assert.Equal(t, "https://cognito-idp.us-east-1.amazonaws.com/us-east-1_TestPool123/.well-known/jwks.json", cfg.JWKSUrl)
+
+	// Los valores explícitos se preservan
+	custom := Config{Region: "us-east-1", UserPoolID: "p", Timeout: 5 * time.Second, JWKSUrl: "https://keys.example.com"}.WithDefaults()
+	assert.Equal(t, 5*time.Second, custom.Timeout)
+	assert.Equal(t, "https://keys.example.com", custom.JWKSUrl)
+}
+
+func TestClient_EnsureContextWithTimeout_OperationOverride(t *testing.T) {
+	c := &Client{config: Config{
+		Timeout:           30 * time.Second,
+		OperationTimeouts: map[string]time.Duration{"ValidateToken": 2 * time.Second},
+	}}
+
+	assertTimeout := func(operationName string, want time.Duration) {
+		t.Helper()
+		start := time.Now()
+		ctx, cancel := c.ensureContextWithTimeout(context.Background(), operationName)
+		defer cancel()
+
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, start.Add(want), deadline, time.Second)
+	}
+
+	assertTimeout("ValidateToken", 2*time.Second)
+	assertTimeout("RegisterUser", 30*time.Second)
+
+	// Un deadline del caller siempre tiene prioridad sobre el override
+	parent, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	ctx, cancelOp := c.ensureContextWithTimeout(parent, "ValidateToken")
+	defer cancelOp()
+	parentDeadline, _ := parent.Deadline()
+	deadline, _ := ctx.Deadline()
+	assert.Equal(t, parentDeadline, deadline)
+}
+
+func TestClient_OperationTimeout_DefaultsToDefaultTimeout(t *testing.T) {
+	c := &Client{config: Config{OperationTimeouts: map[string]time.Duration{"RegisterUser": 0}}}
+
+	assert.Equal(t, DefaultTimeout, c.operationTimeout("RegisterUser"))
+	assert.Equal(t, DefaultTimeout, c.operationTimeout("Authenticate"))
+}
+
 func TestNewClient_WithSecret(t *testing.T) {
 	cfg := Config{
 		Region:         "us-east-1",
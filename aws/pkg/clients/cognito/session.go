@@ -20,14 +20,14 @@ func (c *Client) SignOut(ctx context.Context, accessToken string) error {
 		return ErrInvalidToken
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "SignOut")
 	defer cancel()
 
 	input := &cognitoidentityprovider.GlobalSignOutInput{
 		AccessToken: aws.String(accessToken),
 	}
 
-	_, err = c.executeOperation(ctx, "SignOut", func() (interface{}, error) {
+	_, err = c.executeOperation(ctx, "SignOut", func(ctx context.Context) (interface{}, error) {
 		return c.cognitoClient.GlobalSignOut(ctx, input)
 	})
 
@@ -54,14 +54,14 @@ func (c *Client) GlobalSignOut(ctx context.Context, accessToken string) error {
 		return ErrInvalidToken
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "GlobalSignOut")
 	defer cancel()
 
 	input := &cognitoidentityprovider.GlobalSignOutInput{
 		AccessToken: aws.String(accessToken),
 	}
 
-	_, err = c.executeOperation(ctx, "GlobalSignOut", func() (interface{}, error) {
+	_, err = c.executeOperation(ctx, "GlobalSignOut", func(ctx context.Context) (interface{}, error) {
 		return c.cognitoClient.GlobalSignOut(ctx, input)
 	})
 
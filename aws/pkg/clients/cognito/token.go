@@ -10,14 +10,30 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/skolldire/go-engine/pkg/core/auth"
 )
 
+// NewTokenValidator adapta un Service de Cognito a auth.TokenValidator para que
+// pueda combinarse con otros proveedores (p. ej. en auth.NewIssuerValidators)
+func NewTokenValidator(svc Service) auth.TokenValidator {
+	return auth.ValidatorFunc(func(ctx context.Context, token string) (*auth.Claims, error) {
+		claims, err := svc.ValidateToken(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		return claims.AuthClaims(), nil
+	})
+}
+
 // ValidateToken valida un token JWT generado por Cognito usando JWKS
 func (c *Client) ValidateToken(ctx context.Context, token string) (*TokenClaims, error) {
 	if token == "" {
 		return nil, ErrInvalidToken
 	}
 
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "ValidateToken")
+	defer cancel()
+
 	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v (expected RSA)", token.Header["alg"])
@@ -111,7 +127,7 @@ func (c *Client) GetUserByAccessToken(ctx context.Context, accessToken string) (
 		return nil, ErrInvalidAccessToken
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "GetUserByAccessToken")
 	defer cancel()
 
 	input := &cognitoidentityprovider.GetUserInput{
@@ -119,7 +135,7 @@ func (c *Client) GetUserByAccessToken(ctx context.Context, accessToken string) (
 	}
 
 	var result *cognitoidentityprovider.GetUserOutput
-	_, err := c.executeOperation(ctx, "GetUserByAccessToken", func() (interface{}, error) {
+	_, err := c.executeOperation(ctx, "GetUserByAccessToken", func(ctx context.Context) (interface{}, error) {
 		var err error
 		result, err = c.cognitoClient.GetUser(ctx, input)
 		return result, err
@@ -179,7 +195,7 @@ func (c *Client) RefreshToken(ctx context.Context, req RefreshTokenRequest) (*Au
 		return nil, fmt.Errorf("%w: username required when client secret is configured", ErrMissingRequiredField)
 	}
 
-	ctx, cancel := c.ensureContextWithTimeout(ctx)
+	ctx, cancel := c.ensureContextWithTimeout(ctx, "RefreshToken")
 	defer cancel()
 
 	authParams := map[string]string{
@@ -198,7 +214,7 @@ func (c *Client) RefreshToken(ctx context.Context, req RefreshTokenRequest) (*Au
 	}
 
 	var result *cognitoidentityprovider.InitiateAuthOutput
-	_, err := c.executeOperation(ctx, "RefreshToken", func() (interface{}, error) {
+	_, err := c.executeOperation(ctx, "RefreshToken", func(ctx context.Context) (interface{}, error) {
 		var err error
 		result, err = c.cognitoClient.InitiateAuth(ctx, input)
 		return result, err
@@ -0,0 +1,56 @@
+package cognito
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubValidator struct {
+	Service
+	claims *TokenClaims
+	err    error
+}
+
+func (s stubValidator) ValidateToken(ctx context.Context, token string) (*TokenClaims, error) {
+	return s.claims, s.err
+}
+
+func TestTokenClaims_KeepsCognitoJSONKeys(t *testing.T) {
+	data, err := json.Marshal(TokenClaims{Username: "jdoe", Groups: []string{"admins"}})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(data), `"cognito:username":"jdoe"`)
+	assert.Contains(t, string(data), `"cognito:groups":["admins"]`)
+}
+
+func TestNewTokenValidator_ConvertsClaims(t *testing.T) {
+	validator := NewTokenValidator(stubValidator{claims: &TokenClaims{
+		Sub:      "user-1",
+		Username: "jdoe",
+		Groups:   []string{"admins"},
+		Iss:      "https://cognito-idp.us-east-1.amazonaws.com/us-east-1_Pool",
+		Exp:      1700000000,
+		TokenUse: "id",
+	}})
+
+	claims, err := validator.ValidateToken(context.Background(), "token")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Sub)
+	assert.Equal(t, "jdoe", claims.Username)
+	assert.Equal(t, []string{"admins"}, claims.Groups)
+	assert.Equal(t, int64(1700000000), claims.Exp)
+	assert.Equal(t, "id", claims.TokenUse)
+}
+
+func TestNewTokenValidator_PropagatesErrors(t *testing.T) {
+	validator := NewTokenValidator(stubValidator{err: ErrExpiredToken})
+
+	claims, err := validator.ValidateToken(context.Background(), "token")
+	assert.Nil(t, claims)
+	assert.True(t, errors.Is(err, ErrExpiredToken))
+}
@@ -41,6 +41,7 @@ type Config struct {
 	EnableLogging  bool              `mapstructure:"enable_logging" json:"enable_logging"`
 	WithResilience bool              `mapstructure:"with_resilience" json:"with_resilience"`
 	Resilience     resilience.Config `mapstructure:"resilience" json:"resilience"`
+	SlowThreshold  time.Duration     `mapstructure:"slow_threshold" json:"slow_threshold"`
 	Timeout        time.Duration     `mapstructure:"timeout" json:"timeout"`
 }
 
@@ -17,6 +17,8 @@ import (
 )
 
 func NewClient(acf aws.Config, cfg Config, log logger.Service) Service {
+	log = logger.OrNoop(log)
+
 	s3Client := s3.NewFromConfig(acf, func(o *s3.Options) {
 		if cfg.Region != "" {
 			o.Region = cfg.Region
@@ -33,6 +35,7 @@ func NewClient(acf aws.Config, cfg Config, log logger.Service) Service {
 		WithResilience: cfg.WithResilience,
 		Resilience:     cfg.Resilience,
 		Timeout:        timeout,
+		SlowThreshold:  cfg.SlowThreshold,
 	}
 
 	c := &S3Client{
@@ -71,7 +74,7 @@ func (c *S3Client) PutObject(ctx context.Context, key string, body io.Reader, co
 		input.Metadata = metadata
 	}
 
-	_, err := c.Execute(ctx, "PutObject", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "PutObject", func(ctx context.Context) (interface{}, error) {
 		return c.transferManager.UploadObject(ctx, input)
 	})
 
@@ -87,7 +90,7 @@ func (c *S3Client) GetObject(ctx context.Context, key string) (io.ReadCloser, er
 		return nil, ErrInvalidInput
 	}
 
-	result, err := c.Execute(ctx, "GetObject", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "GetObject", func(ctx context.Context) (interface{}, error) {
 		return c.s3Client.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: aws.String(c.bucket),
 			Key:    aws.String(key),
@@ -114,7 +117,7 @@ func (c *S3Client) DeleteObject(ctx context.Context, key string) error {
 		return ErrInvalidInput
 	}
 
-	_, err := c.Execute(ctx, "DeleteObject", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "DeleteObject", func(ctx context.Context) (interface{}, error) {
 		return c.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
 			Bucket: aws.String(c.bucket),
 			Key:    aws.String(key),
@@ -133,7 +136,7 @@ func (c *S3Client) HeadObject(ctx context.Context, key string) (*ObjectMetadata,
 		return nil, ErrInvalidInput
 	}
 
-	result, err := c.Execute(ctx, "HeadObject", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "HeadObject", func(ctx context.Context) (interface{}, error) {
 		return c.s3Client.HeadObject(ctx, &s3.HeadObjectInput{
 			Bucket: aws.String(c.bucket),
 			Key:    aws.String(key),
@@ -183,7 +186,7 @@ func (c *S3Client) ListObjects(ctx context.Context, prefix string, maxKeys int32
 			requestMaxKeys = remaining
 		}
 
-		result, err := c.Execute(ctx, "ListObjects", func() (interface{}, error) {
+		result, err := c.ExecuteContext(ctx, "ListObjects", func(ctx context.Context) (interface{}, error) {
 			input := &s3.ListObjectsV2Input{
 				Bucket:  aws.String(c.bucket),
 				Prefix:  aws.String(prefix),
@@ -234,7 +237,7 @@ func (c *S3Client) CopyObject(ctx context.Context, sourceKey, destKey string) er
 	}
 
 	source := fmt.Sprintf("%s/%s", c.bucket, url.PathEscape(sourceKey))
-	_, err := c.Execute(ctx, "CopyObject", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "CopyObject", func(ctx context.Context) (interface{}, error) {
 		return c.s3Client.CopyObject(ctx, &s3.CopyObjectInput{
 			Bucket:     aws.String(c.bucket),
 			CopySource: aws.String(source),
@@ -263,7 +266,7 @@ func (c *S3Client) GetPresignedURL(ctx context.Context, key string, expiration t
 	ctx, cancel := c.ContextWithTimeout(ctx)
 	defer cancel()
 
-	result, err := c.Execute(ctx, "GetPresignedURL", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "GetPresignedURL", func(ctx context.Context) (interface{}, error) {
 		request, err := c.presigner.PresignGetObject(ctx, &s3.GetObjectInput{
 			Bucket: aws.String(c.bucket),
 			Key:    aws.String(key),
@@ -301,7 +304,7 @@ func (c *S3Client) GetPresignedPutURL(ctx context.Context, key, contentType stri
 	ctx, cancel := c.ContextWithTimeout(ctx)
 	defer cancel()
 
-	result, err := c.Execute(ctx, "GetPresignedPutURL", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "GetPresignedPutURL", func(ctx context.Context) (interface{}, error) {
 		input := &s3.PutObjectInput{
 			Bucket: aws.String(c.bucket),
 			Key:    aws.String(key),
@@ -14,6 +14,8 @@ import (
 )
 
 func NewClient(acf aws.Config, cfg Config, log logger.Service) Service {
+	log = logger.OrNoop(log)
+
 	sesClient := ses.NewFromConfig(acf, func(o *ses.Options) {
 		if cfg.Region != "" {
 			o.Region = cfg.Region
@@ -30,6 +32,7 @@ func NewClient(acf aws.Config, cfg Config, log logger.Service) Service {
 		WithResilience: cfg.WithResilience,
 		Resilience:     cfg.Resilience,
 		Timeout:        timeout,
+		SlowThreshold:  cfg.SlowThreshold,
 	}
 
 	c := &SESClient{
@@ -136,7 +139,7 @@ func (c *SESClient) SendEmail(ctx context.Context, message EmailMessage) (*SendE
 		ReplyToAddresses: replyTo,
 	}
 
-	result, err := c.Execute(ctx, "SendEmail", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "SendEmail", func(ctx context.Context) (interface{}, error) {
 		return c.sesClient.SendEmail(ctx, input)
 	})
 
@@ -161,7 +164,7 @@ func (c *SESClient) SendRawEmail(ctx context.Context, rawMessage []byte, destina
 		return nil, ErrInvalidInput
 	}
 
-	result, err := c.Execute(ctx, "SendRawEmail", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "SendRawEmail", func(ctx context.Context) (interface{}, error) {
 		return c.sesClient.SendRawEmail(ctx, &ses.SendRawEmailInput{
 			RawMessage: &types.RawMessage{
 				Data: rawMessage,
@@ -246,7 +249,7 @@ func (c *SESClient) SendBulkEmail(ctx context.Context, from EmailAddress, subjec
 }
 
 func (c *SESClient) GetSendQuota(ctx context.Context) (*SendQuota, error) {
-	result, err := c.Execute(ctx, "GetSendQuota", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "GetSendQuota", func(ctx context.Context) (interface{}, error) {
 		return c.sesClient.GetSendQuota(ctx, &ses.GetSendQuotaInput{})
 	})
 
@@ -266,7 +269,7 @@ func (c *SESClient) GetSendQuota(ctx context.Context) (*SendQuota, error) {
 }
 
 func (c *SESClient) GetSendStatistics(ctx context.Context) ([]SendDataPoint, error) {
-	result, err := c.Execute(ctx, "GetSendStatistics", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "GetSendStatistics", func(ctx context.Context) (interface{}, error) {
 		return c.sesClient.GetSendStatistics(ctx, &ses.GetSendStatisticsInput{})
 	})
 
@@ -302,7 +305,7 @@ func (c *SESClient) VerifyEmailAddress(ctx context.Context, email string) error
 		return ErrInvalidInput
 	}
 
-	_, err := c.Execute(ctx, "VerifyEmailAddress", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "VerifyEmailAddress", func(ctx context.Context) (interface{}, error) {
 		return c.sesClient.VerifyEmailAddress(ctx, &ses.VerifyEmailAddressInput{
 			EmailAddress: aws.String(email),
 		})
@@ -320,7 +323,7 @@ func (c *SESClient) DeleteVerifiedEmailAddress(ctx context.Context, email string
 		return ErrInvalidInput
 	}
 
-	_, err := c.Execute(ctx, "DeleteVerifiedEmailAddress", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "DeleteVerifiedEmailAddress", func(ctx context.Context) (interface{}, error) {
 		return c.sesClient.DeleteVerifiedEmailAddress(ctx, &ses.DeleteVerifiedEmailAddressInput{
 			EmailAddress: aws.String(email),
 		})
@@ -334,7 +337,7 @@ func (c *SESClient) DeleteVerifiedEmailAddress(ctx context.Context, email string
 }
 
 func (c *SESClient) ListVerifiedEmailAddresses(ctx context.Context) ([]string, error) {
-	result, err := c.Execute(ctx, "ListVerifiedEmailAddresses", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "ListVerifiedEmailAddresses", func(ctx context.Context) (interface{}, error) {
 		return c.sesClient.ListVerifiedEmailAddresses(ctx, &ses.ListVerifiedEmailAddressesInput{})
 	})
 
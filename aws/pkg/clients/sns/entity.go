@@ -29,12 +29,20 @@ var (
 )
 
 type Config struct {
+	// Enabled set to false keeps the block in config but skips building and
+	// validating the client; nil (omitted) means enabled
+	Enabled        *bool             `mapstructure:"enabled" json:"enabled,omitempty"`
 	BaseEndpoint   string            `mapstructure:"base_endpoint" json:"base_endpoint"`
 	EnableLogging  bool              `mapstructure:"enable_logging" json:"enable_logging"`
 	WithResilience bool              `mapstructure:"with_resilience" json:"with_resilience"`
 	Resilience     resilience.Config `mapstructure:"resilience" json:"resilience"`
 }
 
+// IsEnabled reports whether the client should be built; true unless enabled: false
+func (c Config) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
 type BulkSMSResult struct {
 	Successful []SMSResult
 	Failed     []SMSResult
@@ -0,0 +1,101 @@
+package sqs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+func (c *Cliente) SendJSONCompressed(ctx context.Context, queueURL string, mensaje interface{},
+	atributos map[string]types.MessageAttributeValue) (string, error) {
+	if queueURL == "" || mensaje == nil {
+		return "", ErrInvalidInput
+	}
+
+	jsonBytes, err := json.Marshal(mensaje)
+	if err != nil {
+		return "", fmt.Errorf("error converting message to JSON: %w", err)
+	}
+
+	body, err := compressBody(jsonBytes)
+	if err != nil {
+		return "", fmt.Errorf("error compressing message: %w", err)
+	}
+
+	marked := make(map[string]types.MessageAttributeValue, len(atributos)+1)
+	for name, value := range atributos {
+		marked[name] = value
+	}
+	marked[CompressionAttribute] = types.MessageAttributeValue{
+		DataType:    aws.String("String"),
+		StringValue: aws.String(CompressionGzipBase64),
+	}
+
+	return c.SendMsj(ctx, queueURL, body, marked)
+}
+
+// DecodeBody returns the message body, transparently decompressing messages sent with
+// SendJSONCompressed. Other messages are returned unchanged.
+func DecodeBody(msg types.Message) (string, error) {
+	body := aws.ToString(msg.Body)
+
+	encoding, ok := msg.MessageAttributes[CompressionAttribute]
+	if !ok || aws.ToString(encoding.StringValue) != CompressionGzipBase64 {
+		return body, nil
+	}
+
+	decoded, err := decompressBody(body)
+	if err != nil {
+		return "", fmt.Errorf("error decompressing message: %w", err)
+	}
+	return decoded, nil
+}
+
+func compressBody(payload []byte) (string, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(payload); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func decompressBody(body string) (string, error) {
+	compressed, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return "", err
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
+
+// messageSize approximates how SQS counts a message against MaxMessageSize: the body
+// plus each attribute's name, data type and value.
+func messageSize(body *string, atributos map[string]types.MessageAttributeValue) int {
+	size := len(aws.ToString(body))
+	for name, value := range atributos {
+		size += len(name) + len(aws.ToString(value.DataType)) +
+			len(aws.ToString(value.StringValue)) + len(value.BinaryValue)
+	}
+	return size
+}
@@ -0,0 +1,81 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCliente_SendMsj_TooLarge(t *testing.T) {
+	client := NewClient(aws.Config{Region: "us-east-1"}, Config{}, &mockLogger{})
+
+	_, err := client.SendMsj(context.Background(), "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+		strings.Repeat("x", MaxMessageSize+1), nil)
+	assert.ErrorIs(t, err, ErrMessageTooLarge)
+}
+
+func TestCliente_SendJSON_TooLargeCountsAttributes(t *testing.T) {
+	client := NewClient(aws.Config{Region: "us-east-1"}, Config{}, &mockLogger{})
+
+	payload := map[string]string{"data": strings.Repeat("x", MaxMessageSize-20)}
+	attributes := map[string]types.MessageAttributeValue{
+		"trace": {DataType: aws.String("String"), StringValue: aws.String(strings.Repeat("t", 64))},
+	}
+
+	_, err := client.SendJSON(context.Background(), "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue", payload, attributes)
+	assert.ErrorIs(t, err, ErrMessageTooLarge)
+}
+
+func TestCliente_SendJSONCompressed_FitsLargeRepetitivePayload(t *testing.T) {
+	log := &mockLogger{}
+	log.On("WrapError", mock.Anything, mock.Anything).Return(errors.New("mock error"))
+	client := NewClient(aws.Config{Region: "us-east-1"}, Config{}, log)
+
+	payload := map[string]string{"data": strings.Repeat("x", 2*MaxMessageSize)}
+
+	// Fails at AWS without credentials, but must get past the size check
+	_, err := client.SendJSONCompressed(context.Background(), "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue", payload, nil)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrMessageTooLarge)
+}
+
+func TestDecodeBody_RoundTrip(t *testing.T) {
+	body, err := compressBody([]byte(`{"event":"order.placed"}`))
+	require.NoError(t, err)
+
+	msg := types.Message{
+		Body: aws.String(body),
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			CompressionAttribute: {DataType: aws.String("String"), StringValue: aws.String(CompressionGzipBase64)},
+		},
+	}
+
+	decoded, err := DecodeBody(msg)
+	require.NoError(t, err)
+	assert.Equal(t, `{"event":"order.placed"}`, decoded)
+}
+
+func TestDecodeBody_Uncompressed(t *testing.T) {
+	decoded, err := DecodeBody(types.Message{Body: aws.String(`{"event":"order.placed"}`)})
+	require.NoError(t, err)
+	assert.Equal(t, `{"event":"order.placed"}`, decoded)
+}
+
+func TestDecodeBody_CorruptPayload(t *testing.T) {
+	msg := types.Message{
+		Body: aws.String("not base64!"),
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			CompressionAttribute: {DataType: aws.String("String"), StringValue: aws.String(CompressionGzipBase64)},
+		},
+	}
+
+	_, err := DecodeBody(msg)
+	assert.Error(t, err)
+}
@@ -14,7 +14,15 @@ import (
 type Service interface {
 	SendMsj(ctx context.Context, queueURL string, mensaje string, atributos map[string]types.MessageAttributeValue) (string, error)
 	SendJSON(ctx context.Context, queueURL string, mensaje interface{}, atributos map[string]types.MessageAttributeValue) (string, error)
+	// SendJSONCompressed gzips and base64-encodes the JSON payload and marks it with the
+	// CompressionAttribute so DecodeBody can restore it on the consumer side.
+	SendJSONCompressed(ctx context.Context, queueURL string, mensaje interface{}, atributos map[string]types.MessageAttributeValue) (string, error)
+	// ReceiveMsj returns a non-nil, empty slice and a nil error when the queue has no
+	// messages, so callers check len(msgs) == 0 rather than treating it as a failure.
 	ReceiveMsj(ctx context.Context, queueURL string, maxMensajes int32, tiempoEspera int32) ([]types.Message, error)
+	// ReceiveMsjBlocking long-polls until at least one message arrives or ctx is done,
+	// in which case it returns ctx.Err(). Use it in worker loops instead of ReceiveMsj.
+	ReceiveMsjBlocking(ctx context.Context, queueURL string, maxMensajes int32) ([]types.Message, error)
 	DeleteMsj(ctx context.Context, queueURL string, receiptHandle string) error
 	CreateQueue(ctx context.Context, nombre string, atributos map[string]string) (string, error)
 	DeleteQueue(ctx context.Context, queueURL string) error
@@ -24,10 +32,24 @@ type Service interface {
 }
 
 type Config struct {
+	// Enabled set to false keeps the block in config but skips building and
+	// validating the client; nil (omitted) means enabled
+	Enabled        *bool             `mapstructure:"enabled" json:"enabled,omitempty"`
 	Endpoint       string            `mapstructure:"endpoint" json:"endpoint"`
 	EnableLogging  bool              `mapstructure:"enable_logging" json:"enable_logging"`
 	WithResilience bool              `mapstructure:"with_resilience" json:"with_resilience"`
 	Resilience     resilience.Config `mapstructure:"resilience" json:"resilience"`
+	// DefaultMessageGroupID is sent as MessageGroupId on every message to a FIFO queue
+	// (URL ending in ".fifo") unless overridden with WithMessageGroupID.
+	DefaultMessageGroupID string `mapstructure:"default_message_group_id" json:"default_message_group_id"`
+	// DefaultMessageAttributes are added as String attributes to every sent message;
+	// attributes passed by the caller with the same name take precedence.
+	DefaultMessageAttributes map[string]string `mapstructure:"default_message_attributes" json:"default_message_attributes"`
+}
+
+// IsEnabled reports whether the client should be built; true unless enabled: false
+func (c Config) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
 }
 
 var (
@@ -39,10 +61,26 @@ var (
 	ErrListarColas     = errors.New("error listing queues")
 	ErrObtenerURLCola  = errors.New("error getting queue URL")
 	ErrInvalidInput    = errors.New("invalid input")
+	// ErrMessageTooLarge is returned before calling AWS when body plus attributes exceed
+	// MaxMessageSize; use SendJSONCompressed or offload the payload to S3.
+	ErrMessageTooLarge = errors.New("message exceeds SQS maximum size")
 )
 
 const (
 	DefaultTimeout = 5 * time.Second
+
+	// MaxWaitTimeSeconds is the longest long-poll SQS allows per ReceiveMessage call
+	MaxWaitTimeSeconds = 20
+
+	// MaxMessageSize is the SQS limit for a message body plus its attributes
+	MaxMessageSize = 256 * 1024
+
+	// CompressionAttribute marks messages sent by SendJSONCompressed
+	CompressionAttribute = "Content-Encoding"
+	// CompressionGzipBase64 is the CompressionAttribute value for gzip+base64 bodies
+	CompressionGzipBase64 = "gzip+base64"
+
+	fifoQueueSuffix = ".fifo"
 )
 
 type Cliente struct {
@@ -50,4 +88,7 @@ type Cliente struct {
 	logger     logger.Service
 	logging    bool
 	resilience *resilience.Service
+
+	defaultGroupID    string
+	defaultAttributes map[string]types.MessageAttributeValue
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
@@ -14,6 +16,8 @@ import (
 )
 
 func NewClient(acf aws.Config, cfg Config, l logger.Service) Service {
+	l = logger.OrNoop(l)
+
 	sqsClient := sqs.NewFromConfig(acf, func(o *sqs.Options) {
 		if cfg.Endpoint != "" {
 			o.BaseEndpoint = aws.String(cfg.Endpoint)
@@ -21,9 +25,11 @@ func NewClient(acf aws.Config, cfg Config, l logger.Service) Service {
 	})
 
 	cliente := &Cliente{
-		cliente: sqsClient,
-		logger:  l,
-		logging: cfg.EnableLogging,
+		cliente:           sqsClient,
+		logger:            l,
+		logging:           cfg.EnableLogging,
+		defaultGroupID:    cfg.DefaultMessageGroupID,
+		defaultAttributes: stringAttributes(cfg.DefaultMessageAttributes),
 	}
 
 	if cfg.WithResilience {
@@ -52,7 +58,7 @@ func (c *Cliente) ensureContextWithTimeout(ctx context.Context) (context.Context
 }
 
 func (c *Cliente) execute(ctx context.Context, operationName string,
-	operation func() (interface{}, error)) (interface{}, error) {
+	operation func(ctx context.Context) (interface{}, error)) (interface{}, error) {
 	ctx, cancel := c.ensureContextWithTimeout(ctx)
 	defer cancel()
 
@@ -60,7 +66,7 @@ func (c *Cliente) execute(ctx context.Context, operationName string,
 }
 
 func (c *Cliente) executeOperation(ctx context.Context, operationName string,
-	operation func() (interface{}, error)) (interface{}, error) {
+	operation func(ctx context.Context) (interface{}, error)) (interface{}, error) {
 	logFields := map[string]interface{}{"operation": operationName, "service": "SQS"}
 
 	if c.resilience != nil {
@@ -71,12 +77,12 @@ func (c *Cliente) executeOperation(ctx context.Context, operationName string,
 }
 
 func (c *Cliente) executeWithResilience(ctx context.Context, operationName string,
-	operation func() (interface{}, error), logFields map[string]interface{}) (interface{}, error) {
+	operation func(ctx context.Context) (interface{}, error), logFields map[string]interface{}) (interface{}, error) {
 	if c.logging {
 		c.logger.Debug(ctx, fmt.Sprintf("starting SQS operation with resilience: %s", operationName), logFields)
 	}
 
-	result, err := c.resilience.Execute(ctx, operation)
+	result, err := c.resilience.ExecuteNamedContext(ctx, operationName, operation)
 
 	if err != nil && c.logging {
 		c.logger.Error(ctx, err, logFields)
@@ -88,12 +94,12 @@ func (c *Cliente) executeWithResilience(ctx context.Context, operationName strin
 }
 
 func (c *Cliente) executeWithLogging(ctx context.Context, operationName string,
-	operation func() (interface{}, error), logFields map[string]interface{}) (interface{}, error) {
+	operation func(ctx context.Context) (interface{}, error), logFields map[string]interface{}) (interface{}, error) {
 	if c.logging {
 		c.logger.Debug(ctx, fmt.Sprintf("starting SQS operation: %s", operationName), logFields)
 	}
 
-	result, err := operation()
+	result, err := operation(ctx)
 
 	if err != nil && c.logging {
 		c.logger.Error(ctx, err, logFields)
@@ -110,13 +116,12 @@ func (c *Cliente) SendMsj(ctx context.Context, queueURL string, mensaje string,
 		return "", ErrInvalidInput
 	}
 
-	input := &sqs.SendMessageInput{
-		QueueUrl:          aws.String(queueURL),
-		MessageBody:       aws.String(mensaje),
-		MessageAttributes: atributos,
+	input := c.buildSendInput(ctx, queueURL, mensaje, atributos)
+	if size := messageSize(input.MessageBody, input.MessageAttributes); size > MaxMessageSize {
+		return "", fmt.Errorf("%w: %d bytes (limit %d)", ErrMessageTooLarge, size, MaxMessageSize)
 	}
 
-	result, err := c.execute(ctx, "SendMsj", func() (interface{}, error) {
+	result, err := c.execute(ctx, "SendMsj", func(ctx context.Context) (interface{}, error) {
 		return c.cliente.SendMessage(ctx, input)
 	})
 
@@ -134,6 +139,62 @@ func (c *Cliente) SendMsj(ctx context.Context, queueURL string, mensaje string,
 	return *response.MessageId, nil
 }
 
+// buildSendInput merges the client defaults into the message: caller attributes win
+// over DefaultMessageAttributes and the context group ID wins over DefaultMessageGroupID.
+func (c *Cliente) buildSendInput(ctx context.Context, queueURL string, mensaje string,
+	atributos map[string]types.MessageAttributeValue) *sqs.SendMessageInput {
+	input := &sqs.SendMessageInput{
+		QueueUrl:          aws.String(queueURL),
+		MessageBody:       aws.String(mensaje),
+		MessageAttributes: atributos,
+	}
+
+	if len(c.defaultAttributes) > 0 {
+		merged := make(map[string]types.MessageAttributeValue, len(c.defaultAttributes)+len(atributos))
+		for name, value := range c.defaultAttributes {
+			merged[name] = value
+		}
+		for name, value := range atributos {
+			merged[name] = value
+		}
+		input.MessageAttributes = merged
+	}
+
+	if strings.HasSuffix(queueURL, fifoQueueSuffix) {
+		groupID := c.defaultGroupID
+		if override, ok := ctx.Value(messageGroupIDKey{}).(string); ok && override != "" {
+			groupID = override
+		}
+		if groupID != "" {
+			input.MessageGroupId = aws.String(groupID)
+		}
+	}
+
+	return input
+}
+
+type messageGroupIDKey struct{}
+
+// WithMessageGroupID overrides the client's DefaultMessageGroupID for sends made with ctx
+func WithMessageGroupID(ctx context.Context, groupID string) context.Context {
+	return context.WithValue(ctx, messageGroupIDKey{}, groupID)
+}
+
+func stringAttributes(values map[string]string) map[string]types.MessageAttributeValue {
+	if len(values) == 0 {
+		return nil
+	}
+
+	attributes := make(map[string]types.MessageAttributeValue, len(values))
+	for name, value := range values {
+		attributes[name] = types.MessageAttributeValue{
+			DataType:    aws.String("String"),
+			StringValue: aws.String(value),
+		}
+	}
+	return attributes
+}
+
 func (c *Cliente) SendJSON(ctx context.Context, queueURL string, mensaje interface{},
 	atributos map[string]types.MessageAttributeValue) (string, error) {
 	if queueURL == "" || mensaje == nil {
@@ -165,7 +226,7 @@ func (c *Cliente) ReceiveMsj(ctx context.Context, queueURL string, maxMensajes i
 		MessageAttributeNames: []string{"All"},
 	}
 
-	result, err := c.execute(ctx, "RecibirMensajes", func() (interface{}, error) {
+	result, err := c.execute(ctx, "RecibirMensajes", func(ctx context.Context) (interface{}, error) {
 		return c.cliente.ReceiveMessage(ctx, input)
 	})
 
@@ -180,9 +241,40 @@ func (c *Cliente) ReceiveMsj(ctx context.Context, queueURL string, maxMensajes i
 	if response == nil {
 		return nil, c.logger.WrapError(fmt.Errorf("received nil response"), ErrRecibirMensajes.Error())
 	}
+	if response.Messages == nil {
+		return []types.Message{}, nil
+	}
 	return response.Messages, nil
 }
 
+func (c *Cliente) ReceiveMsjBlocking(ctx context.Context, queueURL string, maxMensajes int32) ([]types.Message, error) {
+	if queueURL == "" {
+		return nil, ErrInvalidInput
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Each poll waits up to MaxWaitTimeSeconds server-side, so give it its own
+		// deadline instead of the DefaultTimeout applied to deadline-less contexts.
+		pollCtx, cancel := context.WithTimeout(ctx, MaxWaitTimeSeconds*time.Second+DefaultTimeout)
+		msgs, err := c.ReceiveMsj(pollCtx, queueURL, maxMensajes, MaxWaitTimeSeconds)
+		cancel()
+
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, err
+		}
+		if len(msgs) > 0 {
+			return msgs, nil
+		}
+	}
+}
+
 func (c *Cliente) DeleteMsj(ctx context.Context, queueURL string, receiptHandle string) error {
 	if queueURL == "" || receiptHandle == "" {
 		return ErrInvalidInput
@@ -193,7 +285,7 @@ func (c *Cliente) DeleteMsj(ctx context.Context, queueURL string, receiptHandle
 		ReceiptHandle: aws.String(receiptHandle),
 	}
 
-	_, err := c.execute(ctx, "DeleteMsj", func() (interface{}, error) {
+	_, err := c.execute(ctx, "DeleteMsj", func(ctx context.Context) (interface{}, error) {
 		return c.cliente.DeleteMessage(ctx, input)
 	})
 
@@ -217,7 +309,7 @@ func (c *Cliente) CreateQueue(ctx context.Context, nombre string, atributos map[
 		input.Attributes = atributos
 	}
 
-	result, err := c.execute(ctx, "CreateQueue", func() (interface{}, error) {
+	result, err := c.execute(ctx, "CreateQueue", func(ctx context.Context) (interface{}, error) {
 		return c.cliente.CreateQueue(ctx, input)
 	})
 
@@ -240,7 +332,7 @@ func (c *Cliente) DeleteQueue(ctx context.Context, queueURL string) error {
 		return ErrInvalidInput
 	}
 
-	_, err := c.execute(ctx, "DeleteQueue", func() (interface{}, error) {
+	_, err := c.execute(ctx, "DeleteQueue", func(ctx context.Context) (interface{}, error) {
 		return c.cliente.DeleteQueue(ctx, &sqs.DeleteQueueInput{
 			QueueUrl: aws.String(queueURL),
 		})
@@ -259,7 +351,7 @@ func (c *Cliente) ListQueue(ctx context.Context, prefijo string) ([]string, erro
 		input.QueueNamePrefix = aws.String(prefijo)
 	}
 
-	result, err := c.execute(ctx, "ListQueue", func() (interface{}, error) {
+	result, err := c.execute(ctx, "ListQueue", func(ctx context.Context) (interface{}, error) {
 		return c.cliente.ListQueues(ctx, input)
 	})
 
@@ -282,7 +374,7 @@ func (c *Cliente) GetURLQueue(ctx context.Context, nombre string) (string, error
 		return "", ErrInvalidInput
 	}
 
-	result, err := c.execute(ctx, "GetURLQueue", func() (interface{}, error) {
+	result, err := c.execute(ctx, "GetURLQueue", func(ctx context.Context) (interface{}, error) {
 		return c.cliente.GetQueueUrl(ctx, &sqs.GetQueueUrlInput{
 			QueueName: aws.String(nombre),
 		})
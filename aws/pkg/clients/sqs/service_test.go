@@ -2,7 +2,14 @@ package sqs
 
 import (
 	"context"
+	"crypto/md5"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -14,6 +21,7 @@ import (
 	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type mockLogger struct {
@@ -375,3 +383,130 @@ func TestCliente_EnsureContextWithTimeout_WithDeadline(t *testing.T) {
 	assert.NotNil(t, cancelFunc)
 	cancelFunc()
 }
+
+func TestCliente_BuildSendInput_AppliesDefaults(t *testing.T) {
+	cfg := Config{
+		DefaultMessageGroupID:    "orders",
+		DefaultMessageAttributes: map[string]string{"source": "billing", "version": "1"},
+	}
+	cliente := NewClient(aws.Config{Region: "us-east-1"}, cfg, &mockLogger{}).(*Cliente)
+
+	input := cliente.buildSendInput(context.Background(), "https://sqs.us-east-1.amazonaws.com/123456789012/orders.fifo", "body", nil)
+
+	assert.Equal(t, "orders", aws.ToString(input.MessageGroupId))
+	assert.Equal(t, "billing", aws.ToString(input.MessageAttributes["source"].StringValue))
+	assert.Equal(t, "String", aws.ToString(input.MessageAttributes["source"].DataType))
+	assert.Equal(t, "1", aws.ToString(input.MessageAttributes["version"].StringValue))
+}
+
+func TestCliente_BuildSendInput_CallerOverridesDefaults(t *testing.T) {
+	cfg := Config{
+		DefaultMessageGroupID:    "orders",
+		DefaultMessageAttributes: map[string]string{"source": "billing", "version": "1"},
+	}
+	cliente := NewClient(aws.Config{Region: "us-east-1"}, cfg, &mockLogger{}).(*Cliente)
+
+	ctx := WithMessageGroupID(context.Background(), "customer-42")
+	attributes := map[string]types.MessageAttributeValue{
+		"version": {DataType: aws.String("Number"), StringValue: aws.String("2")},
+	}
+
+	input := cliente.buildSendInput(ctx, "https://sqs.us-east-1.amazonaws.com/123456789012/orders.fifo", "body", attributes)
+
+	assert.Equal(t, "customer-42", aws.ToString(input.MessageGroupId))
+	assert.Equal(t, "billing", aws.ToString(input.MessageAttributes["source"].StringValue))
+	assert.Equal(t, "2", aws.ToString(input.MessageAttributes["version"].StringValue))
+	assert.Equal(t, "Number", aws.ToString(input.MessageAttributes["version"].DataType))
+	assert.Len(t, attributes, 1, "caller map must not be mutated")
+}
+
+func TestCliente_BuildSendInput_StandardQueueSkipsGroupID(t *testing.T) {
+	cfg := Config{DefaultMessageGroupID: "orders"}
+	cliente := NewClient(aws.Config{Region: "us-east-1"}, cfg, &mockLogger{}).(*Cliente)
+
+	input := cliente.buildSendInput(context.Background(), "https://sqs.us-east-1.amazonaws.com/123456789012/orders", "body", nil)
+
+	assert.Nil(t, input.MessageGroupId)
+	assert.Nil(t, input.MessageAttributes)
+}
+
+func TestCliente_ReceiveMsjBlocking_InvalidInput(t *testing.T) {
+	client := NewClient(aws.Config{Region: "us-east-1"}, Config{}, &mockLogger{})
+
+	_, err := client.ReceiveMsjBlocking(context.Background(), "", 10)
+	assert.Equal(t, ErrInvalidInput, err)
+}
+
+func TestCliente_ReceiveMsjBlocking_ContextCancelled(t *testing.T) {
+	client := NewClient(aws.Config{Region: "us-east-1"}, Config{}, &mockLogger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	msgs, err := client.ReceiveMsjBlocking(ctx, "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue", 10)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, msgs)
+}
+
+// newPollServer serves ReceiveMessage with the next entry of responses (an empty
+// receive once they run out), passing the call number to onCall first
+func newPollServer(t *testing.T, responses []string, onCall func(call int32)) (*Cliente, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var input struct{ WaitTimeSeconds int32 }
+		_ = json.Unmarshal(body, &input)
+		assert.Equal(t, int32(MaxWaitTimeSeconds), input.WaitTimeSeconds, "every poll long-polls")
+
+		call := calls.Add(1)
+		if onCall != nil {
+			onCall(call)
+		}
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		if int(call) <= len(responses) {
+			_, _ = w.Write([]byte(responses[call-1]))
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(aws.Config{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  aws.AnonymousCredentials{},
+	}, Config{}, logger.NewNoop()).(*Cliente)
+	return client, &calls
+}
+
+func TestCliente_ReceiveMsjBlocking_PollsUntilMessagesArrive(t *testing.T) {
+	body := "hello"
+	message := fmt.Sprintf(`{"Messages":[{"MessageId":"m1","ReceiptHandle":"rh-1","Body":%q,"MD5OfBody":"%x"}]}`,
+		body, md5.Sum([]byte(body)))
+	client, calls := newPollServer(t, []string{`{}`, `{"Messages":[]}`, message}, nil)
+
+	msgs, err := client.ReceiveMsjBlocking(context.Background(), "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue", 10)
+
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, "m1", aws.ToString(msgs[0].MessageId))
+	assert.Equal(t, body, aws.ToString(msgs[0].Body))
+	assert.Equal(t, int32(3), calls.Load(), "empty receives are polled again")
+}
+
+func TestCliente_ReceiveMsjBlocking_StopsWhenContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, calls := newPollServer(t, nil, func(call int32) {
+		if call == 2 {
+			cancel()
+		}
+	})
+
+	msgs, err := client.ReceiveMsjBlocking(ctx, "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue", 10)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, msgs)
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -7,11 +7,27 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	"github.com/skolldire/go-engine/pkg/core/client"
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
 	"github.com/skolldire/go-engine/pkg/utilities/resilience"
 )
 
 const (
 	DefaultTimeout = 5 * time.Second
+	// DefaultTaggingConcurrency is used by AddTagsToResources when concurrency is zero
+	DefaultTaggingConcurrency = 10
+)
+
+const (
+	// MaxStandardValueSize is the largest value, in bytes, a Standard tier parameter accepts
+	MaxStandardValueSize = 4096
+	// MaxAdvancedValueSize is the largest value, in bytes, an Advanced tier parameter accepts
+	MaxAdvancedValueSize = 8192
+)
+
+const (
+	ParameterTierStandard           = "Standard"
+	ParameterTierAdvanced           = "Advanced"
+	ParameterTierIntelligentTiering = "Intelligent-Tiering"
 )
 
 const (
@@ -26,6 +42,8 @@ var (
 	ErrGetParameter      = errors.New("error getting parameter")
 	ErrPutParameter      = errors.New("error putting parameter")
 	ErrDeleteParameter   = errors.New("error deleting parameter")
+	ErrInvalidJSON       = errors.New("invalid JSON parameter value")
+	ErrValueTooLarge     = errors.New("parameter value exceeds tier limit")
 )
 
 type Config struct {
@@ -33,6 +51,7 @@ type Config struct {
 	EnableLogging  bool              `mapstructure:"enable_logging" json:"enable_logging"`
 	WithResilience bool              `mapstructure:"with_resilience" json:"with_resilience"`
 	Resilience     resilience.Config `mapstructure:"resilience" json:"resilience"`
+	SlowThreshold  time.Duration     `mapstructure:"slow_threshold" json:"slow_threshold"`
 	Timeout        time.Duration     `mapstructure:"timeout" json:"timeout"`
 }
 
@@ -69,17 +88,41 @@ type Service interface {
 	GetParameter(ctx context.Context, name string, decrypt bool) (*Parameter, error)
 
 	// GetParameters retrieves multiple parameters by their names.
-	// Returns a map keyed by parameter name.
+	// Returns a map keyed by parameter name; names that do not exist are silently omitted.
 	GetParameters(ctx context.Context, names []string, decrypt bool) (map[string]*Parameter, error)
 
+	// GetParametersStrict behaves like GetParameters but fails with ErrParameterNotFound,
+	// listing every missing name, when any requested parameter does not exist.
+	GetParametersStrict(ctx context.Context, names []string, decrypt bool) (map[string]*Parameter, error)
+
 	// GetParametersByPath retrieves all parameters under a given path.
 	// If recursive is true, includes parameters in sub-paths.
 	GetParametersByPath(ctx context.Context, path string, recursive bool, decrypt bool) ([]*Parameter, error)
 
+	// GetParametersByPathPage retrieves a single page of parameters under a given path.
+	// Pass the zero token for the first page and then the previous page's Next, until
+	// HasMore reports false. Use it instead of GetParametersByPath for large trees.
+	GetParametersByPathPage(ctx context.Context, path string, recursive, decrypt bool, token cloud.PageToken) (*cloud.Page[*Parameter], error)
+
 	// PutParameter creates or updates a parameter.
 	// If overwrite is false and parameter exists, returns an error.
 	PutParameter(ctx context.Context, name, value, parameterType, description string, overwrite bool, tags map[string]string) error
 
+	// GetParameterJSON retrieves a parameter and unmarshals its JSON value into dest.
+	// Decoding failures wrap ErrInvalidJSON.
+	GetParameterJSON(ctx context.Context, name string, decrypt bool, dest interface{}) error
+
+	// PutParameterJSON marshals v and stores it like PutParameter in the given tier
+	// (ParameterTierStandard when empty). Values larger than the tier allows
+	// (MaxStandardValueSize, or MaxAdvancedValueSize for Advanced and Intelligent-Tiering)
+	// are rejected with ErrValueTooLarge before calling AWS.
+	PutParameterJSON(ctx context.Context, name string, v interface{}, parameterType, tier, description string, overwrite bool, tags map[string]string) error
+
+	// PutParameterIfChanged writes the parameter (overwriting it) only when it does not
+	// exist or its current value or type differs, so unchanged values do not create new
+	// versions. It reports whether a write occurred.
+	PutParameterIfChanged(ctx context.Context, name, value, parameterType, description string, tags map[string]string) (bool, error)
+
 	// PutSecureParameter creates or updates a SecureString parameter (encrypted).
 	PutSecureParameter(ctx context.Context, name, value, description string, overwrite bool, tags map[string]string) error
 
@@ -96,6 +139,12 @@ type Service interface {
 	// AddTagsToResource adds tags to an SSM resource (parameter, document, etc.).
 	AddTagsToResource(ctx context.Context, resourceType, resourceID string, tags map[string]string) error
 
+	// AddTagsToResources adds tags to every resource in ids with at most concurrency
+	// (DefaultTaggingConcurrency when zero) calls in flight. The result has one entry per
+	// ID: nil on success, the error otherwise. The returned error is non-nil only for
+	// invalid input or if ctx ended before every resource was tagged.
+	AddTagsToResources(ctx context.Context, resourceType string, ids []string, tags map[string]string, concurrency int) (map[string]error, error)
+
 	// ListTagsForResource retrieves tags associated with an SSM resource.
 	ListTagsForResource(ctx context.Context, resourceType, resourceID string) (map[string]string, error)
 
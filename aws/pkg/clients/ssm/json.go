@@ -0,0 +1,72 @@
+package ssm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+func (c *SSMClient) GetParameterJSON(ctx context.Context, name string, decrypt bool, dest interface{}) error {
+	if dest == nil {
+		return ErrInvalidInput
+	}
+
+	param, err := c.GetParameter(ctx, name, decrypt)
+	if err != nil {
+		return err
+	}
+
+	return decodeParameterJSON(name, param.Value, dest)
+}
+
+func (c *SSMClient) PutParameterJSON(ctx context.Context, name string, v interface{}, parameterType, tier, description string, overwrite bool, tags map[string]string) error {
+	value, err := encodeParameterJSON(name, v, tier)
+	if err != nil {
+		return err
+	}
+
+	return c.putParameter(ctx, name, value, parameterType, tier, description, overwrite, tags)
+}
+
+func decodeParameterJSON(name, value string, dest interface{}) error {
+	if err := json.Unmarshal([]byte(value), dest); err != nil {
+		return fmt.Errorf("%w: parameter %s: %w", ErrInvalidJSON, name, err)
+	}
+	return nil
+}
+
+func encodeParameterJSON(name string, v interface{}, tier string) (string, error) {
+	maxSize, err := maxValueSize(tier)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("%w: parameter %s: %w", ErrInvalidJSON, name, err)
+	}
+
+	if len(data) > maxSize {
+		return "", fmt.Errorf("%w: parameter %s is %d bytes, %s tier allows %d",
+			ErrValueTooLarge, name, len(data), tierName(tier), maxSize)
+	}
+	return string(data), nil
+}
+
+// maxValueSize returns the value size limit of tier; an empty tier is Standard
+func maxValueSize(tier string) (int, error) {
+	switch tier {
+	case "", ParameterTierStandard:
+		return MaxStandardValueSize, nil
+	case ParameterTierAdvanced, ParameterTierIntelligentTiering:
+		return MaxAdvancedValueSize, nil
+	}
+	return 0, fmt.Errorf("%w: unknown parameter tier %q", ErrInvalidInput, tier)
+}
+
+func tierName(tier string) string {
+	if tier == "" {
+		return ParameterTierStandard
+	}
+	return tier
+}
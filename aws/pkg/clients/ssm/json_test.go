@@ -0,0 +1,58 @@
+package ssm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type featureFlags struct {
+	Checkout bool   `json:"checkout"`
+	Theme    string `json:"theme"`
+}
+
+func TestParameterJSON_RoundTrip(t *testing.T) {
+	value, err := encodeParameterJSON("/app/flags", featureFlags{Checkout: true, Theme: "dark"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, `{"checkout":true,"theme":"dark"}`, value)
+
+	var flags featureFlags
+	require.NoError(t, decodeParameterJSON("/app/flags", value, &flags))
+	assert.Equal(t, featureFlags{Checkout: true, Theme: "dark"}, flags)
+}
+
+func TestDecodeParameterJSON_InvalidValue(t *testing.T) {
+	var flags featureFlags
+	err := decodeParameterJSON("/app/flags", "checkout=true", &flags)
+
+	require.ErrorIs(t, err, ErrInvalidJSON)
+	assert.Contains(t, err.Error(), "/app/flags")
+}
+
+func TestEncodeParameterJSON_Errors(t *testing.T) {
+	_, err := encodeParameterJSON("/app/big", map[string]string{"blob": strings.Repeat("x", MaxStandardValueSize)}, "")
+	assert.ErrorIs(t, err, ErrValueTooLarge)
+
+	_, err = encodeParameterJSON("/app/chan", make(chan int), "")
+	assert.ErrorIs(t, err, ErrInvalidJSON)
+
+	_, err = encodeParameterJSON("/app/flags", featureFlags{}, "Premium")
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestEncodeParameterJSON_TierLimits(t *testing.T) {
+	big := map[string]string{"blob": strings.Repeat("x", 6000)}
+
+	_, err := encodeParameterJSON("/app/big", big, ParameterTierStandard)
+	assert.ErrorIs(t, err, ErrValueTooLarge)
+
+	for _, tier := range []string{ParameterTierAdvanced, ParameterTierIntelligentTiering} {
+		_, err = encodeParameterJSON("/app/big", big, tier)
+		assert.NoError(t, err, tier)
+	}
+
+	_, err = encodeParameterJSON("/app/huge", map[string]string{"blob": strings.Repeat("x", MaxAdvancedValueSize)}, ParameterTierAdvanced)
+	assert.ErrorIs(t, err, ErrValueTooLarge)
+}
@@ -1,19 +1,25 @@
 package ssm
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/skolldire/go-engine/pkg/core/client"
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
+	"github.com/skolldire/go-engine/pkg/utilities/task_executor"
 	"github.com/skolldire/go-engine/pkg/utilities/validation"
 )
 
 func NewClient(acf aws.Config, cfg Config, log logger.Service) Service {
+	log = logger.OrNoop(log)
+
 	ssmClient := ssm.NewFromConfig(acf, func(o *ssm.Options) {
 		if cfg.Region != "" {
 			o.Region = cfg.Region
@@ -30,6 +36,7 @@ func NewClient(acf aws.Config, cfg Config, log logger.Service) Service {
 		WithResilience: cfg.WithResilience,
 		Resilience:     cfg.Resilience,
 		Timeout:        timeout,
+		SlowThreshold:  cfg.SlowThreshold,
 	}
 
 	c := &SSMClient{
@@ -53,7 +60,7 @@ func (c *SSMClient) GetParameter(ctx context.Context, name string, decrypt bool)
 		return nil, ErrInvalidInput
 	}
 
-	result, err := c.Execute(ctx, "GetParameter", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "GetParameter", func(ctx context.Context) (interface{}, error) {
 		return c.ssmClient.GetParameter(ctx, &ssm.GetParameterInput{
 			Name:           aws.String(name),
 			WithDecryption: aws.Bool(decrypt),
@@ -72,11 +79,29 @@ func (c *SSMClient) GetParameter(ctx context.Context, name string, decrypt bool)
 }
 
 func (c *SSMClient) GetParameters(ctx context.Context, names []string, decrypt bool) (map[string]*Parameter, error) {
+	params, _, err := c.getParameters(ctx, names, decrypt)
+	return params, err
+}
+
+func (c *SSMClient) GetParametersStrict(ctx context.Context, names []string, decrypt bool) (map[string]*Parameter, error) {
+	params, invalid, err := c.getParameters(ctx, names, decrypt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(invalid) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrParameterNotFound, strings.Join(invalid, ", "))
+	}
+
+	return params, nil
+}
+
+func (c *SSMClient) getParameters(ctx context.Context, names []string, decrypt bool) (map[string]*Parameter, []string, error) {
 	if len(names) == 0 {
-		return nil, ErrInvalidInput
+		return nil, nil, ErrInvalidInput
 	}
 
-	result, err := c.Execute(ctx, "GetParameters", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "GetParameters", func(ctx context.Context) (interface{}, error) {
 		return c.ssmClient.GetParameters(ctx, &ssm.GetParametersInput{
 			Names:          names,
 			WithDecryption: aws.Bool(decrypt),
@@ -84,12 +109,12 @@ func (c *SSMClient) GetParameters(ctx context.Context, names []string, decrypt b
 	})
 
 	if err != nil {
-		return nil, c.GetLogger().WrapError(err, ErrGetParameter.Error())
+		return nil, nil, c.GetLogger().WrapError(err, ErrGetParameter.Error())
 	}
 
 	response, err := client.SafeTypeAssert[*ssm.GetParametersOutput](result)
 	if err != nil {
-		return nil, c.GetLogger().WrapError(err, ErrGetParameter.Error())
+		return nil, nil, c.GetLogger().WrapError(err, ErrGetParameter.Error())
 	}
 	params := make(map[string]*Parameter)
 
@@ -98,7 +123,7 @@ func (c *SSMClient) GetParameters(ctx context.Context, names []string, decrypt b
 		params[p.Name] = p
 	}
 
-	return params, nil
+	return params, response.InvalidParameters, nil
 }
 
 func (c *SSMClient) GetParametersByPath(ctx context.Context, path string, recursive bool, decrypt bool) ([]*Parameter, error) {
@@ -107,40 +132,64 @@ func (c *SSMClient) GetParametersByPath(ctx context.Context, path string, recurs
 	}
 
 	allParams := make([]*Parameter, 0, 100)
-	var nextToken *string
+	var token cloud.PageToken
 
 	for {
-		result, err := c.Execute(ctx, "GetParametersByPath", func() (interface{}, error) {
-			return c.ssmClient.GetParametersByPath(ctx, &ssm.GetParametersByPathInput{
-				Path:           aws.String(path),
-				Recursive:      aws.Bool(recursive),
-				WithDecryption: aws.Bool(decrypt),
-				NextToken:      nextToken,
-			})
-		})
-
-		if err != nil {
-			return nil, c.GetLogger().WrapError(err, ErrGetParameter.Error())
-		}
-
-		response, err := client.SafeTypeAssert[*ssm.GetParametersByPathOutput](result)
+		page, err := c.GetParametersByPathPage(ctx, path, recursive, decrypt, token)
 		if err != nil {
-			return nil, c.GetLogger().WrapError(err, ErrGetParameter.Error())
-		}
-		for _, param := range response.Parameters {
-			allParams = append(allParams, mapParameter(&param))
+			return nil, err
 		}
+		allParams = append(allParams, page.Items...)
 
-		if response.NextToken == nil {
+		if !page.HasMore() {
 			break
 		}
-		nextToken = response.NextToken
+		token = page.Next
 	}
 
 	return allParams, nil
 }
 
+func (c *SSMClient) GetParametersByPathPage(ctx context.Context, path string, recursive, decrypt bool, token cloud.PageToken) (*cloud.Page[*Parameter], error) {
+	if path == "" {
+		return nil, ErrInvalidInput
+	}
+
+	input := &ssm.GetParametersByPathInput{
+		Path:           aws.String(path),
+		Recursive:      aws.Bool(recursive),
+		WithDecryption: aws.Bool(decrypt),
+	}
+	if !token.IsFirst() {
+		input.NextToken = aws.String(string(token))
+	}
+
+	result, err := c.ExecuteContext(ctx, "GetParametersByPath", func(ctx context.Context) (interface{}, error) {
+		return c.ssmClient.GetParametersByPath(ctx, input)
+	})
+
+	if err != nil {
+		return nil, c.GetLogger().WrapError(err, ErrGetParameter.Error())
+	}
+
+	response, err := client.SafeTypeAssert[*ssm.GetParametersByPathOutput](result)
+	if err != nil {
+		return nil, c.GetLogger().WrapError(err, ErrGetParameter.Error())
+	}
+
+	params := make([]*Parameter, 0, len(response.Parameters))
+	for _, param := range response.Parameters {
+		params = append(params, mapParameter(&param))
+	}
+
+	return &cloud.Page[*Parameter]{Items: params, Next: cloud.PageToken(aws.ToString(response.NextToken))}, nil
+}
+
 func (c *SSMClient) PutParameter(ctx context.Context, name, value, parameterType, description string, overwrite bool, tags map[string]string) error {
+	return c.putParameter(ctx, name, value, parameterType, "", description, overwrite, tags)
+}
+
+func (c *SSMClient) putParameter(ctx context.Context, name, value, parameterType, tier, description string, overwrite bool, tags map[string]string) error {
 	if name == "" || value == "" {
 		return ErrInvalidInput
 	}
@@ -149,7 +198,11 @@ func (c *SSMClient) PutParameter(ctx context.Context, name, value, parameterType
 		return fmt.Errorf("%w: parameter name %v", ErrInvalidInput, err)
 	}
 
-	if err := validation.GetGlobalValidator().Var(value, "max=4096"); err != nil {
+	maxSize, err := maxValueSize(tier)
+	if err != nil {
+		return err
+	}
+	if err := validation.GetGlobalValidator().Var(value, fmt.Sprintf("max=%d", maxSize)); err != nil {
 		return fmt.Errorf("%w: parameter value %v", ErrInvalidInput, err)
 	}
 
@@ -164,6 +217,10 @@ func (c *SSMClient) PutParameter(ctx context.Context, name, value, parameterType
 		Overwrite: aws.Bool(overwrite),
 	}
 
+	if tier != "" {
+		input.Tier = types.ParameterTier(tier)
+	}
+
 	if description != "" {
 		input.Description = aws.String(description)
 	}
@@ -179,7 +236,7 @@ func (c *SSMClient) PutParameter(ctx context.Context, name, value, parameterType
 		input.Tags = tagList
 	}
 
-	_, err := c.Execute(ctx, "PutParameter", func() (interface{}, error) {
+	_, err = c.ExecuteContext(ctx, "PutParameter", func(ctx context.Context) (interface{}, error) {
 		return c.ssmClient.PutParameter(ctx, input)
 	})
 
@@ -194,12 +251,42 @@ func (c *SSMClient) PutSecureParameter(ctx context.Context, name, value, descrip
 	return c.PutParameter(ctx, name, value, ParameterTypeSecureString, description, overwrite, tags)
 }
 
+func (c *SSMClient) PutParameterIfChanged(ctx context.Context, name, value, parameterType, description string, tags map[string]string) (bool, error) {
+	if name == "" || value == "" {
+		return false, ErrInvalidInput
+	}
+
+	if parameterType == "" {
+		parameterType = ParameterTypeString
+	}
+
+	current, err := c.GetParameter(ctx, name, true)
+	if err != nil && !isParameterNotFound(err) {
+		return false, err
+	}
+
+	if !parameterChanged(current, value, parameterType) {
+		return false, nil
+	}
+
+	if err := c.PutParameter(ctx, name, value, parameterType, description, true, tags); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// parameterChanged reports whether writing value with parameterType would change the
+// current parameter; a missing parameter (nil) counts as changed
+func parameterChanged(current *Parameter, value, parameterType string) bool {
+	return current == nil || current.Value != value || current.Type != parameterType
+}
+
 func (c *SSMClient) DeleteParameter(ctx context.Context, name string) error {
 	if name == "" {
 		return ErrInvalidInput
 	}
 
-	_, err := c.Execute(ctx, "DeleteParameter", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "DeleteParameter", func(ctx context.Context) (interface{}, error) {
 		return c.ssmClient.DeleteParameter(ctx, &ssm.DeleteParameterInput{
 			Name: aws.String(name),
 		})
@@ -217,7 +304,7 @@ func (c *SSMClient) DeleteParameters(ctx context.Context, names []string) (*Dele
 		return nil, ErrInvalidInput
 	}
 
-	result, err := c.Execute(ctx, "DeleteParameters", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "DeleteParameters", func(ctx context.Context) (interface{}, error) {
 		return c.ssmClient.DeleteParameters(ctx, &ssm.DeleteParametersInput{
 			Names: names,
 		})
@@ -246,7 +333,7 @@ func (c *SSMClient) GetParameterHistory(ctx context.Context, name string) ([]*Pa
 	var nextToken *string
 
 	for {
-		result, err := c.Execute(ctx, "GetParameterHistory", func() (interface{}, error) {
+		result, err := c.ExecuteContext(ctx, "GetParameterHistory", func(ctx context.Context) (interface{}, error) {
 			return c.ssmClient.GetParameterHistory(ctx, &ssm.GetParameterHistoryInput{
 				Name:      aws.String(name),
 				NextToken: nextToken,
@@ -287,7 +374,7 @@ func (c *SSMClient) AddTagsToResource(ctx context.Context, resourceType, resourc
 		})
 	}
 
-	_, err := c.Execute(ctx, "AddTagsToResource", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "AddTagsToResource", func(ctx context.Context) (interface{}, error) {
 		return c.ssmClient.AddTagsToResource(ctx, &ssm.AddTagsToResourceInput{
 			ResourceType: types.ResourceTypeForTagging(resourceType),
 			ResourceId:   aws.String(resourceID),
@@ -302,12 +389,44 @@ func (c *SSMClient) AddTagsToResource(ctx context.Context, resourceType, resourc
 	return nil
 }
 
+func (c *SSMClient) AddTagsToResources(ctx context.Context, resourceType string, ids []string, tags map[string]string, concurrency int) (map[string]error, error) {
+	if resourceType == "" || len(tags) == 0 {
+		return nil, ErrInvalidInput
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultTaggingConcurrency
+	}
+
+	tasks := make(map[string]task_executor.Tasker, len(ids))
+	for _, id := range ids {
+		tasks[id] = task_executor.Task[string, struct{}]{
+			Func: func(ctx context.Context, id string) (struct{}, error) {
+				return struct{}{}, c.AddTagsToResource(ctx, resourceType, id, tags)
+			},
+			Args: id,
+		}
+	}
+
+	poolResults := task_executor.WorkerPool(ctx, tasks, concurrency, task_executor.WithPrioritySupport(false))
+
+	results := make(map[string]error, len(ids))
+	for _, id := range ids {
+		res, ok := poolResults[id]
+		if !ok {
+			results[id] = cmp.Or(ctx.Err(), task_executor.ErrPoolCancelled)
+			continue
+		}
+		results[id] = res.Err
+	}
+	return results, ctx.Err()
+}
+
 func (c *SSMClient) ListTagsForResource(ctx context.Context, resourceType, resourceID string) (map[string]string, error) {
 	if resourceType == "" || resourceID == "" {
 		return nil, ErrInvalidInput
 	}
 
-	result, err := c.Execute(ctx, "ListTagsForResource", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "ListTagsForResource", func(ctx context.Context) (interface{}, error) {
 		return c.ssmClient.ListTagsForResource(ctx, &ssm.ListTagsForResourceInput{
 			ResourceType: types.ResourceTypeForTagging(resourceType),
 			ResourceId:   aws.String(resourceID),
@@ -335,8 +454,7 @@ func (c *SSMClient) ListTagsForResource(ctx context.Context, resourceType, resou
 func (c *SSMClient) ParameterExists(ctx context.Context, name string) (bool, error) {
 	_, err := c.GetParameter(ctx, name, false)
 	if err != nil {
-		var notFoundErr *types.ParameterNotFound
-		if errors.As(err, &notFoundErr) {
+		if isParameterNotFound(err) {
 			return false, nil
 		}
 		return false, err
@@ -344,6 +462,11 @@ func (c *SSMClient) ParameterExists(ctx context.Context, name string) (bool, err
 	return true, nil
 }
 
+func isParameterNotFound(err error) bool {
+	var notFoundErr *types.ParameterNotFound
+	return errors.As(err, &notFoundErr)
+}
+
 func (c *SSMClient) EnableLogging(enable bool) {
 	c.SetLogging(enable)
 }
@@ -0,0 +1,183 @@
+package ssm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParameterChanged(t *testing.T) {
+	current := &Parameter{Name: "/app/url", Value: "https://a", Type: ParameterTypeString}
+
+	tests := []struct {
+		name          string
+		current       *Parameter
+		value         string
+		parameterType string
+		want          bool
+	}{
+		{name: "not found", current: nil, value: "https://a", parameterType: ParameterTypeString, want: true},
+		{name: "same value and type", current: current, value: "https://a", parameterType: ParameterTypeString, want: false},
+		{name: "different value", current: current, value: "https://b", parameterType: ParameterTypeString, want: true},
+		{name: "different type", current: current, value: "https://a", parameterType: ParameterTypeSecureString, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parameterChanged(tt.current, tt.value, tt.parameterType))
+		})
+	}
+}
+
+func TestIsParameterNotFound(t *testing.T) {
+	notFound := &types.ParameterNotFound{}
+
+	assert.True(t, isParameterNotFound(notFound))
+	assert.True(t, isParameterNotFound(fmt.Errorf("error getting parameter: %w", notFound)))
+	assert.False(t, isParameterNotFound(errors.New("throttled")))
+}
+
+func TestAddTagsToResources(t *testing.T) {
+	var mu sync.Mutex
+	var tagged []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var input struct {
+			ResourceId string
+			Tags       []struct{ Key, Value string }
+		}
+		_ = json.Unmarshal(body, &input)
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		if input.ResourceId == "/app/missing" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"InvalidResourceId","message":"resource not found"}`))
+			return
+		}
+		mu.Lock()
+		tagged = append(tagged, input.ResourceId+":"+input.Tags[0].Key+"="+input.Tags[0].Value)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	svc := NewClient(aws.Config{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  aws.AnonymousCredentials{},
+	}, Config{}, nil)
+
+	results, err := svc.AddTagsToResources(context.Background(), "Parameter",
+		[]string{"/app/a", "/app/b", "/app/missing"}, map[string]string{"cost-center": "42"}, 2)
+
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.NoError(t, results["/app/a"])
+	assert.NoError(t, results["/app/b"])
+	var invalid *types.InvalidResourceId
+	assert.ErrorAs(t, results["/app/missing"], &invalid)
+
+	sort.Strings(tagged)
+	assert.Equal(t, []string{"/app/a:cost-center=42", "/app/b:cost-center=42"}, tagged)
+}
+
+func TestPutParameterJSON_Tiers(t *testing.T) {
+	var inputs []struct {
+		Name  string
+		Value string
+		Tier  string
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var input struct {
+			Name  string
+			Value string
+			Tier  string
+		}
+		_ = json.Unmarshal(body, &input)
+		inputs = append(inputs, input)
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		_, _ = w.Write([]byte(`{"Version":1,"Tier":"Advanced"}`))
+	}))
+	defer server.Close()
+
+	svc := NewClient(aws.Config{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  aws.AnonymousCredentials{},
+	}, Config{}, nil)
+	ctx := context.Background()
+	big := map[string]string{"blob": strings.Repeat("x", 6000)}
+
+	err := svc.PutParameterJSON(ctx, "/app/standard", big, ParameterTypeString, "", "", true, nil)
+	require.ErrorIs(t, err, ErrValueTooLarge)
+	assert.Empty(t, inputs, "oversized values never reach AWS")
+
+	require.NoError(t, svc.PutParameterJSON(ctx, "/app/advanced", big, ParameterTypeString, ParameterTierAdvanced, "", true, nil))
+	require.Len(t, inputs, 1)
+	assert.Equal(t, "/app/advanced", inputs[0].Name)
+	assert.Equal(t, ParameterTierAdvanced, inputs[0].Tier)
+	assert.Len(t, inputs[0].Value, 6000+len(`{"blob":""}`))
+}
+
+func TestGetParametersByPathPage(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var input struct{ NextToken string }
+		_ = json.Unmarshal(body, &input)
+		tokens = append(tokens, input.NextToken)
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		if input.NextToken == "" {
+			_, _ = w.Write([]byte(`{"Parameters":[{"Name":"/app/a","Value":"1"}],"NextToken":"page-2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"Parameters":[{"Name":"/app/b","Value":"2"}]}`))
+	}))
+	defer server.Close()
+
+	svc := NewClient(aws.Config{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  aws.AnonymousCredentials{},
+	}, Config{}, nil)
+	ctx := context.Background()
+
+	first, err := svc.GetParametersByPathPage(ctx, "/app/", true, false, "")
+	require.NoError(t, err)
+	require.Len(t, first.Items, 1)
+	assert.Equal(t, "/app/a", first.Items[0].Name)
+	assert.True(t, first.HasMore())
+
+	last, err := svc.GetParametersByPathPage(ctx, "/app/", true, false, first.Next)
+	require.NoError(t, err)
+	require.Len(t, last.Items, 1)
+	assert.Equal(t, "/app/b", last.Items[0].Name)
+	assert.False(t, last.HasMore())
+
+	all, err := svc.GetParametersByPath(ctx, "/app/", true, false)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+	assert.Equal(t, []string{"", "page-2", "", "page-2"}, tokens)
+}
+
+func TestAddTagsToResources_InvalidInput(t *testing.T) {
+	svc := NewClient(aws.Config{Region: "us-east-1"}, Config{}, nil)
+
+	_, err := svc.AddTagsToResources(context.Background(), "Parameter", []string{"/app/a"}, nil, 0)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
@@ -0,0 +1,65 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+)
+
+// ErrConsistentReadOnIndex is returned when ConsistentRead is used on a Query or Scan of
+// a secondary index
+var ErrConsistentReadOnIndex = errors.New("consistent read is not supported on secondary indexes")
+
+// ConsistentRead is an option for GetItem, Query and Scan (and their Typed variants)
+// that sets ConsistentRead on the input, for read-after-write consistency:
+//
+//	err := dc.GetItemTyped(ctx, "orders", key, &order, dynamo.ConsistentRead())
+//
+// Global secondary indexes only support eventually consistent reads, so a Query or Scan
+// with IndexName fails with ErrConsistentReadOnIndex before reaching DynamoDB. For a local
+// secondary index, set ConsistentRead on the input directly instead. Other operations
+// are left unchanged.
+func ConsistentRead() func(*dynamodb.Options) {
+	return withInputMiddleware(consistentReadMiddleware)
+}
+
+// withInputMiddleware returns an option that runs m before the operation input is
+// serialized, so it can adjust or reject the input
+func withInputMiddleware(m middleware.InitializeMiddleware) func(*dynamodb.Options) {
+	return func(o *dynamodb.Options) {
+		o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+			return stack.Initialize.Add(m, middleware.Before)
+		})
+	}
+}
+
+var consistentReadMiddleware = middleware.InitializeMiddlewareFunc("ConsistentRead",
+	func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+		if err := setConsistentRead(in.Parameters); err != nil {
+			return middleware.InitializeOutput{}, middleware.Metadata{}, err
+		}
+		return next.HandleInitialize(ctx, in)
+	})
+
+func setConsistentRead(params interface{}) error {
+	switch input := params.(type) {
+	case *dynamodb.GetItemInput:
+		input.ConsistentRead = aws.Bool(true)
+	case *dynamodb.QueryInput:
+		if input.IndexName != nil {
+			return retry_backoff.Permanent(fmt.Errorf("%w: %s", ErrConsistentReadOnIndex, aws.ToString(input.IndexName)))
+		}
+		input.ConsistentRead = aws.Bool(true)
+	case *dynamodb.ScanInput:
+		if input.IndexName != nil {
+			return retry_backoff.Permanent(fmt.Errorf("%w: %s", ErrConsistentReadOnIndex, aws.ToString(input.IndexName)))
+		}
+		input.ConsistentRead = aws.Bool(true)
+	}
+	return nil
+}
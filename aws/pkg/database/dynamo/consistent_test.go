@@ -0,0 +1,87 @@
+package dynamo
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedRead holds the read settings of one request seen by newRecordingDynamoClient
+type recordedRead struct {
+	ConsistentRead           *bool
+	ProjectionExpression     string
+	ExpressionAttributeNames map[string]string
+}
+
+// newRecordingDynamoClient points a real SDK client at a server that records the read
+// settings of each request
+func newRecordingDynamoClient(t *testing.T, response string) (*DynamoClient, *[]recordedRead) {
+	var reads []recordedRead
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var input recordedRead
+		_ = json.Unmarshal(body, &input)
+		reads = append(reads, input)
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	sdk := dynamodb.NewFromConfig(aws.Config{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  aws.AnonymousCredentials{},
+	})
+	return &DynamoClient{client: sdk, logger: &mockLogger{}}, &reads
+}
+
+func TestConsistentRead_SetsInput(t *testing.T) {
+	dc, reads := newRecordingDynamoClient(t, `{"Item":{"id":{"S":"o-1"}},"Items":[]}`)
+	ctx := context.Background()
+	key := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "o-1"}}
+
+	var item struct {
+		ID string `dynamodbav:"id"`
+	}
+	require.NoError(t, dc.GetItemTyped(ctx, "orders", key, &item, ConsistentRead()))
+	require.NoError(t, dc.GetItemTyped(ctx, "orders", key, &item))
+
+	var items []map[string]interface{}
+	_, err := dc.QueryTyped(ctx, &dynamodb.QueryInput{TableName: aws.String("orders")}, &items, ConsistentRead())
+	require.NoError(t, err)
+	_, err = dc.ScanTyped(ctx, &dynamodb.ScanInput{TableName: aws.String("orders")}, &items, ConsistentRead())
+	require.NoError(t, err)
+
+	assert.Equal(t, "o-1", item.ID)
+	consistent := make([]bool, len(*reads))
+	for i, read := range *reads {
+		consistent[i] = aws.ToBool(read.ConsistentRead)
+	}
+	assert.Equal(t, []bool{true, false, true, true}, consistent)
+}
+
+func TestConsistentRead_RejectsIndexQueries(t *testing.T) {
+	dc, reads := newRecordingDynamoClient(t, `{"Items":[]}`)
+	var items []map[string]interface{}
+
+	_, err := dc.QueryTyped(context.Background(), &dynamodb.QueryInput{
+		TableName: aws.String("orders"),
+		IndexName: aws.String("status-index"),
+	}, &items, ConsistentRead())
+
+	require.ErrorIs(t, err, ErrConsistentReadOnIndex)
+	assert.Contains(t, err.Error(), "status-index")
+	assert.True(t, retry_backoff.IsPermanent(err))
+	assert.Empty(t, *reads, "the request never reaches DynamoDB")
+}
@@ -14,15 +14,23 @@ const (
 	DefaultTimeout         = 30 * time.Second
 	DefaultQueryLimit      = int32(50)
 	DefaultMaxBatchItems   = 25
+	DefaultMaxBatchGetKeys = 100
+	DefaultBatchGetRetries = 5
+	DefaultBatchGetBackoff = 50 * time.Millisecond
 	DefaultItemNotFoundMsg = "item not found"
 )
 
 var (
-	ErrItemNotFound    = errors.New(DefaultItemNotFoundMsg)
-	ErrInvalidKey      = errors.New("invalid primary key")
-	ErrBatchSizeExceed = errors.New("batch size exceeds maximum allowed")
-	ErrMarshal         = errors.New("error serializing data")
-	ErrUnmarshal       = errors.New("error deserializing data")
+	ErrItemNotFound       = errors.New(DefaultItemNotFoundMsg)
+	ErrInvalidKey         = errors.New("invalid primary key")
+	ErrBatchSizeExceed    = errors.New("batch size exceeds maximum allowed")
+	ErrMarshal            = errors.New("error serializing data")
+	ErrUnmarshal          = errors.New("error deserializing data")
+	ErrUnprocessedKeys    = errors.New("keys remain unprocessed after retries")
+	ErrConditionFailed    = errors.New("conditional check failed")
+	ErrThroughputExceeded = errors.New("throughput exceeded")
+	ErrTableNotFound      = errors.New("table or index not found")
+	ErrEmptyUpdate        = errors.New("update has no SET, ADD or REMOVE actions")
 )
 
 type Service interface {
@@ -38,17 +46,30 @@ type Service interface {
 }
 
 type Config struct {
+	// Enabled set to false keeps the block in config but skips building and
+	// validating the client; nil (omitted) means enabled
+	Enabled        *bool             `mapstructure:"enabled" json:"enabled,omitempty"`
 	Endpoint       string            `mapstructure:"endpoint" json:"endpoint"`
+	Region         string            `mapstructure:"region" json:"region"`
 	TablePrefix    string            `mapstructure:"table_prefix" json:"table_prefix"`
 	EnableLogging  bool              `mapstructure:"enable_logging" json:"enable_logging"`
 	WithResilience bool              `mapstructure:"with_resilience" json:"with_resilience"`
 	Resilience     resilience.Config `mapstructure:"resilience" json:"resilience"`
+	SlowThreshold  time.Duration     `mapstructure:"slow_threshold" json:"slow_threshold"`
+}
+
+// IsEnabled reports whether the client should be built; true unless enabled: false
+func (c Config) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
 }
 
 type DynamoClient struct {
-	client      Service
-	logger      logger.Service
-	logging     bool
-	resilience  *resilience.Service
-	tablePrefix string
+	client        Service
+	logger        logger.Service
+	logging       bool
+	resilience    *resilience.Service
+	tablePrefix   string
+	slowThreshold time.Duration
+	// batchGetBackoff overrides DefaultBatchGetBackoff when positive
+	batchGetBackoff time.Duration
 }
@@ -0,0 +1,31 @@
+package dynamo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/smithy-go"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+)
+
+// normalizeDynamoError wraps DynamoDB API errors with the matching sentinel so callers
+// can use errors.Is. Conditional-check failures and missing tables are marked
+// retry_backoff.Permanent so the resilience layer does not retry them; throughput
+// errors stay retryable. Other errors are returned unchanged.
+func normalizeDynamoError(err error) error {
+	var apiErr smithy.APIError
+	if err == nil || !errors.As(err, &apiErr) {
+		return err
+	}
+
+	switch apiErr.ErrorCode() {
+	case "ConditionalCheckFailedException":
+		return retry_backoff.Permanent(fmt.Errorf("%w: %w", ErrConditionFailed, err))
+	case "ResourceNotFoundException":
+		return retry_backoff.Permanent(fmt.Errorf("%w: %w", ErrTableNotFound, err))
+	case "ProvisionedThroughputExceededException", "RequestLimitExceeded", "ThrottlingException":
+		return fmt.Errorf("%w: %w", ErrThroughputExceeded, err)
+	default:
+		return err
+	}
+}
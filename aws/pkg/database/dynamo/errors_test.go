@@ -0,0 +1,117 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+	"github.com/skolldire/go-engine/pkg/utilities/circuit_breaker"
+	"github.com/skolldire/go-engine/pkg/utilities/resilience"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeDynamoError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		sentinel  error
+		permanent bool
+	}{
+		{
+			name:      "conditional check failed",
+			err:       &types.ConditionalCheckFailedException{Message: aws.String("the conditional request failed")},
+			sentinel:  ErrConditionFailed,
+			permanent: true,
+		},
+		{
+			name:     "provisioned throughput exceeded",
+			err:      &types.ProvisionedThroughputExceededException{Message: aws.String("rate exceeded")},
+			sentinel: ErrThroughputExceeded,
+		},
+		{
+			name:     "request limit exceeded",
+			err:      &types.RequestLimitExceeded{Message: aws.String("account limit")},
+			sentinel: ErrThroughputExceeded,
+		},
+		{
+			name:     "throttling",
+			err:      &smithy.GenericAPIError{Code: "ThrottlingException"},
+			sentinel: ErrThroughputExceeded,
+		},
+		{
+			name:      "resource not found",
+			err:       &types.ResourceNotFoundException{Message: aws.String("requested resource not found")},
+			sentinel:  ErrTableNotFound,
+			permanent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := normalizeDynamoError(tt.err)
+			assert.ErrorIs(t, err, tt.sentinel)
+			assert.ErrorIs(t, err, tt.err, "the original AWS error stays in the chain")
+			assert.Equal(t, tt.permanent, retry_backoff.IsPermanent(err))
+		})
+	}
+}
+
+func TestNormalizeDynamoError_PassThrough(t *testing.T) {
+	assert.NoError(t, normalizeDynamoError(nil))
+
+	plain := errors.New("dial tcp: connection refused")
+	assert.Same(t, plain, normalizeDynamoError(plain))
+
+	validation := &smithy.GenericAPIError{Code: "ValidationException"}
+	assert.Same(t, validation, normalizeDynamoError(validation))
+}
+
+// putItemStub fails every PutItem with err and counts the attempts
+type putItemStub struct {
+	Service
+	err   error
+	calls int
+}
+
+func (s *putItemStub) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	s.calls++
+	return nil, s.err
+}
+
+func newResilientClient(stub *putItemStub) *DynamoClient {
+	log := &mockLogger{}
+	return &DynamoClient{
+		client: stub,
+		logger: log,
+		resilience: resilience.NewResilienceService(resilience.Config{
+			RetryConfig:          &retry_backoff.Config{MaxRetries: 2, InitialWaitTime: 1, MaxWaitTime: 1},
+			CircuitBreakerConfig: &circuit_breaker.Config{Name: "dynamo-test"},
+		}, log),
+	}
+}
+
+func TestExecute_RetriesThroughputButNotConditionalFailures(t *testing.T) {
+	throttled := &putItemStub{err: &types.ProvisionedThroughputExceededException{Message: aws.String("rate exceeded")}}
+	_, err := newResilientClient(throttled).PutItem(context.Background(), &dynamodb.PutItemInput{})
+	assert.ErrorIs(t, err, ErrThroughputExceeded)
+	assert.Equal(t, 3, throttled.calls, "throughput errors are retried")
+
+	conditional := &putItemStub{err: &types.ConditionalCheckFailedException{Message: aws.String("the conditional request failed")}}
+	_, err = newResilientClient(conditional).PutItem(context.Background(), &dynamodb.PutItemInput{})
+	require.ErrorIs(t, err, ErrConditionFailed)
+	assert.Equal(t, 1, conditional.calls, "conditional failures are not retried")
+}
+
+func TestExecute_NormalizesWithoutResilience(t *testing.T) {
+	stub := &putItemStub{err: &types.ResourceNotFoundException{Message: aws.String("requested resource not found")}}
+	dc := &DynamoClient{client: stub, logger: &mockLogger{}}
+
+	_, err := dc.PutItem(context.Background(), &dynamodb.PutItemInput{})
+	assert.ErrorIs(t, err, ErrTableNotFound)
+}
@@ -0,0 +1,226 @@
+package dynamo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultIdempotencyTable is the table used by Idempotent (TablePrefix applies). Its
+	// partition key is the string attribute "id"; enable DynamoDB TTL on "expires_at".
+	DefaultIdempotencyTable = "idempotency"
+	// DefaultIdempotencyTTL is used when Idempotent receives a zero ttl
+	DefaultIdempotencyTTL = 24 * time.Hour
+	// DefaultIdempotencyInProgressTTL bounds a claim while fn runs when ctx has no deadline;
+	// it matches the maximum Lambda timeout
+	DefaultIdempotencyInProgressTTL = 15 * time.Minute
+
+	// idempotencyReleaseTimeout bounds the release of a key after fn fails
+	idempotencyReleaseTimeout = 5 * time.Second
+
+	idempotencyKeyAttr     = "id"
+	idempotencyStatusAttr  = "status"
+	idempotencyResultAttr  = "result"
+	idempotencyExpiresAttr = "expires_at"
+	idempotencyClaimAttr   = "claim"
+
+	idempotencyInProgress = "in_progress"
+	idempotencyCompleted  = "completed"
+)
+
+// ErrIdempotencyInProgress is returned on replay while the first call for the key is still
+// running, or was released by a failure in the meantime; retry later
+var ErrIdempotencyInProgress = errors.New("idempotent operation in progress")
+
+// ErrIdempotencyClaimLost is returned with fn's result when the claim expired while fn ran
+// and another call took the key over, so the result was not recorded
+var ErrIdempotencyClaimLost = errors.New("idempotency claim lost")
+
+// IdempotencyOption configures Idempotent and IdempotentInTable
+type IdempotencyOption func(*idempotencyOptions)
+
+type idempotencyOptions struct {
+	inProgressTTL time.Duration
+}
+
+// WithInProgressTTL sets how long a claim holds the key while fn runs. When the process
+// dies inside fn (a crash or a Lambda timeout), a retry can take the key over once this
+// expires instead of getting ErrIdempotencyInProgress until ttl. The default is the time
+// left before ctx's deadline, or DefaultIdempotencyInProgressTTL without one.
+func WithInProgressTTL(d time.Duration) IdempotencyOption {
+	return func(o *idempotencyOptions) {
+		o.inProgressTTL = d
+	}
+}
+
+// Idempotent runs fn at most once per key within ttl, using DefaultIdempotencyTable.
+// See IdempotentInTable.
+func Idempotent(ctx context.Context, store *DynamoClient, key string, ttl time.Duration, fn func() (interface{}, error), opts ...IdempotencyOption) (interface{}, error) {
+	return IdempotentInTable(ctx, store, DefaultIdempotencyTable, key, ttl, fn, opts...)
+}
+
+// claimTTL returns how long a claim lasts while fn runs, never more than ttl
+func (o idempotencyOptions) claimTTL(ctx context.Context, ttl time.Duration) time.Duration {
+	claim := o.inProgressTTL
+	if claim <= 0 {
+		claim = DefaultIdempotencyInProgressTTL
+		if deadline, ok := ctx.Deadline(); ok {
+			claim = max(time.Until(deadline), time.Second)
+		}
+	}
+	return min(claim, ttl)
+}
+
+// IdempotentInTable claims key in table with a conditional put, runs fn and stores its
+// JSON-encoded result. Later calls with the same key within ttl do not run fn: they return
+// the stored result decoded into interface{} (objects become map[string]interface{},
+// numbers float64), or ErrIdempotencyInProgress while the first call is running.
+//
+// While fn runs the claim only lasts the in-progress TTL (see WithInProgressTTL), so a
+// call that dies inside fn does not lock the key for ttl; the completed result is kept
+// for ttl. When fn fails the key is released so a retry runs fn again. Expired keys are
+// reclaimed even before DynamoDB's TTL sweep removes them.
+//
+// Each claim carries a random token that the completion and the release are conditioned
+// on, so a call whose claim expired and was taken over never overwrites or deletes the
+// new owner's item; its completion then fails with ErrIdempotencyClaimLost.
+func IdempotentInTable(ctx context.Context, store *DynamoClient, table, key string, ttl time.Duration, fn func() (interface{}, error), opts ...IdempotencyOption) (interface{}, error) {
+	if key == "" {
+		return nil, ErrInvalidKey
+	}
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	var options idempotencyOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	tableName := aws.String(store.TableName(table))
+	itemKey := map[string]types.AttributeValue{idempotencyKeyAttr: &types.AttributeValueMemberS{Value: key}}
+	claim := &types.AttributeValueMemberS{Value: uuid.NewString()}
+	now := time.Now()
+
+	_, err := store.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: tableName,
+		Item: map[string]types.AttributeValue{
+			idempotencyKeyAttr:     &types.AttributeValueMemberS{Value: key},
+			idempotencyStatusAttr:  &types.AttributeValueMemberS{Value: idempotencyInProgress},
+			idempotencyExpiresAttr: unixSeconds(now.Add(options.claimTTL(ctx, ttl))),
+			idempotencyClaimAttr:   claim,
+		},
+		ConditionExpression:      aws.String("attribute_not_exists(#id) OR #expires_at < :now"),
+		ExpressionAttributeNames: map[string]string{"#id": idempotencyKeyAttr, "#expires_at": idempotencyExpiresAttr},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": unixSeconds(now),
+		},
+	})
+	if errors.Is(err, ErrConditionFailed) {
+		return replayIdempotent(ctx, store, tableName, itemKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := fn()
+	if err != nil {
+		if relErr := releaseIdempotencyKey(ctx, store, tableName, itemKey, claim); relErr != nil {
+			return nil, errors.Join(err, fmt.Errorf("releasing idempotency key %s: %w", key, relErr))
+		}
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return result, fmt.Errorf("%w: idempotent result for %s: %w", ErrMarshal, key, err)
+	}
+
+	_, err = store.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:           tableName,
+		Key:                 itemKey,
+		UpdateExpression:    aws.String("SET #status = :completed, #result = :result, #expires_at = :expires_at"),
+		ConditionExpression: aws.String("#claim = :claim"),
+		ExpressionAttributeNames: map[string]string{
+			"#status":     idempotencyStatusAttr,
+			"#result":     idempotencyResultAttr,
+			"#expires_at": idempotencyExpiresAttr,
+			"#claim":      idempotencyClaimAttr,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":completed":  &types.AttributeValueMemberS{Value: idempotencyCompleted},
+			":result":     &types.AttributeValueMemberS{Value: string(encoded)},
+			":expires_at": unixSeconds(now.Add(ttl)),
+			":claim":      claim,
+		},
+	})
+	if errors.Is(err, ErrConditionFailed) {
+		return result, fmt.Errorf("%w: %s", ErrIdempotencyClaimLost, key)
+	}
+	if err != nil {
+		return result, fmt.Errorf("recording idempotent result for %s: %w", key, err)
+	}
+	return result, nil
+}
+
+// releaseIdempotencyKey deletes a claim after fn failed so a retry runs fn again. It runs
+// even when ctx is already cancelled, and leaves the item alone if it no longer holds
+// claim (another call took over the expired claim).
+func releaseIdempotencyKey(ctx context.Context, store *DynamoClient, tableName *string, itemKey map[string]types.AttributeValue, claim types.AttributeValue) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), idempotencyReleaseTimeout)
+	defer cancel()
+
+	_, err := store.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName:                 tableName,
+		Key:                       itemKey,
+		ConditionExpression:       aws.String("#claim = :claim"),
+		ExpressionAttributeNames:  map[string]string{"#claim": idempotencyClaimAttr},
+		ExpressionAttributeValues: map[string]types.AttributeValue{":claim": claim},
+	})
+	if errors.Is(err, ErrConditionFailed) {
+		return nil
+	}
+	return err
+}
+
+// replayIdempotent returns the stored result of a key claimed by an earlier call
+func replayIdempotent(ctx context.Context, store *DynamoClient, tableName *string, itemKey map[string]types.AttributeValue) (interface{}, error) {
+	out, err := store.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      tableName,
+		Key:            itemKey,
+		ConsistentRead: aws.Bool(true),
+	})
+	if errors.Is(err, ErrItemNotFound) {
+		return nil, ErrIdempotencyInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status, _ := out.Item[idempotencyStatusAttr].(*types.AttributeValueMemberS)
+	if status == nil || status.Value != idempotencyCompleted {
+		return nil, ErrIdempotencyInProgress
+	}
+
+	encoded, _ := out.Item[idempotencyResultAttr].(*types.AttributeValueMemberS)
+	if encoded == nil {
+		return nil, nil
+	}
+	var result interface{}
+	if err := json.Unmarshal([]byte(encoded.Value), &result); err != nil {
+		return nil, fmt.Errorf("%w: idempotent result: %w", ErrUnmarshal, err)
+	}
+	return result, nil
+}
+
+func unixSeconds(t time.Time) *types.AttributeValueMemberN {
+	return &types.AttributeValueMemberN{Value: strconv.FormatInt(t.Unix(), 10)}
+}
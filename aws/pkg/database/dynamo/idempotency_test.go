@@ -0,0 +1,268 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// idempotencyStub keeps one table in memory and evaluates the conditions of
+// IdempotentInTable: a put fails while an unexpired item holds the key, and the update
+// and delete fail once the item carries another claim token
+type idempotencyStub struct {
+	Service
+	items        map[string]map[string]types.AttributeValue
+	tables       []string
+	deleteCtxErr error
+}
+
+func newIdempotencyStub() *idempotencyStub {
+	return &idempotencyStub{items: map[string]map[string]types.AttributeValue{}}
+}
+
+func stubKey(key map[string]types.AttributeValue) string {
+	return key[idempotencyKeyAttr].(*types.AttributeValueMemberS).Value
+}
+
+func (s *idempotencyStub) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	s.tables = append(s.tables, aws.ToString(params.TableName))
+	id := stubKey(params.Item)
+	if existing, ok := s.items[id]; ok {
+		expires, _ := strconv.ParseInt(existing[idempotencyExpiresAttr].(*types.AttributeValueMemberN).Value, 10, 64)
+		now, _ := strconv.ParseInt(params.ExpressionAttributeValues[":now"].(*types.AttributeValueMemberN).Value, 10, 64)
+		if expires >= now {
+			return nil, &types.ConditionalCheckFailedException{Message: aws.String("the conditional request failed")}
+		}
+	}
+	s.items[id] = params.Item
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (s *idempotencyStub) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	return &dynamodb.GetItemOutput{Item: s.items[stubKey(params.Key)]}, nil
+}
+
+// holdsClaim reports whether the item for key still carries the :claim token
+func (s *idempotencyStub) holdsClaim(key map[string]types.AttributeValue, values map[string]types.AttributeValue) bool {
+	claim, _ := s.items[stubKey(key)][idempotencyClaimAttr].(*types.AttributeValueMemberS)
+	return claim != nil && claim.Value == values[":claim"].(*types.AttributeValueMemberS).Value
+}
+
+func (s *idempotencyStub) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	if !s.holdsClaim(params.Key, params.ExpressionAttributeValues) {
+		return nil, &types.ConditionalCheckFailedException{Message: aws.String("the conditional request failed")}
+	}
+	item := s.items[stubKey(params.Key)]
+	item[idempotencyStatusAttr] = params.ExpressionAttributeValues[":completed"]
+	item[idempotencyResultAttr] = params.ExpressionAttributeValues[":result"]
+	item[idempotencyExpiresAttr] = params.ExpressionAttributeValues[":expires_at"]
+	return &dynamodb.UpdateItemOutput{}, nil
+}
+
+func (s *idempotencyStub) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	s.deleteCtxErr = ctx.Err()
+	if !s.holdsClaim(params.Key, params.ExpressionAttributeValues) {
+		return nil, &types.ConditionalCheckFailedException{Message: aws.String("the conditional request failed")}
+	}
+	delete(s.items, stubKey(params.Key))
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func newIdempotencyClient(stub *idempotencyStub) *DynamoClient {
+	return &DynamoClient{client: stub, logger: &mockLogger{}, tablePrefix: "dev"}
+}
+
+func TestIdempotent_RunsOnceAndReplaysResult(t *testing.T) {
+	stub := newIdempotencyStub()
+	dc := newIdempotencyClient(stub)
+	ctx := context.Background()
+
+	calls := 0
+	fn := func() (interface{}, error) {
+		calls++
+		return map[string]interface{}{"order_id": "o-1", "total": 42}, nil
+	}
+
+	first, err := Idempotent(ctx, dc, "order-o-1", 0, fn)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"order_id": "o-1", "total": 42}, first)
+
+	replay, err := Idempotent(ctx, dc, "order-o-1", 0, fn)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"order_id": "o-1", "total": float64(42)}, replay)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []string{"dev-idempotency", "dev-idempotency"}, stub.tables)
+}
+
+func TestIdempotent_FailureReleasesKey(t *testing.T) {
+	stub := newIdempotencyStub()
+	dc := newIdempotencyClient(stub)
+	boom := errors.New("boom")
+
+	_, err := Idempotent(context.Background(), dc, "k", 0, func() (interface{}, error) { return nil, boom })
+	require.ErrorIs(t, err, boom)
+	assert.Empty(t, stub.items)
+
+	result, err := Idempotent(context.Background(), dc, "k", 0, func() (interface{}, error) { return "ok", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result)
+}
+
+func TestIdempotent_InProgress(t *testing.T) {
+	stub := newIdempotencyStub()
+	dc := newIdempotencyClient(stub)
+
+	_, err := Idempotent(context.Background(), dc, "k", 0, func() (interface{}, error) {
+		_, err := Idempotent(context.Background(), dc, "k", 0, func() (interface{}, error) {
+			t.Fatal("nested call must not run fn")
+			return nil, nil
+		})
+		assert.ErrorIs(t, err, ErrIdempotencyInProgress)
+		return "done", nil
+	})
+	require.NoError(t, err)
+}
+
+func TestIdempotent_ReclaimsExpiredKey(t *testing.T) {
+	stub := newIdempotencyStub()
+	dc := newIdempotencyClient(stub)
+	stub.items["k"] = map[string]types.AttributeValue{
+		idempotencyKeyAttr:     &types.AttributeValueMemberS{Value: "k"},
+		idempotencyStatusAttr:  &types.AttributeValueMemberS{Value: idempotencyCompleted},
+		idempotencyResultAttr:  &types.AttributeValueMemberS{Value: `"stale"`},
+		idempotencyExpiresAttr: &types.AttributeValueMemberN{Value: "1"},
+	}
+
+	result, err := Idempotent(context.Background(), dc, "k", 0, func() (interface{}, error) { return "fresh", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "fresh", result)
+}
+
+// expiresIn returns how far from now the stored item for key expires
+func expiresIn(t *testing.T, stub *idempotencyStub, key string) time.Duration {
+	t.Helper()
+	expires, err := strconv.ParseInt(stub.items[key][idempotencyExpiresAttr].(*types.AttributeValueMemberN).Value, 10, 64)
+	require.NoError(t, err)
+	return time.Until(time.Unix(expires, 0))
+}
+
+func TestIdempotent_InProgressClaimIsShort(t *testing.T) {
+	stub := newIdempotencyStub()
+	dc := newIdempotencyClient(stub)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	_, err := Idempotent(ctx, dc, "lambda", time.Hour, func() (interface{}, error) {
+		assert.InDelta(t, 30*time.Second, expiresIn(t, stub, "lambda"), float64(2*time.Second),
+			"the claim follows the ctx deadline (the function timeout)")
+		return "ok", nil
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, time.Hour, expiresIn(t, stub, "lambda"), float64(2*time.Second), "the result is kept for ttl")
+
+	_, err = Idempotent(context.Background(), dc, "explicit", time.Hour, func() (interface{}, error) {
+		assert.InDelta(t, 2*time.Minute, expiresIn(t, stub, "explicit"), float64(2*time.Second))
+		return "ok", nil
+	}, WithInProgressTTL(2*time.Minute))
+	require.NoError(t, err)
+
+	_, err = Idempotent(context.Background(), dc, "default", time.Hour, func() (interface{}, error) {
+		assert.InDelta(t, DefaultIdempotencyInProgressTTL, expiresIn(t, stub, "default"), float64(2*time.Second))
+		return "ok", nil
+	})
+	require.NoError(t, err)
+}
+
+func TestIdempotent_TakesOverExpiredInProgressClaim(t *testing.T) {
+	stub := newIdempotencyStub()
+	dc := newIdempotencyClient(stub)
+	// left behind by a call that crashed inside fn
+	stub.items["k"] = map[string]types.AttributeValue{
+		idempotencyKeyAttr:     &types.AttributeValueMemberS{Value: "k"},
+		idempotencyStatusAttr:  &types.AttributeValueMemberS{Value: idempotencyInProgress},
+		idempotencyExpiresAttr: unixSeconds(time.Now().Add(-time.Second)),
+	}
+
+	result, err := Idempotent(context.Background(), dc, "k", 0, func() (interface{}, error) { return "retried", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "retried", result)
+}
+
+func TestIdempotent_ReleaseSurvivesCancelledContext(t *testing.T) {
+	stub := newIdempotencyStub()
+	dc := newIdempotencyClient(stub)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	_, err := Idempotent(ctx, dc, "k", 0, func() (interface{}, error) {
+		cancel() // e.g. the Lambda deadline passed while fn ran
+		return nil, errors.New("boom")
+	})
+
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, context.Canceled)
+	assert.NoError(t, stub.deleteCtxErr)
+	assert.Empty(t, stub.items, "the key is released")
+}
+
+// takeOver replaces the claim on key as another call does once the claim expires
+func takeOver(stub *idempotencyStub, key string) map[string]types.AttributeValue {
+	item := map[string]types.AttributeValue{
+		idempotencyKeyAttr:     &types.AttributeValueMemberS{Value: key},
+		idempotencyStatusAttr:  &types.AttributeValueMemberS{Value: idempotencyInProgress},
+		idempotencyExpiresAttr: unixSeconds(time.Now().Add(time.Minute)),
+		idempotencyClaimAttr:   &types.AttributeValueMemberS{Value: "other-claim"},
+	}
+	stub.items[key] = item
+	return item
+}
+
+func TestIdempotent_ReleaseKeepsTakenOverItem(t *testing.T) {
+	stub := newIdempotencyStub()
+	dc := newIdempotencyClient(stub)
+	boom := errors.New("boom")
+
+	var owner map[string]types.AttributeValue
+	_, err := Idempotent(context.Background(), dc, "k", 0, func() (interface{}, error) {
+		// our claim expired and another call took the key over, still in progress
+		owner = takeOver(stub, "k")
+		return nil, boom
+	})
+
+	assert.ErrorIs(t, err, boom)
+	assert.NotErrorIs(t, err, ErrConditionFailed)
+	assert.Equal(t, owner, stub.items["k"], "the new owner's claim is not deleted")
+}
+
+func TestIdempotent_CompletionAfterLostClaim(t *testing.T) {
+	stub := newIdempotencyStub()
+	dc := newIdempotencyClient(stub)
+
+	var owner map[string]types.AttributeValue
+	result, err := Idempotent(context.Background(), dc, "k", 0, func() (interface{}, error) {
+		owner = takeOver(stub, "k")
+		return "late", nil
+	})
+
+	assert.ErrorIs(t, err, ErrIdempotencyClaimLost)
+	assert.Equal(t, "late", result)
+	assert.Equal(t, idempotencyInProgress, owner[idempotencyStatusAttr].(*types.AttributeValueMemberS).Value,
+		"the new owner's claim is not overwritten")
+	assert.NotContains(t, owner, idempotencyResultAttr)
+}
+
+func TestIdempotent_EmptyKey(t *testing.T) {
+	_, err := Idempotent(context.Background(), newIdempotencyClient(newIdempotencyStub()), "", 0, func() (interface{}, error) {
+		t.Fatal("fn must not run")
+		return nil, nil
+	})
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
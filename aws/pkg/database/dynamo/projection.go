@@ -0,0 +1,91 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+)
+
+// ErrInvalidProjection is returned when ProjectionAttributes receives an empty attribute
+// name or path segment
+var ErrInvalidProjection = errors.New("invalid projection attribute")
+
+// projectionAliasPrefix keeps projection aliases apart from the #0, #1... names
+// generated by the expression builder
+const projectionAliasPrefix = "#proj"
+
+// ProjectionAttributes is an option for GetItem, Query and Scan (and their Typed
+// variants) that fetches only attrs. It builds ProjectionExpression and aliases every
+// name through ExpressionAttributeNames, so reserved words such as "name" or "status"
+// need no special handling. Nested map attributes use dots ("address.city"); list
+// indexes are not supported. Names already on the input are kept.
+//
+//	err := dc.GetItemTyped(ctx, "users", key, &user, dynamo.ProjectionAttributes("id", "name", "status"))
+func ProjectionAttributes(attrs ...string) func(*dynamodb.Options) {
+	if len(attrs) == 0 {
+		return func(*dynamodb.Options) {}
+	}
+	return withInputMiddleware(middleware.InitializeMiddlewareFunc("ProjectionAttributes",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			if err := setProjection(in.Parameters, attrs); err != nil {
+				return middleware.InitializeOutput{}, middleware.Metadata{}, err
+			}
+			return next.HandleInitialize(ctx, in)
+		}))
+}
+
+func setProjection(params interface{}, attrs []string) error {
+	switch input := params.(type) {
+	case *dynamodb.GetItemInput:
+		return applyProjection(&input.ProjectionExpression, &input.ExpressionAttributeNames, attrs)
+	case *dynamodb.QueryInput:
+		return applyProjection(&input.ProjectionExpression, &input.ExpressionAttributeNames, attrs)
+	case *dynamodb.ScanInput:
+		return applyProjection(&input.ProjectionExpression, &input.ExpressionAttributeNames, attrs)
+	}
+	return nil
+}
+
+func applyProjection(projection **string, names *map[string]string, attrs []string) error {
+	expr, merged, err := buildProjection(attrs, *names)
+	if err != nil {
+		return err
+	}
+	*projection, *names = aws.String(expr), merged
+	return nil
+}
+
+// buildProjection returns the projection expression for attrs and a copy of names with
+// one alias per distinct path segment added
+func buildProjection(attrs []string, names map[string]string) (string, map[string]string, error) {
+	merged := make(map[string]string, len(names)+len(attrs))
+	maps.Copy(merged, names)
+
+	aliases := make(map[string]string, len(attrs))
+	paths := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		segments := strings.Split(attr, ".")
+		for i, segment := range segments {
+			if segment == "" {
+				return "", nil, retry_backoff.Permanent(fmt.Errorf("%w: %q", ErrInvalidProjection, attr))
+			}
+			alias, ok := aliases[segment]
+			if !ok {
+				alias = projectionAliasPrefix + strconv.Itoa(len(aliases))
+				aliases[segment] = alias
+				merged[alias] = segment
+			}
+			segments[i] = alias
+		}
+		paths = append(paths, strings.Join(segments, "."))
+	}
+	return strings.Join(paths, ", "), merged, nil
+}
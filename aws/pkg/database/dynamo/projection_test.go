@@ -0,0 +1,76 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildProjection_AliasesReservedWords(t *testing.T) {
+	expr, names, err := buildProjection([]string{"name", "status", "address.name", "data.size"}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "#proj0, #proj1, #proj2.#proj0, #proj3.#proj4", expr)
+	assert.Equal(t, map[string]string{
+		"#proj0": "name",
+		"#proj1": "status",
+		"#proj2": "address",
+		"#proj3": "data",
+		"#proj4": "size",
+	}, names)
+}
+
+func TestBuildProjection_KeepsExistingNames(t *testing.T) {
+	existing := map[string]string{"#0": "status"}
+
+	_, names, err := buildProjection([]string{"id"}, existing)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"#0": "status", "#proj0": "id"}, names)
+	assert.Len(t, existing, 1, "the caller's map is not modified")
+}
+
+func TestBuildProjection_EmptySegment(t *testing.T) {
+	_, _, err := buildProjection([]string{"address..city"}, nil)
+	assert.ErrorIs(t, err, ErrInvalidProjection)
+}
+
+func TestProjectionAttributes_SetsInput(t *testing.T) {
+	dc, reads := newRecordingDynamoClient(t, `{"Item":{"id":{"S":"u-1"},"name":{"S":"Ana"}},"Items":[]}`)
+	ctx := context.Background()
+	key := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "u-1"}}
+
+	var user struct {
+		ID   string `dynamodbav:"id"`
+		Name string `dynamodbav:"name"`
+	}
+	require.NoError(t, dc.GetItemTyped(ctx, "users", key, &user, ProjectionAttributes("id", "name")))
+	assert.Equal(t, "Ana", user.Name)
+
+	keyCond := expression.Key("status").Equal(expression.Value("active"))
+	var users []map[string]interface{}
+	_, err := dc.QueryIndex(ctx, "users", "status-index", keyCond, &users, ProjectionAttributes("id", "name"))
+	require.NoError(t, err)
+
+	require.Len(t, *reads, 2)
+	assert.Equal(t, "#proj0, #proj1", (*reads)[0].ProjectionExpression)
+	assert.Equal(t, map[string]string{"#proj0": "id", "#proj1": "name"}, (*reads)[0].ExpressionAttributeNames)
+	assert.Equal(t, "#proj0, #proj1", (*reads)[1].ProjectionExpression)
+	assert.Equal(t, map[string]string{"#0": "status", "#proj0": "id", "#proj1": "name"}, (*reads)[1].ExpressionAttributeNames)
+}
+
+func TestProjectionAttributes_NoAttributesIsNoop(t *testing.T) {
+	dc, reads := newRecordingDynamoClient(t, `{"Items":[]}`)
+	var items []map[string]interface{}
+
+	_, err := dc.ScanTyped(context.Background(), &dynamodb.ScanInput{TableName: aws.String("users")}, &items, ProjectionAttributes())
+
+	require.NoError(t, err)
+	assert.Empty(t, (*reads)[0].ProjectionExpression)
+}
@@ -3,9 +3,11 @@ package dynamo
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/skolldire/go-engine/pkg/core/client"
@@ -21,7 +23,12 @@ func (dc *DynamoClient) TableName(name string) string {
 }
 
 func NewClient(acf aws.Config, cfg Config, log logger.Service) Service {
+	log = logger.OrNoop(log)
+
 	client := dynamodb.NewFromConfig(acf, func(o *dynamodb.Options) {
+		if cfg.Region != "" {
+			o.Region = cfg.Region
+		}
 		if cfg.Endpoint != "" {
 			o.BaseEndpoint = aws.String(cfg.Endpoint)
 			log.Debug(context.Background(), "connecting to external endpoint", map[string]interface{}{"endpoint": cfg.Endpoint})
@@ -31,10 +38,11 @@ func NewClient(acf aws.Config, cfg Config, log logger.Service) Service {
 	})
 
 	dc := &DynamoClient{
-		client:      client,
-		logger:      log,
-		logging:     cfg.EnableLogging,
-		tablePrefix: cfg.TablePrefix,
+		client:        client,
+		logger:        log,
+		logging:       cfg.EnableLogging,
+		tablePrefix:   cfg.TablePrefix,
+		slowThreshold: cfg.SlowThreshold,
 	}
 
 	if cfg.WithResilience {
@@ -45,41 +53,23 @@ func NewClient(acf aws.Config, cfg Config, log logger.Service) Service {
 	return dc
 }
 
-func (dc *DynamoClient) execute(ctx context.Context, operationName string, operation func() (interface{}, error)) (interface{}, error) {
+func (dc *DynamoClient) execute(ctx context.Context, operationName string, call client.ContextOperation) (interface{}, error) {
 	ctx, cancel := dc.ensureContextWithTimeout(ctx)
 	defer cancel()
 
-	logFields := map[string]interface{}{"operation": operationName}
-
-	if dc.resilience != nil {
-		if dc.logging {
-			dc.logger.Debug(ctx, fmt.Sprintf("starting DynamoDB operation with resilience: %s", operationName), logFields)
-		}
-
-		result, err := dc.resilience.Execute(ctx, operation)
-
-		if err != nil && dc.logging {
-			dc.logger.Error(ctx, fmt.Errorf("error in DynamoDB operation: %w", err), logFields)
-		} else if dc.logging {
-			dc.logger.Debug(ctx, fmt.Sprintf("DynamoDB operation completed with resilience: %s", operationName), logFields)
-		}
-
-		return result, err
-	}
-
-	if dc.logging {
-		dc.logger.Debug(ctx, fmt.Sprintf("starting DynamoDB operation: %s", operationName), logFields)
-	}
-
-	result, err := operation()
-
-	if err != nil && dc.logging {
-		dc.logger.Error(ctx, err, logFields)
-	} else if dc.logging {
-		dc.logger.Debug(ctx, fmt.Sprintf("DynamoDB operation completed: %s", operationName), logFields)
+	operation := func(ctx context.Context) (interface{}, error) {
+		result, err := call(ctx)
+		return result, normalizeDynamoError(err)
 	}
 
-	return result, err
+	return client.ExecuteContext(ctx, client.ExecuteOptions{
+		Service:       "DynamoDB",
+		Operation:     operationName,
+		Logger:        dc.logger,
+		Logging:       dc.logging,
+		Resilience:    dc.resilience,
+		SlowThreshold: dc.slowThreshold,
+	}, operation)
 }
 
 func (dc *DynamoClient) ensureContextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
@@ -90,7 +80,7 @@ func (dc *DynamoClient) ensureContextWithTimeout(ctx context.Context) (context.C
 }
 
 func (dc *DynamoClient) GetItem(ctx context.Context, input *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
-	result, err := dc.execute(ctx, "GetItem", func() (interface{}, error) {
+	result, err := dc.execute(ctx, "GetItem", func(ctx context.Context) (interface{}, error) {
 		return dc.client.GetItem(ctx, input, optFns...)
 	})
 
@@ -130,7 +120,7 @@ func (dc *DynamoClient) GetItemTyped(ctx context.Context, tableName string, key
 }
 
 func (dc *DynamoClient) PutItem(ctx context.Context, input *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
-	result, err := dc.execute(ctx, "PutItem", func() (interface{}, error) {
+	result, err := dc.execute(ctx, "PutItem", func(ctx context.Context) (interface{}, error) {
 		return dc.client.PutItem(ctx, input, optFns...)
 	})
 
@@ -160,7 +150,7 @@ func (dc *DynamoClient) PutItemTyped(ctx context.Context, tableName string, item
 }
 
 func (dc *DynamoClient) DeleteItem(ctx context.Context, input *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
-	result, err := dc.execute(ctx, "DeleteItem", func() (interface{}, error) {
+	result, err := dc.execute(ctx, "DeleteItem", func(ctx context.Context) (interface{}, error) {
 		return dc.client.DeleteItem(ctx, input, optFns...)
 	})
 
@@ -185,7 +175,7 @@ func (dc *DynamoClient) DeleteItemByKey(ctx context.Context, tableName string, k
 }
 
 func (dc *DynamoClient) UpdateItem(ctx context.Context, input *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
-	result, err := dc.execute(ctx, "UpdateItem", func() (interface{}, error) {
+	result, err := dc.execute(ctx, "UpdateItem", func(ctx context.Context) (interface{}, error) {
 		return dc.client.UpdateItem(ctx, input, optFns...)
 	})
 
@@ -205,7 +195,7 @@ func (dc *DynamoClient) Query(ctx context.Context, input *dynamodb.QueryInput, o
 		input.Limit = aws.Int32(DefaultQueryLimit)
 	}
 
-	result, err := dc.execute(ctx, "Query", func() (interface{}, error) {
+	result, err := dc.execute(ctx, "Query", func(ctx context.Context) (interface{}, error) {
 		return dc.client.Query(ctx, input, optFns...)
 	})
 
@@ -234,12 +224,36 @@ func (dc *DynamoClient) QueryTyped(ctx context.Context, input *dynamodb.QueryInp
 	return output, nil
 }
 
+// QueryIndex queries the indexName secondary index (GSI or LSI) of tableName with
+// keyCondition, applying the table prefix and DefaultQueryLimit, and unmarshals the
+// page into items via QueryTyped. Use the returned output's LastEvaluatedKey to page.
+//
+//	keyCond := expression.Key("status").Equal(expression.Value("active"))
+//	out, err := dc.QueryIndex(ctx, "users", "status-index", keyCond, &users)
+func (dc *DynamoClient) QueryIndex(ctx context.Context, tableName, indexName string, keyCondition expression.KeyConditionBuilder, items interface{}, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	if err != nil {
+		return nil, fmt.Errorf("invalid key condition for index %s: %w", indexName, err)
+	}
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(dc.TableName(tableName)),
+		IndexName:                 aws.String(indexName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Limit:                     aws.Int32(DefaultQueryLimit),
+	}
+
+	return dc.QueryTyped(ctx, input, items, optFns...)
+}
+
 func (dc *DynamoClient) Scan(ctx context.Context, input *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
 	if input.Limit == nil || *input.Limit == 0 {
 		input.Limit = aws.Int32(DefaultQueryLimit)
 	}
 
-	result, err := dc.execute(ctx, "Scan", func() (interface{}, error) {
+	result, err := dc.execute(ctx, "Scan", func(ctx context.Context) (interface{}, error) {
 		return dc.client.Scan(ctx, input, optFns...)
 	})
 
@@ -278,7 +292,7 @@ func (dc *DynamoClient) BatchWriteItem(ctx context.Context, input *dynamodb.Batc
 		return nil, ErrBatchSizeExceed
 	}
 
-	result, err := dc.execute(ctx, "BatchWriteItem", func() (interface{}, error) {
+	result, err := dc.execute(ctx, "BatchWriteItem", func(ctx context.Context) (interface{}, error) {
 		return dc.client.BatchWriteItem(ctx, input, optFns...)
 	})
 
@@ -303,7 +317,7 @@ func (dc *DynamoClient) BatchGetItem(ctx context.Context, input *dynamodb.BatchG
 		return nil, ErrBatchSizeExceed
 	}
 
-	result, err := dc.execute(ctx, "BatchGetItem", func() (interface{}, error) {
+	result, err := dc.execute(ctx, "BatchGetItem", func(ctx context.Context) (interface{}, error) {
 		return dc.client.BatchGetItem(ctx, input, optFns...)
 	})
 
@@ -318,12 +332,84 @@ func (dc *DynamoClient) BatchGetItem(ctx context.Context, input *dynamodb.BatchG
 	return output, nil
 }
 
+// BatchGetItemTyped fetches keys from tableName and unmarshals the items into dest
+// (a pointer to a slice) via attributevalue.UnmarshalListOfMaps. Keys are sent in
+// chunks of DefaultMaxBatchGetKeys; UnprocessedKeys are retried with exponential
+// backoff up to DefaultBatchGetRetries times, after which ErrUnprocessedKeys is
+// returned. Items for keys that do not exist are simply absent from dest.
+func (dc *DynamoClient) BatchGetItemTyped(ctx context.Context, tableName string, keys []map[string]types.AttributeValue, dest interface{}) error {
+	name := dc.TableName(tableName)
+	var items []map[string]types.AttributeValue
+
+	for start := 0; start < len(keys); start += DefaultMaxBatchGetKeys {
+		end := start + DefaultMaxBatchGetKeys
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		chunkItems, err := dc.batchGetChunk(ctx, name, types.KeysAndAttributes{Keys: keys[start:end]})
+		if err != nil {
+			return err
+		}
+		items = append(items, chunkItems...)
+	}
+
+	if err := attributevalue.UnmarshalListOfMaps(items, dest); err != nil {
+		return dc.logger.WrapError(err, ErrUnmarshal.Error())
+	}
+	return nil
+}
+
+// batchGetChunk runs one BatchGetItem request and retries its UnprocessedKeys
+func (dc *DynamoClient) batchGetChunk(ctx context.Context, tableName string, pending types.KeysAndAttributes) ([]map[string]types.AttributeValue, error) {
+	backoff := dc.batchGetBackoff
+	if backoff <= 0 {
+		backoff = DefaultBatchGetBackoff
+	}
+
+	var items []map[string]types.AttributeValue
+	for attempt := 0; ; attempt++ {
+		input := &dynamodb.BatchGetItemInput{
+			RequestItems: map[string]types.KeysAndAttributes{tableName: pending},
+		}
+		result, err := dc.execute(ctx, "BatchGetItem", func(ctx context.Context) (interface{}, error) {
+			return dc.client.BatchGetItem(ctx, input)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		output, err := client.SafeTypeAssert[*dynamodb.BatchGetItemOutput](result)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected BatchGetItem result: %w", err)
+		}
+		items = append(items, output.Responses[tableName]...)
+
+		unprocessed, ok := output.UnprocessedKeys[tableName]
+		if !ok || len(unprocessed.Keys) == 0 {
+			return items, nil
+		}
+		if attempt == DefaultBatchGetRetries {
+			return nil, fmt.Errorf("%w: %d keys in %s", ErrUnprocessedKeys, len(unprocessed.Keys), tableName)
+		}
+
+		timer := time.NewTimer(backoff << attempt)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		pending = unprocessed
+	}
+}
+
 func (dc *DynamoClient) TransactWriteItems(ctx context.Context, input *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
 	if len(input.TransactItems) > DefaultMaxBatchItems {
 		return nil, ErrBatchSizeExceed
 	}
 
-	result, err := dc.execute(ctx, "TransactWriteItems", func() (interface{}, error) {
+	result, err := dc.execute(ctx, "TransactWriteItems", func(ctx context.Context) (interface{}, error) {
 		return dc.client.TransactWriteItems(ctx, input, optFns...)
 	})
 
@@ -0,0 +1,163 @@
+package dynamo
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/skolldire/go-engine/pkg/utilities/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(ctx context.Context, msg string, fields map[string]interface{})     {}
+func (m *mockLogger) Info(ctx context.Context, msg string, fields map[string]interface{})      {}
+func (m *mockLogger) Warn(ctx context.Context, msg string, fields map[string]interface{})      {}
+func (m *mockLogger) Error(ctx context.Context, err error, fields map[string]interface{})      {}
+func (m *mockLogger) FatalError(ctx context.Context, err error, fields map[string]interface{}) {}
+func (m *mockLogger) WrapError(err error, msg string) error                                    { return err }
+func (m *mockLogger) WithField(key string, value interface{}) logger.Service                   { return m }
+func (m *mockLogger) WithFields(fields map[string]interface{}) logger.Service                  { return m }
+func (m *mockLogger) GetLogLevel() string                                                      { return "info" }
+func (m *mockLogger) SetLogLevel(level string) error                                           { return nil }
+
+// batchGetStub answers BatchGetItem with one item per key. During the first
+// holdBackFor calls it returns the first holdBack keys as UnprocessedKeys;
+// alwaysReject returns every key as unprocessed on every call.
+type batchGetStub struct {
+	Service
+	calls        []int
+	holdBack     int
+	holdBackFor  int
+	alwaysReject bool
+}
+
+func (s *batchGetStub) BatchGetItem(ctx context.Context, params *dynamodb.BatchGetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchGetItemOutput, error) {
+	out := &dynamodb.BatchGetItemOutput{
+		Responses:       map[string][]map[string]types.AttributeValue{},
+		UnprocessedKeys: map[string]types.KeysAndAttributes{},
+	}
+	for table, req := range params.RequestItems {
+		s.calls = append(s.calls, len(req.Keys))
+		keys := req.Keys
+		if s.alwaysReject || len(s.calls) <= s.holdBackFor {
+			n := s.holdBack
+			if s.alwaysReject || n > len(keys) {
+				n = len(keys)
+			}
+			out.UnprocessedKeys[table] = types.KeysAndAttributes{Keys: keys[:n]}
+			keys = keys[n:]
+		}
+		out.Responses[table] = append(out.Responses[table], keys...)
+	}
+	return out, nil
+}
+
+type batchItem struct {
+	ID string `dynamodbav:"id"`
+}
+
+func batchKeys(n int) []map[string]types.AttributeValue {
+	keys := make([]map[string]types.AttributeValue, n)
+	for i := range keys {
+		keys[i] = map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: strconv.Itoa(i)}}
+	}
+	return keys
+}
+
+func newBatchClient(stub *batchGetStub) *DynamoClient {
+	return &DynamoClient{
+		client:          stub,
+		logger:          &mockLogger{},
+		tablePrefix:     "dev",
+		batchGetBackoff: time.Millisecond,
+	}
+}
+
+func TestBatchGetItemTyped_ChunksAndRetriesUnprocessedKeys(t *testing.T) {
+	stub := &batchGetStub{holdBack: 3, holdBackFor: 1}
+	dc := newBatchClient(stub)
+
+	var items []batchItem
+	err := dc.BatchGetItemTyped(context.Background(), "users", batchKeys(250), &items)
+
+	require.NoError(t, err)
+	assert.Len(t, items, 250)
+	// first chunk of 100 returns 3 unprocessed keys that are retried before the next chunk
+	assert.Equal(t, []int{100, 3, 100, 50}, stub.calls)
+
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		seen[item.ID] = true
+	}
+	assert.Len(t, seen, 250)
+}
+
+func TestBatchGetItemTyped_ErrorWhenKeysRemainUnprocessed(t *testing.T) {
+	stub := &batchGetStub{alwaysReject: true}
+	dc := newBatchClient(stub)
+
+	var items []batchItem
+	err := dc.BatchGetItemTyped(context.Background(), "users", batchKeys(2), &items)
+
+	assert.ErrorIs(t, err, ErrUnprocessedKeys)
+	assert.Contains(t, err.Error(), "dev-users")
+	assert.Len(t, stub.calls, DefaultBatchGetRetries+1)
+}
+
+func TestBatchGetItemTyped_NoKeys(t *testing.T) {
+	stub := &batchGetStub{}
+	dc := newBatchClient(stub)
+
+	var items []batchItem
+	require.NoError(t, dc.BatchGetItemTyped(context.Background(), "users", nil, &items))
+	assert.Empty(t, items)
+	assert.Empty(t, stub.calls)
+}
+
+// queryStub records the QueryInput and returns a fixed page of items
+type queryStub struct {
+	Service
+	input *dynamodb.QueryInput
+}
+
+func (s *queryStub) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	s.input = params
+	return &dynamodb.QueryOutput{Items: batchKeys(2)}, nil
+}
+
+func TestQueryIndex_BuildsIndexQuery(t *testing.T) {
+	stub := &queryStub{}
+	dc := &DynamoClient{client: stub, logger: &mockLogger{}, tablePrefix: "dev"}
+
+	var items []batchItem
+	keyCond := expression.Key("status").Equal(expression.Value("active"))
+	_, err := dc.QueryIndex(context.Background(), "users", "status-index", keyCond, &items)
+
+	require.NoError(t, err)
+	assert.Equal(t, []batchItem{{ID: "0"}, {ID: "1"}}, items)
+	assert.Equal(t, "dev-users", aws.ToString(stub.input.TableName))
+	assert.Equal(t, "status-index", aws.ToString(stub.input.IndexName))
+	assert.Equal(t, DefaultQueryLimit, aws.ToInt32(stub.input.Limit))
+	assert.Equal(t, "#0 = :0", aws.ToString(stub.input.KeyConditionExpression))
+	assert.Equal(t, map[string]string{"#0": "status"}, stub.input.ExpressionAttributeNames)
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "active"}, stub.input.ExpressionAttributeValues[":0"])
+}
+
+func TestQueryIndex_InvalidKeyCondition(t *testing.T) {
+	stub := &queryStub{}
+	dc := &DynamoClient{client: stub, logger: &mockLogger{}}
+
+	var items []batchItem
+	_, err := dc.QueryIndex(context.Background(), "users", "status-index", expression.KeyConditionBuilder{}, &items)
+
+	assert.Error(t, err)
+	assert.Nil(t, stub.input)
+}
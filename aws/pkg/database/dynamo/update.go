@@ -0,0 +1,99 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// UpdateBuilder composes an UpdateItem expression fluently:
+//
+//	update := dynamo.NewUpdate().
+//		Set("status", "shipped").
+//		Add("version", 1).
+//		Remove("draft").
+//		Condition(expression.AttributeExists(expression.Name("id")))
+type UpdateBuilder struct {
+	update    expression.UpdateBuilder
+	condition *expression.ConditionBuilder
+	empty     bool
+}
+
+// NewUpdate returns an empty UpdateBuilder
+func NewUpdate() *UpdateBuilder {
+	return &UpdateBuilder{empty: true}
+}
+
+// Set adds "SET field = value"
+func (b *UpdateBuilder) Set(field string, value interface{}) *UpdateBuilder {
+	b.update = b.update.Set(expression.Name(field), expression.Value(value))
+	b.empty = false
+	return b
+}
+
+// Add adds "ADD field value": increments a number or adds elements to a set
+func (b *UpdateBuilder) Add(field string, value interface{}) *UpdateBuilder {
+	b.update = b.update.Add(expression.Name(field), expression.Value(value))
+	b.empty = false
+	return b
+}
+
+// Remove adds "REMOVE field"
+func (b *UpdateBuilder) Remove(field string) *UpdateBuilder {
+	b.update = b.update.Remove(expression.Name(field))
+	b.empty = false
+	return b
+}
+
+// Condition makes the update conditional; a failed condition returns ErrConditionFailed
+func (b *UpdateBuilder) Condition(cond expression.ConditionBuilder) *UpdateBuilder {
+	b.condition = &cond
+	return b
+}
+
+// Build compiles the update and optional condition into a DynamoDB expression
+func (b *UpdateBuilder) Build() (expression.Expression, error) {
+	if b == nil || b.empty {
+		return expression.Expression{}, ErrEmptyUpdate
+	}
+
+	builder := expression.NewBuilder().WithUpdate(b.update)
+	if b.condition != nil {
+		builder = builder.WithCondition(*b.condition)
+	}
+	return builder.Build()
+}
+
+// UpdateItemWithBuilder applies update to the item with key in tableName (table prefix
+// applied) and unmarshals the updated item (ReturnValues ALL_NEW) into item.
+func (dc *DynamoClient) UpdateItemWithBuilder(ctx context.Context, tableName string, key map[string]types.AttributeValue, update *UpdateBuilder, item interface{}, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	expr, err := update.Build()
+	if err != nil {
+		return nil, fmt.Errorf("invalid update expression: %w", err)
+	}
+
+	input := &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(dc.TableName(tableName)),
+		Key:                       key,
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		ReturnValues:              types.ReturnValueAllNew,
+	}
+
+	output, err := dc.UpdateItem(ctx, input, optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attributevalue.UnmarshalMap(output.Attributes, item); err != nil {
+		return nil, dc.logger.WrapError(err, ErrUnmarshal.Error())
+	}
+	return output, nil
+}
@@ -0,0 +1,147 @@
+package dynamo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var placeholderPattern = regexp.MustCompile(`[#:][0-9]+`)
+
+// resolveUpdate substitutes the placeholders of a built update expression so
+// assertions do not depend on the builder's placeholder numbering
+func resolveUpdate(t *testing.T, b *UpdateBuilder) string {
+	t.Helper()
+	expr, err := b.Build()
+	require.NoError(t, err)
+
+	return placeholderPattern.ReplaceAllStringFunc(aws.ToString(expr.Update()), func(p string) string {
+		if name, ok := expr.Names()[p]; ok {
+			return name
+		}
+		switch v := expr.Values()[p].(type) {
+		case *types.AttributeValueMemberS:
+			return "'" + v.Value + "'"
+		case *types.AttributeValueMemberN:
+			return v.Value
+		}
+		return p
+	})
+}
+
+func TestUpdateBuilder_Combinations(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *UpdateBuilder
+		want    string
+	}{
+		{
+			name:    "set",
+			builder: NewUpdate().Set("status", "shipped"),
+			want:    "SET status = 'shipped'\n",
+		},
+		{
+			name:    "add",
+			builder: NewUpdate().Add("counter", 1),
+			want:    "ADD counter 1\n",
+		},
+		{
+			name:    "remove",
+			builder: NewUpdate().Remove("draft"),
+			want:    "REMOVE draft\n",
+		},
+		{
+			name:    "set add remove",
+			builder: NewUpdate().Set("status", "shipped").Add("counter", 1).Remove("draft").Set("owner", "ana"),
+			want:    "ADD counter 1\nREMOVE draft\nSET status = 'shipped', owner = 'ana'\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, resolveUpdate(t, tt.builder))
+		})
+	}
+}
+
+func TestUpdateBuilder_EmptyUpdate(t *testing.T) {
+	_, err := NewUpdate().Build()
+	assert.ErrorIs(t, err, ErrEmptyUpdate)
+
+	_, err = NewUpdate().Condition(expression.AttributeExists(expression.Name("id"))).Build()
+	assert.ErrorIs(t, err, ErrEmptyUpdate)
+}
+
+// updateItemStub records the UpdateItemInput and returns attributes as the updated item
+type updateItemStub struct {
+	Service
+	input      *dynamodb.UpdateItemInput
+	attributes map[string]types.AttributeValue
+}
+
+func (s *updateItemStub) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	s.input = params
+	return &dynamodb.UpdateItemOutput{Attributes: s.attributes}, nil
+}
+
+func TestUpdateItemWithBuilder(t *testing.T) {
+	stub := &updateItemStub{attributes: map[string]types.AttributeValue{
+		"id":      &types.AttributeValueMemberS{Value: "o-1"},
+		"status":  &types.AttributeValueMemberS{Value: "shipped"},
+		"counter": &types.AttributeValueMemberN{Value: "2"},
+	}}
+	dc := &DynamoClient{client: stub, logger: &mockLogger{}, tablePrefix: "dev"}
+	key := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "o-1"}}
+
+	update := NewUpdate().
+		Set("status", "shipped").
+		Add("counter", 1).
+		Remove("draft").
+		Condition(expression.AttributeExists(expression.Name("id")))
+
+	var order struct {
+		ID      string `dynamodbav:"id"`
+		Status  string `dynamodbav:"status"`
+		Counter int    `dynamodbav:"counter"`
+	}
+	_, err := dc.UpdateItemWithBuilder(context.Background(), "orders", key, update, &order)
+
+	require.NoError(t, err)
+	assert.Equal(t, "o-1", order.ID)
+	assert.Equal(t, "shipped", order.Status)
+	assert.Equal(t, 2, order.Counter)
+
+	in := stub.input
+	assert.Equal(t, "dev-orders", aws.ToString(in.TableName))
+	assert.Equal(t, key, in.Key)
+	assert.Equal(t, types.ReturnValueAllNew, in.ReturnValues)
+	assert.NotEmpty(t, aws.ToString(in.UpdateExpression))
+	assert.Contains(t, aws.ToString(in.ConditionExpression), "attribute_exists")
+	assert.ElementsMatch(t, []string{"status", "counter", "draft", "id"}, valuesOf(in.ExpressionAttributeNames))
+}
+
+func TestUpdateItemWithBuilder_EmptyUpdate(t *testing.T) {
+	stub := &updateItemStub{}
+	dc := &DynamoClient{client: stub, logger: &mockLogger{}}
+
+	var item map[string]interface{}
+	_, err := dc.UpdateItemWithBuilder(context.Background(), "orders", nil, NewUpdate(), &item)
+
+	assert.ErrorIs(t, err, ErrEmptyUpdate)
+	assert.Nil(t, stub.input)
+}
+
+func valuesOf(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
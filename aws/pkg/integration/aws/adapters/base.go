@@ -3,10 +3,12 @@ package adapters
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/skolldire/go-engine/pkg/integration/cloud"
 )
 
@@ -25,8 +27,27 @@ type RetryPolicy struct {
 	RetriableErrors []string
 }
 
+// Option customizes the service adapters created by NewBaseAdapter
+type Option func(*options)
+
+type options struct {
+	s3UsePathStyle bool
+}
+
+// WithS3UsePathStyle makes the S3 adapter use path-style addressing (endpoint/bucket/key)
+func WithS3UsePathStyle(enabled bool) Option {
+	return func(o *options) {
+		o.s3UsePathStyle = enabled
+	}
+}
+
 // NewBaseAdapter creates a new base adapter that routes requests to service adapters
-func NewBaseAdapter(cfg aws.Config, timeout time.Duration, retries RetryPolicy) cloud.Client {
+func NewBaseAdapter(cfg aws.Config, timeout time.Duration, retries RetryPolicy, opts ...Option) cloud.Client {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	adapter := &baseAdapter{
 		config:   cfg,
 		timeout:  timeout,
@@ -38,7 +59,9 @@ func NewBaseAdapter(cfg aws.Config, timeout time.Duration, retries RetryPolicy)
 	adapter.adapters["sqs"] = newSQSAdapter(cfg, timeout, retries)
 	adapter.adapters["sns"] = newSNSAdapter(cfg, timeout, retries)
 	adapter.adapters["lambda"] = newLambdaAdapter(cfg, timeout, retries)
-	adapter.adapters["s3"] = newS3Adapter(cfg, timeout, retries)
+	adapter.adapters["s3"] = newS3Adapter(cfg, timeout, retries, func(s3o *s3.Options) {
+		s3o.UsePathStyle = o.s3UsePathStyle
+	})
 	adapter.adapters["ses"] = newSESAdapter(cfg, timeout, retries)
 	adapter.adapters["ssm"] = newSSMAdapter(cfg, timeout, retries)
 
@@ -67,15 +90,30 @@ func (b *baseAdapter) Do(ctx context.Context, req *cloud.Request) (*cloud.Respon
 	}
 
 	// Apply per-request timeout if specified
+	cancel := context.CancelFunc(func() {})
 	if req.Timeout > 0 {
-		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
-		defer cancel()
 	} else if b.timeout > 0 {
-		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, b.timeout)
-		defer cancel()
 	}
 
-	return adapter.Do(ctx, req)
+	resp, err := adapter.Do(ctx, req)
+	if err == nil && resp != nil && resp.BodyStream != nil {
+		// The stream is read after Do returns: the timeout ends when it is closed
+		resp.BodyStream = &cancelOnClose{ReadCloser: resp.BodyStream, cancel: cancel}
+		return resp, nil
+	}
+	cancel()
+	return resp, err
+}
+
+// cancelOnClose releases a request context when the response stream is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
 }
@@ -2,12 +2,18 @@ package adapters
 
 import (
 	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/skolldire/go-engine/pkg/integration/cloud"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewBaseAdapter(t *testing.T) {
@@ -92,3 +98,77 @@ func TestBaseAdapter_Do_WithTimeout(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, resp)
 }
+
+func TestNewBaseAdapter_S3PathStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		wantPath string
+		wantHost string
+	}{
+		{name: "path style", opts: []Option{WithS3UsePathStyle(true)}, wantPath: "/my-bucket/docs/a.txt"},
+		{name: "virtual host default", wantPath: "/docs/a.txt", wantHost: "my-bucket.s3.local.test"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath, gotHost string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath, gotHost = r.URL.Path, r.Host
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+			_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+			cfg := aws.Config{
+				Region:       "us-east-1",
+				BaseEndpoint: aws.String("http://s3.local.test:" + port),
+				Credentials:  aws.AnonymousCredentials{},
+				// The SDK forces path style for IP endpoints, so use a hostname and route every dial to the test server
+				HTTPClient: &http.Client{Transport: &http.Transport{
+					DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+						return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+					},
+				}},
+			}
+			adapter := NewBaseAdapter(cfg, 5*time.Second, RetryPolicy{}, tt.opts...)
+
+			_, err := adapter.Do(context.Background(), &cloud.Request{
+				Operation: "s3.put_object",
+				Path:      "my-bucket/docs/a.txt",
+				Body:      []byte("hello"),
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPath, gotPath)
+			if tt.wantHost != "" {
+				assert.True(t, strings.HasPrefix(gotHost, tt.wantHost), "host %q", gotHost)
+			}
+		})
+	}
+}
+
+// streamingAdapter returns a body stream and keeps the context it was called with
+type streamingAdapter struct {
+	ctx context.Context
+}
+
+func (s *streamingAdapter) Do(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	s.ctx = ctx
+	return &cloud.Response{StatusCode: 200, BodyStream: io.NopCloser(strings.NewReader("data"))}, nil
+}
+
+func TestBaseAdapter_Do_StreamKeepsTimeoutUntilClose(t *testing.T) {
+	inner := &streamingAdapter{}
+	adapter := &baseAdapter{timeout: time.Minute, adapters: map[string]cloud.Client{"s3": inner}}
+
+	resp, err := adapter.Do(context.Background(), &cloud.Request{Operation: "s3.get_object", Path: "bucket/key", StreamBody: true})
+	require.NoError(t, err)
+	assert.NoError(t, inner.ctx.Err(), "the stream is still readable after Do returns")
+
+	body, err := io.ReadAll(resp.BodyStream)
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(body))
+
+	require.NoError(t, resp.BodyStream.Close())
+	assert.ErrorIs(t, inner.ctx.Err(), context.Canceled)
+}
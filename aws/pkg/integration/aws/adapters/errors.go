@@ -51,11 +51,20 @@ func normalizeAWSError(err error, operation string) *cloud.Error {
 		}
 	}
 
-	return cloud.NewErrorWithCause(
+	return withAWSErrorCode(cloud.NewErrorWithCause(
 		fmt.Sprintf("%s.error", operation),
 		err.Error(),
 		err,
-	).WithMetadata("status_code", statusCode)
+	).WithMetadata("status_code", statusCode), err)
+}
+
+// withAWSErrorCode records the raw AWS error code of err in cloudErr's metadata
+func withAWSErrorCode(cloudErr *cloud.Error, err error) *cloud.Error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() != "" {
+		cloudErr.WithMetadata(cloud.MetadataAWSErrorCode, apiErr.ErrorCode())
+	}
+	return cloudErr
 }
 
 // normalizeSQSError converts AWS SQS errors to normalized cloud.Error
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/aws/smithy-go"
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -124,3 +126,15 @@ func TestNormalizeLambdaError(t *testing.T) {
 // Note: normalizeS3Error, normalizeSESError, and normalizeSSMError are tested
 // in their respective adapter files (s3_test.go, ses_test.go, ssm_test.go)
 // since they are private functions in those files
+
+func TestNormalizeAWSError_PreservesAWSErrorCode(t *testing.T) {
+	apiErr := &smithy.GenericAPIError{Code: "ProvisionedThroughputExceededException", Message: "rate exceeded"}
+
+	result := normalizeAWSError(apiErr, "dynamodb.put_item")
+
+	assert.Equal(t, "dynamodb.put_item.error", result.Code)
+	assert.Equal(t, "ProvisionedThroughputExceededException", cloud.AWSErrorCode(result))
+
+	plain := normalizeAWSError(errors.New("connection reset"), "sqs.send")
+	assert.NotContains(t, plain.Metadata, cloud.MetadataAWSErrorCode)
+}
@@ -11,8 +11,13 @@ import (
 	"github.com/skolldire/go-engine/pkg/integration/cloud"
 )
 
+// lambdaAPI is the subset of the Lambda SDK client used by the adapter
+type lambdaAPI interface {
+	Invoke(ctx context.Context, params *lambda.InvokeInput, optFns ...func(*lambda.Options)) (*lambda.InvokeOutput, error)
+}
+
 type lambdaAdapter struct {
-	client  *lambda.Client
+	client  lambdaAPI
 	timeout time.Duration
 	retries RetryPolicy
 }
@@ -71,11 +76,13 @@ func (a *lambdaAdapter) invoke(ctx context.Context, req *cloud.Request) (*cloud.
 		statusCode = 200 // Default to 200 if not set
 	}
 
-	headers := make(map[string]string)
-	if result.FunctionError != nil {
-		headers["lambda.function_error"] = *result.FunctionError
-		statusCode = 500 // Function error
+	// The invoke call succeeded but the handler failed: surface it as an error
+	// so retries and observability don't treat it as a successful call
+	if fnErr := aws.ToString(result.FunctionError); fnErr != "" {
+		return nil, normalizeLambdaFunctionError(req.Path, fnErr, result.Payload)
 	}
+
+	headers := make(map[string]string)
 	if result.LogResult != nil {
 		headers["lambda.log_result"] = *result.LogResult
 	}
@@ -89,3 +96,16 @@ func (a *lambdaAdapter) invoke(ctx context.Context, req *cloud.Request) (*cloud.
 		},
 	}, nil
 }
+
+// normalizeLambdaFunctionError converts a handled/unhandled function error into cloud.Error
+func normalizeLambdaFunctionError(functionName, functionError string, payload []byte) *cloud.Error {
+	err := cloud.NewError(
+		cloud.ErrCodeLambdaFunctionError,
+		fmt.Sprintf("lambda function %s returned %s error", functionName, functionError),
+	)
+	err.StatusCode = 500
+	return err.
+		WithMetadata("status_code", 500).
+		WithMetadata("lambda.function_error", functionError).
+		WithMetadata("lambda.payload", payload)
+}
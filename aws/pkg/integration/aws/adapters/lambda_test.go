@@ -2,9 +2,11 @@ package adapters
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/skolldire/go-engine/pkg/integration/cloud"
 	"github.com/stretchr/testify/assert"
 )
@@ -39,3 +41,55 @@ func TestLambdaAdapter_Invoke_InvalidPath(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "function name/path is required")
 }
+
+// fakeLambdaAPI returns a canned Invoke output
+type fakeLambdaAPI struct {
+	output *lambda.InvokeOutput
+	err    error
+}
+
+func (f *fakeLambdaAPI) Invoke(ctx context.Context, params *lambda.InvokeInput, optFns ...func(*lambda.Options)) (*lambda.InvokeOutput, error) {
+	return f.output, f.err
+}
+
+func TestLambdaAdapter_Invoke_UnhandledFunctionError(t *testing.T) {
+	adapter := &lambdaAdapter{client: &fakeLambdaAPI{output: &lambda.InvokeOutput{
+		StatusCode:    200,
+		FunctionError: aws.String("Unhandled"),
+		Payload:       []byte(`{"errorMessage":"boom","errorType":"RuntimeError"}`),
+	}}}
+
+	req := &cloud.Request{
+		Operation: "lambda.invoke",
+		Path:      "my-function",
+		Body:      []byte("{}"),
+	}
+
+	resp, err := adapter.Do(context.Background(), req)
+	assert.Nil(t, resp)
+
+	var cloudErr *cloud.Error
+	assert.True(t, errors.As(err, &cloudErr))
+	assert.Equal(t, cloud.ErrCodeLambdaFunctionError, cloudErr.Code)
+	assert.Equal(t, 500, cloudErr.StatusCode)
+	assert.Equal(t, "Unhandled", cloudErr.Metadata["lambda.function_error"])
+	assert.Equal(t, []byte(`{"errorMessage":"boom","errorType":"RuntimeError"}`), cloudErr.Metadata["lambda.payload"])
+}
+
+func TestLambdaAdapter_Invoke_Success(t *testing.T) {
+	adapter := &lambdaAdapter{client: &fakeLambdaAPI{output: &lambda.InvokeOutput{
+		StatusCode: 200,
+		Payload:    []byte(`{"ok":true}`),
+	}}}
+
+	req := &cloud.Request{
+		Operation: "lambda.invoke",
+		Path:      "my-function",
+		Body:      []byte("{}"),
+	}
+
+	resp, err := adapter.Do(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, []byte(`{"ok":true}`), resp.Body)
+}
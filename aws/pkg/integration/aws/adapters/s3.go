@@ -6,26 +6,58 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/skolldire/go-engine/pkg/integration/cloud"
 )
 
+// s3API is the subset of the S3 SDK client used by the adapter
+type s3API interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+	CopyObject(ctx context.Context, params *s3.CopyObjectInput, optFns ...func(*s3.Options)) (*s3.CopyObjectOutput, error)
+	PutObjectTagging(ctx context.Context, params *s3.PutObjectTaggingInput, optFns ...func(*s3.Options)) (*s3.PutObjectTaggingOutput, error)
+	CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error)
+	UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error)
+	CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error)
+	AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error)
+}
+
+// s3Presigner is the subset of the S3 presign client used by the adapter
+type s3Presigner interface {
+	PresignGetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+	PresignPutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.PresignOptions)) (*v4.PresignedHTTPRequest, error)
+}
+
+const (
+	// s3MinPresignExpiry and s3MaxPresignExpiry bound presigned URL lifetimes (SigV4 limit)
+	s3MinPresignExpiry = time.Second
+	s3MaxPresignExpiry = 7 * 24 * time.Hour
+)
+
 type s3Adapter struct {
-	client  *s3.Client
-	timeout time.Duration
-	retries RetryPolicy
+	client    s3API
+	presigner s3Presigner
+	timeout   time.Duration
+	retries   RetryPolicy
 }
 
-func newS3Adapter(cfg aws.Config, timeout time.Duration, retries RetryPolicy) cloud.Client {
+func newS3Adapter(cfg aws.Config, timeout time.Duration, retries RetryPolicy, optFns ...func(*s3.Options)) cloud.Client {
+	client := s3.NewFromConfig(cfg, optFns...)
 	return &s3Adapter{
-		client:  s3.NewFromConfig(cfg),
-		timeout: timeout,
-		retries: retries,
+		client:    client,
+		presigner: s3.NewPresignClient(client),
+		timeout:   timeout,
+		retries:   retries,
 	}
 }
 
@@ -43,6 +75,12 @@ func (a *s3Adapter) Do(ctx context.Context, req *cloud.Request) (*cloud.Response
 		return a.listObjects(ctx, req)
 	case "s3.copy_object":
 		return a.copyObject(ctx, req)
+	case "s3.put_object_tagging":
+		return a.putObjectTagging(ctx, req)
+	case "s3.multipart_upload":
+		return a.multipartUpload(ctx, req)
+	case "s3.presign_get", "s3.presign_put":
+		return a.presign(ctx, req)
 	default:
 		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, fmt.Sprintf("unsupported S3 operation: %s", req.Operation))
 	}
@@ -117,14 +155,21 @@ func (a *s3Adapter) getObject(ctx context.Context, req *cloud.Request) (*cloud.R
 	if err != nil {
 		return nil, normalizeS3Error(err, "s3.get_object")
 	}
-	defer func() {
-		_ = result.Body.Close() // Ignore error on cleanup
-	}()
 
-	// Read body
-	body, err := io.ReadAll(result.Body)
-	if err != nil {
-		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, fmt.Sprintf("failed to read object body: %v", err))
+	var body []byte
+	var stream io.ReadCloser
+	if req.StreamBody {
+		stream = result.Body
+	} else {
+		defer func() {
+			_ = result.Body.Close() // Ignore error on cleanup
+		}()
+
+		// Read body
+		body, err = io.ReadAll(result.Body)
+		if err != nil {
+			return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, fmt.Sprintf("failed to read object body: %v", err))
+		}
 	}
 
 	headers := make(map[string]string)
@@ -141,6 +186,7 @@ func (a *s3Adapter) getObject(ctx context.Context, req *cloud.Request) (*cloud.R
 	return &cloud.Response{
 		StatusCode: 200,
 		Body:       body,
+		BodyStream: stream,
 		Headers:    headers,
 		Metadata: map[string]interface{}{
 			"s3.content_type":   aws.ToString(result.ContentType),
@@ -173,6 +219,203 @@ func (a *s3Adapter) deleteObject(ctx context.Context, req *cloud.Request) (*clou
 	}, nil
 }
 
+// putObjectTagging replaces the object's tag set with the s3.tag.<name> headers
+func (a *s3Adapter) putObjectTagging(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	// Path format: "bucket/key"
+	bucket, key := parseS3Path(req.Path)
+	if bucket == "" || key == "" {
+		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "path must be in format 'bucket/key'")
+	}
+
+	var tags []s3types.Tag
+	for k, v := range req.Headers {
+		if strings.HasPrefix(k, "s3.tag.") {
+			tags = append(tags, s3types.Tag{
+				Key:   aws.String(strings.TrimPrefix(k, "s3.tag.")),
+				Value: aws.String(v),
+			})
+		}
+	}
+
+	result, err := a.client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
+		Bucket:  aws.String(bucket),
+		Key:     aws.String(key),
+		Tagging: &s3types.Tagging{TagSet: tags},
+	})
+	if err != nil {
+		return nil, normalizeS3Error(err, "s3.put_object_tagging")
+	}
+
+	return &cloud.Response{
+		StatusCode: 200,
+		Metadata: map[string]interface{}{
+			"s3.version_id": aws.ToString(result.VersionId),
+		},
+	}, nil
+}
+
+// s3CompletedPart is the JSON shape of one part in a multipart "complete" body
+type s3CompletedPart struct {
+	PartNumber int32  `json:"part_number"`
+	ETag       string `json:"etag"`
+}
+
+// multipartUpload runs one step of a multipart upload, chosen by the
+// s3.multipart_action header:
+//   - create: starts the upload (s3.content_type / s3.metadata.* apply) and returns s3.upload_id
+//   - upload_part: uploads Body as part s3.part_number of s3.upload_id and returns its s3.etag
+//   - complete: completes s3.upload_id from a JSON array of s3CompletedPart in Body
+//   - abort: aborts s3.upload_id, discarding the uploaded parts
+func (a *s3Adapter) multipartUpload(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	// Path format: "bucket/key"
+	bucket, key := parseS3Path(req.Path)
+	if bucket == "" || key == "" {
+		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "path must be in format 'bucket/key'")
+	}
+
+	action := req.Headers["s3.multipart_action"]
+	uploadID := req.Headers["s3.upload_id"]
+	if action != "create" && uploadID == "" {
+		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "s3.upload_id header is required")
+	}
+
+	switch action {
+	case "create":
+		input := &s3.CreateMultipartUploadInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		}
+		if contentType, ok := req.Headers["s3.content_type"]; ok {
+			input.ContentType = aws.String(contentType)
+		}
+		metadata := make(map[string]string)
+		for k, v := range req.Headers {
+			if strings.HasPrefix(k, "s3.metadata.") {
+				metadata[strings.TrimPrefix(k, "s3.metadata.")] = v
+			}
+		}
+		if len(metadata) > 0 {
+			input.Metadata = metadata
+		}
+
+		result, err := a.client.CreateMultipartUpload(ctx, input)
+		if err != nil {
+			return nil, normalizeS3Error(err, "s3.multipart_upload")
+		}
+		return &cloud.Response{
+			StatusCode: 200,
+			Headers:    map[string]string{"s3.upload_id": aws.ToString(result.UploadId)},
+		}, nil
+
+	case "upload_part":
+		partNumber, err := strconv.ParseInt(req.Headers["s3.part_number"], 10, 32)
+		if err != nil || partNumber < 1 {
+			return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "s3.part_number header must be a positive integer")
+		}
+
+		result, err := a.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:     aws.String(bucket),
+			Key:        aws.String(key),
+			UploadId:   aws.String(uploadID),
+			PartNumber: aws.Int32(int32(partNumber)),
+			Body:       bytes.NewReader(req.Body),
+		})
+		if err != nil {
+			return nil, normalizeS3Error(err, "s3.multipart_upload")
+		}
+		return &cloud.Response{
+			StatusCode: 200,
+			Headers:    map[string]string{"s3.etag": aws.ToString(result.ETag)},
+		}, nil
+
+	case "complete":
+		var parts []s3CompletedPart
+		if err := json.Unmarshal(req.Body, &parts); err != nil || len(parts) == 0 {
+			return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "complete requires a JSON array of parts")
+		}
+		completed := make([]s3types.CompletedPart, len(parts))
+		for i, p := range parts {
+			completed[i] = s3types.CompletedPart{PartNumber: aws.Int32(p.PartNumber), ETag: aws.String(p.ETag)}
+		}
+
+		result, err := a.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          aws.String(bucket),
+			Key:             aws.String(key),
+			UploadId:        aws.String(uploadID),
+			MultipartUpload: &s3types.CompletedMultipartUpload{Parts: completed},
+		})
+		if err != nil {
+			return nil, normalizeS3Error(err, "s3.multipart_upload")
+		}
+		return &cloud.Response{
+			StatusCode: 200,
+			Headers: map[string]string{
+				"s3.etag": aws.ToString(result.ETag),
+			},
+			Metadata: map[string]interface{}{
+				"s3.etag":                   aws.ToString(result.ETag),
+				"s3.version_id":             aws.ToString(result.VersionId),
+				"s3.server_side_encryption": string(result.ServerSideEncryption),
+			},
+		}, nil
+
+	case "abort":
+		_, err := a.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+			Bucket:   aws.String(bucket),
+			Key:      aws.String(key),
+			UploadId: aws.String(uploadID),
+		})
+		if err != nil {
+			return nil, normalizeS3Error(err, "s3.multipart_upload")
+		}
+		return &cloud.Response{StatusCode: 204}, nil
+
+	default:
+		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, fmt.Sprintf("unsupported s3.multipart_action: %q", action))
+	}
+}
+
+// presign returns a presigned GET or PUT URL for the object in the s3.presigned_url
+// header, valid for s3.presign_expiry_seconds (1 second to 7 days). For PUT,
+// s3.content_type is signed, so the upload must send the same Content-Type.
+func (a *s3Adapter) presign(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	// Path format: "bucket/key"
+	bucket, key := parseS3Path(req.Path)
+	if bucket == "" || key == "" {
+		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "path must be in format 'bucket/key'")
+	}
+
+	seconds, err := strconv.ParseInt(req.Headers["s3.presign_expiry_seconds"], 10, 64)
+	expiry := time.Duration(seconds) * time.Second
+	if err != nil || expiry < s3MinPresignExpiry || expiry > s3MaxPresignExpiry {
+		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest,
+			fmt.Sprintf("s3.presign_expiry_seconds must be between %d and %d", int64(s3MinPresignExpiry/time.Second), int64(s3MaxPresignExpiry/time.Second)))
+	}
+	withExpiry := s3.WithPresignExpires(expiry)
+
+	var presigned *v4.PresignedHTTPRequest
+	if req.Operation == "s3.presign_put" {
+		input := &s3.PutObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)}
+		if contentType, ok := req.Headers["s3.content_type"]; ok {
+			input.ContentType = aws.String(contentType)
+		}
+		presigned, err = a.presigner.PresignPutObject(ctx, input, withExpiry)
+	} else {
+		presigned, err = a.presigner.PresignGetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)}, withExpiry)
+	}
+	if err != nil {
+		return nil, normalizeS3Error(err, req.Operation)
+	}
+
+	return &cloud.Response{
+		StatusCode: 200,
+		Headers: map[string]string{
+			"s3.presigned_url":    presigned.URL,
+			"s3.presigned_method": presigned.Method,
+		},
+	}, nil
+}
+
 func (a *s3Adapter) headObject(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
 	// Path format: "bucket/key"
 	bucket, key := parseS3Path(req.Path)
@@ -238,6 +481,12 @@ func (a *s3Adapter) listObjects(ctx context.Context, req *cloud.Request) (*cloud
 		if delimiter, ok := req.QueryParams["Delimiter"]; ok {
 			input.Delimiter = aws.String(delimiter)
 		}
+		if token, ok := req.QueryParams["ContinuationToken"]; ok && token != "" {
+			input.ContinuationToken = aws.String(token)
+		}
+	}
+	if token, _ := req.PageToken(); !token.IsFirst() {
+		input.ContinuationToken = aws.String(string(token))
 	}
 
 	result, err := a.client.ListObjectsV2(ctx, input)
@@ -262,7 +511,10 @@ func (a *s3Adapter) listObjects(ctx context.Context, req *cloud.Request) (*cloud
 		StatusCode: 200,
 		Body:       body,
 		Headers: map[string]string{
-			"s3.object_count": fmt.Sprintf("%d", len(result.Contents)),
+			"s3.object_count":            fmt.Sprintf("%d", len(result.Contents)),
+			"s3.is_truncated":            strconv.FormatBool(aws.ToBool(result.IsTruncated)),
+			"s3.next_continuation_token": aws.ToString(result.NextContinuationToken),
+			cloud.NextPageTokenHeader:    aws.ToString(result.NextContinuationToken),
 		},
 	}, nil
 }
@@ -332,9 +584,9 @@ func normalizeS3Error(err error, operation string) *cloud.Error {
 		return nil
 	}
 
-	return cloud.NewErrorWithCause(
+	return withAWSErrorCode(cloud.NewErrorWithCause(
 		fmt.Sprintf("%s.error", operation),
 		err.Error(),
 		err,
-	).WithMetadata("status_code", 500)
+	).WithMetadata("status_code", 500), err)
 }
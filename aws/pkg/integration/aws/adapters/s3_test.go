@@ -3,11 +3,17 @@ package adapters
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/skolldire/go-engine/pkg/integration/cloud"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestS3Adapter_Do_InvalidOperation(t *testing.T) {
@@ -195,3 +201,226 @@ func TestNormalizeS3Error(t *testing.T) {
 		})
 	}
 }
+
+// fakeS3API embeds s3API so tests only implement the calls they exercise
+type fakeS3API struct {
+	s3API
+	listInput  *s3.ListObjectsV2Input
+	listOutput *s3.ListObjectsV2Output
+	tagInput   *s3.PutObjectTaggingInput
+
+	createInput   *s3.CreateMultipartUploadInput
+	partInputs    []*s3.UploadPartInput
+	completeInput *s3.CompleteMultipartUploadInput
+	abortInput    *s3.AbortMultipartUploadInput
+}
+
+func (f *fakeS3API) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	f.listInput = params
+	return f.listOutput, nil
+}
+
+func (f *fakeS3API) PutObjectTagging(ctx context.Context, params *s3.PutObjectTaggingInput, optFns ...func(*s3.Options)) (*s3.PutObjectTaggingOutput, error) {
+	f.tagInput = params
+	return &s3.PutObjectTaggingOutput{VersionId: aws.String("v2")}, nil
+}
+
+func (f *fakeS3API) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	return &s3.GetObjectOutput{
+		Body:          io.NopCloser(strings.NewReader("report")),
+		ContentType:   aws.String("text/csv"),
+		ContentLength: aws.Int64(6),
+	}, nil
+}
+
+func TestS3Adapter_GetObject_StreamBody(t *testing.T) {
+	adapter := &s3Adapter{client: &fakeS3API{}}
+
+	resp, err := adapter.Do(context.Background(), &cloud.Request{Operation: "s3.get_object", Path: "bucket/a.csv", StreamBody: true})
+	require.NoError(t, err)
+	require.NotNil(t, resp.BodyStream)
+	assert.Nil(t, resp.Body)
+	assert.Equal(t, "6", resp.Headers["s3.content_length"])
+	body, _ := io.ReadAll(resp.BodyStream)
+	assert.Equal(t, "report", string(body))
+
+	resp, err = adapter.Do(context.Background(), &cloud.Request{Operation: "s3.get_object", Path: "bucket/a.csv"})
+	require.NoError(t, err)
+	assert.Nil(t, resp.BodyStream)
+	assert.Equal(t, "report", string(resp.Body))
+}
+
+func (f *fakeS3API) CreateMultipartUpload(ctx context.Context, params *s3.CreateMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CreateMultipartUploadOutput, error) {
+	f.createInput = params
+	return &s3.CreateMultipartUploadOutput{UploadId: aws.String("upload-1")}, nil
+}
+
+func (f *fakeS3API) UploadPart(ctx context.Context, params *s3.UploadPartInput, optFns ...func(*s3.Options)) (*s3.UploadPartOutput, error) {
+	f.partInputs = append(f.partInputs, params)
+	return &s3.UploadPartOutput{ETag: aws.String(fmt.Sprintf(`"etag-%d"`, aws.ToInt32(params.PartNumber)))}, nil
+}
+
+func (f *fakeS3API) CompleteMultipartUpload(ctx context.Context, params *s3.CompleteMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.CompleteMultipartUploadOutput, error) {
+	f.completeInput = params
+	return &s3.CompleteMultipartUploadOutput{ETag: aws.String(`"final-2"`)}, nil
+}
+
+func (f *fakeS3API) AbortMultipartUpload(ctx context.Context, params *s3.AbortMultipartUploadInput, optFns ...func(*s3.Options)) (*s3.AbortMultipartUploadOutput, error) {
+	f.abortInput = params
+	return &s3.AbortMultipartUploadOutput{}, nil
+}
+
+func TestS3Adapter_MultipartUpload(t *testing.T) {
+	fake := &fakeS3API{}
+	adapter := &s3Adapter{client: fake}
+	ctx := context.Background()
+	step := func(action string, headers map[string]string, body []byte) (*cloud.Response, error) {
+		h := map[string]string{"s3.multipart_action": action}
+		for k, v := range headers {
+			h[k] = v
+		}
+		return adapter.Do(ctx, &cloud.Request{Operation: "s3.multipart_upload", Path: "bucket/backups/db.tar", Headers: h, Body: body})
+	}
+
+	resp, err := step("create", map[string]string{"s3.content_type": "application/x-tar"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "upload-1", resp.Headers["s3.upload_id"])
+	assert.Equal(t, "application/x-tar", aws.ToString(fake.createInput.ContentType))
+
+	resp, err = step("upload_part", map[string]string{"s3.upload_id": "upload-1", "s3.part_number": "1"}, []byte("part"))
+	require.NoError(t, err)
+	assert.Equal(t, `"etag-1"`, resp.Headers["s3.etag"])
+	assert.Equal(t, int32(1), aws.ToInt32(fake.partInputs[0].PartNumber))
+
+	resp, err = step("complete", map[string]string{"s3.upload_id": "upload-1"}, []byte(`[{"part_number":1,"etag":"\"etag-1\""}]`))
+	require.NoError(t, err)
+	assert.Equal(t, `"final-2"`, resp.Headers["s3.etag"])
+	assert.Equal(t, []s3types.CompletedPart{{PartNumber: aws.Int32(1), ETag: aws.String(`"etag-1"`)}}, fake.completeInput.MultipartUpload.Parts)
+
+	resp, err = step("abort", map[string]string{"s3.upload_id": "upload-1"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 204, resp.StatusCode)
+	assert.Equal(t, "upload-1", aws.ToString(fake.abortInput.UploadId))
+}
+
+func TestS3Adapter_MultipartUpload_InvalidRequests(t *testing.T) {
+	adapter := &s3Adapter{client: &fakeS3API{}}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		body    []byte
+	}{
+		{name: "missing upload id", headers: map[string]string{"s3.multipart_action": "upload_part", "s3.part_number": "1"}},
+		{name: "bad part number", headers: map[string]string{"s3.multipart_action": "upload_part", "s3.upload_id": "u", "s3.part_number": "0"}},
+		{name: "empty parts", headers: map[string]string{"s3.multipart_action": "complete", "s3.upload_id": "u"}, body: []byte("[]")},
+		{name: "unknown action", headers: map[string]string{"s3.multipart_action": "resume", "s3.upload_id": "u"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := adapter.Do(context.Background(), &cloud.Request{
+				Operation: "s3.multipart_upload",
+				Path:      "bucket/key",
+				Headers:   tt.headers,
+				Body:      tt.body,
+			})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestS3Adapter_Presign(t *testing.T) {
+	adapter := newS3Adapter(aws.Config{
+		Region: "us-east-1",
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}, nil
+		}),
+	}, 0, RetryPolicy{})
+
+	resp, err := adapter.Do(context.Background(), &cloud.Request{
+		Operation: "s3.presign_get",
+		Path:      "bucket/reports/2024.csv",
+		Headers:   map[string]string{"s3.presign_expiry_seconds": "900"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "GET", resp.Headers["s3.presigned_method"])
+	assert.Contains(t, resp.Headers["s3.presigned_url"], "reports/2024.csv")
+	assert.Contains(t, resp.Headers["s3.presigned_url"], "X-Amz-Expires=900")
+
+	resp, err = adapter.Do(context.Background(), &cloud.Request{
+		Operation: "s3.presign_put",
+		Path:      "bucket/uploads/a.png",
+		Headers:   map[string]string{"s3.presign_expiry_seconds": "60", "s3.content_type": "image/png"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "PUT", resp.Headers["s3.presigned_method"])
+	assert.Contains(t, resp.Headers["s3.presigned_url"], "X-Amz-Expires=60")
+}
+
+func TestS3Adapter_Presign_InvalidExpiry(t *testing.T) {
+	adapter := newS3Adapter(aws.Config{Region: "us-east-1"}, 0, RetryPolicy{})
+
+	for _, expiry := range []string{"", "0", "604801", "soon"} {
+		_, err := adapter.Do(context.Background(), &cloud.Request{
+			Operation: "s3.presign_get",
+			Path:      "bucket/key",
+			Headers:   map[string]string{"s3.presign_expiry_seconds": expiry},
+		})
+
+		var cloudErr *cloud.Error
+		require.ErrorAs(t, err, &cloudErr, "expiry %q", expiry)
+		assert.Equal(t, cloud.ErrCodeInvalidRequest, cloudErr.Code)
+	}
+}
+
+func TestS3Adapter_PutObjectTagging(t *testing.T) {
+	fake := &fakeS3API{}
+	adapter := &s3Adapter{client: fake}
+
+	resp, err := adapter.Do(context.Background(), &cloud.Request{
+		Operation: "s3.put_object_tagging",
+		Path:      "bucket/reports/2024.csv",
+		Headers:   map[string]string{"s3.tag.cost-center": "42", "s3.content_type": "text/csv"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "bucket", aws.ToString(fake.tagInput.Bucket))
+	assert.Equal(t, "reports/2024.csv", aws.ToString(fake.tagInput.Key))
+	assert.Equal(t, []s3types.Tag{{Key: aws.String("cost-center"), Value: aws.String("42")}}, fake.tagInput.Tagging.TagSet)
+	assert.Equal(t, "v2", resp.Metadata["s3.version_id"])
+}
+
+func TestS3Adapter_ListObjects_PaginationHeaders(t *testing.T) {
+	fake := &fakeS3API{listOutput: &s3.ListObjectsV2Output{
+		Contents:              []s3types.Object{{Key: aws.String("a.txt"), Size: aws.Int64(3)}},
+		IsTruncated:           aws.Bool(true),
+		NextContinuationToken: aws.String("token-2"),
+	}}
+	adapter := &s3Adapter{client: fake}
+
+	resp, err := adapter.Do(context.Background(), &cloud.Request{
+		Operation:   "s3.list_objects",
+		Path:        "bucket/prefix",
+		QueryParams: map[string]string{"ContinuationToken": "token-1"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", aws.ToString(fake.listInput.ContinuationToken))
+	assert.Equal(t, "true", resp.Headers["s3.is_truncated"])
+	assert.Equal(t, "token-2", resp.Headers["s3.next_continuation_token"])
+	assert.Equal(t, "1", resp.Headers["s3.object_count"])
+}
+
+func TestS3Adapter_ListObjects_PageToken(t *testing.T) {
+	fake := &fakeS3API{listOutput: &s3.ListObjectsV2Output{
+		NextContinuationToken: aws.String("token-2"),
+	}}
+	adapter := &s3Adapter{client: fake}
+
+	req := (&cloud.Request{Operation: "s3.list_objects", Path: "bucket"}).WithPageToken("token-1")
+	resp, err := adapter.Do(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", aws.ToString(fake.listInput.ContinuationToken))
+	assert.Equal(t, cloud.PageToken("token-2"), resp.NextPageToken())
+}
@@ -2,6 +2,7 @@ package adapters
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -30,6 +31,10 @@ func (a *snsAdapter) Do(ctx context.Context, req *cloud.Request) (*cloud.Respons
 	switch req.Operation {
 	case "sns.publish":
 		return a.publish(ctx, req)
+	case "sns.set_subscription_attributes":
+		return a.setSubscriptionAttributes(ctx, req)
+	case "sns.get_subscription_attributes":
+		return a.getSubscriptionAttributes(ctx, req)
 	default:
 		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, fmt.Sprintf("unsupported SNS operation: %s", req.Operation))
 	}
@@ -82,3 +87,53 @@ func (a *snsAdapter) publish(ctx context.Context, req *cloud.Request) (*cloud.Re
 		},
 	}, nil
 }
+
+// filterPolicyAttributes are subscription attributes whose value must be a JSON document
+var filterPolicyAttributes = map[string]bool{"FilterPolicy": true, "DeliveryPolicy": true, "RedrivePolicy": true}
+
+func (a *snsAdapter) setSubscriptionAttributes(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	if req.Path == "" {
+		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "subscription ARN/path is required")
+	}
+
+	attributeName := req.Headers["sns.attribute_name"]
+	if attributeName == "" {
+		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "sns.attribute_name header is required")
+	}
+	if filterPolicyAttributes[attributeName] && len(req.Body) > 0 && !json.Valid(req.Body) {
+		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, fmt.Sprintf("%s must be valid JSON", attributeName))
+	}
+
+	_, err := a.client.SetSubscriptionAttributes(ctx, &sns.SetSubscriptionAttributesInput{
+		SubscriptionArn: aws.String(req.Path),
+		AttributeName:   aws.String(attributeName),
+		AttributeValue:  aws.String(string(req.Body)),
+	})
+	if err != nil {
+		return nil, normalizeSNSError(err, "sns.set_subscription_attributes")
+	}
+
+	return &cloud.Response{
+		StatusCode: 200,
+	}, nil
+}
+
+func (a *snsAdapter) getSubscriptionAttributes(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	if req.Path == "" {
+		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "subscription ARN/path is required")
+	}
+
+	result, err := a.client.GetSubscriptionAttributes(ctx, &sns.GetSubscriptionAttributesInput{
+		SubscriptionArn: aws.String(req.Path),
+	})
+	if err != nil {
+		return nil, normalizeSNSError(err, "sns.get_subscription_attributes")
+	}
+
+	body, _ := json.Marshal(result.Attributes)
+
+	return &cloud.Response{
+		StatusCode: 200,
+		Body:       body,
+	}, nil
+}
@@ -39,3 +39,54 @@ func TestSNSAdapter_Publish_InvalidPath(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "topic ARN/path is required")
 }
+
+func TestSNSAdapter_SetSubscriptionAttributes_Validation(t *testing.T) {
+	cfg := aws.Config{Region: "us-east-1"}
+	adapter := newSNSAdapter(cfg, 0, RetryPolicy{})
+
+	tests := []struct {
+		name    string
+		req     *cloud.Request
+		message string
+	}{
+		{
+			name:    "missing subscription",
+			req:     &cloud.Request{Operation: "sns.set_subscription_attributes", Headers: map[string]string{"sns.attribute_name": "FilterPolicy"}},
+			message: "subscription ARN/path is required",
+		},
+		{
+			name:    "missing attribute name",
+			req:     &cloud.Request{Operation: "sns.set_subscription_attributes", Path: "arn:aws:sns:us-east-1:123:topic:sub"},
+			message: "sns.attribute_name header is required",
+		},
+		{
+			name: "invalid filter policy",
+			req: &cloud.Request{
+				Operation: "sns.set_subscription_attributes",
+				Path:      "arn:aws:sns:us-east-1:123:topic:sub",
+				Headers:   map[string]string{"sns.attribute_name": "FilterPolicy"},
+				Body:      []byte(`{"event": [`),
+			},
+			message: "FilterPolicy must be valid JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := adapter.Do(context.Background(), tt.req)
+			assert.Nil(t, resp)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestSNSAdapter_GetSubscriptionAttributes_InvalidPath(t *testing.T) {
+	cfg := aws.Config{Region: "us-east-1"}
+	adapter := newSNSAdapter(cfg, 0, RetryPolicy{})
+
+	resp, err := adapter.Do(context.Background(), &cloud.Request{Operation: "sns.get_subscription_attributes"})
+	assert.Nil(t, resp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "subscription ARN/path is required")
+}
@@ -2,6 +2,8 @@ package adapters
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -14,6 +16,12 @@ import (
 	"github.com/skolldire/go-engine/pkg/integration/cloud"
 )
 
+// maxQueuesPerPage is the largest page ListQueues accepts
+const maxQueuesPerPage = 1000
+
+// maxSendBatchEntries is the largest batch SendMessageBatch accepts
+const maxSendBatchEntries = 10
+
 type sqsAdapter struct {
 	client  *sqs.Client
 	timeout time.Duration
@@ -32,10 +40,14 @@ func (a *sqsAdapter) Do(ctx context.Context, req *cloud.Request) (*cloud.Respons
 	switch req.Operation {
 	case "sqs.send_message":
 		return a.sendMessage(ctx, req)
+	case "sqs.send_message_batch":
+		return a.sendMessageBatch(ctx, req)
 	case "sqs.receive_message":
 		return a.receiveMessages(ctx, req)
 	case "sqs.delete_message":
 		return a.deleteMessage(ctx, req)
+	case "sqs.change_message_visibility":
+		return a.changeMessageVisibility(ctx, req)
 	case "sqs.create_queue":
 		return a.createQueue(ctx, req)
 	case "sqs.delete_queue":
@@ -54,41 +66,16 @@ func (a *sqsAdapter) sendMessage(ctx context.Context, req *cloud.Request) (*clou
 		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "queue URL/path is required")
 	}
 
-	input := &sqs.SendMessageInput{
-		QueueUrl:    aws.String(req.Path),
-		MessageBody: aws.String(string(req.Body)),
-	}
-
 	// Parse headers for SQS-specific attributes
-	if req.Headers != nil {
-		if delaySeconds, ok := req.Headers["sqs.delay_seconds"]; ok {
-			if delay, err := strconv.ParseInt(delaySeconds, 10, 32); err == nil {
-				input.DelaySeconds = int32(delay)
-			}
-		}
-
-		if groupID, ok := req.Headers["sqs.message_group_id"]; ok {
-			input.MessageGroupId = aws.String(groupID)
-		}
-
-		if dedupeID, ok := req.Headers["sqs.message_dedupe_id"]; ok {
-			input.MessageDeduplicationId = aws.String(dedupeID)
-		}
-
-		// Parse message attributes
-		attrs := make(map[string]types.MessageAttributeValue)
-		for k, v := range req.Headers {
-			if strings.HasPrefix(k, "sqs.message_attribute.") {
-				attrName := strings.TrimPrefix(k, "sqs.message_attribute.")
-				attrs[attrName] = types.MessageAttributeValue{
-					DataType:    aws.String("String"),
-					StringValue: aws.String(v),
-				}
-			}
-		}
-		if len(attrs) > 0 {
-			input.MessageAttributes = attrs
-		}
+	send := parseSQSSendHeaders(req.Headers)
+	send.applyFIFODedupe(req.Path, req.Headers, string(req.Body))
+	input := &sqs.SendMessageInput{
+		QueueUrl:               aws.String(req.Path),
+		MessageBody:            aws.String(string(req.Body)),
+		DelaySeconds:           send.delaySeconds,
+		MessageGroupId:         send.groupID,
+		MessageDeduplicationId: send.dedupeID,
+		MessageAttributes:      send.attributes,
 	}
 
 	result, err := a.client.SendMessage(ctx, input)
@@ -110,6 +97,143 @@ func (a *sqsAdapter) sendMessage(ctx context.Context, req *cloud.Request) (*clou
 	}, nil
 }
 
+// sqsSendHeaders holds the per-message settings carried in request headers
+type sqsSendHeaders struct {
+	delaySeconds int32
+	groupID      *string
+	dedupeID     *string
+	attributes   map[string]types.MessageAttributeValue
+}
+
+// parseSQSSendHeaders reads sqs.delay_seconds, sqs.message_group_id,
+// sqs.message_dedupe_id and sqs.message_attribute.<name> headers
+func parseSQSSendHeaders(headers map[string]string) sqsSendHeaders {
+	var send sqsSendHeaders
+	if headers == nil {
+		return send
+	}
+
+	if delaySeconds, ok := headers["sqs.delay_seconds"]; ok {
+		if delay, err := strconv.ParseInt(delaySeconds, 10, 32); err == nil {
+			send.delaySeconds = int32(delay)
+		}
+	}
+
+	if groupID, ok := headers["sqs.message_group_id"]; ok {
+		send.groupID = aws.String(groupID)
+	}
+
+	if dedupeID, ok := headers["sqs.message_dedupe_id"]; ok {
+		send.dedupeID = aws.String(dedupeID)
+	}
+
+	// Parse message attributes
+	attrs := make(map[string]types.MessageAttributeValue)
+	for k, v := range headers {
+		if strings.HasPrefix(k, "sqs.message_attribute.") {
+			attrName := strings.TrimPrefix(k, "sqs.message_attribute.")
+			attrs[attrName] = types.MessageAttributeValue{
+				DataType:    aws.String("String"),
+				StringValue: aws.String(v),
+			}
+		}
+	}
+	if len(attrs) > 0 {
+		send.attributes = attrs
+	}
+	return send
+}
+
+// applyFIFODedupe sets a deduplication ID for FIFO queues (".fifo" URL suffix) when the
+// message has none: the hex SHA-256 of the body, deterministic and within the 128
+// character limit. The sqs.disable_auto_dedupe header ("true") opts out, for queues
+// with ContentBasedDeduplication enabled.
+func (s *sqsSendHeaders) applyFIFODedupe(queueURL string, headers map[string]string, body string) {
+	if s.dedupeID != nil || !strings.HasSuffix(queueURL, ".fifo") || headers["sqs.disable_auto_dedupe"] == "true" {
+		return
+	}
+	sum := sha256.Sum256([]byte(body))
+	s.dedupeID = aws.String(hex.EncodeToString(sum[:]))
+}
+
+// sqsBatchEntry is the JSON shape of one sqs.send_message_batch body entry; headers
+// are interpreted like sqs.send_message request headers
+type sqsBatchEntry struct {
+	ID      string            `json:"id"`
+	Body    string            `json:"body"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// sendMessageBatch sends up to 10 messages, taken from a JSON array of sqsBatchEntry in
+// the body. Per-entry failures are reported in the response body, not as an error.
+func (a *sqsAdapter) sendMessageBatch(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	if req.Path == "" {
+		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "queue URL/path is required")
+	}
+
+	var entries []sqsBatchEntry
+	if err := json.Unmarshal(req.Body, &entries); err != nil {
+		return nil, cloud.NewErrorWithCause(cloud.ErrCodeInvalidRequest, "invalid batch entries JSON", err)
+	}
+	if len(entries) == 0 || len(entries) > maxSendBatchEntries {
+		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, fmt.Sprintf("batch must contain 1 to %d entries", maxSendBatchEntries))
+	}
+
+	input := &sqs.SendMessageBatchInput{
+		QueueUrl: aws.String(req.Path),
+		Entries:  make([]types.SendMessageBatchRequestEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		send := parseSQSSendHeaders(entry.Headers)
+		send.applyFIFODedupe(req.Path, entry.Headers, entry.Body)
+		input.Entries = append(input.Entries, types.SendMessageBatchRequestEntry{
+			Id:                     aws.String(entry.ID),
+			MessageBody:            aws.String(entry.Body),
+			DelaySeconds:           send.delaySeconds,
+			MessageGroupId:         send.groupID,
+			MessageDeduplicationId: send.dedupeID,
+			MessageAttributes:      send.attributes,
+		})
+	}
+
+	result, err := a.client.SendMessageBatch(ctx, input)
+	if err != nil {
+		return nil, normalizeSQSError(err, "sqs.send_message_batch")
+	}
+
+	successful := make([]map[string]interface{}, 0, len(result.Successful))
+	for _, entry := range result.Successful {
+		successful = append(successful, map[string]interface{}{
+			"id":              aws.ToString(entry.Id),
+			"message_id":      aws.ToString(entry.MessageId),
+			"sequence_number": aws.ToString(entry.SequenceNumber),
+		})
+	}
+	failed := make([]map[string]interface{}, 0, len(result.Failed))
+	for _, entry := range result.Failed {
+		failed = append(failed, map[string]interface{}{
+			"id":           aws.ToString(entry.Id),
+			"code":         aws.ToString(entry.Code),
+			"message":      aws.ToString(entry.Message),
+			"sender_fault": entry.SenderFault,
+		})
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"successful": successful,
+		"failed":     failed,
+	})
+
+	return &cloud.Response{
+		StatusCode: 200,
+		Body:       body,
+		Headers: map[string]string{
+			"sqs.successful_count": strconv.Itoa(len(successful)),
+			"sqs.failed_count":     strconv.Itoa(len(failed)),
+		},
+	}, nil
+}
+
 func (a *sqsAdapter) receiveMessages(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
 	if req.Path == "" {
 		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "queue URL/path is required")
@@ -132,6 +256,13 @@ func (a *sqsAdapter) receiveMessages(ctx context.Context, req *cloud.Request) (*
 				input.WaitTimeSeconds = int32(wait)
 			}
 		}
+
+		if names, ok := req.QueryParams["MessageSystemAttributeNames"]; ok && names != "" {
+			for _, name := range strings.Split(names, ",") {
+				input.MessageSystemAttributeNames = append(input.MessageSystemAttributeNames,
+					types.MessageSystemAttributeName(strings.TrimSpace(name)))
+			}
+		}
 	}
 
 	// Default values
@@ -198,6 +329,47 @@ func (a *sqsAdapter) deleteMessage(ctx context.Context, req *cloud.Request) (*cl
 	}, nil
 }
 
+func (a *sqsAdapter) changeMessageVisibility(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	if req.Path == "" {
+		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "queue URL/path is required")
+	}
+
+	receiptHandle := ""
+	if req.Headers != nil {
+		receiptHandle = req.Headers["sqs.receipt_handle"]
+	}
+
+	if receiptHandle == "" {
+		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "receipt handle is required")
+	}
+
+	var visibilityTimeout int32
+	if req.QueryParams != nil {
+		if timeout, ok := req.QueryParams["VisibilityTimeout"]; ok {
+			parsed, err := strconv.ParseInt(timeout, 10, 32)
+			if err != nil {
+				return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, fmt.Sprintf("invalid VisibilityTimeout: %s", timeout))
+			}
+			visibilityTimeout = int32(parsed)
+		}
+	}
+
+	input := &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          aws.String(req.Path),
+		ReceiptHandle:     aws.String(receiptHandle),
+		VisibilityTimeout: visibilityTimeout,
+	}
+
+	_, err := a.client.ChangeMessageVisibility(ctx, input)
+	if err != nil {
+		return nil, normalizeSQSError(err, "sqs.change_message_visibility")
+	}
+
+	return &cloud.Response{
+		StatusCode: 204, // No Content
+	}, nil
+}
+
 func (a *sqsAdapter) createQueue(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
 	if req.Path == "" {
 		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "queue name is required")
@@ -265,6 +437,13 @@ func (a *sqsAdapter) listQueues(ctx context.Context, req *cloud.Request) (*cloud
 			input.QueueNamePrefix = aws.String(prefix)
 		}
 	}
+	if token, paged := req.PageToken(); paged {
+		// SQS only returns a NextToken when MaxResults is set
+		input.MaxResults = aws.Int32(maxQueuesPerPage)
+		if !token.IsFirst() {
+			input.NextToken = aws.String(string(token))
+		}
+	}
 
 	result, err := a.client.ListQueues(ctx, input)
 	if err != nil {
@@ -284,7 +463,8 @@ func (a *sqsAdapter) listQueues(ctx context.Context, req *cloud.Request) (*cloud
 		StatusCode: 200,
 		Body:       bodyBytes,
 		Headers: map[string]string{
-			"sqs.queue_count": strconv.Itoa(len(result.QueueUrls)),
+			"sqs.queue_count":         strconv.Itoa(len(result.QueueUrls)),
+			cloud.NextPageTokenHeader: aws.ToString(result.NextToken),
 		},
 	}, nil
 }
@@ -2,11 +2,16 @@ package adapters
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/skolldire/go-engine/pkg/integration/cloud"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSQSAdapter_Do_InvalidOperation(t *testing.T) {
@@ -89,6 +94,42 @@ func TestSQSAdapter_DeleteMessage_MissingReceiptHandle(t *testing.T) {
 	assert.Contains(t, err.Error(), "receipt handle is required")
 }
 
+func TestSQSAdapter_ChangeMessageVisibility_MissingReceiptHandle(t *testing.T) {
+	cfg := aws.Config{Region: "us-east-1"}
+	adapter := newSQSAdapter(cfg, 0, RetryPolicy{})
+
+	req := &cloud.Request{
+		Operation: "sqs.change_message_visibility",
+		Path:      "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+	}
+
+	resp, err := adapter.Do(context.Background(), req)
+	assert.Nil(t, resp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "receipt handle is required")
+}
+
+func TestSQSAdapter_ChangeMessageVisibility_InvalidTimeout(t *testing.T) {
+	cfg := aws.Config{Region: "us-east-1"}
+	adapter := newSQSAdapter(cfg, 0, RetryPolicy{})
+
+	req := &cloud.Request{
+		Operation: "sqs.change_message_visibility",
+		Path:      "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+		Headers: map[string]string{
+			"sqs.receipt_handle": "handle",
+		},
+		QueryParams: map[string]string{
+			"VisibilityTimeout": "soon",
+		},
+	}
+
+	resp, err := adapter.Do(context.Background(), req)
+	assert.Nil(t, resp)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid VisibilityTimeout")
+}
+
 func TestSQSAdapter_CreateQueue_InvalidPath(t *testing.T) {
 	cfg := aws.Config{Region: "us-east-1"}
 	adapter := newSQSAdapter(cfg, 0, RetryPolicy{})
@@ -133,3 +174,102 @@ func TestSQSAdapter_GetQueueURL_InvalidPath(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "queue name is required")
 }
+
+func TestSQSAdapter_SendMessageBatch(t *testing.T) {
+	var gotInput struct {
+		QueueUrl string
+		Entries  []struct {
+			Id                string
+			MessageBody       string
+			MessageGroupId    string
+			MessageAttributes map[string]struct{ StringValue string }
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &gotInput)
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		_, _ = w.Write([]byte(`{
+			"Successful":[{"Id":"a","MessageId":"m-1"}],
+			"Failed":[{"Id":"b","Code":"InvalidParameterValue","Message":"bad","SenderFault":true}]
+		}`))
+	}))
+	defer server.Close()
+
+	adapter := newSQSAdapter(aws.Config{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  aws.AnonymousCredentials{},
+	}, 0, RetryPolicy{})
+
+	resp, err := adapter.Do(context.Background(), &cloud.Request{
+		Operation: "sqs.send_message_batch",
+		Path:      server.URL + "/123456789012/orders.fifo",
+		Body: []byte(`[
+			{"id":"a","body":"one","headers":{"sqs.message_group_id":"g1","sqs.message_attribute.tenant":"acme"}},
+			{"id":"b","body":"two"}
+		]`),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, gotInput.Entries, 2)
+	assert.Equal(t, "a", gotInput.Entries[0].Id)
+	assert.Equal(t, "one", gotInput.Entries[0].MessageBody)
+	assert.Equal(t, "g1", gotInput.Entries[0].MessageGroupId)
+	assert.Equal(t, "acme", gotInput.Entries[0].MessageAttributes["tenant"].StringValue)
+
+	assert.JSONEq(t, `{
+		"successful":[{"id":"a","message_id":"m-1","sequence_number":""}],
+		"failed":[{"id":"b","code":"InvalidParameterValue","message":"bad","sender_fault":true}]
+	}`, string(resp.Body))
+	assert.Equal(t, "1", resp.Headers["sqs.failed_count"])
+}
+
+func TestSQSAdapter_SendMessageBatch_InvalidEntries(t *testing.T) {
+	adapter := newSQSAdapter(aws.Config{Region: "us-east-1"}, 0, RetryPolicy{})
+
+	for _, body := range []string{`not json`, `[]`, `[{},{},{},{},{},{},{},{},{},{},{}]`} {
+		resp, err := adapter.Do(context.Background(), &cloud.Request{
+			Operation: "sqs.send_message_batch",
+			Path:      "queue-url",
+			Body:      []byte(body),
+		})
+		assert.Nil(t, resp)
+		var cloudErr *cloud.Error
+		require.ErrorAs(t, err, &cloudErr, body)
+		assert.Equal(t, cloud.ErrCodeInvalidRequest, cloudErr.Code)
+	}
+}
+
+func TestSQSSendHeaders_ApplyFIFODedupe(t *testing.T) {
+	const fifo = "https://sqs.us-east-1.amazonaws.com/123456789012/orders.fifo"
+	const standard = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+	// sha256("hello")
+	const helloSum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	tests := []struct {
+		name     string
+		queueURL string
+		headers  map[string]string
+		want     string
+	}{
+		{name: "fifo without dedupe id", queueURL: fifo, want: helloSum},
+		{name: "explicit dedupe id wins", queueURL: fifo, headers: map[string]string{"sqs.message_dedupe_id": "order-1"}, want: "order-1"},
+		{name: "opt out", queueURL: fifo, headers: map[string]string{"sqs.disable_auto_dedupe": "true"}, want: ""},
+		{name: "standard queue", queueURL: standard, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			send := parseSQSSendHeaders(tt.headers)
+			send.applyFIFODedupe(tt.queueURL, tt.headers, "hello")
+			assert.Equal(t, tt.want, aws.ToString(send.dedupeID))
+		})
+	}
+
+	first := parseSQSSendHeaders(nil)
+	first.applyFIFODedupe(fifo, nil, "hello")
+	second := parseSQSSendHeaders(nil)
+	second.applyFIFODedupe(fifo, nil, "hello")
+	assert.Equal(t, first.dedupeID, second.dedupeID, "deterministic across calls")
+	assert.LessOrEqual(t, len(aws.ToString(first.dedupeID)), 128)
+}
@@ -252,6 +252,10 @@ func (a *ssmAdapter) getParametersByPath(ctx context.Context, req *cloud.Request
 		}
 	}
 
+	if token, paged := req.PageToken(); paged {
+		return a.getParametersByPathPage(ctx, req.Path, recursive, decrypt, token)
+	}
+
 	var allParams []interface{}
 	var nextToken *string
 
@@ -289,6 +293,39 @@ func (a *ssmAdapter) getParametersByPath(ctx context.Context, req *cloud.Request
 	}, nil
 }
 
+// getParametersByPathPage returns a single page instead of looping over all of them
+func (a *ssmAdapter) getParametersByPathPage(ctx context.Context, path string, recursive, decrypt bool, token cloud.PageToken) (*cloud.Response, error) {
+	input := &ssm.GetParametersByPathInput{
+		Path:           aws.String(path),
+		Recursive:      aws.Bool(recursive),
+		WithDecryption: aws.Bool(decrypt),
+	}
+	if !token.IsFirst() {
+		input.NextToken = aws.String(string(token))
+	}
+
+	result, err := a.client.GetParametersByPath(ctx, input)
+	if err != nil {
+		return nil, normalizeSSMError(err, "ssm.get_parameters_by_path")
+	}
+
+	params := make([]interface{}, 0, len(result.Parameters))
+	for _, param := range result.Parameters {
+		params = append(params, mapParameter(&param))
+	}
+
+	body, _ := json.Marshal(params)
+
+	return &cloud.Response{
+		StatusCode: 200,
+		Body:       body,
+		Headers: map[string]string{
+			"ssm.parameter_count":     fmt.Sprintf("%d", len(params)),
+			cloud.NextPageTokenHeader: aws.ToString(result.NextToken),
+		},
+	}, nil
+}
+
 func (a *ssmAdapter) getParameterHistory(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
 	if req.Path == "" {
 		return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "parameter name is required")
@@ -329,14 +366,27 @@ func (a *ssmAdapter) getParameterHistory(ctx context.Context, req *cloud.Request
 	}, nil
 }
 
+// ssmParameterFilter is the JSON shape of one entry of the ParameterFilters query param
+type ssmParameterFilter struct {
+	Key    string   `json:"Key"`
+	Option string   `json:"Option,omitempty"`
+	Values []string `json:"Values"`
+}
+
+// describeParameters accepts a JSON-encoded ParameterFilters query param in the
+// DescribeParameters shape, e.g.
+// [{"Key":"tag:env","Values":["prod"]},{"Key":"Path","Option":"Recursive","Values":["/app"]}]
 func (a *ssmAdapter) describeParameters(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
 	input := &ssm.DescribeParametersInput{}
 
 	// Parse query params
 	if req.QueryParams != nil {
-		if path, ok := req.QueryParams["ParameterFilters"]; ok {
-			// Simplified - should parse JSON filters
-			_ = path
+		if raw, ok := req.QueryParams["ParameterFilters"]; ok && raw != "" {
+			filters, err := parseSSMParameterFilters(raw)
+			if err != nil {
+				return nil, err
+			}
+			input.ParameterFilters = filters
 		}
 		if maxResults, ok := req.QueryParams["MaxResults"]; ok {
 			if max, err := parseInt(maxResults); err == nil {
@@ -382,6 +432,29 @@ func (a *ssmAdapter) describeParameters(ctx context.Context, req *cloud.Request)
 	}, nil
 }
 
+func parseSSMParameterFilters(raw string) ([]types.ParameterStringFilter, error) {
+	var parsed []ssmParameterFilter
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		return nil, cloud.NewErrorWithCause(cloud.ErrCodeInvalidRequest, "invalid ParameterFilters JSON", err)
+	}
+
+	filters := make([]types.ParameterStringFilter, 0, len(parsed))
+	for _, f := range parsed {
+		if f.Key == "" || len(f.Values) == 0 {
+			return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "each parameter filter requires Key and Values")
+		}
+		filter := types.ParameterStringFilter{
+			Key:    aws.String(f.Key),
+			Values: f.Values,
+		}
+		if f.Option != "" {
+			filter.Option = aws.String(f.Option)
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
 func normalizeSSMError(err error, operation string) *cloud.Error {
 	if err == nil {
 		return nil
@@ -390,11 +463,11 @@ func normalizeSSMError(err error, operation string) *cloud.Error {
 	// Check for ParameterNotFound using errors.As
 	var notFoundErr *types.ParameterNotFound
 	if errors.As(err, &notFoundErr) {
-		return cloud.NewErrorWithCause(
+		return withAWSErrorCode(cloud.NewErrorWithCause(
 			cloud.ErrCodeNotFound,
 			fmt.Sprintf("Parameter not found: %v", err),
 			err,
-		).WithMetadata("status_code", 404)
+		).WithMetadata("status_code", 404), err)
 	}
 
 	return normalizeAWSError(err, operation)
@@ -2,12 +2,18 @@ package adapters
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/skolldire/go-engine/pkg/integration/cloud"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSSMAdapter_Do_InvalidOperation(t *testing.T) {
@@ -130,6 +136,54 @@ func TestSSMAdapter_DescribeParameters(t *testing.T) {
 	assert.Nil(t, resp)
 }
 
+func TestSSMAdapter_DescribeParameters_AppliesFilters(t *testing.T) {
+	var gotInput struct {
+		ParameterFilters []ssmParameterFilter
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &gotInput)
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		_, _ = w.Write([]byte(`{"Parameters":[{"Name":"/app/db/url","Type":"String","Version":3}]}`))
+	}))
+	defer server.Close()
+
+	adapter := newSSMAdapter(aws.Config{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  aws.AnonymousCredentials{},
+	}, 0, RetryPolicy{})
+
+	resp, err := adapter.Do(context.Background(), &cloud.Request{
+		Operation: "ssm.describe_parameters",
+		QueryParams: map[string]string{
+			"ParameterFilters": `[{"Key":"tag:env","Values":["prod"]},{"Key":"Path","Option":"Recursive","Values":["/app"]}]`,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1", resp.Headers["ssm.parameter_count"])
+
+	assert.Equal(t, []ssmParameterFilter{
+		{Key: "tag:env", Values: []string{"prod"}},
+		{Key: "Path", Option: "Recursive", Values: []string{"/app"}},
+	}, gotInput.ParameterFilters)
+}
+
+func TestSSMAdapter_DescribeParameters_InvalidFilters(t *testing.T) {
+	adapter := newSSMAdapter(aws.Config{Region: "us-east-1"}, 0, RetryPolicy{})
+
+	for _, filters := range []string{`tag:env=prod`, `[{"Key":"tag:env"}]`} {
+		resp, err := adapter.Do(context.Background(), &cloud.Request{
+			Operation:   "ssm.describe_parameters",
+			QueryParams: map[string]string{"ParameterFilters": filters},
+		})
+		assert.Nil(t, resp)
+		var cloudErr *cloud.Error
+		require.ErrorAs(t, err, &cloudErr, filters)
+		assert.Equal(t, cloud.ErrCodeInvalidRequest, cloudErr.Code)
+	}
+}
+
 func TestNormalizeSSMError(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -167,3 +221,14 @@ func TestNormalizeSSMError(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeSSMError_ParameterNotFoundPreservesAWSCode(t *testing.T) {
+	err := &types.ParameterNotFound{Message: aws.String("/app/missing")}
+
+	result := normalizeSSMError(err, "ssm.get_parameter")
+
+	assert.Equal(t, cloud.ErrCodeNotFound, result.Code)
+	assert.Equal(t, 404, result.Metadata["status_code"])
+	assert.Equal(t, "ParameterNotFound", result.Metadata[cloud.MetadataAWSErrorCode])
+	assert.Equal(t, "ParameterNotFound", cloud.AWSErrorCode(result))
+}
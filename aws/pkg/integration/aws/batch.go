@@ -0,0 +1,66 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/skolldire/go-engine/pkg/utilities/task_executor"
+)
+
+// ErrBatchItemNotRun is reported for inputs that were never executed (e.g. ctx cancelled first)
+var ErrBatchItemNotRun = errors.New("batch item was not executed")
+
+// Result is the outcome of a single BatchDo input
+type Result[O any] struct {
+	Value O
+	Err   error
+}
+
+// BatchDo applies fn to every input with at most concurrency operations in flight,
+// using task_executor.WorkerPool. Results are keyed by input position and always
+// contain one entry per input; per-item failures are reported in Result.Err.
+// The returned error is non-nil only if ctx ended before the batch completed.
+//
+// Example (upload 10k objects with 20 workers):
+//
+//	results, err := aws.BatchDo(ctx, client, files, func(ctx context.Context, c aws.Client, f File) (*cloud.Response, error) {
+//	    return aws.S3PutObject(ctx, c, bucket, f.Key, f.Body, "application/octet-stream", nil)
+//	}, 20)
+func BatchDo[I, O any](ctx context.Context, client Client, inputs []I, fn func(context.Context, Client, I) (O, error), concurrency int, options ...task_executor.Option) (map[int]Result[O], error) {
+	tasks := make(map[string]task_executor.Tasker, len(inputs))
+	for i, input := range inputs {
+		tasks[strconv.Itoa(i)] = task_executor.Task[I, O]{
+			Func: func(ctx context.Context, in I) (O, error) {
+				return fn(ctx, client, in)
+			},
+			Args: input,
+		}
+	}
+
+	options = append([]task_executor.Option{task_executor.WithPrioritySupport(false)}, options...)
+	poolResults := task_executor.WorkerPool(ctx, tasks, concurrency, options...)
+
+	results := make(map[int]Result[O], len(inputs))
+	for i := range inputs {
+		res, ok := poolResults[strconv.Itoa(i)]
+		if !ok {
+			results[i] = Result[O]{Err: ErrBatchItemNotRun}
+			continue
+		}
+
+		var item Result[O]
+		item.Err = res.Err
+		if res.Res != nil {
+			value, ok := res.Res.(O)
+			if !ok && item.Err == nil {
+				item.Err = fmt.Errorf("unexpected batch result type %T", res.Res)
+			}
+			item.Value = value
+		}
+		results[i] = item
+	}
+
+	return results, ctx.Err()
+}
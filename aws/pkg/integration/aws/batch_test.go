@@ -0,0 +1,70 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchDo_IndexesResultsByPosition(t *testing.T) {
+	inputs := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	var inFlight, maxInFlight int32
+
+	results, err := BatchDo(context.Background(), &mockClientHelper{}, inputs,
+		func(ctx context.Context, c Client, in int) (string, error) {
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				seen := atomic.LoadInt32(&maxInFlight)
+				if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+					break
+				}
+			}
+			if in%3 == 0 {
+				return "", fmt.Errorf("item %d failed", in)
+			}
+			return fmt.Sprintf("obj-%d", in), nil
+		}, 3)
+
+	require.NoError(t, err)
+	require.Len(t, results, len(inputs))
+	for i := range inputs {
+		if i%3 == 0 {
+			assert.EqualError(t, results[i].Err, fmt.Sprintf("item %d failed", i))
+			continue
+		}
+		assert.NoError(t, results[i].Err)
+		assert.Equal(t, fmt.Sprintf("obj-%d", i), results[i].Value)
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(3))
+}
+
+func TestBatchDo_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := BatchDo(ctx, &mockClientHelper{}, []string{"a", "b"},
+		func(ctx context.Context, c Client, in string) (string, error) {
+			return in, nil
+		}, 2)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	require.Len(t, results, 2)
+	for _, res := range results {
+		assert.Error(t, res.Err)
+		assert.True(t, errors.Is(res.Err, ErrBatchItemNotRun) || errors.Is(res.Err, context.Canceled))
+	}
+}
+
+func TestBatchDo_EmptyInputs(t *testing.T) {
+	results, err := BatchDo(context.Background(), &mockClientHelper{}, nil,
+		func(ctx context.Context, c Client, in int) (int, error) { return in, nil }, 4)
+
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
@@ -27,6 +27,10 @@ type Options struct {
 	Middlewares []cloud.Middleware // Optional: middleware chain (logging, metrics, tracing)
 	Timeout     time.Duration      // Optional: default 30s
 	RetryPolicy RetryPolicy        // Optional: retries OFF by default
+
+	RegionFailover *RegionFailover // Optional: read failover to a secondary region
+
+	S3ForcePathStyle bool // Optional: path-style S3 addressing (MinIO, LocalStack)
 }
 
 // RetryPolicy controls retry behavior
@@ -48,15 +52,33 @@ func NewWithOptions(cfg aws.Config, opts Options) Client {
 		retries.MaxAttempts = 3
 	}
 
-	// Create base adapter that handles routing to service adapters
-	baseAdapter := adapters.NewBaseAdapter(cfg, timeout, adapters.RetryPolicy{
+	adapterRetries := adapters.RetryPolicy{
 		Enabled:         retries.Enabled,
 		MaxAttempts:     retries.MaxAttempts,
 		RetriableErrors: retries.RetriableErrors,
-	})
+	}
+
+	adapterOpts := []adapters.Option{adapters.WithS3UsePathStyle(opts.S3ForcePathStyle)}
+
+	// Create base adapter that handles routing to service adapters
+	// With region failover, one base adapter per region is created instead
+	var client cloud.Client
+	if fo := opts.RegionFailover; fo != nil && fo.Primary != "" && fo.Secondary != "" {
+		primaryCfg := cfg.Copy()
+		primaryCfg.Region = fo.Primary
+		secondaryCfg := cfg.Copy()
+		secondaryCfg.Region = fo.Secondary
+		client = newFailoverClient(
+			adapters.NewBaseAdapter(primaryCfg, timeout, adapterRetries, adapterOpts...),
+			adapters.NewBaseAdapter(secondaryCfg, timeout, adapterRetries, adapterOpts...),
+			fo.Primary,
+			fo.Secondary,
+		)
+	} else {
+		client = adapters.NewBaseAdapter(cfg, timeout, adapterRetries, adapterOpts...)
+	}
 
 	// Apply middleware chain (observability is optional middleware)
-	client := cloud.Client(baseAdapter)
 	for _, mw := range opts.Middlewares {
 		client = mw(client)
 	}
@@ -78,7 +100,17 @@ func WithRetry() Options {
 	}
 }
 
+// WithS3ForcePathStyle makes S3 requests use path-style addressing (endpoint/bucket/key)
+// instead of the SDK's default virtual-hosted style (bucket.endpoint/key).
+// It is required by most S3-compatible local services such as MinIO and LocalStack,
+// which cannot resolve per-bucket hostnames; combine it with a custom endpoint
+// (aws.Config.BaseEndpoint) pointing at the service.
+func WithS3ForcePathStyle(enabled bool) Options {
+	return Options{S3ForcePathStyle: enabled}
+}
+
 // WithObservability adds logging, metrics, and tracing middleware
+// Each dependency is optional: a nil logger, recorder or tracer skips that middleware.
 func WithObservability(logger logger.Service, metricsRecorder observability.MetricsRecorder, tracer telemetry.Tracer) Options {
 	middlewares := []cloud.Middleware{}
 	if logger != nil {
@@ -92,3 +124,19 @@ func WithObservability(logger logger.Service, metricsRecorder observability.Metr
 	}
 	return Options{Middlewares: middlewares}
 }
+
+// WithBodyCapture logs a sampled copy of request and response bodies at Debug for
+// troubleshooting payloads: sampleRate is the fraction of requests captured (0 to 1)
+// and bodies are cut to maxBytes after redact (optional, e.g.
+// observability.RedactJSONFields) has scrubbed them. Nothing is logged unless log has
+// Debug enabled; a nil logger disables the capture.
+func WithBodyCapture(log logger.Service, sampleRate float64, maxBytes int, redact observability.BodyRedactor) Options {
+	if log == nil {
+		return Options{}
+	}
+	return Options{Middlewares: []cloud.Middleware{observability.BodyCapture(log, observability.BodyCaptureConfig{
+		SampleRate: sampleRate,
+		MaxBytes:   maxBytes,
+		Redact:     redact,
+	})}}
+}
@@ -131,6 +131,11 @@ func TestNewWithOptions_WithObservability_PartialNil(t *testing.T) {
 	assert.Len(t, opts.Middlewares, 2)
 }
 
+func TestWithBodyCapture(t *testing.T) {
+	assert.Len(t, WithBodyCapture(&mockLogger{}, 0.5, 1024, nil).Middlewares, 1)
+	assert.Empty(t, WithBodyCapture(nil, 1, 1024, nil).Middlewares)
+}
+
 func TestRetryPolicy_DefaultValues(t *testing.T) {
 	policy := RetryPolicy{}
 	assert.False(t, policy.Enabled)
@@ -158,6 +163,8 @@ func (m *mockMetricsRecorder) RecordRequest(operation string, duration time.Dura
 }
 func (m *mockMetricsRecorder) RecordRetry(operation string)    {}
 func (m *mockMetricsRecorder) RecordThrottle(operation string) {}
+func (m *mockMetricsRecorder) RecordCacheEvent(ctx context.Context, operation string, hit bool) {
+}
 
 // Ensure mockMetricsRecorder implements observability.MetricsRecorder
 var _ observability.MetricsRecorder = (*mockMetricsRecorder)(nil)
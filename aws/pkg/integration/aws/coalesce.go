@@ -0,0 +1,171 @@
+package aws
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+	"github.com/skolldire/go-engine/pkg/integration/observability"
+	"github.com/skolldire/go-engine/pkg/utilities/cache"
+)
+
+// DefaultCoalescingTimeout bounds a shared request when WithCoalescingTimeout is not set;
+// it matches the client's default timeout
+const DefaultCoalescingTimeout = 30 * time.Second
+
+// CacheOption configures the coalescing and deduplication middlewares
+type CacheOption func(*cacheConfig)
+
+type cacheConfig struct {
+	metrics           observability.CacheMetricsRecorder
+	coalescingTimeout time.Duration
+}
+
+// WithCacheMetrics reports hits and misses through recorder.RecordCacheEvent when the
+// recorder implements observability.CacheMetricsRecorder (the telemetry recorder does).
+// A nil recorder, or one without RecordCacheEvent, disables reporting.
+func WithCacheMetrics(recorder observability.MetricsRecorder) CacheOption {
+	return func(c *cacheConfig) {
+		c.metrics, _ = recorder.(observability.CacheMetricsRecorder)
+	}
+}
+
+// WithCoalescingTimeout bounds the request shared by coalesced callers, which runs detached
+// from their cancellation; zero uses DefaultCoalescingTimeout. Set it to the client's
+// Options.Timeout when that is not the default.
+func WithCoalescingTimeout(d time.Duration) CacheOption {
+	return func(c *cacheConfig) {
+		c.coalescingTimeout = d
+	}
+}
+
+func newCacheConfig(opts []CacheOption) cacheConfig {
+	cfg := cacheConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.metrics == nil {
+		cfg.metrics = observability.NoopMetricsRecorder{}
+	}
+	if cfg.coalescingTimeout <= 0 {
+		cfg.coalescingTimeout = DefaultCoalescingTimeout
+	}
+	return cfg
+}
+
+// WithRequestCoalescing shares one in-flight request among concurrent identical reads
+// Requests are identical when operation, path, query params, headers and body match;
+// per-request headers such as the correlation ID stamped by WithCorrelationPropagation
+// are ignored. Only read operations (get_, list_, head_, describe_) are coalesced; writes
+// always reach AWS. Each caller receives its own copy of the shared response.
+//
+// The shared request runs detached from the first caller's cancellation so that one
+// caller giving up does not fail the others, bounded by WithCoalescingTimeout instead;
+// every caller still stops waiting when its own ctx is done.
+//
+// With WithCacheMetrics, joining an in-flight request is reported as a hit and
+// starting one as a miss.
+func WithRequestCoalescing(opts ...CacheOption) Options {
+	return Options{Middlewares: []cloud.Middleware{RequestCoalescing(opts...)}}
+}
+
+// RequestCoalescing returns the middleware used by WithRequestCoalescing
+func RequestCoalescing(opts ...CacheOption) cloud.Middleware {
+	cfg := newCacheConfig(opts)
+	return func(next cloud.Client) cloud.Client {
+		return &coalescingMiddleware{
+			next:    next,
+			metrics: cfg.metrics,
+			timeout: cfg.coalescingTimeout,
+		}
+	}
+}
+
+type coalescingMiddleware struct {
+	next     cloud.Client
+	metrics  observability.CacheMetricsRecorder
+	timeout  time.Duration
+	inflight cache.Group[string, *cloud.Response]
+}
+
+func (m *coalescingMiddleware) Do(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	if req == nil || req.StreamBody || !isReadOperation(req.Operation) {
+		return m.next.Do(ctx, req)
+	}
+
+	shared := context.WithoutCancel(ctx)
+	resp, joined, err := m.inflight.Do(ctx, coalescingKey(req), func() (*cloud.Response, error) {
+		ctx, cancel := context.WithTimeout(shared, m.timeout)
+		defer cancel()
+		return m.next.Do(ctx, req)
+	})
+	m.metrics.RecordCacheEvent(ctx, req.Operation, joined)
+	if err != nil {
+		return nil, err
+	}
+	return cloneResponse(resp), nil
+}
+
+// coalescingKey identifies requests that would produce the same response
+func coalescingKey(req *cloud.Request) string {
+	var b strings.Builder
+	b.WriteString(req.Operation)
+	b.WriteByte(0)
+	b.WriteString(req.Path)
+	b.WriteByte(0)
+	writeSortedMap(&b, req.QueryParams, nil)
+	b.WriteByte(0)
+	writeSortedMap(&b, req.Headers, isPerRequestHeader)
+	b.WriteByte(0)
+	b.Write(req.Body)
+	return b.String()
+}
+
+// isPerRequestHeader reports headers that differ between otherwise identical requests
+// without changing the response, such as a stamped correlation ID
+func isPerRequestHeader(name string) bool {
+	return strings.HasSuffix(name, "."+CorrelationAttribute)
+}
+
+// writeSortedMap writes m in key order, leaving out the keys skip reports
+func writeSortedMap(b *strings.Builder, m map[string]string, skip func(string) bool) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if skip == nil || !skip(k) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(m[k])
+		b.WriteByte('&')
+	}
+}
+
+// cloneResponse copies resp so callers sharing a coalesced result cannot affect each other
+func cloneResponse(resp *cloud.Response) *cloud.Response {
+	if resp == nil {
+		return nil
+	}
+	clone := *resp
+	if resp.Body != nil {
+		clone.Body = append([]byte(nil), resp.Body...)
+	}
+	if resp.Headers != nil {
+		clone.Headers = make(map[string]string, len(resp.Headers))
+		for k, v := range resp.Headers {
+			clone.Headers[k] = v
+		}
+	}
+	if resp.Metadata != nil {
+		clone.Metadata = make(map[string]interface{}, len(resp.Metadata))
+		for k, v := range resp.Metadata {
+			clone.Metadata[k] = v
+		}
+	}
+	return &clone
+}
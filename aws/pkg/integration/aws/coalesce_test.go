@@ -0,0 +1,254 @@
+package aws
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+	"github.com/skolldire/go-engine/pkg/integration/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingClient counts calls per operation, signals started (when set) on each call
+// and blocks until release is closed
+type countingClient struct {
+	calls   int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c *countingClient) Do(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	atomic.AddInt32(&c.calls, 1)
+	if c.started != nil {
+		c.started <- struct{}{}
+	}
+	if c.release != nil {
+		<-c.release
+	}
+	return &cloud.Response{
+		StatusCode: 200,
+		Body:       []byte(`{"Value":"secret"}`),
+		Metadata:   map[string]interface{}{"op": req.Operation},
+	}, nil
+}
+
+// cacheEventRecorder counts RecordCacheEvent hits and misses
+type cacheEventRecorder struct {
+	mockMetricsRecorder
+	hits, misses atomic.Int32
+}
+
+func (r *cacheEventRecorder) RecordCacheEvent(ctx context.Context, operation string, hit bool) {
+	if hit {
+		r.hits.Add(1)
+		return
+	}
+	r.misses.Add(1)
+}
+
+func TestRequestCoalescing_ConcurrentIdenticalReads(t *testing.T) {
+	base := &countingClient{release: make(chan struct{})}
+	client := RequestCoalescing()(base)
+
+	const callers = 50
+	var wg sync.WaitGroup
+	responses := make([]*cloud.Response, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := SSMGetParameter(context.Background(), client, "/app/db/password", true)
+			assert.NoError(t, err)
+			responses[i] = resp
+		}(i)
+	}
+
+	// Let the callers pile up on the in-flight request before releasing it
+	time.Sleep(20 * time.Millisecond)
+	close(base.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&base.calls))
+	for _, resp := range responses {
+		require.NotNil(t, resp)
+		assert.Equal(t, `{"Value":"secret"}`, string(resp.Body))
+	}
+
+	// Each caller owns its copy
+	responses[0].Metadata["op"] = "mutated"
+	assert.Equal(t, "ssm.get_parameter", responses[1].Metadata["op"])
+}
+
+func TestRequestCoalescing_DifferentReadsNotShared(t *testing.T) {
+	base := &countingClient{}
+	client := RequestCoalescing()(base)
+
+	_, err := SSMGetParameter(context.Background(), client, "/a", false)
+	require.NoError(t, err)
+	_, err = SSMGetParameter(context.Background(), client, "/a", true)
+	require.NoError(t, err)
+	_, err = SSMGetParameter(context.Background(), client, "/b", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&base.calls))
+}
+
+func TestRequestCoalescing_RecordsCacheEvents(t *testing.T) {
+	base := &countingClient{started: make(chan struct{}, 1), release: make(chan struct{})}
+	recorder := &cacheEventRecorder{}
+	client := RequestCoalescing(WithCacheMetrics(recorder))(base)
+
+	leader := make(chan error, 1)
+	go func() {
+		_, err := SSMGetParameter(context.Background(), client, "/app/db/password", true)
+		leader <- err
+	}()
+	<-base.started // The shared request stays in flight until release is closed
+
+	// Callers arriving now join it; a cancelled ctx makes them return right after joining
+	joinCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 2; i++ {
+		_, err := SSMGetParameter(joinCtx, client, "/app/db/password", true)
+		require.ErrorIs(t, err, context.Canceled)
+	}
+	close(base.release)
+	require.NoError(t, <-leader)
+
+	base.started = nil
+	_, err := SQSSendMessageBytes(context.Background(), client, "my-queue", []byte("write"))
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), recorder.misses.Load())
+	assert.Equal(t, int32(2), recorder.hits.Load(), "writes are not reported")
+}
+
+func TestWithCacheMetrics_RecorderWithoutCacheEvents(t *testing.T) {
+	plain := struct{ observability.MetricsRecorder }{observability.NewNoopMetricsRecorder()}
+	client := RequestCoalescing(WithCacheMetrics(plain))(&countingClient{})
+
+	_, err := SSMGetParameter(context.Background(), client, "/app/db/password", true)
+	require.NoError(t, err)
+}
+
+func TestRequestCoalescing_WritesBypass(t *testing.T) {
+	base := &countingClient{release: make(chan struct{})}
+	client := RequestCoalescing()(base)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := SQSSendMessageBytes(context.Background(), client, "my-queue", []byte("same"))
+			assert.NoError(t, err)
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(base.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(5), atomic.LoadInt32(&base.calls))
+}
+
+func TestRequestCoalescing_StreamsBypass(t *testing.T) {
+	base := &countingClient{release: make(chan struct{})}
+	client := RequestCoalescing()(base)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Do(context.Background(), &cloud.Request{Operation: "s3.get_object", Path: "bucket/key", StreamBody: true})
+			assert.NoError(t, err)
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(base.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&base.calls))
+}
+
+func TestRequestCoalescing_CallerCancellationDoesNotFailOthers(t *testing.T) {
+	base := &countingClient{release: make(chan struct{})}
+	client := RequestCoalescing()(base)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstDone := make(chan error, 1)
+	go func() {
+		_, err := SSMGetParameter(ctx, client, "/a", false)
+		firstDone <- err
+	}()
+	for atomic.LoadInt32(&base.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	secondDone := make(chan error, 1)
+	go func() {
+		_, err := SSMGetParameter(context.Background(), client, "/a", false)
+		secondDone <- err
+	}()
+
+	cancel()
+	assert.ErrorIs(t, <-firstDone, context.Canceled)
+
+	time.Sleep(20 * time.Millisecond)
+	close(base.release)
+	assert.NoError(t, <-secondDone)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&base.calls))
+}
+
+// deadlineClient reports whether the shared request ran with a deadline
+type deadlineClient struct {
+	deadline chan time.Duration
+}
+
+func (c *deadlineClient) Do(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		c.deadline <- 0
+	} else {
+		c.deadline <- time.Until(deadline)
+	}
+	return &cloud.Response{StatusCode: 200}, nil
+}
+
+func TestRequestCoalescing_SharedRequestIsBounded(t *testing.T) {
+	base := &deadlineClient{deadline: make(chan time.Duration, 2)}
+
+	_, err := RequestCoalescing()(base).Do(context.Background(), &cloud.Request{Operation: "ssm.get_parameter", Path: "/a"})
+	require.NoError(t, err)
+	assert.InDelta(t, DefaultCoalescingTimeout, <-base.deadline, float64(time.Second))
+
+	client := RequestCoalescing(WithCoalescingTimeout(5 * time.Second))(base)
+	_, err = client.Do(context.Background(), &cloud.Request{Operation: "ssm.get_parameter", Path: "/a"})
+	require.NoError(t, err)
+	assert.InDelta(t, 5*time.Second, <-base.deadline, float64(time.Second))
+}
+
+func TestCoalescingKey_IgnoresCorrelationHeaders(t *testing.T) {
+	read := func(correlationID string) *cloud.Request {
+		return &cloud.Request{
+			Operation: "s3.get_object",
+			Path:      "bucket/key",
+			Headers: map[string]string{
+				"s3.version_id":                       "v1",
+				"s3.metadata." + CorrelationAttribute: correlationID,
+			},
+		}
+	}
+
+	assert.Equal(t, coalescingKey(read("req-1")), coalescingKey(read("req-2")))
+
+	other := read("req-1")
+	other.Headers["s3.version_id"] = "v2"
+	assert.NotEqual(t, coalescingKey(read("req-1")), coalescingKey(other))
+}
@@ -0,0 +1,223 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+)
+
+// DefaultPoisonThreshold is used when ConsumerOptions.PoisonThreshold is zero
+const DefaultPoisonThreshold = 5
+
+// approximateReceiveCount is the SQS system attribute counting deliveries of a message
+const approximateReceiveCount = "ApproximateReceiveCount"
+
+// ErrMessageSettled is returned when a MessageHandle was already acked or nacked
+var ErrMessageSettled = errors.New("message already acked or nacked")
+
+// SQSMessage is a message received by SQSConsume
+type SQSMessage struct {
+	MessageID     string            `json:"message_id"`
+	ReceiptHandle string            `json:"receipt_handle"`
+	Body          string            `json:"body"`
+	Attributes    map[string]string `json:"attributes"`
+}
+
+// ReceiveCount returns the ApproximateReceiveCount system attribute, or 0 if absent
+func (m SQSMessage) ReceiveCount() int {
+	count, err := strconv.Atoi(m.Attributes[approximateReceiveCount])
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+// ConsumerOptions configures SQSConsume
+type ConsumerOptions struct {
+	MaxMessages     int32
+	WaitTimeSeconds int32
+	// PoisonThreshold is the receive count above which a message is considered poison
+	PoisonThreshold int
+	// OnPoisonMessage is invoked before the handler for messages whose receive count
+	// exceeds PoisonThreshold, typically to alert or archive them before the queue's
+	// redrive policy moves them to the DLQ. The handler still runs afterwards; delete the
+	// message here (SQSDeleteMessage) to drop it instead of letting it be redelivered.
+	OnPoisonMessage func(ctx context.Context, msg SQSMessage)
+	// NackOnError makes messages whose handler failed visible again immediately instead
+	// of after the queue's visibility timeout
+	NackOnError bool
+	// Metrics, when set, receives per-cycle counts and the in-flight message gauge
+	Metrics ConsumerMetricsRecorder
+}
+
+// SQSConsume receives messages from queueURL until ctx is done, passing each to handler
+// Messages are deleted when handler returns nil and left for redelivery otherwise.
+// It returns nil once ctx is done, or the first receive/delete/visibility error.
+func SQSConsume(ctx context.Context, client Client, queueURL string, opts ConsumerOptions, handler func(ctx context.Context, msg SQSMessage) error) error {
+	if opts.MaxMessages <= 0 {
+		opts.MaxMessages = 1
+	}
+	if opts.PoisonThreshold <= 0 {
+		opts.PoisonThreshold = DefaultPoisonThreshold
+	}
+
+	if opts.Metrics == nil {
+		opts.Metrics = noopConsumerMetrics{}
+	}
+
+	for ctx.Err() == nil {
+		messages, err := sqsReceive(ctx, client, queueURL, opts.MaxMessages, opts.WaitTimeSeconds)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			opts.Metrics.RecordConsumerCycle(queueURL, ConsumerCycleStats{Err: err})
+			return err
+		}
+
+		if err := consumeBatch(ctx, client, queueURL, opts, messages, handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// consumeBatch handles one received batch, reporting its stats even when it stops early
+func consumeBatch(ctx context.Context, client Client, queueURL string, opts ConsumerOptions, messages []SQSMessage, handler func(ctx context.Context, msg SQSMessage) error) (err error) {
+	stats := ConsumerCycleStats{Received: len(messages)}
+	inFlight := len(messages)
+	opts.Metrics.RecordInFlight(queueURL, inFlight)
+	defer func() {
+		stats.Err = err
+		opts.Metrics.RecordConsumerCycle(queueURL, stats)
+		if inFlight != 0 {
+			// Stopped early: the rest of the batch is abandoned to redelivery
+			opts.Metrics.RecordInFlight(queueURL, 0)
+		}
+	}()
+
+	for _, msg := range messages {
+		if opts.OnPoisonMessage != nil && msg.ReceiveCount() > opts.PoisonThreshold {
+			opts.OnPoisonMessage(ctx, msg)
+		}
+
+		if err := handler(ctx, msg); err != nil {
+			stats.Failed++
+			if opts.NackOnError {
+				if err := SQSChangeMessageVisibility(ctx, client, queueURL, msg.ReceiptHandle, 0); err != nil {
+					return fmt.Errorf("failed to change visibility of message %s: %w", msg.MessageID, err)
+				}
+				stats.VisibilityChanged++
+			}
+		} else {
+			stats.Processed++
+			if err := SQSDeleteMessage(ctx, client, queueURL, msg.ReceiptHandle); err != nil {
+				return fmt.Errorf("failed to delete message %s: %w", msg.MessageID, err)
+			}
+			stats.Deleted++
+		}
+
+		inFlight--
+		opts.Metrics.RecordInFlight(queueURL, inFlight)
+	}
+	return nil
+}
+
+// sqsReceive receives a batch requesting the ApproximateReceiveCount attribute
+func sqsReceive(ctx context.Context, client Client, queueURL string, maxMessages, waitTimeSeconds int32) ([]SQSMessage, error) {
+	req := &cloud.Request{
+		Operation: "sqs.receive_message",
+		Path:      queueURL,
+		QueryParams: map[string]string{
+			"MaxNumberOfMessages":         strconv.Itoa(int(maxMessages)),
+			"WaitTimeSeconds":             strconv.Itoa(int(waitTimeSeconds)),
+			"MessageSystemAttributeNames": approximateReceiveCount,
+		},
+	}
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []SQSMessage
+	if len(resp.Body) == 0 {
+		return messages, nil
+	}
+	if err := resp.UnmarshalBody(&messages); err != nil {
+		return nil, fmt.Errorf("failed to decode received messages: %w", err)
+	}
+	return messages, nil
+}
+
+// MessageHandle is a received message that can be acknowledged or returned to the queue
+type MessageHandle struct {
+	SQSMessage
+
+	client    Client
+	queueURL  string
+	offloaded *s3Pointer // Set by SQSReceiveExtended for payloads stored in S3
+
+	mu      sync.Mutex
+	settled bool
+}
+
+// Ack deletes the message from the queue, then its offloaded S3 payload if any
+func (h *MessageHandle) Ack(ctx context.Context) error {
+	if err := h.settle(func() error {
+		return SQSDeleteMessage(ctx, h.client, h.queueURL, h.ReceiptHandle)
+	}); err != nil {
+		return err
+	}
+
+	if h.offloaded != nil {
+		if err := S3DeleteObject(ctx, h.client, h.offloaded.Bucket, h.offloaded.Key); err != nil {
+			return fmt.Errorf("message deleted but offloaded payload s3://%s/%s was not: %w", h.offloaded.Bucket, h.offloaded.Key, err)
+		}
+	}
+	return nil
+}
+
+// Nack makes the message immediately visible again (visibility timeout 0) for redelivery
+func (h *MessageHandle) Nack(ctx context.Context) error {
+	return h.settle(func() error {
+		return SQSChangeMessageVisibility(ctx, h.client, h.queueURL, h.ReceiptHandle, 0)
+	})
+}
+
+// settle runs op once; a failed op leaves the handle unsettled so it can be retried
+func (h *MessageHandle) settle(op func() error) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.settled {
+		return ErrMessageSettled
+	}
+	if err := op(); err != nil {
+		return err
+	}
+	h.settled = true
+	return nil
+}
+
+// SQSReceiveWithHandles receives messages from queueURL wrapped in MessageHandles
+// Each handle must be Acked or Nacked; unsettled messages reappear after the queue's visibility timeout.
+func SQSReceiveWithHandles(ctx context.Context, client Client, queueURL string, maxMessages int32, waitTimeSeconds int32) ([]*MessageHandle, error) {
+	messages, err := sqsReceive(ctx, client, queueURL, maxMessages, waitTimeSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	handles := make([]*MessageHandle, len(messages))
+	for i, msg := range messages {
+		handles[i] = &MessageHandle{
+			SQSMessage: msg,
+			client:     client,
+			queueURL:   queueURL,
+		}
+	}
+	return handles, nil
+}
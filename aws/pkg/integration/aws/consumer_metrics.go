@@ -0,0 +1,81 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/skolldire/go-engine/pkg/utilities/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ConsumerCycleStats counts what one SQSConsume poll cycle did with its batch
+type ConsumerCycleStats struct {
+	Received          int
+	Processed         int // handler returned nil
+	Failed            int // handler returned an error
+	Deleted           int
+	VisibilityChanged int // failed messages returned to the queue with NackOnError
+	// Err is the receive, delete or visibility error that ended the cycle; nil otherwise
+	Err error
+}
+
+// ConsumerMetricsRecorder receives SQSConsume metrics for dashboards.
+// RecordConsumerCycle is called after every poll cycle, empty and failed ones included;
+// RecordInFlight whenever the number of received but not yet settled messages changes.
+type ConsumerMetricsRecorder interface {
+	RecordConsumerCycle(queueURL string, stats ConsumerCycleStats)
+	RecordInFlight(queueURL string, inFlight int)
+}
+
+type noopConsumerMetrics struct{}
+
+func (noopConsumerMetrics) RecordConsumerCycle(string, ConsumerCycleStats) {}
+
+func (noopConsumerMetrics) RecordInFlight(string, int) {}
+
+// telemetryConsumerMetrics emits sqs.consumer.* counters and the in-flight gauge
+type telemetryConsumerMetrics struct {
+	telemetry telemetry.Metrics
+}
+
+// NewTelemetryConsumerMetrics records consumer metrics as the counters
+// sqs.consumer.{received,processed,failed,deleted,visibility_changed,errors} and the
+// gauge sqs.consumer.in_flight, all with a queue_url attribute. A nil tel records nothing.
+func NewTelemetryConsumerMetrics(tel telemetry.Metrics) ConsumerMetricsRecorder {
+	if tel == nil {
+		return noopConsumerMetrics{}
+	}
+	return &telemetryConsumerMetrics{telemetry: tel}
+}
+
+func (r *telemetryConsumerMetrics) RecordConsumerCycle(queueURL string, stats ConsumerCycleStats) {
+	queue := attribute.String("queue_url", queueURL)
+	counters := []struct {
+		name  string
+		value int
+	}{
+		{"sqs.consumer.received", stats.Received},
+		{"sqs.consumer.processed", stats.Processed},
+		{"sqs.consumer.failed", stats.Failed},
+		{"sqs.consumer.deleted", stats.Deleted},
+		{"sqs.consumer.visibility_changed", stats.VisibilityChanged},
+		{"sqs.consumer.errors", boolToInt(stats.Err != nil)},
+	}
+	for _, c := range counters {
+		if c.value > 0 {
+			r.telemetry.Counter(context.Background(), c.name, int64(c.value), queue)
+		}
+	}
+}
+
+func (r *telemetryConsumerMetrics) RecordInFlight(queueURL string, inFlight int) {
+	r.telemetry.Gauge(context.Background(), "sqs.consumer.in_flight", float64(inFlight),
+		attribute.String("queue_url", queueURL),
+	)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
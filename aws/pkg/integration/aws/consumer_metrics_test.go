@@ -0,0 +1,170 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type fakeConsumerMetrics struct {
+	cycles   []ConsumerCycleStats
+	inFlight []int
+}
+
+func (f *fakeConsumerMetrics) RecordConsumerCycle(queueURL string, stats ConsumerCycleStats) {
+	f.cycles = append(f.cycles, stats)
+}
+
+func (f *fakeConsumerMetrics) RecordInFlight(queueURL string, inFlight int) {
+	f.inFlight = append(f.inFlight, inFlight)
+}
+
+func TestSQSConsume_RecordsMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sqs.receive_message"
+	})).Return(&cloud.Response{
+		StatusCode: 200,
+		Body: []byte(`[
+			{"message_id":"ok-1","receipt_handle":"rh-1"},
+			{"message_id":"bad","receipt_handle":"rh-2"},
+			{"message_id":"ok-2","receipt_handle":"rh-3"}
+		]`),
+	}, nil).Once()
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sqs.delete_message"
+	})).Return(&cloud.Response{StatusCode: 204}, nil).Twice()
+
+	metrics := &fakeConsumerMetrics{}
+	err := SQSConsume(ctx, m, "my-queue", ConsumerOptions{MaxMessages: 3, Metrics: metrics},
+		func(ctx context.Context, msg SQSMessage) error {
+			switch msg.MessageID {
+			case "bad":
+				return errors.New("cannot process")
+			case "ok-2":
+				cancel()
+			}
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, []ConsumerCycleStats{{Received: 3, Processed: 2, Failed: 1, Deleted: 2}}, metrics.cycles)
+	assert.Equal(t, []int{3, 2, 1, 0}, metrics.inFlight)
+	m.AssertExpectations(t)
+}
+
+func TestSQSConsume_RecordsMetricsWhenDeleteFails(t *testing.T) {
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sqs.receive_message"
+	})).Return(&cloud.Response{
+		StatusCode: 200,
+		Body:       []byte(`[{"message_id":"m1","receipt_handle":"rh-1"},{"message_id":"m2","receipt_handle":"rh-2"}]`),
+	}, nil).Once()
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sqs.delete_message"
+	})).Return(nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "boom")).Once()
+
+	metrics := &fakeConsumerMetrics{}
+	err := SQSConsume(context.Background(), m, "my-queue", ConsumerOptions{MaxMessages: 2, Metrics: metrics},
+		func(ctx context.Context, msg SQSMessage) error { return nil })
+
+	require.Error(t, err)
+	assert.Equal(t, []ConsumerCycleStats{{Received: 2, Processed: 1, Err: err}}, metrics.cycles)
+	assert.Equal(t, []int{2, 0}, metrics.inFlight)
+}
+
+func TestSQSConsume_NackOnError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sqs.receive_message"
+	})).Return(&cloud.Response{
+		StatusCode: 200,
+		Body:       []byte(`[{"message_id":"bad","receipt_handle":"rh-1"}]`),
+	}, nil).Once()
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sqs.change_message_visibility" &&
+			req.Headers["sqs.receipt_handle"] == "rh-1" &&
+			req.QueryParams["VisibilityTimeout"] == "0"
+	})).Return(&cloud.Response{StatusCode: 200}, nil).Once()
+
+	metrics := &fakeConsumerMetrics{}
+	err := SQSConsume(ctx, m, "my-queue", ConsumerOptions{NackOnError: true, Metrics: metrics},
+		func(ctx context.Context, msg SQSMessage) error {
+			cancel()
+			return errors.New("cannot process")
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, []ConsumerCycleStats{{Received: 1, Failed: 1, VisibilityChanged: 1}}, metrics.cycles)
+	m.AssertExpectations(t)
+}
+
+func TestSQSConsume_RecordsFailedReceiveCycle(t *testing.T) {
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, mock.Anything).Return(nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "boom")).Once()
+
+	metrics := &fakeConsumerMetrics{}
+	err := SQSConsume(context.Background(), m, "my-queue", ConsumerOptions{Metrics: metrics},
+		func(ctx context.Context, msg SQSMessage) error { return nil })
+
+	require.Error(t, err)
+	assert.Equal(t, []ConsumerCycleStats{{Err: err}}, metrics.cycles)
+	assert.Empty(t, metrics.inFlight)
+}
+
+type recordedMetric struct {
+	name  string
+	value float64
+	attrs []attribute.KeyValue
+}
+
+type fakeTelemetryMetrics struct {
+	counters []recordedMetric
+	gauges   []recordedMetric
+}
+
+func (f *fakeTelemetryMetrics) Counter(ctx context.Context, name string, value int64, attrs ...attribute.KeyValue) {
+	f.counters = append(f.counters, recordedMetric{name: name, value: float64(value), attrs: attrs})
+}
+
+func (f *fakeTelemetryMetrics) Gauge(ctx context.Context, name string, value float64, attrs ...attribute.KeyValue) {
+	f.gauges = append(f.gauges, recordedMetric{name: name, value: value, attrs: attrs})
+}
+
+func (f *fakeTelemetryMetrics) Histogram(ctx context.Context, name string, value float64, attrs ...attribute.KeyValue) {
+}
+
+func TestNewTelemetryConsumerMetrics(t *testing.T) {
+	tel := &fakeTelemetryMetrics{}
+	recorder := NewTelemetryConsumerMetrics(tel)
+
+	recorder.RecordConsumerCycle("my-queue", ConsumerCycleStats{Received: 2, Processed: 1, Failed: 1, Deleted: 1, Err: errors.New("delete failed")})
+	recorder.RecordInFlight("my-queue", 1)
+
+	queue := []attribute.KeyValue{attribute.String("queue_url", "my-queue")}
+	assert.Equal(t, []recordedMetric{
+		{name: "sqs.consumer.received", value: 2, attrs: queue},
+		{name: "sqs.consumer.processed", value: 1, attrs: queue},
+		{name: "sqs.consumer.failed", value: 1, attrs: queue},
+		{name: "sqs.consumer.deleted", value: 1, attrs: queue},
+		{name: "sqs.consumer.errors", value: 1, attrs: queue},
+	}, tel.counters)
+	assert.Equal(t, []recordedMetric{{name: "sqs.consumer.in_flight", value: 1, attrs: queue}}, tel.gauges)
+
+	assert.NotPanics(t, func() {
+		NewTelemetryConsumerMetrics(nil).RecordConsumerCycle("my-queue", ConsumerCycleStats{Received: 1})
+	})
+}
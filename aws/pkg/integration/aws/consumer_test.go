@@ -0,0 +1,144 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQSConsume_PoisonMessageCallback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sqs.receive_message" &&
+			req.QueryParams["MessageSystemAttributeNames"] == "ApproximateReceiveCount"
+	})).Return(&cloud.Response{
+		StatusCode: 200,
+		Body: []byte(`[
+			{"message_id":"fresh","receipt_handle":"rh-1","body":"a","attributes":{"ApproximateReceiveCount":"1"}},
+			{"message_id":"poison","receipt_handle":"rh-2","body":"b","attributes":{"ApproximateReceiveCount":"4"}}
+		]`),
+	}, nil).Once()
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sqs.delete_message" && req.Headers["sqs.receipt_handle"] == "rh-1"
+	})).Return(&cloud.Response{StatusCode: 204}, nil).Once()
+
+	var poisoned []SQSMessage
+	var handled []string
+	err := SQSConsume(ctx, m, "my-queue", ConsumerOptions{
+		PoisonThreshold: 3,
+		OnPoisonMessage: func(ctx context.Context, msg SQSMessage) {
+			poisoned = append(poisoned, msg)
+		},
+	}, func(ctx context.Context, msg SQSMessage) error {
+		handled = append(handled, msg.MessageID)
+		if msg.MessageID == "poison" {
+			cancel()
+			return errors.New("still failing")
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, poisoned, 1)
+	assert.Equal(t, "poison", poisoned[0].MessageID)
+	assert.Equal(t, "b", poisoned[0].Body)
+	assert.Equal(t, 4, poisoned[0].ReceiveCount())
+	assert.Equal(t, []string{"fresh", "poison"}, handled)
+	m.AssertExpectations(t)
+}
+
+func TestSQSConsume_DefaultPoisonThreshold(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sqs.receive_message"
+	})).Return(&cloud.Response{
+		StatusCode: 200,
+		Body:       []byte(`[{"message_id":"m1","receipt_handle":"rh-1","attributes":{"ApproximateReceiveCount":"5"}}]`),
+	}, nil).Once()
+
+	called := false
+	err := SQSConsume(ctx, m, "my-queue", ConsumerOptions{
+		OnPoisonMessage: func(ctx context.Context, msg SQSMessage) { called = true },
+	}, func(ctx context.Context, msg SQSMessage) error {
+		cancel()
+		return errors.New("retry later")
+	})
+
+	require.NoError(t, err)
+	assert.False(t, called, "receive count equal to the threshold is not poison")
+}
+
+func TestSQSConsume_ReceiveError(t *testing.T) {
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, mock.Anything).Return(nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "boom"))
+
+	err := SQSConsume(context.Background(), m, "my-queue", ConsumerOptions{}, func(ctx context.Context, msg SQSMessage) error {
+		t.Fatal("handler must not run")
+		return nil
+	})
+
+	assert.Error(t, err)
+}
+
+func TestSQSMessage_ReceiveCount(t *testing.T) {
+	assert.Equal(t, 0, SQSMessage{}.ReceiveCount())
+	assert.Equal(t, 0, SQSMessage{Attributes: map[string]string{"ApproximateReceiveCount": "x"}}.ReceiveCount())
+	assert.Equal(t, 7, SQSMessage{Attributes: map[string]string{"ApproximateReceiveCount": "7"}}.ReceiveCount())
+}
+
+func TestSQSReceiveWithHandles_AckNack(t *testing.T) {
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sqs.receive_message" && req.QueryParams["MaxNumberOfMessages"] == "10"
+	})).Return(&cloud.Response{
+		StatusCode: 200,
+		Body: []byte(`[
+			{"message_id":"ok","receipt_handle":"rh-1","body":"a"},
+			{"message_id":"retry","receipt_handle":"rh-2","body":"b"}
+		]`),
+	}, nil).Once()
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sqs.delete_message" && req.Headers["sqs.receipt_handle"] == "rh-1"
+	})).Return(&cloud.Response{StatusCode: 204}, nil).Once()
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sqs.change_message_visibility" &&
+			req.Headers["sqs.receipt_handle"] == "rh-2" &&
+			req.QueryParams["VisibilityTimeout"] == "0"
+	})).Return(&cloud.Response{StatusCode: 204}, nil).Once()
+
+	ctx := context.Background()
+	handles, err := SQSReceiveWithHandles(ctx, m, "my-queue", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, handles, 2)
+	assert.Equal(t, "a", handles[0].Body)
+
+	require.NoError(t, handles[0].Ack(ctx))
+	require.NoError(t, handles[1].Nack(ctx))
+
+	assert.ErrorIs(t, handles[0].Ack(ctx), ErrMessageSettled)
+	assert.ErrorIs(t, handles[1].Ack(ctx), ErrMessageSettled)
+	m.AssertExpectations(t)
+}
+
+func TestMessageHandle_FailedAckCanBeRetried(t *testing.T) {
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, mock.Anything).Return(nil, cloud.NewError(cloud.ErrCodeServiceUnavailable, "unavailable")).Once()
+	m.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{StatusCode: 204}, nil).Once()
+
+	h := &MessageHandle{SQSMessage: SQSMessage{ReceiptHandle: "rh"}, client: m, queueURL: "my-queue"}
+
+	assert.Error(t, h.Ack(context.Background()))
+	assert.NoError(t, h.Ack(context.Background()))
+	m.AssertExpectations(t)
+}
@@ -0,0 +1,92 @@
+package aws
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CorrelationAttribute is the SQS/SNS message attribute and S3 metadata key carrying
+// the correlation ID injected by WithCorrelationPropagation
+const CorrelationAttribute = "correlation_id"
+
+// CorrelationExtractor returns the correlation ID of the request being served, or ""
+type CorrelationExtractor func(ctx context.Context) string
+
+// correlationHeaderPrefixes maps the operations that carry user metadata to the header
+// prefix their adapter turns into message attributes / object metadata
+var correlationHeaderPrefixes = map[string]string{
+	"sqs.send_message": "sqs.message_attribute.",
+	"sns.publish":      "sns.message_attribute.",
+	"s3.put_object":    "s3.metadata.",
+}
+
+// WithCorrelationPropagation stamps the correlation ID of the current request on
+// outgoing SQS messages, SNS publications (message attribute) and S3 uploads (object
+// metadata) under CorrelationAttribute, so downstream consumers can correlate them.
+// A nil extractor uses DefaultCorrelationExtractor. Values set explicitly by the
+// caller are never overwritten.
+func WithCorrelationPropagation(extract CorrelationExtractor) Options {
+	return Options{Middlewares: []cloud.Middleware{CorrelationPropagation(extract)}}
+}
+
+// CorrelationPropagation returns the middleware used by WithCorrelationPropagation
+func CorrelationPropagation(extract CorrelationExtractor) cloud.Middleware {
+	if extract == nil {
+		extract = DefaultCorrelationExtractor
+	}
+	return func(next cloud.Client) cloud.Client {
+		return &correlationMiddleware{next: next, extract: extract}
+	}
+}
+
+// DefaultCorrelationExtractor returns the router's request ID (chi middleware.RequestID)
+// and falls back to the trace ID of the active OpenTelemetry span
+func DefaultCorrelationExtractor(ctx context.Context) string {
+	if requestID := middleware.GetReqID(ctx); requestID != "" {
+		return requestID
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		return sc.TraceID().String()
+	}
+	return ""
+}
+
+type correlationMiddleware struct {
+	next    cloud.Client
+	extract CorrelationExtractor
+}
+
+func (m *correlationMiddleware) Do(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	if req == nil {
+		return m.next.Do(ctx, req)
+	}
+
+	prefix, ok := correlationHeaderPrefixes[req.Operation]
+	if !ok {
+		return m.next.Do(ctx, req)
+	}
+
+	key := prefix + CorrelationAttribute
+	if _, set := req.Headers[key]; set {
+		return m.next.Do(ctx, req)
+	}
+
+	correlationID := strings.TrimSpace(m.extract(ctx))
+	if correlationID == "" {
+		return m.next.Do(ctx, req)
+	}
+
+	// Copy the request so the caller's headers map is left untouched
+	stamped := *req
+	stamped.Headers = make(map[string]string, len(req.Headers)+1)
+	for k, v := range req.Headers {
+		stamped.Headers[k] = v
+	}
+	stamped.Headers[key] = correlationID
+
+	return m.next.Do(ctx, &stamped)
+}
@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestCorrelationPropagation_StampsSupportedOperations(t *testing.T) {
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-123")
+
+	tests := []struct {
+		operation string
+		header    string
+	}{
+		{"sqs.send_message", "sqs.message_attribute.correlation_id"},
+		{"sns.publish", "sns.message_attribute.correlation_id"},
+		{"s3.put_object", "s3.metadata.correlation_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			next := &mockClientHelper{}
+			next.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+				return req.Headers[tt.header] == "req-123"
+			})).Return(&cloud.Response{StatusCode: 200}, nil).Once()
+
+			original := &cloud.Request{Operation: tt.operation, Headers: map[string]string{"other": "value"}}
+			_, err := CorrelationPropagation(nil)(next).Do(ctx, original)
+
+			assert.NoError(t, err)
+			assert.NotContains(t, original.Headers, tt.header, "caller request must not be mutated")
+			next.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCorrelationPropagation_LeavesOtherRequestsAlone(t *testing.T) {
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "req-123")
+
+	next := &mockClientHelper{}
+	next.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return len(req.Headers) == 0
+	})).Return(&cloud.Response{StatusCode: 200}, nil).Once()
+
+	_, err := CorrelationPropagation(nil)(next).Do(ctx, &cloud.Request{Operation: "s3.get_object"})
+	assert.NoError(t, err)
+	next.AssertExpectations(t)
+}
+
+func TestCorrelationPropagation_KeepsExplicitValue(t *testing.T) {
+	next := &mockClientHelper{}
+	next.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Headers["sqs.message_attribute.correlation_id"] == "explicit"
+	})).Return(&cloud.Response{StatusCode: 200}, nil).Once()
+
+	extract := func(ctx context.Context) string { return "from-context" }
+	_, err := CorrelationPropagation(extract)(next).Do(context.Background(), &cloud.Request{
+		Operation: "sqs.send_message",
+		Headers:   map[string]string{"sqs.message_attribute.correlation_id": "explicit"},
+	})
+	assert.NoError(t, err)
+	next.AssertExpectations(t)
+}
+
+func TestDefaultCorrelationExtractor_FallsBackToTraceID(t *testing.T) {
+	traceID := trace.TraceID{0x01, 0x02, 0x03}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{0x01}})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	assert.Equal(t, traceID.String(), DefaultCorrelationExtractor(ctx))
+	assert.Empty(t, DefaultCorrelationExtractor(context.Background()))
+}
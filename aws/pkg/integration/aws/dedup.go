@@ -0,0 +1,177 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+	"github.com/skolldire/go-engine/pkg/integration/observability"
+)
+
+// IdempotencyKeyHeader carries the caller-supplied idempotency key for sqs.send_message
+const IdempotencyKeyHeader = "sqs.idempotency_key"
+
+// DefaultDedupTTL is used when WithSQSDeduplication receives a zero TTL
+const DefaultDedupTTL = 5 * time.Minute
+
+// ErrDedupKeyNotFound is returned by MemoryDedupStore on a cache miss
+var ErrDedupKeyNotFound = errors.New("dedup key not found")
+
+// DedupStore persists idempotency key -> message ID mappings
+// *redis.RedisClient (database/redis) satisfies this interface directly.
+// Any Get error is treated as a cache miss.
+type DedupStore interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+}
+
+// WithSQSDeduplication enables client-side deduplication of sqs.send_message
+// Sends carrying an IdempotencyKeyHeader that was already sent to the same queue
+// within ttl are skipped and return the prior message ID.
+//
+// This is best-effort protection for standard (non-FIFO) queues: concurrent sends with
+// the same key may both reach SQS, and a store outage disables deduplication.
+// FIFO queues should rely on MessageDeduplicationId instead.
+//
+// With WithCacheMetrics, each keyed send is reported as a hit when skipped and as a
+// miss when it reaches SQS.
+func WithSQSDeduplication(store DedupStore, ttl time.Duration, opts ...CacheOption) Options {
+	return Options{Middlewares: []cloud.Middleware{SQSDeduplication(store, ttl, opts...)}}
+}
+
+// SQSDeduplication returns the middleware used by WithSQSDeduplication
+func SQSDeduplication(store DedupStore, ttl time.Duration, opts ...CacheOption) cloud.Middleware {
+	if ttl <= 0 {
+		ttl = DefaultDedupTTL
+	}
+	cfg := newCacheConfig(opts)
+	return func(next cloud.Client) cloud.Client {
+		return &sqsDedupMiddleware{
+			next:    next,
+			store:   store,
+			ttl:     ttl,
+			metrics: cfg.metrics,
+		}
+	}
+}
+
+type sqsDedupMiddleware struct {
+	next    cloud.Client
+	store   DedupStore
+	ttl     time.Duration
+	metrics observability.CacheMetricsRecorder
+}
+
+func (m *sqsDedupMiddleware) Do(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	if m.store == nil || req == nil || req.Operation != "sqs.send_message" || req.Headers[IdempotencyKeyHeader] == "" {
+		return m.next.Do(ctx, req)
+	}
+
+	key := "sqs-dedup:" + req.Path + ":" + req.Headers[IdempotencyKeyHeader]
+	if messageID, err := m.store.Get(ctx, key); err == nil && messageID != "" {
+		m.metrics.RecordCacheEvent(ctx, req.Operation, true)
+		return &cloud.Response{
+			StatusCode: 200,
+			Headers: map[string]string{
+				"sqs.message_id": messageID,
+			},
+			Metadata: map[string]interface{}{
+				"sqs.message_id":   messageID,
+				"sqs.deduplicated": true,
+			},
+		}, nil
+	}
+	m.metrics.RecordCacheEvent(ctx, req.Operation, false)
+
+	resp, err := m.next.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if messageID := resp.Headers["sqs.message_id"]; messageID != "" {
+		_ = m.store.Set(ctx, key, messageID, m.ttl) // Best-effort: a failed write only disables dedup for this key
+	}
+	return resp, nil
+}
+
+// SQSSendMessageWithKey sends a JSON message tagged with an idempotency key
+// Without WithSQSDeduplication the key is ignored and the message is always sent
+func SQSSendMessageWithKey(ctx context.Context, client Client, queueURL, idempotencyKey string, v interface{}) (messageID string, err error) {
+	req := &cloud.Request{
+		Operation: "sqs.send_message",
+		Path:      queueURL,
+		Headers: map[string]string{
+			IdempotencyKeyHeader: idempotencyKey,
+		},
+	}
+	if err := req.WithJSONBody(v); err != nil {
+		return "", fmt.Errorf("failed to marshal JSON body: %w", err)
+	}
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.Headers["sqs.message_id"], nil
+}
+
+// MemoryDedupStore is an in-process DedupStore with per-entry expiration
+type MemoryDedupStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryDedupEntry
+	writes  int
+}
+
+type memoryDedupEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+// memoryDedupSweepEvery controls how often Set purges expired entries
+const memoryDedupSweepEvery = 1024
+
+// NewMemoryDedupStore creates an empty in-memory DedupStore
+func NewMemoryDedupStore() *MemoryDedupStore {
+	return &MemoryDedupStore{entries: make(map[string]memoryDedupEntry)}
+}
+
+// Get returns the value stored for key, or ErrDedupKeyNotFound if missing or expired
+func (s *MemoryDedupStore) Get(ctx context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return "", ErrDedupKeyNotFound
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(s.entries, key)
+		return "", ErrDedupKeyNotFound
+	}
+	return entry.value, nil
+}
+
+// Set stores value for key until expiration elapses
+func (s *MemoryDedupStore) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	str, ok := value.(string)
+	if !ok {
+		return errors.New("dedup store value must be a string")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.writes++
+	if s.writes%memoryDedupSweepEvery == 0 {
+		for k, e := range s.entries {
+			if now.After(e.expiresAt) {
+				delete(s.entries, k)
+			}
+		}
+	}
+	s.entries[key] = memoryDedupEntry{value: str, expiresAt: now.Add(expiration)}
+	return nil
+}
@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSQSDeduplication_SameKeyIsNoOp(t *testing.T) {
+	next := &mockClientHelper{}
+	next.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{
+		StatusCode: 200,
+		Headers:    map[string]string{"sqs.message_id": "msg-1"},
+	}, nil).Once()
+
+	client := SQSDeduplication(NewMemoryDedupStore(), time.Minute)(next)
+
+	first, err := SQSSendMessageWithKey(context.Background(), client, "queue-url", "order-42", map[string]string{"id": "42"})
+	assert.NoError(t, err)
+	second, err := SQSSendMessageWithKey(context.Background(), client, "queue-url", "order-42", map[string]string{"id": "42"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "msg-1", first)
+	assert.Equal(t, "msg-1", second)
+	next.AssertNumberOfCalls(t, "Do", 1)
+}
+
+func TestSQSDeduplication_RecordsCacheEvents(t *testing.T) {
+	next := &mockClientHelper{}
+	next.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{
+		StatusCode: 200,
+		Headers:    map[string]string{"sqs.message_id": "msg-1"},
+	}, nil)
+
+	recorder := &cacheEventRecorder{}
+	client := SQSDeduplication(NewMemoryDedupStore(), time.Minute, WithCacheMetrics(recorder))(next)
+
+	for i := 0; i < 3; i++ {
+		_, err := SQSSendMessageWithKey(context.Background(), client, "queue-url", "order-42", "payload")
+		assert.NoError(t, err)
+	}
+	_, err := SQSSendMessageBytes(context.Background(), client, "queue-url", []byte("no key"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(1), recorder.misses.Load(), "sends without a key are not reported")
+	assert.Equal(t, int32(2), recorder.hits.Load())
+}
+
+func TestSQSDeduplication_BypassWithoutKey(t *testing.T) {
+	next := &mockClientHelper{}
+	next.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{
+		StatusCode: 200,
+		Headers:    map[string]string{"sqs.message_id": "msg-1"},
+	}, nil)
+
+	client := SQSDeduplication(NewMemoryDedupStore(), time.Minute)(next)
+
+	_, err := SQSSendMessage(context.Background(), client, "queue-url", "payload")
+	assert.NoError(t, err)
+	_, err = SQSSendMessage(context.Background(), client, "queue-url", "payload")
+	assert.NoError(t, err)
+
+	next.AssertNumberOfCalls(t, "Do", 2)
+}
+
+func TestSQSDeduplication_ExpiredKeySendsAgain(t *testing.T) {
+	next := &mockClientHelper{}
+	next.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{
+		StatusCode: 200,
+		Headers:    map[string]string{"sqs.message_id": "msg-1"},
+	}, nil)
+
+	client := SQSDeduplication(NewMemoryDedupStore(), time.Millisecond)(next)
+
+	_, err := SQSSendMessageWithKey(context.Background(), client, "queue-url", "order-42", "payload")
+	assert.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	_, err = SQSSendMessageWithKey(context.Background(), client, "queue-url", "order-42", "payload")
+	assert.NoError(t, err)
+
+	next.AssertNumberOfCalls(t, "Do", 2)
+}
+
+func TestMemoryDedupStore(t *testing.T) {
+	store := NewMemoryDedupStore()
+	ctx := context.Background()
+
+	_, err := store.Get(ctx, "missing")
+	assert.ErrorIs(t, err, ErrDedupKeyNotFound)
+
+	assert.NoError(t, store.Set(ctx, "k", "v", time.Minute))
+	v, err := store.Get(ctx, "k")
+	assert.NoError(t, err)
+	assert.Equal(t, "v", v)
+
+	assert.Error(t, store.Set(ctx, "k", 42, time.Minute))
+}
@@ -0,0 +1,214 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+)
+
+// MaxInlinePayloadSize is the SQS/SNS message size limit; larger payloads are offloaded to S3
+const MaxInlinePayloadSize = 256 * 1024
+
+// s3PointerClass tags pointer messages using the format of the AWS extended clients
+// (payload offloading library). Together with ExtendedPayloadSizeAttribute it lets the
+// Java/Python extended clients read our pointers, and us read theirs.
+const s3PointerClass = "software.amazon.payloadoffloading.PayloadS3Pointer"
+
+// ExtendedPayloadSizeAttribute is the message attribute the AWS extended clients set on a
+// pointer message, holding the size in bytes of the offloaded payload; their consumers
+// only resolve pointers that carry it. SQSReceiveExtended recognises pointers by body.
+const ExtendedPayloadSizeAttribute = "ExtendedPayloadSize"
+
+// s3Pointer references a payload stored in S3 by SQSSendMessageExtended / SNSPublishExtended
+type s3Pointer struct {
+	Bucket string `json:"s3BucketName"`
+	Key    string `json:"s3Key"`
+}
+
+// SQSSendMessageExtended sends v to queueURL, offloading it to bucket when the JSON body
+// exceeds MaxInlinePayloadSize and sending a pointer message instead.
+// Small payloads are sent inline, exactly like SQSSendMessage.
+// Read offloaded messages with SQSReceiveExtended, whose Ack also removes the S3 object.
+func SQSSendMessageExtended(ctx context.Context, client Client, queueURL, bucket string, v interface{}) (messageID string, err error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON body: %w", err)
+	}
+	if len(body) <= MaxInlinePayloadSize {
+		return SQSSendMessageBytes(ctx, client, queueURL, body)
+	}
+
+	pointer, err := offloadPayload(ctx, client, bucket, body)
+	if err != nil {
+		return "", err
+	}
+
+	messageID, err = sendPointer(ctx, client, "sqs", queueURL, pointer, len(body))
+	if err != nil {
+		_ = S3DeleteObject(ctx, client, pointer.Bucket, pointer.Key) // Best effort: nothing references it
+		return "", err
+	}
+	return messageID, nil
+}
+
+// SNSPublishExtended publishes v to topicARN, offloading it to bucket when the JSON body
+// exceeds MaxInlinePayloadSize. Subscribed queues must use raw message delivery for
+// SQSReceiveExtended to recognise the pointer; the S3 object is not removed automatically
+// because several subscribers may read it (use a bucket lifecycle rule instead).
+func SNSPublishExtended(ctx context.Context, client Client, topicARN, bucket string, v interface{}) (messageID string, err error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JSON body: %w", err)
+	}
+	if len(body) <= MaxInlinePayloadSize {
+		return SNSPublish(ctx, client, topicARN, json.RawMessage(body))
+	}
+
+	pointer, err := offloadPayload(ctx, client, bucket, body)
+	if err != nil {
+		return "", err
+	}
+
+	messageID, err = sendPointer(ctx, client, "sns", topicARN, pointer, len(body))
+	if err != nil {
+		_ = S3DeleteObject(ctx, client, pointer.Bucket, pointer.Key) // Best effort: nothing references it
+		return "", err
+	}
+	return messageID, nil
+}
+
+// OffloadedPayloadError reports a received message whose S3 payload could not be fetched.
+// Handle still carries the pointer body and can be Nacked for redelivery.
+type OffloadedPayloadError struct {
+	Handle *MessageHandle
+	Err    error
+}
+
+func (e OffloadedPayloadError) Error() string {
+	return fmt.Sprintf("message %s: %v", e.Handle.MessageID, e.Err)
+}
+
+func (e OffloadedPayloadError) Unwrap() error { return e.Err }
+
+// ExtendedReceiveError lists the messages of a receive whose offloaded payloads could not
+// be fetched. The other handles are still returned, so the caller can process them and
+// Nack (or leave to the visibility timeout) the failed ones.
+type ExtendedReceiveError struct {
+	Messages []OffloadedPayloadError
+}
+
+func (e *ExtendedReceiveError) Error() string {
+	msgs := make([]string, len(e.Messages))
+	for i, m := range e.Messages {
+		msgs[i] = m.Error()
+	}
+	return fmt.Sprintf("%d offloaded payload(s) could not be fetched: %s", len(e.Messages), strings.Join(msgs, "; "))
+}
+
+func (e *ExtendedReceiveError) Unwrap() []error {
+	errs := make([]error, len(e.Messages))
+	for i, m := range e.Messages {
+		errs[i] = m
+	}
+	return errs
+}
+
+// SQSReceiveExtended receives messages like SQSReceiveWithHandles, replacing pointer bodies
+// with the payload fetched from S3. Acking an offloaded message deletes it from the queue
+// and then removes its S3 object.
+// Messages whose payload cannot be fetched are left out of the returned handles and
+// reported in an *ExtendedReceiveError alongside the handles that resolved.
+func SQSReceiveExtended(ctx context.Context, client Client, queueURL string, maxMessages int32, waitTimeSeconds int32) ([]*MessageHandle, error) {
+	handles, err := SQSReceiveWithHandles(ctx, client, queueURL, maxMessages, waitTimeSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]*MessageHandle, 0, len(handles))
+	var failed []OffloadedPayloadError
+	for _, handle := range handles {
+		pointer, ok := parseS3Pointer(handle.Body)
+		if !ok {
+			resolved = append(resolved, handle)
+			continue
+		}
+
+		resp, err := S3GetObject(ctx, client, pointer.Bucket, pointer.Key)
+		if err != nil {
+			failed = append(failed, OffloadedPayloadError{
+				Handle: handle,
+				Err:    fmt.Errorf("failed to fetch offloaded payload s3://%s/%s: %w", pointer.Bucket, pointer.Key, err),
+			})
+			continue
+		}
+		handle.Body = string(resp.Body)
+		handle.offloaded = pointer
+		resolved = append(resolved, handle)
+	}
+
+	if len(failed) > 0 {
+		return resolved, &ExtendedReceiveError{Messages: failed}
+	}
+	return resolved, nil
+}
+
+func offloadPayload(ctx context.Context, client Client, bucket string, body []byte) (*s3Pointer, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("payload is %d bytes, exceeds limit of %d bytes and no offload bucket was given", len(body), MaxInlinePayloadSize)
+	}
+
+	pointer := &s3Pointer{Bucket: bucket, Key: uuid.NewString()}
+	if _, err := S3PutObject(ctx, client, pointer.Bucket, pointer.Key, body, "application/json", nil); err != nil {
+		return nil, fmt.Errorf("failed to offload payload to S3: %w", err)
+	}
+	return pointer, nil
+}
+
+// sendPointer sends pointer through service ("sqs" or "sns") with the
+// ExtendedPayloadSizeAttribute set to the size of the offloaded payload
+func sendPointer(ctx context.Context, client Client, service, target string, pointer *s3Pointer, size int) (messageID string, err error) {
+	operation := "sqs.send_message"
+	if service == "sns" {
+		operation = "sns.publish"
+	}
+	req := &cloud.Request{
+		Operation: operation,
+		Path:      target,
+		Headers: map[string]string{
+			service + ".message_attribute." + ExtendedPayloadSizeAttribute: strconv.Itoa(size),
+		},
+	}
+	req.WithBody(pointer.encode())
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.Headers[service+".message_id"], nil
+}
+
+func (p *s3Pointer) encode() []byte {
+	encoded, _ := json.Marshal([]interface{}{s3PointerClass, p}) // Plain strings, cannot fail
+	return encoded
+}
+
+func parseS3Pointer(body string) (*s3Pointer, bool) {
+	if !strings.HasPrefix(body, `["`+s3PointerClass+`"`) {
+		return nil, false
+	}
+
+	var parts []json.RawMessage
+	if err := json.Unmarshal([]byte(body), &parts); err != nil || len(parts) != 2 {
+		return nil, false
+	}
+
+	var pointer s3Pointer
+	if err := json.Unmarshal(parts[1], &pointer); err != nil || pointer.Bucket == "" || pointer.Key == "" {
+		return nil, false
+	}
+	return &pointer, true
+}
@@ -0,0 +1,164 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func operation(name string) interface{} {
+	return mock.MatchedBy(func(req *cloud.Request) bool { return req.Operation == name })
+}
+
+func TestSQSSendMessageExtended_SmallPayloadInline(t *testing.T) {
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sqs.send_message" && string(req.Body) == `{"id":"42"}`
+	})).Return(&cloud.Response{Headers: map[string]string{"sqs.message_id": "msg-1"}}, nil).Once()
+
+	id, err := SQSSendMessageExtended(context.Background(), m, "queue-url", "payloads", map[string]string{"id": "42"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "msg-1", id)
+	m.AssertExpectations(t)
+}
+
+func TestSQSSendMessageExtended_LargePayloadOffloaded(t *testing.T) {
+	payload := map[string]string{"data": strings.Repeat("x", MaxInlinePayloadSize)}
+	encoded, _ := json.Marshal(payload)
+
+	var storedKey string
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		if req.Operation != "s3.put_object" || !strings.HasPrefix(req.Path, "payloads/") {
+			return false
+		}
+		storedKey = strings.TrimPrefix(req.Path, "payloads/")
+		return len(req.Body) > MaxInlinePayloadSize
+	})).Return(&cloud.Response{StatusCode: 200}, nil).Once()
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		pointer, ok := parseS3Pointer(string(req.Body))
+		return req.Operation == "sqs.send_message" && ok && pointer.Bucket == "payloads" && pointer.Key == storedKey &&
+			req.Headers["sqs.message_attribute."+ExtendedPayloadSizeAttribute] == strconv.Itoa(len(encoded))
+	})).Return(&cloud.Response{Headers: map[string]string{"sqs.message_id": "msg-1"}}, nil).Once()
+
+	id, err := SQSSendMessageExtended(context.Background(), m, "queue-url", "payloads", payload)
+
+	require.NoError(t, err)
+	assert.Equal(t, "msg-1", id)
+	m.AssertExpectations(t)
+}
+
+func TestSNSPublishExtended_LargePayloadOffloaded(t *testing.T) {
+	payload := map[string]string{"data": strings.Repeat("x", MaxInlinePayloadSize)}
+	encoded, _ := json.Marshal(payload)
+
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, operation("s3.put_object")).Return(&cloud.Response{StatusCode: 200}, nil).Once()
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		_, ok := parseS3Pointer(string(req.Body))
+		return req.Operation == "sns.publish" && req.Path == "topic-arn" && ok &&
+			req.Headers["sns.message_attribute."+ExtendedPayloadSizeAttribute] == strconv.Itoa(len(encoded))
+	})).Return(&cloud.Response{Headers: map[string]string{"sns.message_id": "msg-1"}}, nil).Once()
+
+	id, err := SNSPublishExtended(context.Background(), m, "topic-arn", "payloads", payload)
+
+	require.NoError(t, err)
+	assert.Equal(t, "msg-1", id)
+	m.AssertExpectations(t)
+}
+
+func TestSQSSendMessageExtended_SendFailureRemovesObject(t *testing.T) {
+	payload := map[string]string{"data": strings.Repeat("x", MaxInlinePayloadSize)}
+
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, operation("s3.put_object")).Return(&cloud.Response{StatusCode: 200}, nil).Once()
+	m.On("Do", mock.Anything, operation("sqs.send_message")).Return(nil, errors.New("queue unavailable")).Once()
+	m.On("Do", mock.Anything, operation("s3.delete_object")).Return(&cloud.Response{StatusCode: 204}, nil).Once()
+
+	_, err := SQSSendMessageExtended(context.Background(), m, "queue-url", "payloads", payload)
+
+	assert.Error(t, err)
+	m.AssertExpectations(t)
+}
+
+func TestSQSSendMessageExtended_LargePayloadWithoutBucket(t *testing.T) {
+	m := &mockClientHelper{}
+
+	_, err := SQSSendMessageExtended(context.Background(), m, "queue-url", "", strings.Repeat("x", MaxInlinePayloadSize+1))
+
+	assert.Error(t, err)
+	m.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+}
+
+func TestSQSReceiveExtended_FetchesAndCleansUpOffloadedPayload(t *testing.T) {
+	pointer := (&s3Pointer{Bucket: "payloads", Key: "obj-1"}).encode()
+	received, err := json.Marshal([]SQSMessage{
+		{MessageID: "inline", ReceiptHandle: "rh-1", Body: `{"small":true}`},
+		{MessageID: "offloaded", ReceiptHandle: "rh-2", Body: string(pointer)},
+	})
+	require.NoError(t, err)
+
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, operation("sqs.receive_message")).Return(&cloud.Response{Body: received}, nil).Once()
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "s3.get_object" && req.Path == "payloads/obj-1"
+	})).Return(&cloud.Response{Body: []byte(`{"large":true}`)}, nil).Once()
+	m.On("Do", mock.Anything, operation("sqs.delete_message")).Return(&cloud.Response{StatusCode: 204}, nil).Twice()
+	m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "s3.delete_object" && req.Path == "payloads/obj-1"
+	})).Return(&cloud.Response{StatusCode: 204}, nil).Once()
+
+	handles, err := SQSReceiveExtended(context.Background(), m, "queue-url", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, handles, 2)
+	assert.Equal(t, `{"small":true}`, handles[0].Body)
+	assert.Equal(t, `{"large":true}`, handles[1].Body)
+
+	require.NoError(t, handles[0].Ack(context.Background()))
+	require.NoError(t, handles[1].Ack(context.Background()))
+	m.AssertExpectations(t)
+}
+
+func TestSQSReceiveExtended_FetchFailureKeepsOtherMessages(t *testing.T) {
+	pointer := (&s3Pointer{Bucket: "payloads", Key: "missing"}).encode()
+	received, err := json.Marshal([]SQSMessage{
+		{MessageID: "inline", ReceiptHandle: "rh-1", Body: `{"small":true}`},
+		{MessageID: "offloaded", ReceiptHandle: "rh-2", Body: string(pointer)},
+	})
+	require.NoError(t, err)
+
+	m := &mockClientHelper{}
+	m.On("Do", mock.Anything, operation("sqs.receive_message")).Return(&cloud.Response{Body: received}, nil).Once()
+	m.On("Do", mock.Anything, operation("s3.get_object")).Return(nil, errors.New("no such key")).Once()
+	m.On("Do", mock.Anything, operation("sqs.change_message_visibility")).Return(&cloud.Response{StatusCode: 200}, nil).Once()
+
+	handles, err := SQSReceiveExtended(context.Background(), m, "queue-url", 10, 0)
+	require.Len(t, handles, 1)
+	assert.Equal(t, "inline", handles[0].MessageID)
+
+	var recvErr *ExtendedReceiveError
+	require.ErrorAs(t, err, &recvErr)
+	require.Len(t, recvErr.Messages, 1)
+	failed := recvErr.Messages[0]
+	assert.Equal(t, "offloaded", failed.Handle.MessageID)
+	assert.Contains(t, failed.Error(), "s3://payloads/missing")
+
+	require.NoError(t, failed.Handle.Nack(context.Background()))
+	m.AssertExpectations(t)
+}
+
+func TestParseS3Pointer_IgnoresRegularBodies(t *testing.T) {
+	for _, body := range []string{"", `{"id":1}`, `["other",{"s3BucketName":"b","s3Key":"k"}]`, `["` + s3PointerClass + `",{}]`} {
+		_, ok := parseS3Pointer(body)
+		assert.False(t, ok, body)
+	}
+}
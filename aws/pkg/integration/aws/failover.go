@@ -0,0 +1,131 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+)
+
+// RegionFailover configures primary/secondary regions for read operations
+type RegionFailover struct {
+	Primary   string
+	Secondary string
+}
+
+// WithRegionFailover enables failover of read operations from primary to secondary region
+// Only region-level failures (connectivity errors, 5xx) trigger failover; client errors
+// such as NotFound or invalid requests are returned as-is. The region that served each
+// request is reported in Response.Metadata["aws_region"] (and logged by observability.Logging).
+func WithRegionFailover(primary, secondary string) Options {
+	return Options{
+		RegionFailover: &RegionFailover{
+			Primary:   primary,
+			Secondary: secondary,
+		},
+	}
+}
+
+// failoverClient routes reads to the secondary region when the primary is unavailable
+type failoverClient struct {
+	primary         cloud.Client
+	secondary       cloud.Client
+	primaryRegion   string
+	secondaryRegion string
+}
+
+func newFailoverClient(primary, secondary cloud.Client, primaryRegion, secondaryRegion string) cloud.Client {
+	return &failoverClient{
+		primary:         primary,
+		secondary:       secondary,
+		primaryRegion:   primaryRegion,
+		secondaryRegion: secondaryRegion,
+	}
+}
+
+func (f *failoverClient) Do(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	resp, err := f.primary.Do(ctx, req)
+	if err == nil {
+		return withRegion(resp, f.primaryRegion), nil
+	}
+
+	if req == nil || !isReadOperation(req.Operation) || !isRegionFailure(ctx, err) {
+		return nil, tagErrorRegion(err, f.primaryRegion)
+	}
+
+	resp, err = f.secondary.Do(ctx, req)
+	if err != nil {
+		return nil, tagErrorRegion(err, f.secondaryRegion)
+	}
+	resp = withRegion(resp, f.secondaryRegion)
+	resp.Metadata["aws_failover"] = true
+	return resp, nil
+}
+
+// isReadOperation reports whether the operation only reads state and is safe to repeat
+func isReadOperation(operation string) bool {
+	_, verb := splitOperation(operation)
+	for _, prefix := range []string{"get_", "list_", "head_", "describe_"} {
+		if strings.HasPrefix(verb, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRegionFailure reports whether err indicates the region itself is unavailable
+func isRegionFailure(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var cloudErr *cloud.Error
+	if !errors.As(err, &cloudErr) {
+		// Non-normalized errors come from the transport (DNS, connection refused, ...)
+		return true
+	}
+	if cloudErr.Code == cloud.ErrCodeInvalidRequest {
+		return false
+	}
+	return errorStatusCode(cloudErr) >= 500
+}
+
+// errorStatusCode returns the status code of a cloud.Error, falling back to metadata
+func errorStatusCode(err *cloud.Error) int {
+	if err.StatusCode != 0 {
+		return err.StatusCode
+	}
+	if code, ok := err.Metadata["status_code"].(int); ok {
+		return code
+	}
+	return 0
+}
+
+func withRegion(resp *cloud.Response, region string) *cloud.Response {
+	if resp == nil {
+		resp = &cloud.Response{}
+	}
+	if resp.Metadata == nil {
+		resp.Metadata = make(map[string]interface{})
+	}
+	resp.Metadata["aws_region"] = region
+	return resp
+}
+
+func tagErrorRegion(err error, region string) error {
+	var cloudErr *cloud.Error
+	if errors.As(err, &cloudErr) {
+		cloudErr.WithMetadata("aws_region", region)
+	}
+	return err
+}
+
+// splitOperation splits "service.verb" into its parts
+func splitOperation(operation string) (service, verb string) {
+	parts := strings.SplitN(operation, ".", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return operation, ""
+}
@@ -0,0 +1,99 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWithRegionFailover(t *testing.T) {
+	opts := WithRegionFailover("us-east-1", "us-west-2")
+	assert.Equal(t, &RegionFailover{Primary: "us-east-1", Secondary: "us-west-2"}, opts.RegionFailover)
+}
+
+func TestFailoverClient_PrimarySuccess(t *testing.T) {
+	primary := &mockClientHelper{}
+	secondary := &mockClientHelper{}
+	primary.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{StatusCode: 200}, nil)
+
+	client := newFailoverClient(primary, secondary, "us-east-1", "us-west-2")
+	resp, err := client.Do(context.Background(), &cloud.Request{Operation: "ssm.get_parameter", Path: "/p"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "us-east-1", resp.Metadata["aws_region"])
+	secondary.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+}
+
+func TestFailoverClient_FailsOverOnServerError(t *testing.T) {
+	primary := &mockClientHelper{}
+	secondary := &mockClientHelper{}
+	primary.On("Do", mock.Anything, mock.Anything).Return(nil,
+		cloud.NewError(cloud.ErrCodeServiceUnavailable, "unavailable").WithMetadata("status_code", 503))
+	secondary.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{StatusCode: 200}, nil)
+
+	client := newFailoverClient(primary, secondary, "us-east-1", "us-west-2")
+	resp, err := client.Do(context.Background(), &cloud.Request{Operation: "s3.get_object", Path: "b/k"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "us-west-2", resp.Metadata["aws_region"])
+	assert.Equal(t, true, resp.Metadata["aws_failover"])
+}
+
+func TestFailoverClient_FailsOverOnConnectivityError(t *testing.T) {
+	primary := &mockClientHelper{}
+	secondary := &mockClientHelper{}
+	primary.On("Do", mock.Anything, mock.Anything).Return(nil, errors.New("dial tcp: connection refused"))
+	secondary.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{StatusCode: 200}, nil)
+
+	client := newFailoverClient(primary, secondary, "us-east-1", "us-west-2")
+	resp, err := client.Do(context.Background(), &cloud.Request{Operation: "sqs.list_queues"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "us-west-2", resp.Metadata["aws_region"])
+}
+
+func TestFailoverClient_NoFailover(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *cloud.Request
+		err  error
+	}{
+		{
+			name: "not found",
+			req:  &cloud.Request{Operation: "ssm.get_parameter", Path: "/missing"},
+			err:  cloud.NewError(cloud.ErrCodeNotFound, "missing").WithMetadata("status_code", 404),
+		},
+		{
+			name: "invalid request",
+			req:  &cloud.Request{Operation: "s3.get_object", Path: "bucket"},
+			err:  cloud.NewError(cloud.ErrCodeInvalidRequest, "path must be in format 'bucket/key'"),
+		},
+		{
+			name: "write operation",
+			req:  &cloud.Request{Operation: "sqs.send_message", Path: "queue"},
+			err:  cloud.NewError(cloud.ErrCodeServiceUnavailable, "unavailable").WithMetadata("status_code", 503),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &mockClientHelper{}
+			secondary := &mockClientHelper{}
+			primary.On("Do", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			client := newFailoverClient(primary, secondary, "us-east-1", "us-west-2")
+			resp, err := client.Do(context.Background(), tt.req)
+
+			assert.Nil(t, resp)
+			assert.Error(t, err)
+			var cloudErr *cloud.Error
+			assert.True(t, errors.As(err, &cloudErr))
+			assert.Equal(t, "us-east-1", cloudErr.Metadata["aws_region"])
+			secondary.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+		})
+	}
+}
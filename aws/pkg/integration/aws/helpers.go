@@ -1,8 +1,17 @@
 package aws
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
 
 	"github.com/skolldire/go-engine/pkg/integration/cloud"
 )
@@ -40,6 +49,114 @@ func SQSSendMessageBytes(ctx context.Context, client Client, queueURL string, bo
 	return resp.Headers["sqs.message_id"], nil
 }
 
+// SQSMaxBatchEntries is the largest number of messages SQS accepts in one batch
+const SQSMaxBatchEntries = 10
+
+// BatchEntry is one message of SQSSendMessageBatch
+// ID must be unique within the call; Headers accept the same keys as sqs.send_message
+// (sqs.message_group_id, sqs.message_dedupe_id, sqs.delay_seconds,
+// sqs.message_attribute.<name>).
+type BatchEntry struct {
+	ID      string
+	Body    []byte
+	Headers map[string]string
+}
+
+// BatchSuccess is a message accepted by SQS
+type BatchSuccess struct {
+	ID             string `json:"id"`
+	MessageID      string `json:"message_id"`
+	SequenceNumber string `json:"sequence_number,omitempty"`
+}
+
+// BatchFailure is a message SQS rejected, or that was in a batch request that failed
+// SenderFault is true when resending unchanged will fail again.
+type BatchFailure struct {
+	ID          string `json:"id"`
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	SenderFault bool   `json:"sender_fault"`
+}
+
+// BatchResult aggregates the outcome of SQSSendMessageBatch by caller-supplied entry ID
+type BatchResult struct {
+	Successful []BatchSuccess `json:"successful"`
+	Failed     []BatchFailure `json:"failed"`
+}
+
+// SQSSendMessageBatch sends entries in chunks of SQSMaxBatchEntries
+// Messages rejected individually are reported in BatchResult.Failed. When a whole chunk
+// request fails, its entries are reported as failed too, the remaining chunks are still
+// sent, and the chunk errors are returned joined alongside the partial result.
+// AWS SDK equivalent: SendMessageBatch
+func SQSSendMessageBatch(ctx context.Context, client Client, queueURL string, entries []BatchEntry) (*BatchResult, error) {
+	seen := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		if entry.ID == "" {
+			return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, "batch entry ID is required")
+		}
+		if _, dup := seen[entry.ID]; dup {
+			return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, fmt.Sprintf("duplicate batch entry ID %q", entry.ID))
+		}
+		seen[entry.ID] = struct{}{}
+	}
+
+	result := &BatchResult{Successful: []BatchSuccess{}, Failed: []BatchFailure{}}
+	var errs []error
+	for start := 0; start < len(entries); start += SQSMaxBatchEntries {
+		chunk := entries[start:min(start+SQSMaxBatchEntries, len(entries))]
+		if err := sqsSendBatchChunk(ctx, client, queueURL, chunk, result); err != nil {
+			errs = append(errs, err)
+			for _, entry := range chunk {
+				result.Failed = append(result.Failed, batchChunkFailure(entry.ID, err))
+			}
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+func sqsSendBatchChunk(ctx context.Context, client Client, queueURL string, chunk []BatchEntry, result *BatchResult) error {
+	wire := make([]map[string]interface{}, len(chunk))
+	for i, entry := range chunk {
+		wire[i] = map[string]interface{}{
+			"id":      entry.ID,
+			"body":    string(entry.Body),
+			"headers": entry.Headers,
+		}
+	}
+
+	req := &cloud.Request{
+		Operation: "sqs.send_message_batch",
+		Path:      queueURL,
+	}
+	if err := req.WithJSONBody(wire); err != nil {
+		return fmt.Errorf("failed to marshal batch entries: %w", err)
+	}
+
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	var chunkResult BatchResult
+	if err := json.Unmarshal(resp.Body, &chunkResult); err != nil {
+		return fmt.Errorf("failed to decode batch response: %w", err)
+	}
+	result.Successful = append(result.Successful, chunkResult.Successful...)
+	result.Failed = append(result.Failed, chunkResult.Failed...)
+	return nil
+}
+
+func batchChunkFailure(id string, err error) BatchFailure {
+	failure := BatchFailure{ID: id, Message: err.Error()}
+	var cloudErr *cloud.Error
+	if errors.As(err, &cloudErr) {
+		failure.Code = cloudErr.Code
+		failure.SenderFault = !cloudErr.Retriable
+	}
+	return failure
+}
+
 // SQSReceiveMessage receives messages from SQS queue
 // AWS SDK equivalent: ReceiveMessage
 func SQSReceiveMessage(ctx context.Context, client Client, queueURL string, maxMessages int32, waitTimeSeconds int32) (*cloud.Response, error) {
@@ -68,6 +185,23 @@ func SQSDeleteMessage(ctx context.Context, client Client, queueURL string, recei
 	return err
 }
 
+// SQSChangeMessageVisibility changes the visibility timeout of a received message
+// AWS SDK equivalent: ChangeMessageVisibility
+func SQSChangeMessageVisibility(ctx context.Context, client Client, queueURL string, receiptHandle string, visibilityTimeoutSeconds int32) error {
+	req := &cloud.Request{
+		Operation: "sqs.change_message_visibility",
+		Path:      queueURL,
+		Headers: map[string]string{
+			"sqs.receipt_handle": receiptHandle,
+		},
+		QueryParams: map[string]string{
+			"VisibilityTimeout": fmt.Sprintf("%d", visibilityTimeoutSeconds),
+		},
+	}
+	_, err := client.Do(ctx, req)
+	return err
+}
+
 // SQSCreateQueue creates a new SQS queue
 // AWS SDK equivalent: CreateQueue
 func SQSCreateQueue(ctx context.Context, client Client, queueName string, attributes map[string]string) (queueURL string, err error) {
@@ -110,6 +244,31 @@ func SQSListQueues(ctx context.Context, client Client, prefix string) (*cloud.Re
 	return client.Do(ctx, req)
 }
 
+// SQSListQueuesPage lists a single page of SQS queue URLs
+// Pass the previous page's Next token to fetch the next page (zero value for the first).
+// AWS SDK equivalent: ListQueues
+func SQSListQueuesPage(ctx context.Context, client Client, prefix string, token cloud.PageToken) (*cloud.Page[string], error) {
+	req := (&cloud.Request{
+		Operation:   "sqs.list_queues",
+		QueryParams: make(map[string]string),
+	}).WithPageToken(token)
+	if prefix != "" {
+		req.QueryParams["QueueNamePrefix"] = prefix
+	}
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &cloud.Page[string]{Next: resp.NextPageToken()}
+	if len(resp.Body) > 0 {
+		if err := resp.UnmarshalBody(&page.Items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal queue URLs: %w", err)
+		}
+	}
+	return page, nil
+}
+
 // SQSGetQueueURL gets the URL of an SQS queue by name
 // AWS SDK equivalent: GetQueueUrl
 func SQSGetQueueURL(ctx context.Context, client Client, queueName string) (queueURL string, err error) {
@@ -141,8 +300,62 @@ func SNSPublish(ctx context.Context, client Client, topicARN string, v interface
 	return resp.Headers["sns.message_id"], nil
 }
 
+// SNSSetSubscriptionAttributes sets the message filter policy of an SNS subscription
+// The policy is JSON-encoded before sending; a nil or empty policy removes filtering.
+// AWS SDK equivalent: SetSubscriptionAttributes (AttributeName=FilterPolicy)
+func SNSSetSubscriptionAttributes(ctx context.Context, client Client, subscriptionARN string, filterPolicy map[string]interface{}) error {
+	policy := []byte("{}")
+	if len(filterPolicy) > 0 {
+		encoded, err := json.Marshal(filterPolicy)
+		if err != nil {
+			return fmt.Errorf("invalid filter policy: %w", err)
+		}
+		policy = encoded
+	}
+
+	req := &cloud.Request{
+		Operation: "sns.set_subscription_attributes",
+		Path:      subscriptionARN,
+		Headers: map[string]string{
+			"sns.attribute_name": "FilterPolicy",
+		},
+		Body: policy,
+	}
+	_, err := client.Do(ctx, req)
+	return err
+}
+
+// SNSGetSubscriptionAttributes gets the attributes of an SNS subscription
+// (FilterPolicy, RawMessageDelivery, TopicArn, ...), keyed by attribute name
+// AWS SDK equivalent: GetSubscriptionAttributes
+func SNSGetSubscriptionAttributes(ctx context.Context, client Client, subscriptionARN string) (map[string]string, error) {
+	req := &cloud.Request{
+		Operation: "sns.get_subscription_attributes",
+		Path:      subscriptionARN,
+	}
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := make(map[string]string)
+	if len(resp.Body) == 0 {
+		return attributes, nil
+	}
+	if err := resp.UnmarshalBody(&attributes); err != nil {
+		return nil, fmt.Errorf("failed to decode subscription attributes: %w", err)
+	}
+	return attributes, nil
+}
+
+// Lambda payload limits enforced client-side before invoking
+const (
+	LambdaSyncPayloadLimit  = 6 * 1024 * 1024 // RequestResponse invocations
+	LambdaAsyncPayloadLimit = 256 * 1024      // Event invocations
+)
+
 // LambdaInvoke invokes a Lambda function (convenience wrapper)
-// AWS SDK equivalent: Invoke
+// AWS SDK equivalent: Invoke (InvocationType=RequestResponse)
 func LambdaInvoke(ctx context.Context, client Client, functionName string, v interface{}) (*cloud.Response, error) {
 	req := &cloud.Request{
 		Operation: "lambda.invoke",
@@ -151,9 +364,108 @@ func LambdaInvoke(ctx context.Context, client Client, functionName string, v int
 	if err := req.WithJSONBody(v); err != nil {
 		return nil, fmt.Errorf("failed to marshal JSON body: %w", err)
 	}
+	if err := validateLambdaPayload(req.Body, LambdaSyncPayloadLimit, "sync"); err != nil {
+		return nil, err
+	}
 	return client.Do(ctx, req)
 }
 
+// LambdaInvokeAsync invokes a Lambda function without waiting for its result
+// AWS SDK equivalent: Invoke (InvocationType=Event)
+func LambdaInvokeAsync(ctx context.Context, client Client, functionName string, v interface{}) error {
+	req := &cloud.Request{
+		Operation: "lambda.invoke",
+		Path:      functionName,
+		Headers: map[string]string{
+			"lambda.invocation_type": "Event",
+		},
+	}
+	if err := req.WithJSONBody(v); err != nil {
+		return fmt.Errorf("failed to marshal JSON body: %w", err)
+	}
+	if err := validateLambdaPayload(req.Body, LambdaAsyncPayloadLimit, "async"); err != nil {
+		return err
+	}
+	_, err := client.Do(ctx, req)
+	return err
+}
+
+// LambdaFunctionError is returned when the invoked function itself failed
+// (the Invoke call succeeded but the response carried an X-Amz-Function-Error)
+type LambdaFunctionError struct {
+	FunctionName string
+	Type         string // "Handled" or "Unhandled"
+	ErrorType    string
+	ErrorMessage string
+	StackTrace   []string
+	Payload      []byte
+}
+
+// Error implements error interface
+func (e *LambdaFunctionError) Error() string {
+	if e.ErrorMessage != "" {
+		return fmt.Sprintf("lambda function %s returned %s error (%s): %s", e.FunctionName, e.Type, e.ErrorType, e.ErrorMessage)
+	}
+	return fmt.Sprintf("lambda function %s returned %s error", e.FunctionName, e.Type)
+}
+
+// LambdaInvokeInto invokes a Lambda function and decodes its JSON result into T
+// Function errors (handled or unhandled) are returned as *LambdaFunctionError
+func LambdaInvokeInto[T any](ctx context.Context, client Client, functionName string, payload interface{}) (T, error) {
+	var out T
+	resp, err := LambdaInvoke(ctx, client, functionName, payload)
+	if err != nil {
+		var cloudErr *cloud.Error
+		if errors.As(err, &cloudErr) && cloudErr.Code == cloud.ErrCodeLambdaFunctionError {
+			fnType, _ := cloudErr.Metadata["lambda.function_error"].(string)
+			body, _ := cloudErr.Metadata["lambda.payload"].([]byte)
+			return out, newLambdaFunctionError(functionName, fnType, body)
+		}
+		return out, err
+	}
+	if fnErr := resp.Headers["lambda.function_error"]; fnErr != "" {
+		return out, newLambdaFunctionError(functionName, fnErr, resp.Body)
+	}
+	if len(resp.Body) == 0 {
+		return out, nil
+	}
+	if err := resp.UnmarshalBody(&out); err != nil {
+		return out, fmt.Errorf("failed to unmarshal lambda response: %w", err)
+	}
+	return out, nil
+}
+
+// newLambdaFunctionError builds a LambdaFunctionError from the standard Lambda error payload
+func newLambdaFunctionError(functionName, errType string, payload []byte) *LambdaFunctionError {
+	fnErr := &LambdaFunctionError{
+		FunctionName: functionName,
+		Type:         errType,
+		Payload:      payload,
+	}
+	var body struct {
+		ErrorMessage string   `json:"errorMessage"`
+		ErrorType    string   `json:"errorType"`
+		StackTrace   []string `json:"stackTrace"`
+	}
+	if err := json.Unmarshal(payload, &body); err == nil {
+		fnErr.ErrorMessage = body.ErrorMessage
+		fnErr.ErrorType = body.ErrorType
+		fnErr.StackTrace = body.StackTrace
+	}
+	return fnErr
+}
+
+// validateLambdaPayload rejects payloads AWS would refuse, before the call is made
+func validateLambdaPayload(payload []byte, limit int, mode string) error {
+	if len(payload) <= limit {
+		return nil
+	}
+	return cloud.NewError(
+		cloud.ErrCodeInvalidRequest,
+		fmt.Sprintf("lambda %s payload is %d bytes, exceeds limit of %d bytes", mode, len(payload), limit),
+	).WithMetadata("payload_size", len(payload)).WithMetadata("payload_limit", limit)
+}
+
 // S3PutObject uploads an object to S3
 // AWS SDK equivalent: PutObject
 // Path format: "bucket/key"
@@ -173,6 +485,190 @@ func S3PutObject(ctx context.Context, client Client, bucket, key string, body []
 	return client.Do(ctx, req)
 }
 
+// S3PutObjectAutoType uploads an object to S3, detecting the content type when contentType is empty
+// The type is taken from the key's extension, falling back to sniffing the body's first 512 bytes.
+// An explicit contentType is always preserved.
+func S3PutObjectAutoType(ctx context.Context, client Client, bucket, key string, body []byte, contentType string, metadata map[string]string) (*cloud.Response, error) {
+	if contentType == "" {
+		contentType = detectContentType(key, body)
+	}
+	return S3PutObject(ctx, client, bucket, key, body, contentType, metadata)
+}
+
+// detectContentType resolves a MIME type from the key extension or the body content
+func detectContentType(key string, body []byte) string {
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(body) // Considers at most the first 512 bytes
+}
+
+// S3PresignGetObject returns a URL that downloads bucket/key without credentials until
+// expiry (1 second to 7 days) elapses
+// AWS SDK equivalent: PresignClient.PresignGetObject
+func S3PresignGetObject(ctx context.Context, client Client, bucket, key string, expiry time.Duration) (string, error) {
+	return s3Presign(ctx, client, "s3.presign_get", bucket, key, expiry)
+}
+
+// S3PresignPutObject returns a URL that uploads bucket/key with an HTTP PUT without
+// credentials until expiry (1 second to 7 days) elapses
+// AWS SDK equivalent: PresignClient.PresignPutObject
+func S3PresignPutObject(ctx context.Context, client Client, bucket, key string, expiry time.Duration) (string, error) {
+	return s3Presign(ctx, client, "s3.presign_put", bucket, key, expiry)
+}
+
+func s3Presign(ctx context.Context, client Client, operation, bucket, key string, expiry time.Duration) (string, error) {
+	resp, err := client.Do(ctx, &cloud.Request{
+		Operation: operation,
+		Path:      fmt.Sprintf("%s/%s", bucket, key),
+		Headers: map[string]string{
+			"s3.presign_expiry_seconds": strconv.FormatInt(int64(expiry/time.Second), 10),
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.Headers["s3.presigned_url"], nil
+}
+
+const (
+	// S3MinPartSize is the smallest part S3 accepts, except for the last one
+	S3MinPartSize int64 = 5 << 20
+	// S3MaxParts is the largest number of parts in a multipart upload
+	S3MaxParts = 10000
+)
+
+// S3MultipartUpload streams r to bucket/key in parts of partSize bytes (S3MinPartSize when
+// zero or smaller), so only one part is held in memory at a time. A reader shorter than
+// one part is sent with a single S3PutObject instead. If any part fails the upload is
+// aborted, so no orphaned parts are billed. The response carries the object's s3.etag
+// header, like S3PutObject.
+// AWS SDK equivalent: CreateMultipartUpload, UploadPart, CompleteMultipartUpload
+func S3MultipartUpload(ctx context.Context, client Client, bucket, key string, r io.Reader, partSize int64) (*cloud.Response, error) {
+	if partSize < S3MinPartSize {
+		partSize = S3MinPartSize
+	}
+	objectPath := fmt.Sprintf("%s/%s", bucket, key)
+
+	buf := make([]byte, partSize)
+	n, err := io.ReadFull(r, buf)
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return S3PutObject(ctx, client, bucket, key, buf[:n], "", nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload body: %w", err)
+	}
+
+	created, err := client.Do(ctx, &cloud.Request{
+		Operation: "s3.multipart_upload",
+		Path:      objectPath,
+		Headers:   map[string]string{"s3.multipart_action": "create"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	uploadID := created.Headers["s3.upload_id"]
+
+	resp, err := s3UploadParts(ctx, client, objectPath, uploadID, r, buf)
+	if err != nil {
+		_, abortErr := client.Do(context.WithoutCancel(ctx), &cloud.Request{
+			Operation: "s3.multipart_upload",
+			Path:      objectPath,
+			Headers:   map[string]string{"s3.multipart_action": "abort", "s3.upload_id": uploadID},
+		})
+		return nil, errors.Join(err, abortErr)
+	}
+	return resp, nil
+}
+
+// s3UploadParts uploads buf (already holding the first full part) and the rest of r,
+// then completes the upload
+func s3UploadParts(ctx context.Context, client Client, objectPath, uploadID string, r io.Reader, buf []byte) (*cloud.Response, error) {
+	var parts []map[string]interface{}
+	part := buf
+	for partNumber := 1; len(part) > 0; partNumber++ {
+		if partNumber > S3MaxParts {
+			return nil, cloud.NewError(cloud.ErrCodeInvalidRequest, fmt.Sprintf("upload exceeds %d parts; use a larger part size", S3MaxParts))
+		}
+
+		resp, err := client.Do(ctx, &cloud.Request{
+			Operation: "s3.multipart_upload",
+			Path:      objectPath,
+			Body:      part,
+			Headers: map[string]string{
+				"s3.multipart_action": "upload_part",
+				"s3.upload_id":        uploadID,
+				"s3.part_number":      strconv.Itoa(partNumber),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, map[string]interface{}{"part_number": partNumber, "etag": resp.Headers["s3.etag"]})
+
+		n, err := io.ReadFull(r, buf)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("failed to read upload body: %w", err)
+		}
+		part = buf[:n]
+	}
+
+	req := &cloud.Request{
+		Operation: "s3.multipart_upload",
+		Path:      objectPath,
+		Headers:   map[string]string{"s3.multipart_action": "complete", "s3.upload_id": uploadID},
+	}
+	if err := req.WithJSONBody(parts); err != nil {
+		return nil, err
+	}
+	return client.Do(ctx, req)
+}
+
+// S3MaxObjectTags is the largest tag set S3 accepts on an object
+const S3MaxObjectTags = 10
+
+// DefaultTaggingConcurrency is used by S3PutObjectTagsBatch when concurrency is zero
+const DefaultTaggingConcurrency = 10
+
+// S3PutObjectTags replaces the tag set of an object (at most S3MaxObjectTags tags)
+// AWS SDK equivalent: PutObjectTagging
+func S3PutObjectTags(ctx context.Context, client Client, bucket, key string, tags map[string]string) error {
+	if len(tags) == 0 || len(tags) > S3MaxObjectTags {
+		return cloud.NewError(cloud.ErrCodeInvalidRequest, fmt.Sprintf("object tags must contain 1 to %d tags", S3MaxObjectTags))
+	}
+
+	req := &cloud.Request{
+		Operation: "s3.put_object_tagging",
+		Path:      fmt.Sprintf("%s/%s", bucket, key),
+		Headers:   make(map[string]string, len(tags)),
+	}
+	for k, v := range tags {
+		req.Headers["s3.tag."+k] = v
+	}
+	_, err := client.Do(ctx, req)
+	return err
+}
+
+// S3PutObjectTagsBatch applies tags to every key of bucket through BatchDo, with at most
+// concurrency (DefaultTaggingConcurrency when zero) requests in flight. The result has
+// one entry per key: nil on success, the error otherwise. The returned error is non-nil
+// only if ctx ended before every key was tagged.
+func S3PutObjectTagsBatch(ctx context.Context, client Client, bucket string, keys []string, tags map[string]string, concurrency int) (map[string]error, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultTaggingConcurrency
+	}
+
+	results, err := BatchDo(ctx, client, keys, func(ctx context.Context, c Client, key string) (struct{}, error) {
+		return struct{}{}, S3PutObjectTags(ctx, c, bucket, key, tags)
+	}, concurrency)
+
+	byKey := make(map[string]error, len(keys))
+	for i, key := range keys {
+		byKey[key] = results[i].Err
+	}
+	return byKey, err
+}
+
 // S3GetObject retrieves an object from S3
 // AWS SDK equivalent: GetObject
 // Path format: "bucket/key"
@@ -181,7 +677,36 @@ func S3GetObject(ctx context.Context, client Client, bucket, key string) (*cloud
 		Operation: "s3.get_object",
 		Path:      fmt.Sprintf("%s/%s", bucket, key),
 	}
-	return client.Do(ctx, req)
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.DrainBodyStream(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// S3GetObjectStream downloads an object without buffering it, returning the body stream
+// and the object headers (s3.content_type, s3.content_length, s3.etag). The caller must
+// close the stream; the client timeout keeps running until it is closed.
+// AWS SDK equivalent: GetObject
+// Path format: "bucket/key"
+func S3GetObjectStream(ctx context.Context, client Client, bucket, key string) (io.ReadCloser, map[string]string, error) {
+	req := &cloud.Request{
+		Operation:  "s3.get_object",
+		Path:       fmt.Sprintf("%s/%s", bucket, key),
+		StreamBody: true,
+	}
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.BodyStream == nil {
+		// The client buffered the body (e.g. a test double): serve it from memory
+		return io.NopCloser(bytes.NewReader(resp.Body)), resp.Headers, nil
+	}
+	return resp.BodyStream, resp.Headers, nil
 }
 
 // S3DeleteObject deletes an object from S3
@@ -207,7 +732,8 @@ func S3HeadObject(ctx context.Context, client Client, bucket, key string) (*clou
 	return client.Do(ctx, req)
 }
 
-// S3ListObjects lists objects in S3 bucket
+// S3ListObjects lists objects in S3 bucket (first page only, at most 1000 keys)
+// Check Headers["s3.is_truncated"] or use S3ListObjectsPage to continue the listing
 // AWS SDK equivalent: ListObjectsV2
 // Path format: "bucket" or "bucket/prefix"
 func S3ListObjects(ctx context.Context, client Client, bucket, prefix string, maxKeys int32) (*cloud.Response, error) {
@@ -226,6 +752,47 @@ func S3ListObjects(ctx context.Context, client Client, bucket, prefix string, ma
 	return client.Do(ctx, req)
 }
 
+// S3Object is a single entry of an S3 listing
+type S3Object struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+	ETag         string    `json:"etag"`
+}
+
+// S3ObjectPage is one page of an S3 listing plus its continuation token
+type S3ObjectPage = cloud.Page[S3Object]
+
+// S3ListObjectsPage lists a single page of objects in an S3 bucket
+// Pass the previous page's Next token to fetch the next page (zero value for the first).
+// AWS SDK equivalent: ListObjectsV2
+func S3ListObjectsPage(ctx context.Context, client Client, bucket, prefix string, maxKeys int32, token cloud.PageToken) (*S3ObjectPage, error) {
+	path := bucket
+	if prefix != "" {
+		path = fmt.Sprintf("%s/%s", bucket, prefix)
+	}
+	req := (&cloud.Request{
+		Operation:   "s3.list_objects",
+		Path:        path,
+		QueryParams: make(map[string]string),
+	}).WithPageToken(token)
+	if maxKeys > 0 {
+		req.QueryParams["MaxKeys"] = fmt.Sprintf("%d", maxKeys)
+	}
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &S3ObjectPage{Next: resp.NextPageToken()}
+	if len(resp.Body) > 0 {
+		if err := resp.UnmarshalBody(&page.Items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal S3 objects: %w", err)
+		}
+	}
+	return page, nil
+}
+
 // S3CopyObject copies an object within S3
 // AWS SDK equivalent: CopyObject
 // destPath format: "destBucket/destKey"
@@ -388,3 +955,43 @@ func SSMGetParametersByPath(ctx context.Context, client Client, path string, rec
 	}
 	return client.Do(ctx, req)
 }
+
+// SSMParameter is a single parameter returned by SSMGetParametersByPathPage
+type SSMParameter struct {
+	Name             string    `json:"name"`
+	Value            string    `json:"value"`
+	Type             string    `json:"type"`
+	ARN              string    `json:"arn"`
+	Version          int64     `json:"version"`
+	LastModifiedDate time.Time `json:"last_modified_date"`
+	DataType         string    `json:"data_type"`
+}
+
+// SSMGetParametersByPathPage gets a single page of parameters under a path
+// Pass the previous page's Next token to fetch the next page (zero value for the first).
+// AWS SDK equivalent: GetParametersByPath
+func SSMGetParametersByPathPage(ctx context.Context, client Client, path string, recursive, decrypt bool, token cloud.PageToken) (*cloud.Page[SSMParameter], error) {
+	req := (&cloud.Request{
+		Operation:   "ssm.get_parameters_by_path",
+		Path:        path,
+		QueryParams: make(map[string]string),
+	}).WithPageToken(token)
+	if recursive {
+		req.QueryParams["Recursive"] = "true"
+	}
+	if decrypt {
+		req.QueryParams["WithDecryption"] = "true"
+	}
+	resp, err := client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &cloud.Page[SSMParameter]{Next: resp.NextPageToken()}
+	if len(resp.Body) > 0 {
+		if err := resp.UnmarshalBody(&page.Items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal SSM parameters: %w", err)
+		}
+	}
+	return page, nil
+}
@@ -2,10 +2,18 @@ package aws
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/skolldire/go-engine/pkg/integration/cloud"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // mockClientHelper is a mock implementation of Client for testing helpers
@@ -73,6 +81,225 @@ func TestSQSSendMessage(t *testing.T) {
 	}
 }
 
+// batchSQSClient answers sqs.send_message_batch like the adapter: entries whose body is
+// "reject" fail individually and a chunk containing ID "down" fails as a whole
+type batchSQSClient struct {
+	chunkSizes []int
+	headers    []map[string]string
+}
+
+func (c *batchSQSClient) Do(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	var entries []struct {
+		ID      string            `json:"id"`
+		Body    string            `json:"body"`
+		Headers map[string]string `json:"headers"`
+	}
+	if err := json.Unmarshal(req.Body, &entries); err != nil {
+		return nil, err
+	}
+	c.chunkSizes = append(c.chunkSizes, len(entries))
+
+	result := BatchResult{}
+	for _, e := range entries {
+		c.headers = append(c.headers, e.Headers)
+		switch {
+		case e.ID == "down":
+			return nil, &cloud.Error{Code: cloud.ErrCodeServiceUnavailable, Message: "sqs unavailable", Retriable: true}
+		case e.Body == "reject":
+			result.Failed = append(result.Failed, BatchFailure{ID: e.ID, Code: "InvalidMessageContents", SenderFault: true})
+		default:
+			result.Successful = append(result.Successful, BatchSuccess{ID: e.ID, MessageID: "m-" + e.ID})
+		}
+	}
+	body, _ := json.Marshal(result)
+	return &cloud.Response{StatusCode: 200, Body: body}, nil
+}
+
+func TestSQSSendMessageBatch_ChunksAndAggregates(t *testing.T) {
+	client := &batchSQSClient{}
+	entries := make([]BatchEntry, 23)
+	for i := range entries {
+		entries[i] = BatchEntry{ID: fmt.Sprintf("e%d", i), Body: []byte("ok")}
+	}
+	entries[4].Body = []byte("reject")
+	entries[0].Headers = map[string]string{"sqs.message_group_id": "orders", "sqs.message_attribute.tenant": "acme"}
+
+	result, err := SQSSendMessageBatch(context.Background(), client, "queue-url", entries)
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 10, 3}, client.chunkSizes)
+	assert.Len(t, result.Successful, 22)
+	assert.Equal(t, []BatchFailure{{ID: "e4", Code: "InvalidMessageContents", SenderFault: true}}, result.Failed)
+	assert.Equal(t, "m-e0", result.Successful[0].MessageID)
+	assert.Equal(t, entries[0].Headers, client.headers[0])
+}
+
+func TestSQSSendMessageBatch_ChunkErrorReturnsPartialResult(t *testing.T) {
+	client := &batchSQSClient{}
+	entries := make([]BatchEntry, 12)
+	for i := range entries {
+		entries[i] = BatchEntry{ID: fmt.Sprintf("e%d", i), Body: []byte("ok")}
+	}
+	entries[10].ID = "down"
+
+	result, err := SQSSendMessageBatch(context.Background(), client, "queue-url", entries)
+
+	require.Error(t, err)
+	assert.Len(t, result.Successful, 10)
+	require.Len(t, result.Failed, 2)
+	assert.Equal(t, "down", result.Failed[0].ID)
+	assert.Equal(t, cloud.ErrCodeServiceUnavailable, result.Failed[0].Code)
+	assert.False(t, result.Failed[0].SenderFault)
+}
+
+func TestSQSSendMessageBatch_InvalidIDs(t *testing.T) {
+	client := &batchSQSClient{}
+
+	_, err := SQSSendMessageBatch(context.Background(), client, "queue-url", []BatchEntry{{ID: "a"}, {ID: "a"}})
+	assert.ErrorContains(t, err, "duplicate batch entry ID")
+	_, err = SQSSendMessageBatch(context.Background(), client, "queue-url", []BatchEntry{{Body: []byte("x")}})
+	assert.ErrorContains(t, err, "batch entry ID is required")
+	assert.Empty(t, client.chunkSizes)
+}
+
+func TestS3PutObjectTagsBatch(t *testing.T) {
+	client := &mockClientHelper{}
+	denied := cloud.NewError(cloud.ErrCodeAuthorizationFailed, "access denied")
+	client.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Path == "bucket/locked.csv"
+	})).Return(nil, denied)
+	client.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "s3.put_object_tagging" && req.Headers["s3.tag.cost-center"] == "42"
+	})).Return(&cloud.Response{StatusCode: 200}, nil)
+
+	results, err := S3PutObjectTagsBatch(context.Background(), client, "bucket",
+		[]string{"a.csv", "b.csv", "locked.csv"}, map[string]string{"cost-center": "42"}, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]error{"a.csv": nil, "b.csv": nil, "locked.csv": denied}, results)
+}
+
+// multipartClient records the s3 calls made by S3MultipartUpload
+type multipartClient struct {
+	actions   []string
+	parts     [][]byte
+	completed []byte
+	failPart  string
+}
+
+func (c *multipartClient) Do(_ context.Context, req *cloud.Request) (*cloud.Response, error) {
+	action := req.Headers["s3.multipart_action"]
+	if req.Operation == "s3.put_object" {
+		action = "put_object"
+	}
+	c.actions = append(c.actions, action)
+
+	switch action {
+	case "create":
+		return &cloud.Response{StatusCode: 200, Headers: map[string]string{"s3.upload_id": "upload-1"}}, nil
+	case "upload_part":
+		if req.Headers["s3.part_number"] == c.failPart {
+			return nil, errors.New("connection reset")
+		}
+		c.parts = append(c.parts, append([]byte(nil), req.Body...))
+		return &cloud.Response{StatusCode: 200, Headers: map[string]string{"s3.etag": "etag-" + req.Headers["s3.part_number"]}}, nil
+	case "complete":
+		c.completed = req.Body
+		return &cloud.Response{StatusCode: 200, Headers: map[string]string{"s3.etag": "final"}}, nil
+	}
+	return &cloud.Response{StatusCode: 200}, nil
+}
+
+func TestS3MultipartUpload(t *testing.T) {
+	client := &multipartClient{}
+	body := strings.Repeat("a", int(S3MinPartSize)) + strings.Repeat("b", int(S3MinPartSize)) + "tail"
+
+	resp, err := S3MultipartUpload(context.Background(), client, "bucket", "backups/db.tar", strings.NewReader(body), 1024)
+
+	require.NoError(t, err)
+	assert.Equal(t, "final", resp.Headers["s3.etag"])
+	assert.Equal(t, []string{"create", "upload_part", "upload_part", "upload_part", "complete"}, client.actions)
+	require.Len(t, client.parts, 3)
+	assert.Len(t, client.parts[0], int(S3MinPartSize), "part size is raised to the S3 minimum")
+	assert.Equal(t, "tail", string(client.parts[2]))
+	assert.JSONEq(t, `[{"part_number":1,"etag":"etag-1"},{"part_number":2,"etag":"etag-2"},{"part_number":3,"etag":"etag-3"}]`, string(client.completed))
+}
+
+func TestS3MultipartUpload_SmallReaderUsesPutObject(t *testing.T) {
+	client := &multipartClient{}
+
+	_, err := S3MultipartUpload(context.Background(), client, "bucket", "small.txt", strings.NewReader("hello"), 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"put_object"}, client.actions)
+}
+
+func TestS3MultipartUpload_AbortsOnError(t *testing.T) {
+	client := &multipartClient{failPart: "2"}
+	body := strings.Repeat("a", 2*int(S3MinPartSize)+1)
+
+	resp, err := S3MultipartUpload(context.Background(), client, "bucket", "key", strings.NewReader(body), S3MinPartSize)
+
+	assert.Nil(t, resp)
+	assert.ErrorContains(t, err, "connection reset")
+	assert.Equal(t, []string{"create", "upload_part", "upload_part", "abort"}, client.actions)
+}
+
+func TestS3GetObjectStream(t *testing.T) {
+	client := &mockClientHelper{}
+	client.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "s3.get_object" && req.StreamBody
+	})).Return(&cloud.Response{
+		BodyStream: io.NopCloser(strings.NewReader("large payload")),
+		Headers:    map[string]string{"s3.content_type": "text/plain"},
+	}, nil).Once()
+	client.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{
+		BodyStream: io.NopCloser(strings.NewReader("drained")),
+	}, nil).Once()
+
+	stream, headers, err := S3GetObjectStream(context.Background(), client, "bucket", "big.txt")
+	require.NoError(t, err)
+	defer stream.Close()
+	body, _ := io.ReadAll(stream)
+	assert.Equal(t, "large payload", string(body))
+	assert.Equal(t, "text/plain", headers["s3.content_type"])
+
+	resp, err := S3GetObject(context.Background(), client, "bucket", "big.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "drained", string(resp.Body), "byte-based helpers drain a stream")
+	assert.Nil(t, resp.BodyStream)
+}
+
+func TestS3PresignObject(t *testing.T) {
+	client := &mockClientHelper{}
+	client.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "s3.presign_get" && req.Path == "bucket/a.csv" && req.Headers["s3.presign_expiry_seconds"] == "900"
+	})).Return(&cloud.Response{Headers: map[string]string{"s3.presigned_url": "https://get"}}, nil)
+	client.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "s3.presign_put" && req.Headers["s3.presign_expiry_seconds"] == "3600"
+	})).Return(&cloud.Response{Headers: map[string]string{"s3.presigned_url": "https://put"}}, nil)
+
+	getURL, err := S3PresignGetObject(context.Background(), client, "bucket", "a.csv", 15*time.Minute)
+	require.NoError(t, err)
+	putURL, err := S3PresignPutObject(context.Background(), client, "bucket", "a.csv", time.Hour)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://get", getURL)
+	assert.Equal(t, "https://put", putURL)
+}
+
+func TestS3PutObjectTags_InvalidTagCount(t *testing.T) {
+	client := &mockClientHelper{}
+	tooMany := make(map[string]string, S3MaxObjectTags+1)
+	for i := 0; i <= S3MaxObjectTags; i++ {
+		tooMany[fmt.Sprintf("k%d", i)] = "v"
+	}
+
+	assert.Error(t, S3PutObjectTags(context.Background(), client, "bucket", "a.csv", nil))
+	assert.Error(t, S3PutObjectTags(context.Background(), client, "bucket", "a.csv", tooMany))
+	client.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+}
+
 func TestSNSPublish(t *testing.T) {
 	client := &mockClientHelper{}
 	client.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
@@ -91,6 +318,52 @@ func TestSNSPublish(t *testing.T) {
 	}
 }
 
+func TestSNSSetSubscriptionAttributes(t *testing.T) {
+	client := &mockClientHelper{}
+	client.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sns.set_subscription_attributes" &&
+			req.Path == "arn:aws:sns:us-east-1:123:my-topic:sub-1" &&
+			req.Headers["sns.attribute_name"] == "FilterPolicy" &&
+			string(req.Body) == `{"event":["order.placed"]}`
+	})).Return(&cloud.Response{StatusCode: 200}, nil).Once()
+
+	err := SNSSetSubscriptionAttributes(context.Background(), client, "arn:aws:sns:us-east-1:123:my-topic:sub-1",
+		map[string]interface{}{"event": []string{"order.placed"}})
+	if err != nil {
+		t.Errorf("SNSSetSubscriptionAttributes() error = %v", err)
+	}
+	client.AssertExpectations(t)
+}
+
+func TestSNSSetSubscriptionAttributes_InvalidPolicy(t *testing.T) {
+	client := &mockClientHelper{}
+
+	err := SNSSetSubscriptionAttributes(context.Background(), client, "arn:aws:sns:us-east-1:123:my-topic:sub-1",
+		map[string]interface{}{"event": make(chan int)})
+	if err == nil {
+		t.Error("SNSSetSubscriptionAttributes() expected error for non-JSON policy")
+	}
+	client.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+}
+
+func TestSNSGetSubscriptionAttributes(t *testing.T) {
+	client := &mockClientHelper{}
+	client.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "sns.get_subscription_attributes"
+	})).Return(&cloud.Response{
+		StatusCode: 200,
+		Body:       []byte(`{"FilterPolicy":"{\"event\":[\"order.placed\"]}","RawMessageDelivery":"true"}`),
+	}, nil)
+
+	attributes, err := SNSGetSubscriptionAttributes(context.Background(), client, "arn:aws:sns:us-east-1:123:my-topic:sub-1")
+	if err != nil {
+		t.Fatalf("SNSGetSubscriptionAttributes() error = %v", err)
+	}
+	if attributes["FilterPolicy"] != `{"event":["order.placed"]}` || attributes["RawMessageDelivery"] != "true" {
+		t.Errorf("SNSGetSubscriptionAttributes() = %v", attributes)
+	}
+}
+
 func TestLambdaInvoke(t *testing.T) {
 	client := &mockClientHelper{}
 	client.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
@@ -108,3 +381,206 @@ func TestLambdaInvoke(t *testing.T) {
 		t.Errorf("LambdaInvoke() statusCode = %v, want 200", resp.StatusCode)
 	}
 }
+
+func TestLambdaInvokeAsync(t *testing.T) {
+	client := &mockClientHelper{}
+	client.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		return req.Operation == "lambda.invoke" && req.Headers["lambda.invocation_type"] == "Event"
+	})).Return(&cloud.Response{StatusCode: 202}, nil)
+
+	err := LambdaInvokeAsync(context.Background(), client, "my-function", map[string]string{"key": "value"})
+	if err != nil {
+		t.Errorf("LambdaInvokeAsync() error = %v", err)
+	}
+	client.AssertExpectations(t)
+}
+
+func TestLambdaInvoke_PayloadTooLarge(t *testing.T) {
+	client := &mockClientHelper{}
+
+	tooBigAsync := strings.Repeat("a", LambdaAsyncPayloadLimit)
+	err := LambdaInvokeAsync(context.Background(), client, "my-function", tooBigAsync)
+	var cloudErr *cloud.Error
+	if !errors.As(err, &cloudErr) || cloudErr.Code != cloud.ErrCodeInvalidRequest {
+		t.Fatalf("LambdaInvokeAsync() error = %v, want %s", err, cloud.ErrCodeInvalidRequest)
+	}
+
+	tooBigSync := strings.Repeat("a", LambdaSyncPayloadLimit)
+	_, err = LambdaInvoke(context.Background(), client, "my-function", tooBigSync)
+	if !errors.As(err, &cloudErr) || cloudErr.Code != cloud.ErrCodeInvalidRequest {
+		t.Fatalf("LambdaInvoke() error = %v, want %s", err, cloud.ErrCodeInvalidRequest)
+	}
+
+	client.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+}
+
+func TestLambdaInvokeInto(t *testing.T) {
+	type result struct {
+		Status string `json:"status"`
+	}
+
+	t.Run("success decodes payload", func(t *testing.T) {
+		client := &mockClientHelper{}
+		client.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{
+			StatusCode: 200,
+			Body:       []byte(`{"status":"ok"}`),
+			Headers:    map[string]string{},
+		}, nil)
+
+		got, err := LambdaInvokeInto[result](context.Background(), client, "my-function", map[string]string{"key": "value"})
+		if err != nil {
+			t.Fatalf("LambdaInvokeInto() error = %v", err)
+		}
+		if got.Status != "ok" {
+			t.Errorf("LambdaInvokeInto() status = %v, want ok", got.Status)
+		}
+	})
+
+	t.Run("function error returns LambdaFunctionError", func(t *testing.T) {
+		client := &mockClientHelper{}
+		client.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{
+			StatusCode: 500,
+			Body:       []byte(`{"errorMessage":"boom","errorType":"RuntimeError","stackTrace":["main.go:10"]}`),
+			Headers:    map[string]string{"lambda.function_error": "Unhandled"},
+		}, nil)
+
+		_, err := LambdaInvokeInto[result](context.Background(), client, "my-function", nil)
+		var fnErr *LambdaFunctionError
+		if !errors.As(err, &fnErr) {
+			t.Fatalf("LambdaInvokeInto() error = %v, want *LambdaFunctionError", err)
+		}
+		if fnErr.Type != "Unhandled" || fnErr.ErrorType != "RuntimeError" || fnErr.ErrorMessage != "boom" {
+			t.Errorf("LambdaInvokeInto() unexpected function error: %+v", fnErr)
+		}
+	})
+
+	t.Run("invalid payload returns unmarshal error", func(t *testing.T) {
+		client := &mockClientHelper{}
+		client.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{
+			StatusCode: 200,
+			Body:       []byte(`not-json`),
+		}, nil)
+
+		_, err := LambdaInvokeInto[result](context.Background(), client, "my-function", nil)
+		if err == nil || !strings.Contains(err.Error(), "failed to unmarshal lambda response") {
+			t.Errorf("LambdaInvokeInto() error = %v, want unmarshal error", err)
+		}
+	})
+}
+
+func TestLambdaInvokeInto_AdapterFunctionError(t *testing.T) {
+	cloudErr := cloud.NewError(cloud.ErrCodeLambdaFunctionError, "lambda function my-function returned Handled error").
+		WithMetadata("lambda.function_error", "Handled").
+		WithMetadata("lambda.payload", []byte(`{"errorMessage":"invalid input","errorType":"ValidationError"}`))
+
+	client := &mockClientHelper{}
+	client.On("Do", mock.Anything, mock.Anything).Return(nil, cloudErr)
+
+	_, err := LambdaInvokeInto[map[string]interface{}](context.Background(), client, "my-function", nil)
+	var fnErr *LambdaFunctionError
+	if !errors.As(err, &fnErr) {
+		t.Fatalf("LambdaInvokeInto() error = %v, want *LambdaFunctionError", err)
+	}
+	if fnErr.Type != "Handled" || fnErr.ErrorType != "ValidationError" {
+		t.Errorf("LambdaInvokeInto() unexpected function error: %+v", fnErr)
+	}
+}
+
+func TestS3ListObjectsPage(t *testing.T) {
+	client := &mockClientHelper{}
+	client.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		token, _ := req.PageToken()
+		return req.Operation == "s3.list_objects" && req.Path == "bucket/logs" && token == "token-1"
+	})).Return(&cloud.Response{
+		StatusCode: 200,
+		Body:       []byte(`[{"key":"logs/a.txt","size":12,"last_modified":"2026-01-02T03:04:05Z","etag":"\"abc\""}]`),
+		Headers: map[string]string{
+			"s3.is_truncated":         "true",
+			cloud.NextPageTokenHeader: "token-2",
+		},
+	}, nil)
+
+	page, err := S3ListObjectsPage(context.Background(), client, "bucket", "logs", 0, "token-1")
+	if err != nil {
+		t.Fatalf("S3ListObjectsPage() error = %v", err)
+	}
+	if !page.HasMore() || page.Next != "token-2" {
+		t.Errorf("S3ListObjectsPage() next = %q, want token-2", page.Next)
+	}
+	if len(page.Items) != 1 || page.Items[0].Key != "logs/a.txt" || page.Items[0].Size != 12 {
+		t.Errorf("S3ListObjectsPage() objects = %+v", page.Items)
+	}
+}
+
+func TestSQSListQueuesPage(t *testing.T) {
+	client := &mockClientHelper{}
+	client.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		token, paged := req.PageToken()
+		return req.Operation == "sqs.list_queues" && paged && token.IsFirst() && req.QueryParams["QueueNamePrefix"] == "orders"
+	})).Return(&cloud.Response{
+		StatusCode: 200,
+		Body:       []byte(`["https://sqs/orders-a","https://sqs/orders-b"]`),
+		Headers:    map[string]string{cloud.NextPageTokenHeader: "next"},
+	}, nil)
+
+	page, err := SQSListQueuesPage(context.Background(), client, "orders", "")
+	if err != nil {
+		t.Fatalf("SQSListQueuesPage() error = %v", err)
+	}
+	if len(page.Items) != 2 || page.Next != "next" {
+		t.Errorf("SQSListQueuesPage() = %+v", page)
+	}
+}
+
+func TestSSMGetParametersByPathPage(t *testing.T) {
+	client := &mockClientHelper{}
+	client.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+		token, _ := req.PageToken()
+		return req.Operation == "ssm.get_parameters_by_path" && req.Path == "/app/" &&
+			req.QueryParams["Recursive"] == "true" && token == "page-2"
+	})).Return(&cloud.Response{
+		StatusCode: 200,
+		Body:       []byte(`[{"name":"/app/db/host","value":"localhost","type":"String","version":3}]`),
+	}, nil)
+
+	page, err := SSMGetParametersByPathPage(context.Background(), client, "/app/", true, false, "page-2")
+	if err != nil {
+		t.Fatalf("SSMGetParametersByPathPage() error = %v", err)
+	}
+	if page.HasMore() {
+		t.Errorf("SSMGetParametersByPathPage() next = %q, want last page", page.Next)
+	}
+	if len(page.Items) != 1 || page.Items[0].Name != "/app/db/host" || page.Items[0].Version != 3 {
+		t.Errorf("SSMGetParametersByPathPage() items = %+v", page.Items)
+	}
+}
+
+func TestS3PutObjectAutoType(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         string
+		body        []byte
+		contentType string
+		want        string
+	}{
+		{name: "extension", key: "site/index.html", body: []byte("hello"), want: "text/html; charset=utf-8"},
+		{name: "sniffed body", key: "uploads/blob", body: []byte("\x89PNG\r\n\x1a\n0000"), want: "image/png"},
+		{name: "unknown binary", key: "uploads/blob", body: []byte{0x00, 0x01, 0x02}, want: "application/octet-stream"},
+		{name: "explicit preserved", key: "site/index.html", body: []byte("hello"), contentType: "text/plain", want: "text/plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockClientHelper{}
+			m.On("Do", mock.Anything, mock.MatchedBy(func(req *cloud.Request) bool {
+				return req.Operation == "s3.put_object" && req.Headers["s3.content_type"] == tt.want
+			})).Return(&cloud.Response{StatusCode: 200}, nil)
+
+			_, err := S3PutObjectAutoType(context.Background(), m, "bucket", tt.key, tt.body, tt.contentType, nil)
+			if err != nil {
+				t.Fatalf("S3PutObjectAutoType() error = %v", err)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+)
+
+// ErrOperationNotAllowed is the cause of errors returned for operations rejected by
+// WithAllowedOperations / WithDeniedOperations
+var ErrOperationNotAllowed = errors.New("operation not allowed by client policy")
+
+// WithAllowedOperations restricts the client to operations matching one of the prefixes
+// (e.g. "s3.get_", "sqs.", "ssm.get_parameter"); anything else is rejected before the
+// SDK call. It is a defense-in-depth complement to IAM, not a replacement.
+func WithAllowedOperations(prefixes []string) Options {
+	return Options{Middlewares: []cloud.Middleware{OperationFilter(prefixes, nil)}}
+}
+
+// WithDeniedOperations rejects operations matching one of the prefixes before the SDK
+// call (e.g. "s3.delete_", "sqs.delete_queue"). Denied prefixes take precedence over
+// allowed ones.
+func WithDeniedOperations(prefixes []string) Options {
+	return Options{Middlewares: []cloud.Middleware{OperationFilter(nil, prefixes)}}
+}
+
+// OperationFilter returns the middleware used by WithAllowedOperations and
+// WithDeniedOperations. An empty allowed list allows every operation not denied.
+func OperationFilter(allowed, denied []string) cloud.Middleware {
+	return func(next cloud.Client) cloud.Client {
+		return &operationFilter{next: next, allowed: allowed, denied: denied}
+	}
+}
+
+type operationFilter struct {
+	next    cloud.Client
+	allowed []string
+	denied  []string
+}
+
+func (f *operationFilter) Do(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	if req != nil {
+		if prefix, ok := matchOperation(req.Operation, f.denied); ok {
+			return nil, operationNotAllowed(req.Operation, fmt.Sprintf("matches denied prefix %q", prefix))
+		}
+		if len(f.allowed) > 0 {
+			if _, ok := matchOperation(req.Operation, f.allowed); !ok {
+				return nil, operationNotAllowed(req.Operation, "is not in the allowed operations")
+			}
+		}
+	}
+	return f.next.Do(ctx, req)
+}
+
+func matchOperation(operation string, prefixes []string) (string, bool) {
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(operation, prefix) {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+func operationNotAllowed(operation, reason string) error {
+	return cloud.NewErrorWithCause(
+		cloud.ErrCodeAuthorizationFailed,
+		fmt.Sprintf("operation %s rejected by client policy: %s", operation, reason),
+		ErrOperationNotAllowed,
+	).WithMetadata("operation", operation)
+}
@@ -0,0 +1,62 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWithAllowedOperations(t *testing.T) {
+	next := &mockClientHelper{}
+	next.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{StatusCode: 200}, nil)
+
+	client := WithAllowedOperations([]string{"s3.get_", "sqs."}).Middlewares[0](next)
+
+	_, err := client.Do(context.Background(), &cloud.Request{Operation: "s3.get_object"})
+	assert.NoError(t, err)
+	_, err = client.Do(context.Background(), &cloud.Request{Operation: "sqs.send_message"})
+	assert.NoError(t, err)
+
+	_, err = client.Do(context.Background(), &cloud.Request{Operation: "s3.delete_object"})
+	assert.ErrorIs(t, err, ErrOperationNotAllowed)
+
+	var cloudErr *cloud.Error
+	if assert.True(t, errors.As(err, &cloudErr)) {
+		assert.Equal(t, cloud.ErrCodeAuthorizationFailed, cloudErr.Code)
+		assert.Contains(t, cloudErr.Message, "s3.delete_object")
+	}
+	next.AssertNumberOfCalls(t, "Do", 2)
+}
+
+func TestWithDeniedOperations(t *testing.T) {
+	next := &mockClientHelper{}
+	next.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{StatusCode: 200}, nil)
+
+	client := WithDeniedOperations([]string{"s3.delete_", "sqs.delete_queue"}).Middlewares[0](next)
+
+	_, err := client.Do(context.Background(), &cloud.Request{Operation: "sqs.delete_queue"})
+	assert.ErrorIs(t, err, ErrOperationNotAllowed)
+	_, err = client.Do(context.Background(), &cloud.Request{Operation: "s3.delete_object"})
+	assert.ErrorIs(t, err, ErrOperationNotAllowed)
+
+	_, err = client.Do(context.Background(), &cloud.Request{Operation: "sqs.delete_message"})
+	assert.NoError(t, err)
+	next.AssertNumberOfCalls(t, "Do", 1)
+}
+
+func TestOperationFilter_DeniedTakesPrecedence(t *testing.T) {
+	next := &mockClientHelper{}
+	next.On("Do", mock.Anything, mock.Anything).Return(&cloud.Response{StatusCode: 200}, nil)
+
+	client := OperationFilter([]string{"s3."}, []string{"s3.delete_"})(next)
+
+	_, err := client.Do(context.Background(), &cloud.Request{Operation: "s3.put_object"})
+	assert.NoError(t, err)
+	_, err = client.Do(context.Background(), &cloud.Request{Operation: "s3.delete_object"})
+	assert.ErrorIs(t, err, ErrOperationNotAllowed)
+	next.AssertNumberOfCalls(t, "Do", 1)
+}
@@ -0,0 +1,81 @@
+package inbound
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// NewSQSBatchResponse builds the partial batch response of a Lambda SQS handler so only
+// the given messages are redelivered. Requires ReportBatchItemFailures on the event source
+// mapping; without it Lambda ignores the response and deletes the whole batch on success.
+func NewSQSBatchResponse(failedMessageIDs []string) events.SQSEventResponse {
+	failures := make([]events.SQSBatchItemFailure, 0, len(failedMessageIDs))
+	for _, id := range failedMessageIDs {
+		failures = append(failures, events.SQSBatchItemFailure{ItemIdentifier: id})
+	}
+	return events.SQSEventResponse{BatchItemFailures: failures}
+}
+
+// BatchProcessor collects the failed messages of a Lambda SQS batch and emits the
+// matching partial batch response. It is safe for concurrent use.
+type BatchProcessor struct {
+	// FIFO stops processing at the first failure and reports every remaining message as
+	// failed too, preserving message group ordering on FIFO queues
+	FIFO bool
+
+	mu     sync.Mutex
+	failed []string
+	seen   map[string]struct{}
+}
+
+// NewBatchProcessor creates an empty BatchProcessor
+func NewBatchProcessor() *BatchProcessor {
+	return &BatchProcessor{seen: map[string]struct{}{}}
+}
+
+// Fail marks messageID for redelivery; repeated calls report it once
+func (p *BatchProcessor) Fail(messageID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.seen == nil {
+		p.seen = map[string]struct{}{}
+	}
+	if _, ok := p.seen[messageID]; ok {
+		return
+	}
+	p.seen[messageID] = struct{}{}
+	p.failed = append(p.failed, messageID)
+}
+
+// Process runs handler for every record of event, marking those whose handler returns an
+// error as failed, and returns the response for all failures recorded so far
+func (p *BatchProcessor) Process(ctx context.Context, event *events.SQSEvent, handler func(ctx context.Context, msg events.SQSMessage) error) events.SQSEventResponse {
+	if event != nil {
+		for i, record := range event.Records {
+			if err := handler(ctx, record); err != nil {
+				p.Fail(record.MessageId)
+				if p.FIFO {
+					for _, rest := range event.Records[i+1:] {
+						p.Fail(rest.MessageId)
+					}
+					break
+				}
+			}
+		}
+	}
+	return p.Response()
+}
+
+// Failed returns the message IDs marked as failed, in the order they were reported
+func (p *BatchProcessor) Failed() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.failed...)
+}
+
+// Response returns the partial batch response for the failures recorded so far
+func (p *BatchProcessor) Response() events.SQSEventResponse {
+	return NewSQSBatchResponse(p.Failed())
+}
@@ -0,0 +1,84 @@
+package inbound
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func testBatch(ids ...string) *events.SQSEvent {
+	event := &events.SQSEvent{}
+	for _, id := range ids {
+		event.Records = append(event.Records, events.SQSMessage{MessageId: id, Body: id})
+	}
+	return event
+}
+
+func failureIDs(resp events.SQSEventResponse) []string {
+	ids := []string{}
+	for _, f := range resp.BatchItemFailures {
+		ids = append(ids, f.ItemIdentifier)
+	}
+	return ids
+}
+
+func TestBatchProcessor_Process(t *testing.T) {
+	p := NewBatchProcessor()
+
+	resp := p.Process(context.Background(), testBatch("m1", "m2", "m3"), func(ctx context.Context, msg events.SQSMessage) error {
+		if msg.MessageId == "m2" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	if got := failureIDs(resp); !reflect.DeepEqual(got, []string{"m2"}) {
+		t.Errorf("BatchItemFailures = %v, want [m2]", got)
+	}
+}
+
+func TestBatchProcessor_FIFOFailsRemainingMessages(t *testing.T) {
+	p := NewBatchProcessor()
+	p.FIFO = true
+
+	var handled []string
+	resp := p.Process(context.Background(), testBatch("m1", "m2", "m3", "m4"), func(ctx context.Context, msg events.SQSMessage) error {
+		handled = append(handled, msg.MessageId)
+		if msg.MessageId == "m2" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	if !reflect.DeepEqual(handled, []string{"m1", "m2"}) {
+		t.Errorf("handled = %v, want [m1 m2]", handled)
+	}
+	if got := failureIDs(resp); !reflect.DeepEqual(got, []string{"m2", "m3", "m4"}) {
+		t.Errorf("BatchItemFailures = %v, want [m2 m3 m4]", got)
+	}
+}
+
+func TestBatchProcessor_FailDeduplicates(t *testing.T) {
+	var p BatchProcessor
+	p.Fail("m1")
+	p.Fail("m1")
+	p.Fail("m2")
+
+	if got := p.Failed(); !reflect.DeepEqual(got, []string{"m1", "m2"}) {
+		t.Errorf("Failed() = %v, want [m1 m2]", got)
+	}
+}
+
+func TestNewSQSBatchResponse_NoFailuresMarshalsEmptyList(t *testing.T) {
+	body, err := json.Marshal(NewSQSBatchResponse(nil))
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(body) != `{"batchItemFailures":[]}` {
+		t.Errorf("response = %s, want an empty batchItemFailures list", body)
+	}
+}
@@ -0,0 +1,177 @@
+package inbound
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+)
+
+// NormalizeDynamoDBStreamEvent converts a DynamoDB Streams Lambda event to one Request per
+// record. The eventName (INSERT, MODIFY, REMOVE) is in the dynamodb.event_name header and
+// the NewImage, as plain JSON, in Body (empty for REMOVE). The keys and the OldImage (when
+// the stream view includes it) are JSON headers; read them with DynamoDBStreamKeys and
+// DynamoDBStreamOldImage. Numbers are kept as json.Number and binary values are
+// base64-encoded. Records with malformed attributes are left out and reported in a
+// *BatchDecodeError returned alongside the requests of the other records.
+func NormalizeDynamoDBStreamEvent(event *events.DynamoDBEvent) ([]*cloud.Request, error) {
+	if event == nil {
+		return nil, nil
+	}
+
+	requests := make([]*cloud.Request, 0, len(event.Records))
+	var failed []RecordError
+
+	for _, record := range event.Records {
+		req, err := normalizeDynamoDBStreamRecord(record)
+		if err != nil {
+			failed = append(failed, RecordError{
+				ID:             record.EventID,
+				SequenceNumber: record.Change.SequenceNumber,
+				Err:            err,
+			})
+			continue
+		}
+		requests = append(requests, req)
+	}
+
+	if len(failed) > 0 {
+		return requests, &BatchDecodeError{Source: "dynamodb stream", Records: failed}
+	}
+	return requests, nil
+}
+
+func normalizeDynamoDBStreamRecord(record events.DynamoDBEventRecord) (*cloud.Request, error) {
+	change := record.Change
+	req := &cloud.Request{
+		Operation: "dynamodb.stream",
+		Path:      record.EventSourceArn,
+		Method:    "POST", // Optional
+		Headers: map[string]string{
+			"dynamodb.event_id":         record.EventID,
+			"dynamodb.event_name":       record.EventName,
+			"dynamodb.event_source_arn": record.EventSourceArn,
+			"dynamodb.sequence_number":  change.SequenceNumber,
+			"dynamodb.stream_view_type": change.StreamViewType,
+		},
+	}
+
+	keys, err := marshalStreamImage(change.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("keys: %w", err)
+	}
+	if keys != nil {
+		req.Headers["dynamodb.keys"] = string(keys)
+	}
+
+	oldImage, err := marshalStreamImage(change.OldImage)
+	if err != nil {
+		return nil, fmt.Errorf("old image: %w", err)
+	}
+	if oldImage != nil {
+		req.Headers["dynamodb.old_image"] = string(oldImage)
+	}
+
+	req.Body, err = marshalStreamImage(change.NewImage)
+	if err != nil {
+		return nil, fmt.Errorf("new image: %w", err)
+	}
+	return req, nil
+}
+
+// DynamoDBStreamKeys returns the item keys of a request built by NormalizeDynamoDBStreamEvent
+func DynamoDBStreamKeys(req *cloud.Request) (map[string]interface{}, error) {
+	return decodeStreamImageHeader(req, "dynamodb.keys")
+}
+
+// DynamoDBStreamOldImage returns the item before a MODIFY or REMOVE, or nil when the stream
+// view type does not include old images
+func DynamoDBStreamOldImage(req *cloud.Request) (map[string]interface{}, error) {
+	return decodeStreamImageHeader(req, "dynamodb.old_image")
+}
+
+func decodeStreamImageHeader(req *cloud.Request, header string) (map[string]interface{}, error) {
+	if req == nil || req.Headers[header] == "" {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader([]byte(req.Headers[header])))
+	dec.UseNumber()
+	var image map[string]interface{}
+	if err := dec.Decode(&image); err != nil {
+		return nil, fmt.Errorf("invalid %s header: %w", header, err)
+	}
+	return image, nil
+}
+
+// marshalStreamImage encodes an image as plain JSON; nil for an empty image
+func marshalStreamImage(image map[string]events.DynamoDBAttributeValue) ([]byte, error) {
+	if len(image) == 0 {
+		return nil, nil
+	}
+	plain := make(map[string]interface{}, len(image))
+	for name, av := range image {
+		v, err := streamAttributeValue(av)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", name, err)
+		}
+		plain[name] = v
+	}
+	return json.Marshal(plain)
+}
+
+// streamAttributeValue converts an attribute to a JSON-friendly value. The events accessors
+// panic on a value that does not match its type (such as a zero DynamoDBAttributeValue,
+// which reports Binary with no data); that is returned as an error instead.
+func streamAttributeValue(av events.DynamoDBAttributeValue) (v interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed attribute value: %v", r)
+		}
+	}()
+
+	switch av.DataType() {
+	case events.DataTypeBinary:
+		return av.Binary(), nil
+	case events.DataTypeBoolean:
+		return av.Boolean(), nil
+	case events.DataTypeBinarySet:
+		return av.BinarySet(), nil
+	case events.DataTypeList:
+		list := av.List()
+		out := make([]interface{}, len(list))
+		for i, item := range list {
+			if out[i], err = streamAttributeValue(item); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	case events.DataTypeMap:
+		m := av.Map()
+		out := make(map[string]interface{}, len(m))
+		for k, item := range m {
+			if out[k], err = streamAttributeValue(item); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	case events.DataTypeNumber:
+		return json.Number(av.Number()), nil
+	case events.DataTypeNumberSet:
+		set := av.NumberSet()
+		out := make([]json.Number, len(set))
+		for i, n := range set {
+			out[i] = json.Number(n)
+		}
+		return out, nil
+	case events.DataTypeNull:
+		return nil, nil
+	case events.DataTypeString:
+		return av.String(), nil
+	case events.DataTypeStringSet:
+		return av.StringSet(), nil
+	default:
+		return nil, fmt.Errorf("unsupported attribute type %d", av.DataType())
+	}
+}
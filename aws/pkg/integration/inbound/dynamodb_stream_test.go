@@ -0,0 +1,140 @@
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func TestNormalizeDynamoDBStreamEvent_Modify(t *testing.T) {
+	event := &events.DynamoDBEvent{Records: []events.DynamoDBEventRecord{{
+		EventID:        "1",
+		EventName:      "MODIFY",
+		EventSourceArn: "arn:aws:dynamodb:us-east-1:123:table/orders/stream/2024",
+		Change: events.DynamoDBStreamRecord{
+			SequenceNumber: "100",
+			StreamViewType: "NEW_AND_OLD_IMAGES",
+			Keys: map[string]events.DynamoDBAttributeValue{
+				"id":  events.NewStringAttribute("order-1"),
+				"sig": events.NewBinaryAttribute([]byte{0xde, 0xad}),
+			},
+			OldImage: map[string]events.DynamoDBAttributeValue{
+				"id":     events.NewStringAttribute("order-1"),
+				"status": events.NewStringAttribute("pending"),
+			},
+			NewImage: map[string]events.DynamoDBAttributeValue{
+				"id":     events.NewStringAttribute("order-1"),
+				"status": events.NewStringAttribute("shipped"),
+				"total":  events.NewNumberAttribute("12345678901234567890"),
+				"tags":   events.NewStringSetAttribute([]string{"a"}),
+				"meta": events.NewMapAttribute(map[string]events.DynamoDBAttributeValue{
+					"gift": events.NewBooleanAttribute(true),
+					"note": events.NewNullAttribute(),
+				}),
+			},
+		},
+	}}}
+
+	requests, err := NormalizeDynamoDBStreamEvent(event)
+	if err != nil {
+		t.Fatalf("NormalizeDynamoDBStreamEvent() error = %v", err)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("NormalizeDynamoDBStreamEvent() len = %d, want 1", len(requests))
+	}
+	req := requests[0]
+	if req.Headers["dynamodb.event_name"] != "MODIFY" || req.Headers["dynamodb.sequence_number"] != "100" {
+		t.Errorf("NormalizeDynamoDBStreamEvent() headers = %v", req.Headers)
+	}
+
+	want := `{"id":"order-1","meta":{"gift":true,"note":null},"status":"shipped","tags":["a"],"total":12345678901234567890}`
+	if string(req.Body) != want {
+		t.Errorf("NormalizeDynamoDBStreamEvent() body = %s, want %s", req.Body, want)
+	}
+
+	old, err := DynamoDBStreamOldImage(req)
+	if err != nil || old["status"] != "pending" {
+		t.Errorf("DynamoDBStreamOldImage() = %v, %v", old, err)
+	}
+
+	keys, err := DynamoDBStreamKeys(req)
+	if err != nil || keys["sig"] != "3q0=" {
+		t.Errorf("DynamoDBStreamKeys() = %v, %v", keys, err)
+	}
+}
+
+func TestNormalizeDynamoDBStreamEvent_RemoveAndInsert(t *testing.T) {
+	var event events.DynamoDBEvent
+	raw := `{"Records":[
+		{"eventID":"1","eventName":"REMOVE","dynamodb":{"Keys":{"id":{"B":"AQI="}},"OldImage":{"id":{"B":"AQI="},"n":{"N":"7"}}}},
+		{"eventID":"2","eventName":"INSERT","dynamodb":{"Keys":{"id":{"S":"x"}},"NewImage":{"id":{"S":"x"},"bs":{"BS":["AQ=="]}}}}
+	]}`
+	if err := json.Unmarshal([]byte(raw), &event); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	requests, err := NormalizeDynamoDBStreamEvent(&event)
+	if err != nil {
+		t.Fatalf("NormalizeDynamoDBStreamEvent() error = %v", err)
+	}
+
+	removed := requests[0]
+	if removed.Body != nil {
+		t.Errorf("REMOVE body = %s, want nil", removed.Body)
+	}
+	old, err := DynamoDBStreamOldImage(removed)
+	if err != nil || old["n"] != json.Number("7") || old["id"] != "AQI=" {
+		t.Errorf("DynamoDBStreamOldImage() = %v, %v", old, err)
+	}
+
+	inserted := requests[1]
+	if string(inserted.Body) != `{"bs":["AQ=="],"id":"x"}` {
+		t.Errorf("INSERT body = %s", inserted.Body)
+	}
+	if old, err := DynamoDBStreamOldImage(inserted); old != nil || err != nil {
+		t.Errorf("DynamoDBStreamOldImage() = %v, %v, want nil", old, err)
+	}
+}
+
+func TestNormalizeDynamoDBStreamEvent_MalformedAttribute(t *testing.T) {
+	event := &events.DynamoDBEvent{Records: []events.DynamoDBEventRecord{
+		{
+			EventID:   "1",
+			EventName: "INSERT",
+			Change: events.DynamoDBStreamRecord{
+				SequenceNumber: "100",
+				Keys:           map[string]events.DynamoDBAttributeValue{"id": {}},
+			},
+		},
+		{
+			EventID:   "2",
+			EventName: "INSERT",
+			Change: events.DynamoDBStreamRecord{
+				SequenceNumber: "101",
+				Keys:           map[string]events.DynamoDBAttributeValue{"id": events.NewStringAttribute("a")},
+			},
+		},
+	}}
+
+	requests, err := NormalizeDynamoDBStreamEvent(event)
+
+	var decodeErr *BatchDecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("NormalizeDynamoDBStreamEvent() error = %v, want *BatchDecodeError", err)
+	}
+	if len(decodeErr.Records) != 1 || decodeErr.Source != "dynamodb stream" || decodeErr.Records[0].ID != "1" || decodeErr.Records[0].SequenceNumber != "100" {
+		t.Errorf("BatchDecodeError.Records = %+v, want record 1 / 100", decodeErr.Records)
+	}
+	if len(requests) != 1 || requests[0].Headers["dynamodb.event_id"] != "2" {
+		t.Errorf("NormalizeDynamoDBStreamEvent() = %v, want only record 2", requests)
+	}
+}
+
+func TestNormalizeDynamoDBStreamEvent_Nil(t *testing.T) {
+	requests, err := NormalizeDynamoDBStreamEvent(nil)
+	if requests != nil || err != nil {
+		t.Errorf("NormalizeDynamoDBStreamEvent(nil) = %v, %v", requests, err)
+	}
+}
@@ -0,0 +1,72 @@
+package inbound
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// snsNotificationType is the Type of the JSON envelope SNS delivers to SQS without raw message delivery
+const snsNotificationType = "Notification"
+
+type snsEnvelope struct {
+	Type              string                  `json:"Type"`
+	MessageID         string                  `json:"MessageId"`
+	TopicArn          string                  `json:"TopicArn"`
+	Subject           string                  `json:"Subject"`
+	Message           *string                 `json:"Message"`
+	Timestamp         string                  `json:"Timestamp"`
+	MessageAttributes map[string]snsAttribute `json:"MessageAttributes"`
+}
+
+type snsAttribute struct {
+	Type  string `json:"Type"`
+	Value string `json:"Value"`
+}
+
+// UnwrapSNSEnvelope returns the inner Message and MessageAttributes of an SNS notification
+// envelope, as found in SQS bodies of SNS subscriptions without raw message delivery.
+// Attribute values are returned as sent (Binary attributes stay base64). Bodies that are not
+// an SNS notification are returned unchanged with nil attributes.
+func UnwrapSNSEnvelope(body []byte) ([]byte, map[string]string, error) {
+	message, attrs, _, err := unwrapSNSEnvelope(body)
+	return message, attrs, err
+}
+
+// unwrapSNSEnvelope is UnwrapSNSEnvelope also returning the decoded envelope, nil when
+// body is not enveloped
+func unwrapSNSEnvelope(body []byte) ([]byte, map[string]string, *snsEnvelope, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return body, nil, nil, nil
+	}
+
+	var probe struct {
+		Type     string          `json:"Type"`
+		TopicArn string          `json:"TopicArn"`
+		Message  json.RawMessage `json:"Message"`
+	}
+	if err := json.Unmarshal(trimmed, &probe); err != nil {
+		return body, nil, nil, nil
+	}
+	if probe.Type != snsNotificationType || probe.TopicArn == "" || probe.Message == nil {
+		return body, nil, nil, nil
+	}
+
+	var envelope snsEnvelope
+	if err := json.Unmarshal(trimmed, &envelope); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid SNS envelope: %w", err)
+	}
+	if envelope.Message == nil {
+		return nil, nil, nil, fmt.Errorf("invalid SNS envelope: Message is not a string")
+	}
+
+	var attrs map[string]string
+	if len(envelope.MessageAttributes) > 0 {
+		attrs = make(map[string]string, len(envelope.MessageAttributes))
+		for name, attr := range envelope.MessageAttributes {
+			attrs[name] = attr.Value
+		}
+	}
+	return []byte(*envelope.Message), attrs, &envelope, nil
+}
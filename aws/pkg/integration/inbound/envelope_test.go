@@ -0,0 +1,165 @@
+package inbound
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+const snsEnvelopeBody = `{
+  "Type": "Notification",
+  "MessageId": "sns-123",
+  "TopicArn": "arn:aws:sns:us-east-1:123:orders",
+  "Subject": "created",
+  "Message": "{\"order_id\":\"o-1\"}",
+  "Timestamp": "2024-01-02T03:04:05.000Z",
+  "MessageAttributes": {
+    "event_type": {"Type": "String", "Value": "order.created"},
+    "payload": {"Type": "Binary", "Value": "aGk="}
+  }
+}`
+
+func TestUnwrapSNSEnvelope(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantBody  string
+		wantAttrs map[string]string
+		wantErr   bool
+	}{
+		{
+			name:     "notification envelope",
+			body:     snsEnvelopeBody,
+			wantBody: `{"order_id":"o-1"}`,
+			wantAttrs: map[string]string{
+				"event_type": "order.created",
+				"payload":    "aGk=",
+			},
+		},
+		{
+			name:     "envelope without attributes",
+			body:     `{"Type":"Notification","TopicArn":"arn:aws:sns:us-east-1:123:orders","Message":"plain text"}`,
+			wantBody: "plain text",
+		},
+		{name: "plain JSON", body: `{"order_id":"o-1"}`, wantBody: `{"order_id":"o-1"}`},
+		{name: "non JSON", body: "hello", wantBody: "hello"},
+		{name: "JSON array", body: `[1,2]`, wantBody: `[1,2]`},
+		{
+			name:     "subscription confirmation is not unwrapped",
+			body:     `{"Type":"SubscriptionConfirmation","TopicArn":"arn:aws:sns:us-east-1:123:orders","Message":"confirm"}`,
+			wantBody: `{"Type":"SubscriptionConfirmation","TopicArn":"arn:aws:sns:us-east-1:123:orders","Message":"confirm"}`,
+		},
+		{
+			name:    "envelope with non-string message",
+			body:    `{"Type":"Notification","TopicArn":"arn:aws:sns:us-east-1:123:orders","Message":{"order_id":"o-1"}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, attrs, err := UnwrapSNSEnvelope([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnwrapSNSEnvelope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("UnwrapSNSEnvelope() body = %q, want %q", body, tt.wantBody)
+			}
+			if !reflect.DeepEqual(attrs, tt.wantAttrs) {
+				t.Errorf("UnwrapSNSEnvelope() attrs = %v, want %v", attrs, tt.wantAttrs)
+			}
+		})
+	}
+}
+
+func TestNormalizeSQSEvent_WithSNSEnvelope(t *testing.T) {
+	sqsValue := "from-sqs"
+	event := &events.SQSEvent{
+		Records: []events.SQSMessage{
+			{
+				MessageId: "msg-1",
+				Body:      snsEnvelopeBody,
+				MessageAttributes: map[string]events.SQSMessageAttribute{
+					"event_type": {StringValue: &sqsValue, DataType: "String"},
+				},
+			},
+			{MessageId: "msg-2", Body: `{"order_id":"o-2"}`},
+		},
+	}
+
+	requests, err := NormalizeSQSEvent(event, WithSNSEnvelope())
+	if err != nil {
+		t.Fatalf("NormalizeSQSEvent() error = %v", err)
+	}
+
+	enveloped := requests[0]
+	if string(enveloped.Body) != `{"order_id":"o-1"}` {
+		t.Errorf("Body = %s, want inner message", enveloped.Body)
+	}
+	if enveloped.Headers["sns.topic_arn"] != "arn:aws:sns:us-east-1:123:orders" {
+		t.Errorf("Headers[sns.topic_arn] = %v", enveloped.Headers["sns.topic_arn"])
+	}
+	if enveloped.Headers["sns.message_id"] != "sns-123" {
+		t.Errorf("Headers[sns.message_id] = %v", enveloped.Headers["sns.message_id"])
+	}
+
+	var attrs map[string]string
+	if err := json.Unmarshal([]byte(enveloped.Headers["sqs.message_attributes"]), &attrs); err != nil {
+		t.Fatalf("sqs.message_attributes: %v", err)
+	}
+	want := map[string]string{"event_type": "from-sqs", "payload": "aGk="}
+	if !reflect.DeepEqual(attrs, want) {
+		t.Errorf("attributes = %v, want %v", attrs, want)
+	}
+
+	plain := requests[1]
+	if string(plain.Body) != `{"order_id":"o-2"}` {
+		t.Errorf("Body = %s, want unchanged", plain.Body)
+	}
+	if _, ok := plain.Headers["sns.topic_arn"]; ok {
+		t.Error("plain message must not get sns headers")
+	}
+}
+
+func TestNormalizeSQSEvent_KeepsEnvelopeByDefault(t *testing.T) {
+	event := &events.SQSEvent{Records: []events.SQSMessage{{MessageId: "msg-1", Body: snsEnvelopeBody}}}
+
+	requests, err := NormalizeSQSEvent(event)
+	if err != nil {
+		t.Fatalf("NormalizeSQSEvent() error = %v", err)
+	}
+	if string(requests[0].Body) != snsEnvelopeBody {
+		t.Errorf("Body = %s, want the raw envelope", requests[0].Body)
+	}
+}
+
+func TestNormalizeSQSEvent_MalformedSNSEnvelope(t *testing.T) {
+	event := &events.SQSEvent{Records: []events.SQSMessage{
+		{
+			MessageId: "msg-1",
+			Body:      `{"Type":"Notification","TopicArn":"arn:aws:sns:us-east-1:123:orders","Message":42}`,
+		},
+		{MessageId: "msg-2", Body: snsEnvelopeBody},
+	}}
+
+	requests, err := NormalizeSQSEvent(event, WithSNSEnvelope())
+	var decodeErr *BatchDecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("NormalizeSQSEvent() error = %v, want *BatchDecodeError", err)
+	}
+	if len(decodeErr.Records) != 1 || decodeErr.Source != "sqs" || decodeErr.Records[0].ID != "msg-1" {
+		t.Errorf("NormalizeSQSEvent() failed records = %+v, want msg-1", decodeErr.Records)
+	}
+	if len(requests) != 1 || SQSMessageID(requests[0]) != "msg-2" {
+		t.Fatalf("NormalizeSQSEvent() requests = %d, want the well-formed msg-2", len(requests))
+	}
+	if string(requests[0].Body) == snsEnvelopeBody {
+		t.Error("the well-formed envelope must still be unwrapped")
+	}
+}
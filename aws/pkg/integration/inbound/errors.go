@@ -0,0 +1,49 @@
+package inbound
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RecordError is a record a Normalize function could not normalize. ID is the record's
+// MessageId (SQS, SNS) or EventID (Kinesis, DynamoDB Streams); SequenceNumber is set for
+// the stream sources, which report batch item failures by it.
+type RecordError struct {
+	ID             string
+	SequenceNumber string
+	Err            error
+}
+
+func (e RecordError) Error() string {
+	if e.SequenceNumber != "" {
+		return fmt.Sprintf("record %s (sequence %s): %v", e.ID, e.SequenceNumber, e.Err)
+	}
+	return fmt.Sprintf("record %s: %v", e.ID, e.Err)
+}
+
+func (e RecordError) Unwrap() error { return e.Err }
+
+// BatchDecodeError lists the records of an event that could not be normalized. The other
+// records are still returned, so the caller can process them and report the failures
+// (e.g. as batch item failures). Source names the event type: "sqs", "sns", "kinesis" or
+// "dynamodb stream".
+type BatchDecodeError struct {
+	Source  string
+	Records []RecordError
+}
+
+func (e *BatchDecodeError) Error() string {
+	msgs := make([]string, len(e.Records))
+	for i, r := range e.Records {
+		msgs[i] = r.Error()
+	}
+	return fmt.Sprintf("%d %s record(s) failed to decode: %s", len(e.Records), e.Source, strings.Join(msgs, "; "))
+}
+
+func (e *BatchDecodeError) Unwrap() []error {
+	errs := make([]error, len(e.Records))
+	for i, r := range e.Records {
+		errs[i] = r
+	}
+	return errs
+}
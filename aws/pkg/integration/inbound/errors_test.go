@@ -0,0 +1,26 @@
+package inbound
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBatchDecodeError(t *testing.T) {
+	boom := errors.New("boom")
+	err := &BatchDecodeError{Source: "kinesis", Records: []RecordError{
+		{ID: "evt-1", SequenceNumber: "100", Err: boom},
+		{ID: "evt-2", Err: errors.New("bad")},
+	}}
+
+	want := "2 kinesis record(s) failed to decode: record evt-1 (sequence 100): boom; record evt-2: bad"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if !errors.Is(err, boom) {
+		t.Error("BatchDecodeError does not wrap the record errors")
+	}
+	var record RecordError
+	if !errors.As(err, &record) || record.ID != "evt-1" {
+		t.Errorf("errors.As(RecordError) = %+v, want evt-1", record)
+	}
+}
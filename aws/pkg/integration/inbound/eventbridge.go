@@ -0,0 +1,49 @@
+package inbound
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+)
+
+// NormalizeEventBridgeEvent converts an EventBridge (CloudWatch Events) event to a
+// normalized Request. Operation is the event's detail-type (e.g. "Scheduled Event",
+// "OrderPlaced"), Path its source, and Body the raw detail JSON. The event ID and time
+// are set as headers so handlers can deduplicate redeliveries.
+// It fails when detail is not valid JSON.
+func NormalizeEventBridgeEvent(event *events.CloudWatchEvent) (*cloud.Request, error) {
+	if event == nil {
+		return nil, nil
+	}
+
+	req := &cloud.Request{
+		Operation: event.DetailType,
+		Path:      event.Source,
+		Method:    "POST", // Optional
+		Headers: map[string]string{
+			"eventbridge.id":          event.ID,
+			"eventbridge.source":      event.Source,
+			"eventbridge.detail_type": event.DetailType,
+			"eventbridge.time":        event.Time.Format(time.RFC3339),
+			"eventbridge.account":     event.AccountID,
+			"eventbridge.region":      event.Region,
+		},
+	}
+	if len(event.Resources) > 0 {
+		req.Headers["eventbridge.resources"] = strings.Join(event.Resources, ",")
+	}
+
+	// Body as the raw detail JSON
+	if len(event.Detail) > 0 {
+		if !json.Valid(event.Detail) {
+			return nil, fmt.Errorf("eventbridge event %s: detail is not valid JSON", event.ID)
+		}
+		req.Body = []byte(event.Detail)
+	}
+
+	return req, nil
+}
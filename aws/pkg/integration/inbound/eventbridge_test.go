@@ -0,0 +1,59 @@
+package inbound
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func TestNormalizeEventBridgeEvent(t *testing.T) {
+	event := &events.CloudWatchEvent{
+		ID:         "evt-123",
+		DetailType: "OrderPlaced",
+		Source:     "com.example.orders",
+		AccountID:  "123456789012",
+		Time:       time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Region:     "us-east-1",
+		Resources:  []string{"arn:aws:events:us-east-1:123456789012:rule/a", "arn:aws:events:us-east-1:123456789012:rule/b"},
+		Detail:     json.RawMessage(`{"order_id":"o-1"}`),
+	}
+
+	req, err := NormalizeEventBridgeEvent(event)
+	if err != nil {
+		t.Fatalf("NormalizeEventBridgeEvent() error = %v", err)
+	}
+
+	if req.Operation != "OrderPlaced" || req.Path != "com.example.orders" {
+		t.Errorf("NormalizeEventBridgeEvent() operation = %q, path = %q", req.Operation, req.Path)
+	}
+	if string(req.Body) != `{"order_id":"o-1"}` {
+		t.Errorf("NormalizeEventBridgeEvent() body = %s", req.Body)
+	}
+	want := map[string]string{
+		"eventbridge.id":        "evt-123",
+		"eventbridge.source":    "com.example.orders",
+		"eventbridge.time":      "2024-05-01T12:00:00Z",
+		"eventbridge.resources": "arn:aws:events:us-east-1:123456789012:rule/a,arn:aws:events:us-east-1:123456789012:rule/b",
+	}
+	for k, v := range want {
+		if req.Headers[k] != v {
+			t.Errorf("NormalizeEventBridgeEvent() header %s = %q, want %q", k, req.Headers[k], v)
+		}
+	}
+}
+
+func TestNormalizeEventBridgeEvent_InvalidDetail(t *testing.T) {
+	_, err := NormalizeEventBridgeEvent(&events.CloudWatchEvent{ID: "evt-1", Detail: json.RawMessage(`{"broken"`)})
+	if err == nil {
+		t.Error("NormalizeEventBridgeEvent() with invalid detail should fail")
+	}
+}
+
+func TestNormalizeEventBridgeEvent_Nil(t *testing.T) {
+	req, err := NormalizeEventBridgeEvent(nil)
+	if req != nil || err != nil {
+		t.Errorf("NormalizeEventBridgeEvent(nil) = %v, %v", req, err)
+	}
+}
@@ -0,0 +1,83 @@
+package inbound
+
+import (
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+)
+
+// KinesisOption configures NormalizeKinesisEvent
+type KinesisOption func(*kinesisOptions)
+
+type kinesisOptions struct {
+	decode func([]byte) ([]byte, error)
+}
+
+// WithKinesisDecoder applies decode to each record's data, for producers that encode the
+// payload themselves (e.g. gzip from CloudWatch Logs subscriptions, or base64 text).
+// The transport base64 layer is already removed by the events package.
+func WithKinesisDecoder(decode func([]byte) ([]byte, error)) KinesisOption {
+	return func(o *kinesisOptions) {
+		o.decode = decode
+	}
+}
+
+// NormalizeKinesisEvent converts a Kinesis Lambda event to one Request per record, with
+// the record data as Body and the partition key and sequence number as headers.
+// Records that fail WithKinesisDecoder are left out and reported in a *BatchDecodeError
+// returned alongside the requests of the other records.
+func NormalizeKinesisEvent(event *events.KinesisEvent, opts ...KinesisOption) ([]*cloud.Request, error) {
+	if event == nil {
+		return nil, nil
+	}
+
+	var options kinesisOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	requests := make([]*cloud.Request, 0, len(event.Records))
+	var failed []RecordError
+
+	for _, record := range event.Records {
+		data := record.Kinesis.Data
+		if options.decode != nil && len(data) > 0 {
+			decoded, err := options.decode(data)
+			if err != nil {
+				failed = append(failed, RecordError{
+					ID:             record.EventID,
+					SequenceNumber: record.Kinesis.SequenceNumber,
+					Err:            err,
+				})
+				continue
+			}
+			data = decoded
+		}
+
+		req := &cloud.Request{
+			Operation: "kinesis.receive",
+			Path:      record.EventSourceArn,
+			Method:    "POST", // Optional
+			Headers: map[string]string{
+				"kinesis.event_id":                      record.EventID,
+				"kinesis.event_source_arn":              record.EventSourceArn,
+				"kinesis.partition_key":                 record.Kinesis.PartitionKey,
+				"kinesis.sequence_number":               record.Kinesis.SequenceNumber,
+				"kinesis.approximate_arrival_timestamp": record.Kinesis.ApproximateArrivalTimestamp.Format(time.RFC3339),
+			},
+		}
+
+		// Body as raw bytes
+		if len(data) > 0 {
+			req.Body = data
+		}
+
+		requests = append(requests, req)
+	}
+
+	if len(failed) > 0 {
+		return requests, &BatchDecodeError{Source: "kinesis", Records: failed}
+	}
+	return requests, nil
+}
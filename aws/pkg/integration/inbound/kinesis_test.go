@@ -0,0 +1,79 @@
+package inbound
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func kinesisRecord(seq, partitionKey string, data []byte) events.KinesisEventRecord {
+	return events.KinesisEventRecord{
+		EventID:        "shardId-000:" + seq,
+		EventSourceArn: "arn:aws:kinesis:us-east-1:123:stream/orders",
+		Kinesis: events.KinesisRecord{
+			Data:                        data,
+			PartitionKey:                partitionKey,
+			SequenceNumber:              seq,
+			ApproximateArrivalTimestamp: events.SecondsEpochTime{Time: time.Unix(1714564800, 0)},
+		},
+	}
+}
+
+func TestNormalizeKinesisEvent(t *testing.T) {
+	event := &events.KinesisEvent{Records: []events.KinesisEventRecord{
+		kinesisRecord("1", "order-1", []byte(`{"id":1}`)),
+		kinesisRecord("2", "order-2", []byte(`{"id":2}`)),
+	}}
+
+	requests, err := NormalizeKinesisEvent(event)
+	if err != nil {
+		t.Fatalf("NormalizeKinesisEvent() error = %v", err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("NormalizeKinesisEvent() len = %d, want 2", len(requests))
+	}
+	req := requests[1]
+	if req.Operation != "kinesis.receive" || string(req.Body) != `{"id":2}` {
+		t.Errorf("NormalizeKinesisEvent() operation = %q, body = %s", req.Operation, req.Body)
+	}
+	if req.Headers["kinesis.partition_key"] != "order-2" || req.Headers["kinesis.sequence_number"] != "2" {
+		t.Errorf("NormalizeKinesisEvent() headers = %v", req.Headers)
+	}
+}
+
+func TestNormalizeKinesisEvent_DecodeFailuresCollected(t *testing.T) {
+	event := &events.KinesisEvent{Records: []events.KinesisEventRecord{
+		kinesisRecord("1", "a", []byte(base64.StdEncoding.EncodeToString([]byte("first")))),
+		kinesisRecord("2", "b", []byte("%%% not base64")),
+		kinesisRecord("3", "c", []byte(base64.StdEncoding.EncodeToString([]byte("third")))),
+	}}
+
+	requests, err := NormalizeKinesisEvent(event, WithKinesisDecoder(func(data []byte) ([]byte, error) {
+		return base64.StdEncoding.AppendDecode(nil, data)
+	}))
+
+	var decodeErr *BatchDecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("NormalizeKinesisEvent() error = %v, want *BatchDecodeError", err)
+	}
+	if len(decodeErr.Records) != 1 || decodeErr.Source != "kinesis" || decodeErr.Records[0].SequenceNumber != "2" {
+		t.Errorf("NormalizeKinesisEvent() failed records = %+v", decodeErr.Records)
+	}
+	var corrupt base64.CorruptInputError
+	if !errors.As(err, &corrupt) {
+		t.Errorf("NormalizeKinesisEvent() error does not wrap the decoder error: %v", err)
+	}
+	if len(requests) != 2 || string(requests[0].Body) != "first" || string(requests[1].Body) != "third" {
+		t.Errorf("NormalizeKinesisEvent() requests = %v", requests)
+	}
+}
+
+func TestNormalizeKinesisEvent_Nil(t *testing.T) {
+	requests, err := NormalizeKinesisEvent(nil)
+	if requests != nil || err != nil {
+		t.Errorf("NormalizeKinesisEvent(nil) = %v, %v", requests, err)
+	}
+}
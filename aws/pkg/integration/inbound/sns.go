@@ -1,17 +1,46 @@
 package inbound
 
 import (
+	"fmt"
+
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/skolldire/go-engine/pkg/integration/cloud"
 )
 
+// SNSOption configures NormalizeSNSEvent
+type SNSOption func(*snsOptions)
+
+type snsOptions struct {
+	unwrapForwarded bool
+}
+
+// WithForwardedSNSEnvelope unwraps a Message that is itself an SNS notification envelope,
+// as when a notification is forwarded to another topic: the body becomes the inner
+// Message and the inner attributes are merged under the record's own. Any other Message
+// is used as-is.
+func WithForwardedSNSEnvelope() SNSOption {
+	return func(o *snsOptions) {
+		o.unwrapForwarded = true
+	}
+}
+
 // NormalizeSNSEvent converts SNS Lambda event to normalized Request(s)
-func NormalizeSNSEvent(event *events.SNSEvent) ([]*cloud.Request, error) {
+// The Message is the request body and its MessageAttributes values are stored as JSON in
+// the sns.message_attributes header (Binary attributes stay base64). With
+// WithForwardedSNSEnvelope, records whose Message is a malformed SNS envelope are left out
+// and reported in a *BatchDecodeError returned alongside the requests of the other records.
+func NormalizeSNSEvent(event *events.SNSEvent, opts ...SNSOption) ([]*cloud.Request, error) {
 	if event == nil {
 		return nil, nil
 	}
 
+	var options snsOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	requests := make([]*cloud.Request, 0, len(event.Records))
+	var failed []RecordError
 
 	for _, record := range event.Records {
 		req := &cloud.Request{
@@ -32,8 +61,44 @@ func NormalizeSNSEvent(event *events.SNSEvent) ([]*cloud.Request, error) {
 			req.Body = []byte(record.SNS.Message)
 		}
 
+		attrs := make(map[string]string)
+		if options.unwrapForwarded && len(req.Body) > 0 {
+			body, envelopeAttrs, envelope, err := unwrapSNSEnvelope(req.Body)
+			if err != nil {
+				failed = append(failed, RecordError{ID: record.SNS.MessageID, Err: err})
+				continue
+			}
+			if envelope != nil {
+				req.Body = body
+				for k, v := range envelopeAttrs {
+					attrs[k] = v
+				}
+			}
+		}
+
+		for k, v := range record.SNS.MessageAttributes {
+			attrs[k] = snsAttributeValue(v)
+		}
+		if len(attrs) > 0 {
+			req.Headers["sns.message_attributes"] = serializeAttrs(attrs)
+		}
+
 		requests = append(requests, req)
 	}
 
+	if len(failed) > 0 {
+		return requests, &BatchDecodeError{Source: "sns", Records: failed}
+	}
 	return requests, nil
 }
+
+// snsAttributeValue extracts the Value of a Lambda SNS message attribute, which
+// aws-lambda-go decodes as a {"Type": ..., "Value": ...} map
+func snsAttributeValue(attr interface{}) string {
+	if m, ok := attr.(map[string]interface{}); ok {
+		if v, ok := m["Value"].(string); ok {
+			return v
+		}
+	}
+	return fmt.Sprint(attr)
+}
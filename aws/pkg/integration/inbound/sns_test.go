@@ -1,6 +1,7 @@
 package inbound
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -57,3 +58,91 @@ func TestNormalizeSNSEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeSNSEvent_MessageAttributes(t *testing.T) {
+	event := &events.SNSEvent{Records: []events.SNSEventRecord{{SNS: events.SNSEntity{
+		MessageID: "msg-1",
+		Message:   `{"id":1}`,
+		TopicArn:  "arn:aws:sns:us-east-1:123:orders",
+		Subject:   "order created",
+		MessageAttributes: map[string]interface{}{
+			"tenant":    map[string]interface{}{"Type": "String", "Value": "acme"},
+			"signature": map[string]interface{}{"Type": "Binary", "Value": "3q0="},
+		},
+	}}}}
+
+	requests, err := NormalizeSNSEvent(event)
+	if err != nil {
+		t.Fatalf("NormalizeSNSEvent() error = %v", err)
+	}
+	req := requests[0]
+	if string(req.Body) != `{"id":1}` || req.Headers["sns.subject"] != "order created" {
+		t.Errorf("NormalizeSNSEvent() body = %s, headers = %v", req.Body, req.Headers)
+	}
+	if got := req.Headers["sns.message_attributes"]; got != `{"signature":"3q0=","tenant":"acme"}` {
+		t.Errorf("NormalizeSNSEvent() sns.message_attributes = %s", got)
+	}
+}
+
+func TestNormalizeSNSEvent_UnwrapsForwardedEnvelope(t *testing.T) {
+	envelope := `{"Type":"Notification","MessageId":"inner-1","TopicArn":"arn:aws:sns:us-east-1:123:upstream",` +
+		`"Message":"{\"id\":2}","MessageAttributes":{"tenant":{"Type":"String","Value":"inner"},"source":{"Type":"String","Value":"upstream"}}}`
+	event := &events.SNSEvent{Records: []events.SNSEventRecord{{SNS: events.SNSEntity{
+		MessageID: "outer-1",
+		Message:   envelope,
+		TopicArn:  "arn:aws:sns:us-east-1:123:orders",
+		MessageAttributes: map[string]interface{}{
+			"tenant": map[string]interface{}{"Type": "String", "Value": "outer"},
+		},
+	}}}}
+
+	requests, err := NormalizeSNSEvent(event, WithForwardedSNSEnvelope())
+	if err != nil {
+		t.Fatalf("NormalizeSNSEvent() error = %v", err)
+	}
+	req := requests[0]
+	if string(req.Body) != `{"id":2}` {
+		t.Errorf("NormalizeSNSEvent() body = %s, want the inner message", req.Body)
+	}
+	if req.Headers["sns.message_id"] != "outer-1" {
+		t.Errorf("NormalizeSNSEvent() sns.message_id = %s, want outer-1", req.Headers["sns.message_id"])
+	}
+	if got := req.Headers["sns.message_attributes"]; got != `{"source":"upstream","tenant":"outer"}` {
+		t.Errorf("NormalizeSNSEvent() sns.message_attributes = %s", got)
+	}
+}
+
+func TestNormalizeSNSEvent_KeepsForwardedEnvelopeByDefault(t *testing.T) {
+	envelope := `{"Type":"Notification","MessageId":"inner-1","TopicArn":"arn:aws:sns:us-east-1:123:upstream","Message":"{\"id\":2}"}`
+	event := &events.SNSEvent{Records: []events.SNSEventRecord{{SNS: events.SNSEntity{MessageID: "outer-1", Message: envelope}}}}
+
+	requests, err := NormalizeSNSEvent(event)
+	if err != nil {
+		t.Fatalf("NormalizeSNSEvent() error = %v", err)
+	}
+	if string(requests[0].Body) != envelope {
+		t.Errorf("NormalizeSNSEvent() body = %s, want the message unchanged", requests[0].Body)
+	}
+}
+
+func TestNormalizeSNSEvent_MalformedEnvelope(t *testing.T) {
+	event := &events.SNSEvent{Records: []events.SNSEventRecord{
+		{SNS: events.SNSEntity{
+			MessageID: "msg-1",
+			Message:   `{"Type":"Notification","TopicArn":"arn:aws:sns:us-east-1:123:t","Message":42}`,
+		}},
+		{SNS: events.SNSEntity{MessageID: "msg-2", Message: `{"id":2}`}},
+	}}
+
+	requests, err := NormalizeSNSEvent(event, WithForwardedSNSEnvelope())
+	var decodeErr *BatchDecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("NormalizeSNSEvent() error = %v, want *BatchDecodeError", err)
+	}
+	if len(decodeErr.Records) != 1 || decodeErr.Source != "sns" || decodeErr.Records[0].ID != "msg-1" {
+		t.Errorf("NormalizeSNSEvent() failed records = %+v, want msg-1", decodeErr.Records)
+	}
+	if len(requests) != 1 || requests[0].Headers["sns.message_id"] != "msg-2" {
+		t.Errorf("NormalizeSNSEvent() requests = %d, want the well-formed msg-2", len(requests))
+	}
+}
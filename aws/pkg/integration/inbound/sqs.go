@@ -8,13 +8,40 @@ import (
 	"github.com/skolldire/go-engine/pkg/integration/cloud"
 )
 
+// SQSOption configures NormalizeSQSEvent
+type SQSOption func(*sqsOptions)
+
+type sqsOptions struct {
+	unwrapSNS bool
+}
+
+// WithSNSEnvelope unwraps bodies delivered by an SNS subscription without raw message
+// delivery: the request body becomes the inner Message, the envelope's MessageAttributes
+// are merged into sqs.message_attributes (SQS attributes win) and sns.message_id /
+// sns.topic_arn headers are set. Bodies that are not SNS notifications are kept as-is.
+func WithSNSEnvelope() SQSOption {
+	return func(o *sqsOptions) {
+		o.unwrapSNS = true
+	}
+}
+
 // NormalizeSQSEvent converts SQS Lambda event to normalized Request(s)
-func NormalizeSQSEvent(event *events.SQSEvent) ([]*cloud.Request, error) {
+// Each request keeps its MessageId in the sqs.message_id header (see SQSMessageID), so
+// failed requests can be reported with NewSQSBatchResponse.
+// With WithSNSEnvelope, records whose body is a malformed SNS envelope are left out and
+// reported in a *BatchDecodeError returned alongside the requests of the other records.
+func NormalizeSQSEvent(event *events.SQSEvent, opts ...SQSOption) ([]*cloud.Request, error) {
 	if event == nil {
 		return nil, nil
 	}
 
+	var options sqsOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	requests := make([]*cloud.Request, 0, len(event.Records))
+	var failed []RecordError
 
 	for _, record := range event.Records {
 		req := &cloud.Request{
@@ -34,9 +61,25 @@ func NormalizeSQSEvent(event *events.SQSEvent) ([]*cloud.Request, error) {
 			req.Body = []byte(record.Body)
 		}
 
+		attrs := make(map[string]string)
+		if options.unwrapSNS && len(req.Body) > 0 {
+			body, envelopeAttrs, envelope, err := unwrapSNSEnvelope(req.Body)
+			if err != nil {
+				failed = append(failed, RecordError{ID: record.MessageId, Err: err})
+				continue
+			}
+			if envelope != nil {
+				req.Body = body
+				req.Headers["sns.message_id"] = envelope.MessageID
+				req.Headers["sns.topic_arn"] = envelope.TopicArn
+				for k, v := range envelopeAttrs {
+					attrs[k] = v
+				}
+			}
+		}
+
 		// Handle message attributes
 		if len(record.MessageAttributes) > 0 {
-			attrs := make(map[string]string)
 			for k, v := range record.MessageAttributes {
 				if v.StringValue != nil {
 					attrs[k] = *v.StringValue
@@ -45,18 +88,36 @@ func NormalizeSQSEvent(event *events.SQSEvent) ([]*cloud.Request, error) {
 					attrs[k] = base64.StdEncoding.EncodeToString(v.BinaryValue)
 				}
 			}
-			// Store as JSON string in headers
-			if len(attrs) > 0 {
-				req.Headers["sqs.message_attributes"] = serializeAttrs(attrs)
-			}
+		}
+		// Store as JSON string in headers
+		if len(attrs) > 0 {
+			req.Headers["sqs.message_attributes"] = serializeAttrs(attrs)
 		}
 
 		requests = append(requests, req)
 	}
 
+	if len(failed) > 0 {
+		return requests, &BatchDecodeError{Source: "sqs", Records: failed}
+	}
 	return requests, nil
 }
 
+// SQSMessageID returns the SQS MessageId of a request built by NormalizeSQSEvent, or ""
+//
+//	for _, req := range requests {
+//		if err := handle(ctx, req); err != nil {
+//			failed = append(failed, inbound.SQSMessageID(req))
+//		}
+//	}
+//	return inbound.NewSQSBatchResponse(failed), nil
+func SQSMessageID(req *cloud.Request) string {
+	if req == nil {
+		return ""
+	}
+	return req.Headers["sqs.message_id"]
+}
+
 // serializeAttrs converts map to JSON string
 func serializeAttrs(attrs map[string]string) string {
 	jsonBytes, err := json.Marshal(attrs)
@@ -117,3 +117,30 @@ func TestNormalizeSQSEvent_Body(t *testing.T) {
 		t.Errorf("Body = %v, want {\"key\":\"value\"}", string(requests[0].Body))
 	}
 }
+
+func TestSQSMessageID_MapsFailuresToBatchResponse(t *testing.T) {
+	event := &events.SQSEvent{Records: []events.SQSMessage{
+		{MessageId: "msg-1", Body: "ok"},
+		{MessageId: "msg-2", Body: "fail"},
+	}}
+
+	requests, err := NormalizeSQSEvent(event)
+	if err != nil {
+		t.Fatalf("NormalizeSQSEvent() error = %v", err)
+	}
+
+	var failed []string
+	for _, req := range requests {
+		if string(req.Body) == "fail" {
+			failed = append(failed, SQSMessageID(req))
+		}
+	}
+	resp := NewSQSBatchResponse(failed)
+
+	if len(resp.BatchItemFailures) != 1 || resp.BatchItemFailures[0].ItemIdentifier != "msg-2" {
+		t.Errorf("NewSQSBatchResponse() = %+v, want msg-2", resp.BatchItemFailures)
+	}
+	if SQSMessageID(nil) != "" {
+		t.Error("SQSMessageID(nil) should be empty")
+	}
+}
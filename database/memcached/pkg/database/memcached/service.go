@@ -11,6 +11,8 @@ import (
 )
 
 func NewClient(cfg Config, log logger.Service) (Service, error) {
+	log = logger.OrNoop(log)
+
 	if len(cfg.Servers) == 0 {
 		return nil, ErrConnection
 	}
@@ -34,6 +36,7 @@ func NewClient(cfg Config, log logger.Service) (Service, error) {
 		WithResilience: cfg.WithResilience,
 		Resilience:     cfg.Resilience,
 		Timeout:        timeout,
+		SlowThreshold:  cfg.SlowThreshold,
 	}
 
 	c := &MemcachedClient{
@@ -60,7 +63,7 @@ func NewClient(cfg Config, log logger.Service) (Service, error) {
 }
 
 func (c *MemcachedClient) Ping(ctx context.Context) error {
-	_, err := c.Execute(ctx, "Ping", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "Ping", func(context.Context) (interface{}, error) {
 		return nil, c.client.Ping()
 	})
 	return err
@@ -69,7 +72,7 @@ func (c *MemcachedClient) Ping(ctx context.Context) error {
 func (c *MemcachedClient) Get(ctx context.Context, key string) ([]byte, error) {
 	fullKey := c.KeyName(key)
 
-	result, err := c.Execute(ctx, "Get", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "Get", func(context.Context) (interface{}, error) {
 		item, err := c.client.Get(fullKey)
 		if err != nil {
 			if err == memcache.ErrCacheMiss {
@@ -98,7 +101,7 @@ func (c *MemcachedClient) Set(ctx context.Context, key string, value []byte, exp
 		expiration = DefaultExpiration
 	}
 
-	_, err := c.Execute(ctx, "Set", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "Set", func(context.Context) (interface{}, error) {
 		return nil, c.client.Set(&memcache.Item{
 			Key:        fullKey,
 			Value:      value,
@@ -112,7 +115,7 @@ func (c *MemcachedClient) Set(ctx context.Context, key string, value []byte, exp
 func (c *MemcachedClient) Delete(ctx context.Context, key string) error {
 	fullKey := c.KeyName(key)
 
-	_, err := c.Execute(ctx, "Delete", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "Delete", func(context.Context) (interface{}, error) {
 		return nil, c.client.Delete(fullKey)
 	})
 
@@ -126,7 +129,7 @@ func (c *MemcachedClient) Add(ctx context.Context, key string, value []byte, exp
 		expiration = DefaultExpiration
 	}
 
-	_, err := c.Execute(ctx, "Add", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "Add", func(context.Context) (interface{}, error) {
 		return nil, c.client.Add(&memcache.Item{
 			Key:        fullKey,
 			Value:      value,
@@ -144,7 +147,7 @@ func (c *MemcachedClient) Replace(ctx context.Context, key string, value []byte,
 		expiration = DefaultExpiration
 	}
 
-	_, err := c.Execute(ctx, "Replace", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "Replace", func(context.Context) (interface{}, error) {
 		return nil, c.client.Replace(&memcache.Item{
 			Key:        fullKey,
 			Value:      value,
@@ -158,7 +161,7 @@ func (c *MemcachedClient) Replace(ctx context.Context, key string, value []byte,
 func (c *MemcachedClient) Increment(ctx context.Context, key string, delta uint64) (uint64, error) {
 	fullKey := c.KeyName(key)
 
-	result, err := c.Execute(ctx, "Increment", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "Increment", func(context.Context) (interface{}, error) {
 		return c.client.Increment(fullKey, delta)
 	})
 
@@ -176,7 +179,7 @@ func (c *MemcachedClient) Increment(ctx context.Context, key string, delta uint6
 func (c *MemcachedClient) Decrement(ctx context.Context, key string, delta uint64) (uint64, error) {
 	fullKey := c.KeyName(key)
 
-	result, err := c.Execute(ctx, "Decrement", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "Decrement", func(context.Context) (interface{}, error) {
 		return c.client.Decrement(fullKey, delta)
 	})
 
@@ -201,7 +204,7 @@ func (c *MemcachedClient) GetMulti(ctx context.Context, keys []string) (map[stri
 		fullKeys[i] = c.KeyName(key)
 	}
 
-	result, err := c.Execute(ctx, "GetMulti", func() (interface{}, error) {
+	result, err := c.ExecuteContext(ctx, "GetMulti", func(context.Context) (interface{}, error) {
 		items, err := c.client.GetMulti(fullKeys)
 		if err != nil {
 			return nil, err
@@ -227,7 +230,7 @@ func (c *MemcachedClient) GetMulti(ctx context.Context, keys []string) (map[stri
 }
 
 func (c *MemcachedClient) FlushAll(ctx context.Context) error {
-	_, err := c.Execute(ctx, "FlushAll", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "FlushAll", func(context.Context) (interface{}, error) {
 		return nil, c.client.FlushAll()
 	})
 
@@ -12,6 +12,8 @@ import (
 )
 
 func NewClient(ctx context.Context, cfg Config, log logger.Service) (Service, error) {
+	log = logger.OrNoop(log)
+
 	if cfg.URI == "" {
 		return nil, fmt.Errorf("%w: URI is required", ErrConnection)
 	}
@@ -55,6 +57,7 @@ func NewClient(ctx context.Context, cfg Config, log logger.Service) (Service, er
 		WithResilience: cfg.WithResilience,
 		Resilience:     cfg.Resilience,
 		Timeout:        timeout,
+		SlowThreshold:  cfg.SlowThreshold,
 	}
 
 	c := &MongoDBClient{
@@ -90,14 +93,14 @@ func (c *MongoDBClient) GetCollection(name string) *mongo.Collection {
 }
 
 func (c *MongoDBClient) Ping(ctx context.Context) error {
-	_, err := c.Execute(ctx, "Ping", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "Ping", func(ctx context.Context) (interface{}, error) {
 		return nil, c.client.Ping(ctx, nil)
 	})
 	return err
 }
 
 func (c *MongoDBClient) Disconnect(ctx context.Context) error {
-	_, err := c.Execute(ctx, "Disconnect", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "Disconnect", func(ctx context.Context) (interface{}, error) {
 		return nil, c.client.Disconnect(ctx)
 	})
 	return err
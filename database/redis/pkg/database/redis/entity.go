@@ -1,10 +1,14 @@
 package redis
 
 import (
+	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/skolldire/go-engine/pkg/core/client"
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
 	"github.com/skolldire/go-engine/pkg/utilities/resilience"
 )
@@ -25,6 +29,9 @@ var (
 )
 
 type Config struct {
+	// Enabled set to false keeps the block in config but skips building and
+	// validating the client; nil (omitted) means enabled
+	Enabled        *bool             `mapstructure:"enabled" json:"enabled,omitempty"`
 	Host           string            `mapstructure:"host" json:"host"`
 	Port           int               `mapstructure:"port" json:"port"`
 	DB             int               `mapstructure:"db" json:"db"`
@@ -38,12 +45,76 @@ type Config struct {
 	EnableLogging  bool              `mapstructure:"enable_logging" json:"enable_logging"`
 	WithResilience bool              `mapstructure:"with_resilience" json:"with_resilience"`
 	Resilience     resilience.Config `mapstructure:"resilience" json:"resilience"`
+	SlowThreshold  time.Duration     `mapstructure:"slow_threshold" json:"slow_threshold"`
+	// TLS enables TLS with the system root CAs, as managed Redis with in-transit encryption
+	// requires; setting any TLSConfig path enables it too
+	TLS bool `mapstructure:"tls" json:"tls"`
+	// TLSConfig adds a custom CA (CACertPath) or a client certificate for mutual TLS
+	client.TLSConfig `mapstructure:",squash"`
+	// TenantExtractor opts into per-tenant key namespacing; see RedisClient.KeyNameContext
+	TenantExtractor TenantExtractor `mapstructure:"-" json:"-"`
+}
+
+// IsEnabled reports whether the client should be built; true unless enabled: false
+func (c Config) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// Options builds the go-redis options for c; call it on c.WithDefaults(). NewClient and
+// the startup connectivity probe share it, so both connect the same way.
+func (c Config) Options() (*redis.Options, error) {
+	options := &redis.Options{
+		Addr:         fmt.Sprintf("%s:%d", c.Host, c.Port),
+		DB:           c.DB,
+		Password:     c.Password,
+		DialTimeout:  c.DialTimeout,
+		ReadTimeout:  c.ReadTimeout,
+		WriteTimeout: c.WriteTimeout,
+		PoolSize:     c.PoolSize,
+	}
+
+	tlsConfig, err := c.TLSConfig.Load()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil && c.TLS {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	options.TLSConfig = tlsConfig
+	return options, nil
+}
+
+// TenantExtractor returns the tenant ID carried by ctx, or "" when there is none
+type TenantExtractor func(ctx context.Context) string
+
+// WithDefaults returns a copy of c with zero-valued fields set to their defaults:
+// Timeout (DefaultTimeout), DialTimeout (DefaultDialTimeout), ReadTimeout
+// (DefaultReadTimeout), WriteTimeout (DefaultWriteTimeout) and PoolSize (DefaultPoolSize).
+func (c Config) WithDefaults() Config {
+	if c.Timeout == 0 {
+		c.Timeout = DefaultTimeout
+	}
+	if c.DialTimeout == 0 {
+		c.DialTimeout = DefaultDialTimeout
+	}
+	if c.ReadTimeout == 0 {
+		c.ReadTimeout = DefaultReadTimeout
+	}
+	if c.WriteTimeout == 0 {
+		c.WriteTimeout = DefaultWriteTimeout
+	}
+	if c.PoolSize == 0 {
+		c.PoolSize = DefaultPoolSize
+	}
+	return c
 }
 
 type RedisClient struct {
-	client     *redis.Client
-	logger     logger.Service
-	logging    bool
-	resilience *resilience.Service
-	keyPrefix  string
+	client          *redis.Client
+	logger          logger.Service
+	logging         bool
+	resilience      *resilience.Service
+	keyPrefix       string
+	tenantExtractor TenantExtractor
+	slowThreshold   time.Duration
 }
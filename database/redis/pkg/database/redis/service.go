@@ -7,56 +7,30 @@ import (
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/skolldire/go-engine/pkg/core/client"
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
 	"github.com/skolldire/go-engine/pkg/utilities/resilience"
 )
 
 func NewClient(cfg Config, log logger.Service) (*RedisClient, error) {
-	timeoutDuration := cfg.Timeout
-	if timeoutDuration == 0 {
-		timeoutDuration = DefaultTimeout
-	}
-
-	dialTimeout := cfg.DialTimeout
-	if dialTimeout == 0 {
-		dialTimeout = DefaultDialTimeout
-	}
-
-	readTimeout := cfg.ReadTimeout
-	if readTimeout == 0 {
-		readTimeout = DefaultReadTimeout
-	}
-
-	writeTimeout := cfg.WriteTimeout
-	if writeTimeout == 0 {
-		writeTimeout = DefaultWriteTimeout
-	}
+	log = logger.OrNoop(log)
 
-	poolSize := cfg.PoolSize
-	if poolSize == 0 {
-		poolSize = DefaultPoolSize
-	}
+	cfg = cfg.WithDefaults()
 
-	options := &redis.Options{
-		Addr:         fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
-		DB:           cfg.DB,
-		DialTimeout:  dialTimeout,
-		ReadTimeout:  readTimeout,
-		WriteTimeout: writeTimeout,
-		PoolSize:     poolSize,
-	}
-
-	if cfg.Password != "" {
-		options.Password = cfg.Password
+	options, err := cfg.Options()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrConnection, err)
 	}
 
 	client := redis.NewClient(options)
 
 	rc := &RedisClient{
-		client:    client,
-		logger:    log,
-		logging:   cfg.EnableLogging,
-		keyPrefix: cfg.Prefix,
+		client:          client,
+		logger:          log,
+		logging:         cfg.EnableLogging,
+		keyPrefix:       cfg.Prefix,
+		tenantExtractor: cfg.TenantExtractor,
+		slowThreshold:   cfg.SlowThreshold,
 	}
 
 	if cfg.WithResilience {
@@ -64,7 +38,7 @@ func NewClient(cfg Config, log logger.Service) (*RedisClient, error) {
 		rc.resilience = resilienceService
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
 	defer cancel()
 
 	if err := rc.Ping(ctx); err != nil {
@@ -76,10 +50,10 @@ func NewClient(cfg Config, log logger.Service) (*RedisClient, error) {
 			map[string]interface{}{
 				"host":          cfg.Host,
 				"port":          cfg.Port,
-				"dial_timeout":  dialTimeout,
-				"read_timeout":  readTimeout,
-				"write_timeout": writeTimeout,
-				"pool_size":     poolSize,
+				"dial_timeout":  cfg.DialTimeout,
+				"read_timeout":  cfg.ReadTimeout,
+				"write_timeout": cfg.WriteTimeout,
+				"pool_size":     cfg.PoolSize,
 			})
 	}
 
@@ -93,6 +67,20 @@ func (rc *RedisClient) KeyName(key string) string {
 	return fmt.Sprintf("%s:%s", rc.keyPrefix, key)
 }
 
+// KeyNameContext is KeyName with the tenant ID from ctx inserted after the prefix
+// (e.g. "app:tenant123:key") when Config.TenantExtractor is set. Without an
+// extractor, or when ctx carries no tenant, it returns KeyName(key).
+func (rc *RedisClient) KeyNameContext(ctx context.Context, key string) string {
+	if rc.tenantExtractor == nil {
+		return rc.KeyName(key)
+	}
+	tenant := rc.tenantExtractor(ctx)
+	if tenant == "" {
+		return rc.KeyName(key)
+	}
+	return rc.KeyName(fmt.Sprintf("%s:%s", tenant, key))
+}
+
 func (rc *RedisClient) ensureDefaultExpiration(expiration time.Duration) time.Duration {
 	if expiration == 0 {
 		return DefaultExpiration
@@ -113,54 +101,31 @@ func (rc *RedisClient) ensureContextWithTimeout(ctx context.Context) (context.Co
 	return context.WithTimeout(ctx, timeout)
 }
 
-func (rc *RedisClient) execute(ctx context.Context, operationName string, operation func() (interface{}, error)) (interface{}, error) {
+func (rc *RedisClient) execute(ctx context.Context, operationName string, operation client.ContextOperation) (interface{}, error) {
 	ctx, cancel := rc.ensureContextWithTimeout(ctx)
 	defer cancel()
 
-	logFields := map[string]interface{}{"operation": operationName}
-
-	if rc.resilience != nil {
-		if rc.logging {
-			rc.logger.Debug(ctx, fmt.Sprintf("starting Redis operation with resilience: %s", operationName), logFields)
-		}
-
-		result, err := rc.resilience.Execute(ctx, operation)
-
-		if err != nil && rc.logging {
-			rc.logger.Error(ctx, fmt.Errorf("error in Redis operation: %w", err), logFields)
-		} else if rc.logging {
-			rc.logger.Debug(ctx, fmt.Sprintf("Redis operation completed with resilience: %s", operationName), logFields)
-		}
-
-		return result, err
-	}
-
-	if rc.logging {
-		rc.logger.Debug(ctx, fmt.Sprintf("starting Redis operation: %s", operationName), logFields)
-	}
-
-	result, err := operation()
-
-	if err != nil && rc.logging {
-		rc.logger.Error(ctx, err, logFields)
-	} else if rc.logging {
-		rc.logger.Debug(ctx, fmt.Sprintf("Redis operation completed: %s", operationName), logFields)
-	}
-
-	return result, err
+	return client.ExecuteContext(ctx, client.ExecuteOptions{
+		Service:       "Redis",
+		Operation:     operationName,
+		Logger:        rc.logger,
+		Logging:       rc.logging,
+		Resilience:    rc.resilience,
+		SlowThreshold: rc.slowThreshold,
+	}, operation)
 }
 
 func (rc *RedisClient) Ping(ctx context.Context) error {
-	_, err := rc.execute(ctx, "Ping", func() (interface{}, error) {
+	_, err := rc.execute(ctx, "Ping", func(ctx context.Context) (interface{}, error) {
 		return rc.client.Ping(ctx).Result()
 	})
 	return err
 }
 
 func (rc *RedisClient) Get(ctx context.Context, key string) (string, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "Get", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "Get", func(ctx context.Context) (interface{}, error) {
 		return rc.client.Get(ctx, prefixedKey).Result()
 	})
 
@@ -180,10 +145,10 @@ func (rc *RedisClient) Get(ctx context.Context, key string) (string, error) {
 }
 
 func (rc *RedisClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 	expiration = rc.ensureDefaultExpiration(expiration)
 
-	_, err := rc.execute(ctx, "Set", func() (interface{}, error) {
+	_, err := rc.execute(ctx, "Set", func(ctx context.Context) (interface{}, error) {
 		return rc.client.Set(ctx, prefixedKey, value, expiration).Result()
 	})
 
@@ -191,10 +156,10 @@ func (rc *RedisClient) Set(ctx context.Context, key string, value interface{}, e
 }
 
 func (rc *RedisClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 	expiration = rc.ensureDefaultExpiration(expiration)
 
-	result, err := rc.execute(ctx, "SetNX", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "SetNX", func(ctx context.Context) (interface{}, error) {
 		return rc.client.SetNX(ctx, prefixedKey, value, expiration).Result()
 	})
 
@@ -213,10 +178,10 @@ func (rc *RedisClient) SetNX(ctx context.Context, key string, value interface{},
 func (rc *RedisClient) Del(ctx context.Context, keys ...string) (int64, error) {
 	prefixedKeys := make([]string, len(keys))
 	for i, key := range keys {
-		prefixedKeys[i] = rc.KeyName(key)
+		prefixedKeys[i] = rc.KeyNameContext(ctx, key)
 	}
 
-	result, err := rc.execute(ctx, "Del", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "Del", func(ctx context.Context) (interface{}, error) {
 		return rc.client.Del(ctx, prefixedKeys...).Result()
 	})
 
@@ -235,10 +200,10 @@ func (rc *RedisClient) Del(ctx context.Context, keys ...string) (int64, error) {
 func (rc *RedisClient) Exists(ctx context.Context, keys ...string) (int64, error) {
 	prefixedKeys := make([]string, len(keys))
 	for i, key := range keys {
-		prefixedKeys[i] = rc.KeyName(key)
+		prefixedKeys[i] = rc.KeyNameContext(ctx, key)
 	}
 
-	result, err := rc.execute(ctx, "Exists", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "Exists", func(ctx context.Context) (interface{}, error) {
 		return rc.client.Exists(ctx, prefixedKeys...).Result()
 	})
 
@@ -255,10 +220,10 @@ func (rc *RedisClient) Exists(ctx context.Context, keys ...string) (int64, error
 }
 
 func (rc *RedisClient) Expire(ctx context.Context, key string, expiration time.Duration) (bool, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 	expiration = rc.ensureDefaultExpiration(expiration)
 
-	result, err := rc.execute(ctx, "Expire", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "Expire", func(ctx context.Context) (interface{}, error) {
 		return rc.client.Expire(ctx, prefixedKey, expiration).Result()
 	})
 
@@ -275,9 +240,9 @@ func (rc *RedisClient) Expire(ctx context.Context, key string, expiration time.D
 }
 
 func (rc *RedisClient) TTL(ctx context.Context, key string) (time.Duration, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "TTL", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "TTL", func(ctx context.Context) (interface{}, error) {
 		return rc.client.TTL(ctx, prefixedKey).Result()
 	})
 
@@ -294,9 +259,9 @@ func (rc *RedisClient) TTL(ctx context.Context, key string) (time.Duration, erro
 }
 
 func (rc *RedisClient) Incr(ctx context.Context, key string) (int64, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "Incr", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "Incr", func(ctx context.Context) (interface{}, error) {
 		return rc.client.Incr(ctx, prefixedKey).Result()
 	})
 
@@ -313,9 +278,9 @@ func (rc *RedisClient) Incr(ctx context.Context, key string) (int64, error) {
 }
 
 func (rc *RedisClient) IncrBy(ctx context.Context, key string, value int64) (int64, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "IncrBy", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "IncrBy", func(ctx context.Context) (interface{}, error) {
 		return rc.client.IncrBy(ctx, prefixedKey, value).Result()
 	})
 
@@ -332,9 +297,9 @@ func (rc *RedisClient) IncrBy(ctx context.Context, key string, value int64) (int
 }
 
 func (rc *RedisClient) HGet(ctx context.Context, key, field string) (string, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "HGet", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "HGet", func(ctx context.Context) (interface{}, error) {
 		return rc.client.HGet(ctx, prefixedKey, field).Result()
 	})
 
@@ -354,9 +319,9 @@ func (rc *RedisClient) HGet(ctx context.Context, key, field string) (string, err
 }
 
 func (rc *RedisClient) HSet(ctx context.Context, key string, values ...interface{}) (int64, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "HSet", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "HSet", func(ctx context.Context) (interface{}, error) {
 		return rc.client.HSet(ctx, prefixedKey, values...).Result()
 	})
 
@@ -373,9 +338,9 @@ func (rc *RedisClient) HSet(ctx context.Context, key string, values ...interface
 }
 
 func (rc *RedisClient) HGetAll(ctx context.Context, key string) (map[string]string, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "HGetAll", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "HGetAll", func(ctx context.Context) (interface{}, error) {
 		return rc.client.HGetAll(ctx, prefixedKey).Result()
 	})
 
@@ -392,9 +357,9 @@ func (rc *RedisClient) HGetAll(ctx context.Context, key string) (map[string]stri
 }
 
 func (rc *RedisClient) LPush(ctx context.Context, key string, values ...interface{}) (int64, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "LPush", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "LPush", func(ctx context.Context) (interface{}, error) {
 		return rc.client.LPush(ctx, prefixedKey, values...).Result()
 	})
 
@@ -411,9 +376,9 @@ func (rc *RedisClient) LPush(ctx context.Context, key string, values ...interfac
 }
 
 func (rc *RedisClient) RPop(ctx context.Context, key string) (string, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "RPop", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "RPop", func(ctx context.Context) (interface{}, error) {
 		return rc.client.RPop(ctx, prefixedKey).Result()
 	})
 
@@ -433,9 +398,9 @@ func (rc *RedisClient) RPop(ctx context.Context, key string) (string, error) {
 }
 
 func (rc *RedisClient) LRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "LRange", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "LRange", func(ctx context.Context) (interface{}, error) {
 		return rc.client.LRange(ctx, prefixedKey, start, stop).Result()
 	})
 
@@ -468,14 +433,14 @@ func (rc *RedisClient) Client() *redis.Client {
 }
 
 func (rc *RedisClient) ZAdd(ctx context.Context, key string, score float64, member interface{}) (int64, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
 	z := redis.Z{
 		Score:  score,
 		Member: member,
 	}
 
-	result, err := rc.execute(ctx, "ZAdd", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "ZAdd", func(ctx context.Context) (interface{}, error) {
 		return rc.client.ZAdd(ctx, prefixedKey, z).Result()
 	})
 
@@ -492,9 +457,9 @@ func (rc *RedisClient) ZAdd(ctx context.Context, key string, score float64, memb
 }
 
 func (rc *RedisClient) ZAddMulti(ctx context.Context, key string, members ...redis.Z) (int64, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "ZAddMulti", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "ZAddMulti", func(ctx context.Context) (interface{}, error) {
 		return rc.client.ZAdd(ctx, prefixedKey, members...).Result()
 	})
 
@@ -511,9 +476,9 @@ func (rc *RedisClient) ZAddMulti(ctx context.Context, key string, members ...red
 }
 
 func (rc *RedisClient) ZScore(ctx context.Context, key string, member string) (float64, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "ZScore", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "ZScore", func(ctx context.Context) (interface{}, error) {
 		return rc.client.ZScore(ctx, prefixedKey, member).Result()
 	})
 
@@ -533,9 +498,9 @@ func (rc *RedisClient) ZScore(ctx context.Context, key string, member string) (f
 }
 
 func (rc *RedisClient) ZRem(ctx context.Context, key string, members ...interface{}) (int64, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "ZRem", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "ZRem", func(ctx context.Context) (interface{}, error) {
 		return rc.client.ZRem(ctx, prefixedKey, members...).Result()
 	})
 
@@ -552,9 +517,9 @@ func (rc *RedisClient) ZRem(ctx context.Context, key string, members ...interfac
 }
 
 func (rc *RedisClient) ZRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "ZRange", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "ZRange", func(ctx context.Context) (interface{}, error) {
 		return rc.client.ZRange(ctx, prefixedKey, start, stop).Result()
 	})
 
@@ -571,9 +536,9 @@ func (rc *RedisClient) ZRange(ctx context.Context, key string, start, stop int64
 }
 
 func (rc *RedisClient) SAdd(ctx context.Context, key string, members ...interface{}) (int64, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "SAdd", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "SAdd", func(ctx context.Context) (interface{}, error) {
 		return rc.client.SAdd(ctx, prefixedKey, members...).Result()
 	})
 
@@ -590,10 +555,10 @@ func (rc *RedisClient) SAdd(ctx context.Context, key string, members ...interfac
 }
 
 func (rc *RedisClient) SAddWithExpire(ctx context.Context, key string, expiration time.Duration, members ...interface{}) (int64, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 	expiration = rc.ensureDefaultExpiration(expiration)
 
-	count, err := rc.execute(ctx, "SAddWithExpire", func() (interface{}, error) {
+	count, err := rc.execute(ctx, "SAddWithExpire", func(ctx context.Context) (interface{}, error) {
 		count, err := rc.client.SAdd(ctx, prefixedKey, members...).Result()
 		if err != nil {
 			return 0, err
@@ -620,9 +585,9 @@ func (rc *RedisClient) SAddWithExpire(ctx context.Context, key string, expiratio
 }
 
 func (rc *RedisClient) SMembers(ctx context.Context, key string) ([]string, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "SMembers", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "SMembers", func(ctx context.Context) (interface{}, error) {
 		return rc.client.SMembers(ctx, prefixedKey).Result()
 	})
 
@@ -639,9 +604,9 @@ func (rc *RedisClient) SMembers(ctx context.Context, key string) ([]string, erro
 }
 
 func (rc *RedisClient) SIsMember(ctx context.Context, key string, member interface{}) (bool, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "SIsMember", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "SIsMember", func(ctx context.Context) (interface{}, error) {
 		return rc.client.SIsMember(ctx, prefixedKey, member).Result()
 	})
 
@@ -658,9 +623,9 @@ func (rc *RedisClient) SIsMember(ctx context.Context, key string, member interfa
 }
 
 func (rc *RedisClient) SRem(ctx context.Context, key string, members ...interface{}) (int64, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "SRem", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "SRem", func(ctx context.Context) (interface{}, error) {
 		return rc.client.SRem(ctx, prefixedKey, members...).Result()
 	})
 
@@ -677,9 +642,9 @@ func (rc *RedisClient) SRem(ctx context.Context, key string, members ...interfac
 }
 
 func (rc *RedisClient) SCard(ctx context.Context, key string) (int64, error) {
-	prefixedKey := rc.KeyName(key)
+	prefixedKey := rc.KeyNameContext(ctx, key)
 
-	result, err := rc.execute(ctx, "SCard", func() (interface{}, error) {
+	result, err := rc.execute(ctx, "SCard", func(ctx context.Context) (interface{}, error) {
 		return rc.client.SCard(ctx, prefixedKey).Result()
 	})
 
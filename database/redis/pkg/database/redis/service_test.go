@@ -2,6 +2,8 @@ package redis
 
 import (
 	"context"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -60,6 +62,38 @@ func TestNewClient_DefaultValues(t *testing.T) {
 	assert.Contains(t, err.Error(), "connection")
 }
 
+func TestConfig_WithDefaults(t *testing.T) {
+	cfg := Config{Host: "localhost", PoolSize: 50}.WithDefaults()
+
+	assert.Equal(t, "localhost", cfg.Host)
+	assert.Equal(t, DefaultTimeout, cfg.Timeout)
+	assert.Equal(t, DefaultDialTimeout, cfg.DialTimeout)
+	assert.Equal(t, DefaultReadTimeout, cfg.ReadTimeout)
+	assert.Equal(t, DefaultWriteTimeout, cfg.WriteTimeout)
+	assert.Equal(t, 50, cfg.PoolSize, "explicit values are preserved")
+}
+
+func TestConfig_Options(t *testing.T) {
+	cfg := Config{Host: "cache.internal", Port: 6380, Password: "secret", TLS: true}.WithDefaults()
+
+	options, err := cfg.Options()
+	assert.NoError(t, err)
+	assert.Equal(t, "cache.internal:6380", options.Addr)
+	assert.Equal(t, "secret", options.Password)
+	assert.Equal(t, DefaultDialTimeout, options.DialTimeout)
+	assert.Equal(t, DefaultReadTimeout, options.ReadTimeout)
+	assert.NotNil(t, options.TLSConfig, "tls: true enables TLS with the system roots")
+
+	plain, err := Config{Host: "localhost", Port: 6379}.WithDefaults().Options()
+	assert.NoError(t, err)
+	assert.Nil(t, plain.TLSConfig)
+
+	withCA := Config{Host: "localhost", Port: 6379}
+	withCA.CACertPath = "/missing/ca.pem"
+	_, err = withCA.Options()
+	assert.Error(t, err)
+}
+
 func TestNewClient_WithPassword(t *testing.T) {
 	cfg := Config{
 		Host:     "localhost",
@@ -134,6 +168,34 @@ func TestRedisClient_KeyName_NoPrefix(t *testing.T) {
 	assert.Equal(t, "test-key", client.KeyName("test-key"))
 }
 
+type tenantKey struct{}
+
+func tenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+func TestRedisClient_KeyNameContext_WithTenant(t *testing.T) {
+	client := &RedisClient{keyPrefix: "app", tenantExtractor: tenantFromContext}
+	ctx := context.WithValue(context.Background(), tenantKey{}, "tenant123")
+
+	assert.Equal(t, "app:tenant123:key", client.KeyNameContext(ctx, "key"))
+
+	client.keyPrefix = ""
+	assert.Equal(t, "tenant123:key", client.KeyNameContext(ctx, "key"))
+}
+
+func TestRedisClient_KeyNameContext_WithoutTenant(t *testing.T) {
+	tenantCtx := context.WithValue(context.Background(), tenantKey{}, "tenant123")
+
+	withExtractor := &RedisClient{keyPrefix: "app", tenantExtractor: tenantFromContext}
+	assert.Equal(t, "app:key", withExtractor.KeyNameContext(context.Background(), "key"))
+
+	// Opt-in: without an extractor the tenant in ctx is ignored
+	withoutExtractor := &RedisClient{keyPrefix: "app"}
+	assert.Equal(t, "app:key", withoutExtractor.KeyNameContext(tenantCtx, "key"))
+}
+
 func TestRedisClient_EnsureDefaultExpiration(t *testing.T) {
 	log := &mockLogger{}
 	client := &RedisClient{
@@ -582,3 +644,52 @@ func TestRedisClient_Get_WithKeyNotFoundError(t *testing.T) {
 	// In a real scenario with redis.Nil, it should return ErrKeyNotFound
 	// but without connection, we get connection error
 }
+
+func TestRedisClient_Execute_WarnsOnSlowOperation(t *testing.T) {
+	log := &mockLogger{}
+	log.On("Warn", mock.Anything, mock.MatchedBy(func(msg string) bool {
+		return strings.HasPrefix(msg, "slow operation: Get took")
+	}), mock.MatchedBy(func(fields map[string]interface{}) bool {
+		return fields["operation"] == "Get" && fields["slow_threshold_ms"] == int64(5)
+	})).Once()
+
+	client := &RedisClient{
+		logger:        log,
+		slowThreshold: 5 * time.Millisecond,
+		client:        redis.NewClient(&redis.Options{Addr: "localhost:6379"}),
+	}
+
+	_, err := client.execute(context.Background(), "Get", func(context.Context) (interface{}, error) {
+		time.Sleep(20 * time.Millisecond)
+		return "value", nil
+	})
+
+	assert.NoError(t, err)
+	log.AssertExpectations(t)
+}
+
+// BenchmarkRedisClient_Execute compares a logging-enabled client whose logger is at Info
+// (debug fields skipped) with one at Debug. With -benchmem the info case stays at a handful
+// of allocations per op (context timeout only) versus ~100 when debug entries are built.
+func BenchmarkRedisClient_Execute(b *testing.B) {
+	op := func(context.Context) (interface{}, error) { return "OK", nil }
+
+	for _, level := range []string{logger.LevelInfo, logger.LevelDebug} {
+		b.Run("level="+level, func(b *testing.B) {
+			log := logger.NewService(logger.Config{Level: level, OutputWriters: []io.Writer{io.Discard}}, nil)
+			rc := &RedisClient{
+				logger:        log,
+				logging:       true,
+				slowThreshold: time.Second,
+				client:        redis.NewClient(&redis.Options{Addr: "localhost:6379", ReadTimeout: 3 * time.Second}),
+			}
+			ctx := context.Background()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, _ = rc.execute(ctx, "Get", op)
+			}
+		})
+	}
+}
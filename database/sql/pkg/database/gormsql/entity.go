@@ -2,6 +2,7 @@ package gormsql
 
 import (
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
@@ -20,11 +21,21 @@ var (
 	ErrConnection  = errors.New("database connection error")
 	ErrNotFound    = errors.New("record not found")
 	ErrTransaction = errors.New("transaction error")
+	ErrMigration   = errors.New("migration error")
+	ErrReadOnly    = errors.New("write attempted on read-only database client")
+	// ErrDisabled is returned by New for a config with enabled: false; no connection is opened
+	ErrDisabled = errors.New("database client disabled")
+	// ErrTransactionCanceled is returned, joined with ctx.Err(), when the context
+	// ends while Transaction's fn runs; the transaction is rolled back
+	ErrTransactionCanceled = errors.New("transaction rolled back: context done before commit")
 )
 
 // Config holds connection-pool and behaviour settings.
 // The caller is responsible for building the gorm.Dialector (and thus the DSN).
 type Config struct {
+	// Enabled set to false keeps the block in config but makes New return
+	// ErrDisabled without connecting; nil (omitted) means enabled
+	Enabled            *bool             `mapstructure:"enabled" json:"enabled,omitempty"`
 	Type               string            `mapstructure:"type"                json:"type"`
 	MaxIdleConnections int               `mapstructure:"max_idle_connections" json:"max_idle_connections"`
 	MaxOpenConnections int               `mapstructure:"max_open_connections" json:"max_open_connections"`
@@ -35,12 +46,40 @@ type Config struct {
 	AutoMigrate        bool              `mapstructure:"auto_migrate"         json:"auto_migrate"`
 	WithResilience     bool              `mapstructure:"with_resilience"      json:"with_resilience"`
 	Resilience         resilience.Config `mapstructure:"resilience"           json:"resilience"`
+	ReadOnly           bool              `mapstructure:"read_only"            json:"read_only"`
+	SlowThreshold      time.Duration     `mapstructure:"slow_threshold"       json:"slow_threshold"`
+}
+
+// IsEnabled reports whether New builds the client; true unless enabled: false
+func (c Config) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// WithDefaults returns a copy of c with unset pool settings filled in:
+// MaxIdleConnections (DefaultMaxIdleConnections), MaxOpenConnections
+// (DefaultMaxOpenConnections) and ConnMaxLifetime (DefaultConnMaxLifetime).
+func (c Config) WithDefaults() Config {
+	if c.MaxIdleConnections <= 0 {
+		c.MaxIdleConnections = DefaultMaxIdleConnections
+	}
+	if c.MaxOpenConnections <= 0 {
+		c.MaxOpenConnections = DefaultMaxOpenConnections
+	}
+	if c.ConnMaxLifetime <= 0 {
+		c.ConnMaxLifetime = DefaultConnMaxLifetime
+	}
+	return c
 }
 
 type DBClient struct {
-	db         *gorm.DB
-	logger     logger.Service
-	logging    bool
-	resilience *resilience.Service
-	dbType     string
+	db            *gorm.DB
+	logger        logger.Service
+	logging       bool
+	resilience    *resilience.Service
+	dbType        string
+	readOnly      bool
+	slowThreshold time.Duration
+
+	migrationsMu sync.Mutex
+	migrations   []migration
 }
@@ -0,0 +1,91 @@
+package gormsql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migrationsTable records the IDs of applied migrations
+const migrationsTable = "schema_migrations"
+
+type migration struct {
+	id string
+	up func(tx *gorm.DB) error
+}
+
+// schemaMigration is a row of the schema_migrations table
+type schemaMigration struct {
+	ID        string    `gorm:"primaryKey;size:255"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// RegisterMigration adds a migration to be applied by RunMigrations.
+// Migrations run in registration order; ids must be unique and must never change
+// once applied, since they are what schema_migrations records.
+func (dbc *DBClient) RegisterMigration(id string, up func(tx *gorm.DB) error) error {
+	if id == "" || up == nil {
+		return fmt.Errorf("%w: migration requires an id and an up function", ErrMigration)
+	}
+
+	dbc.migrationsMu.Lock()
+	defer dbc.migrationsMu.Unlock()
+	for _, m := range dbc.migrations {
+		if m.id == id {
+			return fmt.Errorf("%w: duplicate migration id %q", ErrMigration, id)
+		}
+	}
+	dbc.migrations = append(dbc.migrations, migration{id: id, up: up})
+	return nil
+}
+
+// RunMigrations applies every registered migration not yet recorded in schema_migrations.
+// Each pending migration runs in its own transaction together with its record, so a
+// failure rolls it back and stops the run; already applied migrations are skipped.
+// It is not safe to run concurrently from several processes against the same database.
+func (dbc *DBClient) RunMigrations(ctx context.Context) error {
+	if dbc.readOnly {
+		return ErrReadOnly
+	}
+	dbc.migrationsMu.Lock()
+	migrations := append([]migration(nil), dbc.migrations...)
+	dbc.migrationsMu.Unlock()
+
+	db := dbc.db.WithContext(ctx)
+	if err := db.Table(migrationsTable).AutoMigrate(&schemaMigration{}); err != nil {
+		return fmt.Errorf("%w: creating %s: %w", ErrMigration, migrationsTable, err)
+	}
+
+	var applied []string
+	if err := db.Table(migrationsTable).Pluck("id", &applied).Error; err != nil {
+		return fmt.Errorf("%w: reading %s: %w", ErrMigration, migrationsTable, err)
+	}
+	done := make(map[string]bool, len(applied))
+	for _, id := range applied {
+		done[id] = true
+	}
+
+	for _, m := range migrations {
+		if done[m.id] {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := m.up(tx); err != nil {
+				return err
+			}
+			return tx.Table(migrationsTable).Create(&schemaMigration{ID: m.id, AppliedAt: time.Now().UTC()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrMigration, m.id, err)
+		}
+
+		if dbc.logging {
+			dbc.logger.Debug(ctx, fmt.Sprintf("migration applied: %s", m.id),
+				map[string]interface{}{"migration": m.id, "db_type": dbc.dbType})
+		}
+	}
+	return nil
+}
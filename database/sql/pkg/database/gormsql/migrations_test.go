@@ -0,0 +1,76 @@
+package gormsql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestRunMigrations_AppliesPendingOnceInOrder(t *testing.T) {
+	client := newSQLiteClient(t)
+	ctx := context.Background()
+
+	var order []string
+	require.NoError(t, client.RegisterMigration("001_create_items", func(tx *gorm.DB) error {
+		order = append(order, "001")
+		return tx.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT)").Error
+	}))
+	require.NoError(t, client.RegisterMigration("002_add_price", func(tx *gorm.DB) error {
+		order = append(order, "002")
+		return tx.Exec("ALTER TABLE items ADD COLUMN price INTEGER").Error
+	}))
+
+	require.NoError(t, client.RunMigrations(ctx))
+	require.NoError(t, client.RunMigrations(ctx)) // Second run is a no-op
+
+	assert.Equal(t, []string{"001", "002"}, order)
+
+	var applied []string
+	require.NoError(t, client.DB().Table(migrationsTable).Order("id").Pluck("id", &applied).Error)
+	assert.Equal(t, []string{"001_create_items", "002_add_price"}, applied)
+}
+
+func TestRunMigrations_FailureRollsBackAndStops(t *testing.T) {
+	client := newSQLiteClient(t)
+	ctx := context.Background()
+	boom := errors.New("boom")
+
+	ran3 := false
+	require.NoError(t, client.RegisterMigration("001", func(tx *gorm.DB) error {
+		return tx.Exec("CREATE TABLE a (id INTEGER)").Error
+	}))
+	require.NoError(t, client.RegisterMigration("002", func(tx *gorm.DB) error {
+		if err := tx.Exec("CREATE TABLE b (id INTEGER)").Error; err != nil {
+			return err
+		}
+		return boom
+	}))
+	require.NoError(t, client.RegisterMigration("003", func(tx *gorm.DB) error {
+		ran3 = true
+		return nil
+	}))
+
+	err := client.RunMigrations(ctx)
+	assert.ErrorIs(t, err, ErrMigration)
+	assert.ErrorIs(t, err, boom)
+	assert.False(t, ran3)
+	assert.False(t, client.DB().Migrator().HasTable("b"), "failed migration is rolled back")
+
+	var applied []string
+	require.NoError(t, client.DB().Table(migrationsTable).Pluck("id", &applied).Error)
+	assert.Equal(t, []string{"001"}, applied)
+}
+
+func TestRegisterMigration_Validation(t *testing.T) {
+	client := &DBClient{}
+	noop := func(tx *gorm.DB) error { return nil }
+
+	require.NoError(t, client.RegisterMigration("001", noop))
+	assert.ErrorIs(t, client.RegisterMigration("001", noop), ErrMigration)
+	assert.ErrorIs(t, client.RegisterMigration("", noop), ErrMigration)
+	assert.ErrorIs(t, client.RegisterMigration("002", nil), ErrMigration)
+}
@@ -0,0 +1,38 @@
+package gormsql
+
+import (
+	"database/sql"
+
+	"gorm.io/gorm"
+)
+
+const readOnlyCallback = "gormsql:read_only"
+
+// registerReadOnlyGuards makes every create, update, delete and raw exec issued through
+// db fail with ErrReadOnly before any statement is sent, including writes made inside
+// Transaction or through DB().
+func registerReadOnlyGuards(db *gorm.DB) error {
+	reject := func(tx *gorm.DB) { _ = tx.AddError(ErrReadOnly) }
+
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:begin_transaction").Register(readOnlyCallback, reject); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:begin_transaction").Register(readOnlyCallback, reject); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:begin_transaction").Register(readOnlyCallback, reject); err != nil {
+		return err
+	}
+	return callbacks.Raw().Before("gorm:raw").Register(readOnlyCallback, reject)
+}
+
+// readOnlyTxOptions returns the options that open read-only transactions on a
+// read-only client. SQL Server's driver rejects read-only transactions, so it
+// relies on the callbacks alone.
+func (dbc *DBClient) readOnlyTxOptions() []*sql.TxOptions {
+	if !dbc.readOnly || dbc.dialect() == dialectSQLServer {
+		return nil
+	}
+	return []*sql.TxOptions{{ReadOnly: true}}
+}
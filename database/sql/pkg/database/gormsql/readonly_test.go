@@ -0,0 +1,90 @@
+package gormsql
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newReadOnlySQLiteClient seeds a file database with one product through a writable
+// client and reopens it with ReadOnly enabled.
+func newReadOnlySQLiteClient(t *testing.T) *DBClient {
+	t.Helper()
+	dsn := filepath.Join(t.TempDir(), "readonly.db")
+
+	writer, err := New(Config{Type: "sqlite", MaxOpenConnections: 1}, sqlite.Open(dsn), noopLogger{})
+	require.NoError(t, err)
+	require.NoError(t, writer.AutoMigrate(&upsertProduct{}))
+	require.NoError(t, writer.Create(context.Background(), &upsertProduct{SKU: "A-1", Name: "Widget", Price: 10}))
+	require.NoError(t, writer.Close())
+
+	client, err := New(Config{Type: "sqlite", MaxOpenConnections: 1, ReadOnly: true}, sqlite.Open(dsn), noopLogger{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestReadOnly_WritesReturnErrReadOnly(t *testing.T) {
+	client := newReadOnlySQLiteClient(t)
+	ctx := context.Background()
+	existing := &upsertProduct{ID: 1}
+
+	writes := map[string]func() error{
+		"Create": func() error { return client.Create(ctx, &upsertProduct{SKU: "B-1", Name: "Gadget"}) },
+		"Update": func() error { return client.Update(ctx, existing, map[string]interface{}{"price": 99}) },
+		"Delete": func() error { return client.Delete(ctx, existing) },
+		"Exec":   func() error { return client.Exec(ctx, "DELETE FROM upsert_products") },
+		"Upsert": func() error {
+			return client.Upsert(ctx, &upsertProduct{SKU: "A-1", Name: "Widget v2"}, []string{"sku"}, nil)
+		},
+		"AutoMigrate":   func() error { return client.AutoMigrate(&schemaMigration{}) },
+		"RunMigrations": func() error { return client.RunMigrations(ctx) },
+		"Transaction": func() error {
+			return client.Transaction(ctx, func(tx *gorm.DB) error {
+				return tx.Create(&upsertProduct{SKU: "C-1", Name: "Gizmo"}).Error
+			})
+		},
+		"DB": func() error {
+			return client.DB().WithContext(ctx).Model(&upsertProduct{}).Where("id = ?", 1).Update("price", 1).Error
+		},
+	}
+
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, write(), ErrReadOnly)
+		})
+	}
+
+	var products []upsertProduct
+	require.NoError(t, client.Find(ctx, &products))
+	require.Len(t, products, 1)
+	assert.Equal(t, "Widget", products[0].Name)
+	assert.Equal(t, 10, products[0].Price)
+	assert.False(t, client.DB().Migrator().HasTable(migrationsTable))
+}
+
+func TestReadOnly_ReadsStillWork(t *testing.T) {
+	client := newReadOnlySQLiteClient(t)
+	ctx := context.Background()
+
+	var product upsertProduct
+	require.NoError(t, client.First(ctx, &product, "sku = ?", "A-1"))
+	assert.Equal(t, "Widget", product.Name)
+
+	var count int64
+	require.NoError(t, client.Count(ctx, &upsertProduct{}, &count))
+	assert.Equal(t, int64(1), count)
+
+	var names []string
+	require.NoError(t, client.Raw(ctx, &names, "SELECT name FROM upsert_products"))
+	assert.Equal(t, []string{"Widget"}, names)
+
+	require.NoError(t, client.Transaction(ctx, func(tx *gorm.DB) error {
+		return tx.First(&product).Error
+	}))
+}
@@ -6,10 +6,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/skolldire/go-engine/pkg/core/client"
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
 	"github.com/skolldire/go-engine/pkg/utilities/resilience"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 	gormlogger "gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
 )
@@ -17,7 +17,14 @@ import (
 // New opens a GORM connection using the caller-supplied dialector.
 // The caller is responsible for importing the appropriate driver and building
 // the dialector (e.g. postgres.Open(dsn), mysql.Open(dsn)).
+// A disabled config (see Config.IsEnabled) returns ErrDisabled without connecting.
 func New(cfg Config, dialector gorm.Dialector, log logger.Service) (*DBClient, error) {
+	if !cfg.IsEnabled() {
+		return nil, ErrDisabled
+	}
+	log = logger.OrNoop(log)
+
+	cfg = cfg.WithDefaults()
 	gormConfig := &gorm.Config{}
 
 	if cfg.TablePrefix != "" {
@@ -35,34 +42,28 @@ func New(cfg Config, dialector gorm.Dialector, log logger.Service) (*DBClient, e
 		return nil, log.WrapError(err, ErrConnection.Error())
 	}
 
+	if cfg.ReadOnly {
+		if err := registerReadOnlyGuards(db); err != nil {
+			return nil, log.WrapError(err, ErrConnection.Error())
+		}
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, log.WrapError(err, ErrConnection.Error())
 	}
 
-	maxIdle := DefaultMaxIdleConnections
-	if cfg.MaxIdleConnections > 0 {
-		maxIdle = cfg.MaxIdleConnections
-	}
-	sqlDB.SetMaxIdleConns(maxIdle)
-
-	maxOpen := DefaultMaxOpenConnections
-	if cfg.MaxOpenConnections > 0 {
-		maxOpen = cfg.MaxOpenConnections
-	}
-	sqlDB.SetMaxOpenConns(maxOpen)
-
-	lifetime := DefaultConnMaxLifetime
-	if cfg.ConnMaxLifetime > 0 {
-		lifetime = cfg.ConnMaxLifetime
-	}
-	sqlDB.SetConnMaxLifetime(lifetime)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConnections)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConnections)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
 	client := &DBClient{
-		db:      db,
-		logger:  log,
-		logging: cfg.EnableLogging,
-		dbType:  cfg.Type,
+		db:            db,
+		logger:        log,
+		logging:       cfg.EnableLogging,
+		dbType:        cfg.Type,
+		readOnly:      cfg.ReadOnly,
+		slowThreshold: cfg.SlowThreshold,
 	}
 
 	if cfg.WithResilience {
@@ -88,35 +89,21 @@ func (dbc *DBClient) ensureContextWithTimeout(ctx context.Context) (context.Cont
 	return context.WithCancel(ctx)
 }
 
-func (dbc *DBClient) execute(ctx context.Context, op string, fn func() (interface{}, error)) (interface{}, error) {
+func (dbc *DBClient) execute(ctx context.Context, op string, fn client.ContextOperation) (interface{}, error) {
 	ctx, cancel := dbc.ensureContextWithTimeout(ctx)
 	defer cancel()
 
-	fields := map[string]interface{}{"operation": op, "db_type": dbc.dbType}
-
-	if dbc.resilience != nil {
-		if dbc.logging {
-			dbc.logger.Debug(ctx, fmt.Sprintf("starting DB operation with resilience: %s", op), fields)
-		}
-		result, err := dbc.resilience.Execute(ctx, fn)
-		if err != nil && dbc.logging {
-			dbc.logger.Error(ctx, fmt.Errorf("error in DB operation: %w", err), fields)
-		} else if dbc.logging {
-			dbc.logger.Debug(ctx, fmt.Sprintf("DB operation completed with resilience: %s", op), fields)
-		}
-		return result, err
-	}
-
-	if dbc.logging {
-		dbc.logger.Debug(ctx, fmt.Sprintf("starting DB operation: %s", op), fields)
-	}
-	result, err := fn()
-	if err != nil && dbc.logging {
-		dbc.logger.Error(ctx, err, fields)
-	} else if dbc.logging {
-		dbc.logger.Debug(ctx, fmt.Sprintf("DB operation completed: %s", op), fields)
-	}
-	return result, err
+	return client.ExecuteContext(ctx, client.ExecuteOptions{
+		Service:       "DB",
+		Operation:     op,
+		Logger:        dbc.logger,
+		Logging:       dbc.logging,
+		Resilience:    dbc.resilience,
+		SlowThreshold: dbc.slowThreshold,
+		Fields: func() map[string]interface{} {
+			return map[string]interface{}{"operation": op, "db_type": dbc.dbType}
+		},
+	}, fn)
 }
 
 // Ping verifies database connectivity using the underlying sql.DB.
@@ -133,14 +120,17 @@ func (dbc *DBClient) WithContext(ctx context.Context) *gorm.DB {
 }
 
 func (dbc *DBClient) Create(ctx context.Context, value interface{}) error {
-	_, err := dbc.execute(ctx, "Create", func() (interface{}, error) {
+	if dbc.readOnly {
+		return ErrReadOnly
+	}
+	_, err := dbc.execute(ctx, "Create", func(ctx context.Context) (interface{}, error) {
 		return nil, dbc.db.WithContext(ctx).Create(value).Error
 	})
 	return err
 }
 
 func (dbc *DBClient) First(ctx context.Context, dest interface{}, conditions ...interface{}) error {
-	_, err := dbc.execute(ctx, "First", func() (interface{}, error) {
+	_, err := dbc.execute(ctx, "First", func(ctx context.Context) (interface{}, error) {
 		return nil, dbc.db.WithContext(ctx).First(dest, conditions...).Error
 	})
 	if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -150,28 +140,34 @@ func (dbc *DBClient) First(ctx context.Context, dest interface{}, conditions ...
 }
 
 func (dbc *DBClient) Find(ctx context.Context, dest interface{}, conditions ...interface{}) error {
-	_, err := dbc.execute(ctx, "Find", func() (interface{}, error) {
+	_, err := dbc.execute(ctx, "Find", func(ctx context.Context) (interface{}, error) {
 		return nil, dbc.db.WithContext(ctx).Find(dest, conditions...).Error
 	})
 	return err
 }
 
 func (dbc *DBClient) Update(ctx context.Context, model interface{}, updates interface{}) error {
-	_, err := dbc.execute(ctx, "Update", func() (interface{}, error) {
+	if dbc.readOnly {
+		return ErrReadOnly
+	}
+	_, err := dbc.execute(ctx, "Update", func(ctx context.Context) (interface{}, error) {
 		return nil, dbc.db.WithContext(ctx).Model(model).Updates(updates).Error
 	})
 	return err
 }
 
 func (dbc *DBClient) Delete(ctx context.Context, value interface{}, conditions ...interface{}) error {
-	_, err := dbc.execute(ctx, "Delete", func() (interface{}, error) {
+	if dbc.readOnly {
+		return ErrReadOnly
+	}
+	_, err := dbc.execute(ctx, "Delete", func(ctx context.Context) (interface{}, error) {
 		return nil, dbc.db.WithContext(ctx).Delete(value, conditions...).Error
 	})
 	return err
 }
 
 func (dbc *DBClient) Count(ctx context.Context, model interface{}, count *int64, conditions ...interface{}) error {
-	_, err := dbc.execute(ctx, "Count", func() (interface{}, error) {
+	_, err := dbc.execute(ctx, "Count", func(ctx context.Context) (interface{}, error) {
 		q := dbc.db.WithContext(ctx).Model(model)
 		if len(conditions) > 0 {
 			q = q.Where(conditions[0], conditions[1:]...)
@@ -182,15 +178,29 @@ func (dbc *DBClient) Count(ctx context.Context, model interface{}, count *int64,
 }
 
 func (dbc *DBClient) Exec(ctx context.Context, sql string, values ...interface{}) error {
-	_, err := dbc.execute(ctx, "Exec", func() (interface{}, error) {
+	if dbc.readOnly {
+		return ErrReadOnly
+	}
+	_, err := dbc.execute(ctx, "Exec", func(ctx context.Context) (interface{}, error) {
 		return nil, dbc.db.WithContext(ctx).Exec(sql, values...).Error
 	})
 	return err
 }
 
+// Transaction runs fn in a transaction, committing when it returns nil. If ctx is
+// cancelled or times out while fn runs, the transaction is rolled back instead of
+// committed and the error matches both ErrTransactionCanceled and ctx.Err().
 func (dbc *DBClient) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
-	_, err := dbc.execute(ctx, "Transaction", func() (interface{}, error) {
-		return nil, dbc.db.WithContext(ctx).Transaction(fn)
+	_, err := dbc.execute(ctx, "Transaction", func(ctx context.Context) (interface{}, error) {
+		return nil, dbc.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := fn(tx); err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("%w: %w", ErrTransactionCanceled, err)
+			}
+			return nil
+		}, dbc.readOnlyTxOptions()...)
 	})
 	if err != nil {
 		return dbc.logger.WrapError(err, ErrTransaction.Error())
@@ -199,55 +209,72 @@ func (dbc *DBClient) Transaction(ctx context.Context, fn func(tx *gorm.DB) error
 }
 
 func (dbc *DBClient) Preload(ctx context.Context, dest interface{}, relation string, conditions ...interface{}) error {
-	_, err := dbc.execute(ctx, "Preload", func() (interface{}, error) {
+	_, err := dbc.execute(ctx, "Preload", func(ctx context.Context) (interface{}, error) {
 		return nil, dbc.db.WithContext(ctx).Preload(relation, conditions...).Find(dest).Error
 	})
 	return err
 }
 
 func (dbc *DBClient) Where(ctx context.Context, dest interface{}, query interface{}, args ...interface{}) error {
-	_, err := dbc.execute(ctx, "Where", func() (interface{}, error) {
+	_, err := dbc.execute(ctx, "Where", func(ctx context.Context) (interface{}, error) {
 		return nil, dbc.db.WithContext(ctx).Where(query, args...).Find(dest).Error
 	})
 	return err
 }
 
 func (dbc *DBClient) Order(ctx context.Context, dest interface{}, value interface{}) error {
-	_, err := dbc.execute(ctx, "Order", func() (interface{}, error) {
+	_, err := dbc.execute(ctx, "Order", func(ctx context.Context) (interface{}, error) {
 		return nil, dbc.db.WithContext(ctx).Order(value).Find(dest).Error
 	})
 	return err
 }
 
 func (dbc *DBClient) Limit(ctx context.Context, dest interface{}, limit int) error {
-	_, err := dbc.execute(ctx, "Limit", func() (interface{}, error) {
+	_, err := dbc.execute(ctx, "Limit", func(ctx context.Context) (interface{}, error) {
 		return nil, dbc.db.WithContext(ctx).Limit(limit).Find(dest).Error
 	})
 	return err
 }
 
 func (dbc *DBClient) Offset(ctx context.Context, dest interface{}, offset int) error {
-	_, err := dbc.execute(ctx, "Offset", func() (interface{}, error) {
+	_, err := dbc.execute(ctx, "Offset", func(ctx context.Context) (interface{}, error) {
 		return nil, dbc.db.WithContext(ctx).Offset(offset).Find(dest).Error
 	})
 	return err
 }
 
+// Upsert inserts value or, when a row with the same conflictColumns exists, updates
+// updateColumns on it. Empty conflictColumns default to the primary key and empty
+// updateColumns leave the existing row untouched (DO NOTHING); use UpsertAll to
+// update every non-key column. SQL Server uses MERGE; other dialects use GORM's
+// ON CONFLICT translation (ON DUPLICATE KEY UPDATE on MySQL).
 func (dbc *DBClient) Upsert(ctx context.Context, value interface{}, conflictColumns []string, updateColumns []string) error {
-	cols := make([]clause.Column, len(conflictColumns))
-	for i, c := range conflictColumns {
-		cols[i] = clause.Column{Name: c}
+	return dbc.upsert(ctx, "Upsert", value, conflictColumns, updateColumns, false)
+}
+
+// UpsertAll is Upsert updating every non-key column of an existing row
+func (dbc *DBClient) UpsertAll(ctx context.Context, value interface{}, conflictColumns []string) error {
+	return dbc.upsert(ctx, "UpsertAll", value, conflictColumns, nil, true)
+}
+
+func (dbc *DBClient) upsert(ctx context.Context, name string, value interface{}, conflictColumns, updateColumns []string, updateAll bool) error {
+	if dbc.readOnly {
+		return ErrReadOnly
 	}
-	_, err := dbc.execute(ctx, "Upsert", func() (interface{}, error) {
-		return nil, dbc.db.WithContext(ctx).Clauses(clause.OnConflict{
-			Columns:   cols,
-			DoUpdates: clause.AssignmentColumns(updateColumns),
-		}).Create(value).Error
+	_, err := dbc.execute(ctx, name, func(ctx context.Context) (interface{}, error) {
+		db := dbc.db.WithContext(ctx)
+		if dbc.dialect() == dialectSQLServer {
+			return nil, mergeUpsert(db, value, conflictColumns, updateColumns, updateAll)
+		}
+		return nil, onConflictUpsert(db, value, conflictColumns, updateColumns, updateAll)
 	})
 	return err
 }
 
 func (dbc *DBClient) AutoMigrate(models ...interface{}) error {
+	if dbc.readOnly {
+		return ErrReadOnly
+	}
 	if err := dbc.db.AutoMigrate(models...); err != nil {
 		return dbc.logger.WrapError(err, "error in auto migration")
 	}
@@ -255,7 +282,7 @@ func (dbc *DBClient) AutoMigrate(models ...interface{}) error {
 }
 
 func (dbc *DBClient) Raw(ctx context.Context, dest interface{}, sql string, values ...interface{}) error {
-	_, err := dbc.execute(ctx, "Raw", func() (interface{}, error) {
+	_, err := dbc.execute(ctx, "Raw", func(ctx context.Context) (interface{}, error) {
 		return nil, dbc.db.WithContext(ctx).Raw(sql, values...).Scan(dest).Error
 	})
 	return err
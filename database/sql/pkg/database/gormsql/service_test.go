@@ -0,0 +1,88 @@
+package gormsql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/skolldire/go-engine/pkg/utilities/resilience"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestNew_DefaultsResilienceWithoutSubConfigs(t *testing.T) {
+	client, err := New(Config{Type: "sqlite", MaxOpenConnections: 1, WithResilience: true}, sqlite.Open(":memory:"), noopLogger{})
+
+	require.NoError(t, err)
+	require.NotNil(t, client)
+}
+
+func TestNew_DisabledConfig(t *testing.T) {
+	disabled := false
+	client, err := New(Config{Enabled: &disabled, Type: "sqlite"}, sqlite.Open(":memory:"), noopLogger{})
+
+	assert.ErrorIs(t, err, ErrDisabled)
+	assert.Nil(t, client)
+}
+
+func TestNew_AcceptsResilienceWithRetryConfig(t *testing.T) {
+	cfg := Config{
+		Type:               "sqlite",
+		MaxOpenConnections: 1,
+		WithResilience:     true,
+		Resilience:         resilience.Config{RetryConfig: &retry_backoff.Config{MaxRetries: 1}},
+	}
+
+	client, err := New(cfg, sqlite.Open(":memory:"), noopLogger{})
+
+	require.NoError(t, err)
+	require.NotNil(t, client)
+}
+
+func TestTransaction_CancelledContextRollsBack(t *testing.T) {
+	client := newSQLiteClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err := client.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := tx.Create(&upsertProduct{SKU: "sku-1", Name: "first"}).Error; err != nil {
+			return err
+		}
+		cancel()
+		return nil
+	})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrTransactionCanceled)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	var count int64
+	require.NoError(t, client.WithContext(context.Background()).Model(&upsertProduct{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestTransaction_CommitsAndPropagatesFnError(t *testing.T) {
+	client := newSQLiteClient(t)
+	ctx := context.Background()
+
+	require.NoError(t, client.Transaction(ctx, func(tx *gorm.DB) error {
+		return tx.Create(&upsertProduct{SKU: "sku-1", Name: "first"}).Error
+	}))
+
+	boom := errors.New("boom")
+	err := client.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := tx.Create(&upsertProduct{SKU: "sku-2", Name: "second"}).Error; err != nil {
+			return err
+		}
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+	assert.NotErrorIs(t, err, ErrTransactionCanceled)
+
+	var count int64
+	require.NoError(t, client.WithContext(ctx).Model(&upsertProduct{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
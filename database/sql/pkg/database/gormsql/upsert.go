@@ -0,0 +1,177 @@
+package gormsql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	dialectSQLServer = "sqlserver"
+	dialectSQLite    = "sqlite"
+)
+
+// dialect returns the normalized database type, falling back to the GORM dialector name
+func (dbc *DBClient) dialect() string {
+	dbType := strings.ToLower(dbc.dbType)
+	if dbType == "" && dbc.db != nil && dbc.db.Dialector != nil {
+		dbType = strings.ToLower(dbc.db.Dialector.Name())
+	}
+	switch dbType {
+	case "mssql", dialectSQLServer:
+		return dialectSQLServer
+	case "sqlite3", dialectSQLite:
+		return dialectSQLite
+	}
+	return dbType
+}
+
+func onConflictUpsert(db *gorm.DB, value interface{}, conflictColumns, updateColumns []string, updateAll bool) error {
+	onConflict := clause.OnConflict{Columns: toColumns(conflictColumns)}
+	switch {
+	case updateAll:
+		onConflict.UpdateAll = true
+	case len(updateColumns) > 0:
+		onConflict.DoUpdates = clause.AssignmentColumns(updateColumns)
+	default:
+		onConflict.DoNothing = true
+	}
+
+	// ON CONFLICT ... DO UPDATE SET needs an explicit target on SQLite and Postgres
+	if len(onConflict.Columns) == 0 && len(onConflict.DoUpdates) > 0 {
+		sch, err := parseSchema(db, value)
+		if err != nil {
+			return err
+		}
+		onConflict.Columns = toColumns(primaryKeyColumns(sch))
+	}
+
+	return db.Clauses(onConflict).Create(value).Error
+}
+
+// mergeUpsert emulates ON CONFLICT on SQL Server with one MERGE statement per record
+func mergeUpsert(db *gorm.DB, value interface{}, conflictColumns, updateColumns []string, updateAll bool) error {
+	sch, err := parseSchema(db, value)
+	if err != nil {
+		return err
+	}
+	if len(conflictColumns) == 0 {
+		conflictColumns = primaryKeyColumns(sch)
+	}
+	if len(conflictColumns) == 0 {
+		return fmt.Errorf("upsert on %s requires conflict columns or a primary key", sch.Table)
+	}
+
+	records := reflect.Indirect(reflect.ValueOf(value))
+	if records.Kind() != reflect.Slice && records.Kind() != reflect.Array {
+		return mergeRecord(db, sch, records, conflictColumns, updateColumns, updateAll)
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i := 0; i < records.Len(); i++ {
+			if err := mergeRecord(tx, sch, reflect.Indirect(records.Index(i)), conflictColumns, updateColumns, updateAll); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func mergeRecord(db *gorm.DB, sch *schema.Schema, record reflect.Value, conflictColumns, updateColumns []string, updateAll bool) error {
+	var columns []string
+	var values []interface{}
+	for _, field := range sch.Fields {
+		if field.DBName == "" || !field.Creatable {
+			continue
+		}
+		v, zero := field.ValueOf(db.Statement.Context, record)
+		if zero && (field.AutoCreateTime > 0 || field.AutoUpdateTime > 0) && field.FieldType == reflect.TypeOf(time.Time{}) {
+			v, zero = db.NowFunc(), false
+		}
+		if zero && (field.AutoIncrement || field.HasDefaultValue) {
+			continue // Let the database assign identity/default values
+		}
+		columns = append(columns, field.DBName)
+		values = append(values, v)
+	}
+
+	if updateAll {
+		primaryKeys := primaryKeyColumns(sch)
+		for _, column := range columns {
+			if !containsColumn(conflictColumns, column) && !containsColumn(primaryKeys, column) {
+				updateColumns = append(updateColumns, column)
+			}
+		}
+	}
+
+	quote := func(name string) string { return db.Statement.Quote(name) }
+	return db.Exec(buildMergeSQL(quote, sch.Table, columns, conflictColumns, updateColumns), values...).Error
+}
+
+// buildMergeSQL renders a SQL Server MERGE upserting one row of columns (bound as ? placeholders)
+func buildMergeSQL(quote func(string) string, table string, columns, conflictColumns, updateColumns []string) string {
+	quoted := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	sourceCols := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = quote(c)
+		placeholders[i] = "?"
+		sourceCols[i] = "source." + quote(c)
+	}
+
+	on := make([]string, len(conflictColumns))
+	for i, c := range conflictColumns {
+		on[i] = fmt.Sprintf("target.%s = source.%s", quote(c), quote(c))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "MERGE INTO %s WITH (HOLDLOCK) AS target USING (VALUES (%s)) AS source (%s) ON %s",
+		quote(table), strings.Join(placeholders, ", "), strings.Join(quoted, ", "), strings.Join(on, " AND "))
+	if len(updateColumns) > 0 {
+		sets := make([]string, len(updateColumns))
+		for i, c := range updateColumns {
+			sets[i] = fmt.Sprintf("target.%s = source.%s", quote(c), quote(c))
+		}
+		fmt.Fprintf(&b, " WHEN MATCHED THEN UPDATE SET %s", strings.Join(sets, ", "))
+	}
+	fmt.Fprintf(&b, " WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s);",
+		strings.Join(quoted, ", "), strings.Join(sourceCols, ", "))
+	return b.String()
+}
+
+func parseSchema(db *gorm.DB, value interface{}) (*schema.Schema, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(value); err != nil {
+		return nil, fmt.Errorf("upsert: %w", err)
+	}
+	return stmt.Schema, nil
+}
+
+func primaryKeyColumns(sch *schema.Schema) []string {
+	columns := make([]string, 0, len(sch.PrimaryFields))
+	for _, field := range sch.PrimaryFields {
+		columns = append(columns, field.DBName)
+	}
+	return columns
+}
+
+func toColumns(names []string) []clause.Column {
+	cols := make([]clause.Column, len(names))
+	for i, name := range names {
+		cols[i] = clause.Column{Name: name}
+	}
+	return cols
+}
+
+func containsColumn(columns []string, column string) bool {
+	for _, c := range columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package gormsql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/skolldire/go-engine/pkg/utilities/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(ctx context.Context, msg string, fields map[string]interface{})     {}
+func (noopLogger) Info(ctx context.Context, msg string, fields map[string]interface{})      {}
+func (noopLogger) Warn(ctx context.Context, msg string, fields map[string]interface{})      {}
+func (noopLogger) Error(ctx context.Context, err error, fields map[string]interface{})      {}
+func (noopLogger) FatalError(ctx context.Context, err error, fields map[string]interface{}) {}
+func (noopLogger) WrapError(err error, msg string) error                                    { return err }
+func (l noopLogger) WithField(key string, value interface{}) logger.Service                 { return l }
+func (l noopLogger) WithFields(fields map[string]interface{}) logger.Service                { return l }
+func (noopLogger) GetLogLevel() string                                                      { return "info" }
+func (noopLogger) SetLogLevel(level string) error                                           { return nil }
+
+type upsertProduct struct {
+	ID    uint   `gorm:"primaryKey"`
+	SKU   string `gorm:"uniqueIndex"`
+	Name  string
+	Price int
+}
+
+func newSQLiteClient(t *testing.T) *DBClient {
+	t.Helper()
+	client, err := New(Config{Type: "sqlite", MaxOpenConnections: 1}, sqlite.Open(":memory:"), noopLogger{})
+	require.NoError(t, err)
+	require.NoError(t, client.AutoMigrate(&upsertProduct{}))
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestUpsert_SQLiteUpdatesOnConflict(t *testing.T) {
+	client := newSQLiteClient(t)
+	ctx := context.Background()
+
+	require.NoError(t, client.Upsert(ctx, &upsertProduct{SKU: "A-1", Name: "Widget", Price: 10}, []string{"sku"}, []string{"name", "price"}))
+	require.NoError(t, client.Upsert(ctx, &upsertProduct{SKU: "A-1", Name: "Widget v2", Price: 12}, []string{"sku"}, []string{"name", "price"}))
+
+	var products []upsertProduct
+	require.NoError(t, client.Find(ctx, &products))
+	require.Len(t, products, 1)
+	assert.Equal(t, "Widget v2", products[0].Name)
+	assert.Equal(t, 12, products[0].Price)
+}
+
+func TestUpsert_SQLiteOnlyUpdatesGivenColumns(t *testing.T) {
+	client := newSQLiteClient(t)
+	ctx := context.Background()
+
+	require.NoError(t, client.Upsert(ctx, &upsertProduct{SKU: "A-1", Name: "Widget", Price: 10}, []string{"sku"}, []string{"price"}))
+	require.NoError(t, client.Upsert(ctx, &upsertProduct{SKU: "A-1", Name: "ignored", Price: 15}, []string{"sku"}, []string{"price"}))
+
+	var product upsertProduct
+	require.NoError(t, client.First(ctx, &product, "sku = ?", "A-1"))
+	assert.Equal(t, "Widget", product.Name)
+	assert.Equal(t, 15, product.Price)
+}
+
+func TestUpsert_SQLiteWithoutUpdateColumnsDoesNothing(t *testing.T) {
+	client := newSQLiteClient(t)
+	ctx := context.Background()
+
+	require.NoError(t, client.Create(ctx, &upsertProduct{ID: 7, SKU: "B-1", Name: "Gadget", Price: 5}))
+	require.NoError(t, client.Upsert(ctx, []upsertProduct{
+		{ID: 7, SKU: "B-1", Name: "Gadget v2", Price: 6},
+		{ID: 8, SKU: "B-2", Name: "Gizmo", Price: 3},
+	}, nil, nil))
+
+	var products []upsertProduct
+	require.NoError(t, client.Order(ctx, &products, "id"))
+	require.Len(t, products, 2)
+	assert.Equal(t, "Gadget", products[0].Name)
+	assert.Equal(t, 5, products[0].Price)
+	assert.Equal(t, "Gizmo", products[1].Name)
+}
+
+func TestUpsertAll_SQLiteDefaultsToPrimaryKeyAndAllColumns(t *testing.T) {
+	client := newSQLiteClient(t)
+	ctx := context.Background()
+
+	require.NoError(t, client.Create(ctx, &upsertProduct{ID: 7, SKU: "B-1", Name: "Gadget", Price: 5}))
+	require.NoError(t, client.UpsertAll(ctx, []upsertProduct{
+		{ID: 7, SKU: "B-1", Name: "Gadget v2", Price: 6},
+		{ID: 8, SKU: "B-2", Name: "Gizmo", Price: 3},
+	}, nil))
+
+	var products []upsertProduct
+	require.NoError(t, client.Order(ctx, &products, "id"))
+	require.Len(t, products, 2)
+	assert.Equal(t, "Gadget v2", products[0].Name)
+	assert.Equal(t, 6, products[0].Price)
+	assert.Equal(t, "Gizmo", products[1].Name)
+}
+
+func TestDBClient_Dialect(t *testing.T) {
+	tests := map[string]string{
+		"sqlserver": dialectSQLServer,
+		"MSSQL":     dialectSQLServer,
+		"sqlite3":   dialectSQLite,
+		"postgres":  "postgres",
+	}
+	for dbType, want := range tests {
+		assert.Equal(t, want, (&DBClient{dbType: dbType}).dialect(), dbType)
+	}
+
+	client := newSQLiteClient(t)
+	client.dbType = ""
+	assert.Equal(t, dialectSQLite, client.dialect(), "falls back to the dialector name")
+}
+
+func TestBuildMergeSQL(t *testing.T) {
+	quote := func(name string) string { return "[" + name + "]" }
+
+	sql := buildMergeSQL(quote, "products", []string{"sku", "name", "price"}, []string{"sku"}, []string{"name", "price"})
+
+	assert.Equal(t, "MERGE INTO [products] WITH (HOLDLOCK) AS target"+
+		" USING (VALUES (?, ?, ?)) AS source ([sku], [name], [price])"+
+		" ON target.[sku] = source.[sku]"+
+		" WHEN MATCHED THEN UPDATE SET target.[name] = source.[name], target.[price] = source.[price]"+
+		" WHEN NOT MATCHED THEN INSERT ([sku], [name], [price]) VALUES (source.[sku], source.[name], source.[price]);", sql)
+
+	insertOnly := buildMergeSQL(quote, "products", []string{"sku"}, []string{"sku"}, nil)
+	assert.NotContains(t, insertOnly, "WHEN MATCHED")
+}
+
+func TestMergeUpsert_BuildsStatementPerRecord(t *testing.T) {
+	client := newSQLiteClient(t)
+	var statements []string
+	dry := client.DB().Session(&gorm.Session{DryRun: true})
+	require.NoError(t, dry.Callback().Raw().After("gorm:raw").Register("test:capture", func(db *gorm.DB) {
+		statements = append(statements, db.Statement.SQL.String())
+	}))
+
+	err := mergeUpsert(dry, []upsertProduct{{SKU: "A-1", Name: "Widget"}, {ID: 3, SKU: "A-2"}}, []string{"sku"}, nil, true)
+	require.NoError(t, err)
+
+	require.Len(t, statements, 2)
+	assert.Contains(t, statements[0], "USING (VALUES (?, ?, ?)) AS source (`sku`, `name`, `price`)")
+	assert.Contains(t, statements[0], "UPDATE SET target.`name` = source.`name`, target.`price` = source.`price`")
+	assert.Contains(t, statements[1], "AS source (`id`, `sku`, `name`, `price`)", "explicit primary keys are inserted")
+	assert.NotContains(t, statements[1], "target.`id` = source.`id`", "primary keys are never updated")
+}
+
+func TestMergeUpsert_WithoutUpdateColumnsOnlyInserts(t *testing.T) {
+	client := newSQLiteClient(t)
+	var statements []string
+	dry := client.DB().Session(&gorm.Session{DryRun: true})
+	require.NoError(t, dry.Callback().Raw().After("gorm:raw").Register("test:capture", func(db *gorm.DB) {
+		statements = append(statements, db.Statement.SQL.String())
+	}))
+
+	require.NoError(t, mergeUpsert(dry, &upsertProduct{SKU: "A-1", Name: "Widget"}, []string{"sku"}, nil, false))
+
+	require.Len(t, statements, 1)
+	assert.NotContains(t, statements[0], "WHEN MATCHED")
+}
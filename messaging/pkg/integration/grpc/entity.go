@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/skolldire/go-engine/pkg/core/client"
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
 	"github.com/skolldire/go-engine/pkg/utilities/resilience"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -37,6 +39,8 @@ type Config struct {
 	WithResilience bool              `mapstructure:"with_resilience" json:"with_resilience"`
 	Resilience     resilience.Config `mapstructure:"resilience" json:"resilience"`
 	TimeOut        time.Duration     `mapstructure:"timeout" json:"timeout"`
+	// TLSConfig enables (mutual) TLS; when empty the connection is insecure
+	client.TLSConfig `mapstructure:",squash"`
 }
 
 type Cliente struct {
@@ -45,4 +49,5 @@ type Cliente struct {
 	logging    bool
 	resilience *resilience.Service
 	target     string
+	creds      credentials.TransportCredentials
 }
@@ -5,20 +5,29 @@ import (
 	"fmt"
 	"net"
 
+	"github.com/skolldire/go-engine/pkg/core/client"
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
 	"github.com/skolldire/go-engine/pkg/utilities/resilience"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 )
 
 func NewCliente(cfg Config, log logger.Service) (Service, error) {
+	log = logger.OrNoop(log)
+
+	creds, err := transportCredentials(cfg.TLSConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.TimeOut)
 	defer cancel()
 
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
 	}
 
 	dialOpts := append(opts, grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
@@ -40,6 +49,7 @@ func NewCliente(cfg Config, log logger.Service) (Service, error) {
 		logger:  log,
 		logging: cfg.EnableLogging,
 		target:  cfg.Target,
+		creds:   creds,
 	}
 
 	if cfg.WithResilience {
@@ -54,6 +64,18 @@ func NewCliente(cfg Config, log logger.Service) (Service, error) {
 	return c, nil
 }
 
+// transportCredentials returns TLS credentials when TLS is configured, insecure otherwise
+func transportCredentials(cfg client.TLSConfig) (credentials.TransportCredentials, error) {
+	tlsCfg, err := cfg.Load()
+	if err != nil {
+		return nil, fmt.Errorf("invalid gRPC client TLS config: %w", err)
+	}
+	if tlsCfg == nil {
+		return insecure.NewCredentials(), nil
+	}
+	return credentials.NewTLS(tlsCfg), nil
+}
+
 func waitForConnection(ctx context.Context, conn *grpc.ClientConn) error {
 	for {
 		state := conn.GetState()
@@ -78,7 +100,7 @@ func waitForConnection(ctx context.Context, conn *grpc.ClientConn) error {
 	}
 }
 
-func (c *Cliente) execute(ctx context.Context, operationName string, operation func() (interface{}, error)) (interface{}, error) {
+func (c *Cliente) execute(ctx context.Context, operationName string, operation func(ctx context.Context) (interface{}, error)) (interface{}, error) {
 	ctx, cancel := c.ensureContextWithTimeout(ctx)
 	defer cancel()
 
@@ -97,7 +119,7 @@ func (c *Cliente) execute(ctx context.Context, operationName string, operation f
 			c.logger.Debug(ctx, fmt.Sprintf("starting gRPC operation with resilience: %s", operationName), logFields)
 		}
 
-		result, err := c.resilience.Execute(ctx, operation)
+		result, err := c.resilience.ExecuteContext(ctx, operation)
 
 		if err != nil && c.logging {
 			c.logger.Error(ctx, fmt.Errorf("error in gRPC operation: %w", err), logFields)
@@ -112,7 +134,7 @@ func (c *Cliente) execute(ctx context.Context, operationName string, operation f
 		c.logger.Debug(ctx, fmt.Sprintf("starting gRPC operation: %s", operationName), logFields)
 	}
 
-	result, err := operation()
+	result, err := operation(ctx)
 
 	if err != nil && c.logging {
 		c.logger.Error(ctx, err, logFields)
@@ -162,7 +184,7 @@ func (c *Cliente) ReconnectIfNeeded(ctx context.Context) error {
 	_ = c.conn.Close()
 
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(c.creds),
 	}
 
 	conn, err := grpc.NewClient(c.target, opts...)
@@ -207,7 +229,5 @@ func (c *Cliente) InvokeRPC(ctx context.Context, operationName string,
 		}
 	}
 
-	return c.execute(ctx, operationName, func() (interface{}, error) {
-		return invokeFunc(ctx)
-	})
+	return c.execute(ctx, operationName, invokeFunc)
 }
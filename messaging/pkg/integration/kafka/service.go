@@ -18,6 +18,8 @@ type client struct {
 // The underlying kafka.Writer and kafka.Reader connect lazily on first use;
 // NewClient itself makes no network calls.
 func NewClient(cfg Config, log logger.Service) (Client, error) {
+	log = logger.OrNoop(log)
+
 	return &client{
 		prod: NewProducer(cfg, log),
 		cons: NewConsumer(cfg, log),
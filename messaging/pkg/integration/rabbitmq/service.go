@@ -11,6 +11,8 @@ import (
 )
 
 func NewClient(cfg Config, log logger.Service) (Service, error) {
+	log = logger.OrNoop(log)
+
 	if cfg.URL == "" {
 		return nil, fmt.Errorf("%w: URL is required", ErrConnection)
 	}
@@ -44,6 +46,7 @@ func NewClient(cfg Config, log logger.Service) (Service, error) {
 		WithResilience: cfg.WithResilience,
 		Resilience:     cfg.Resilience,
 		Timeout:        timeout,
+		SlowThreshold:  cfg.SlowThreshold,
 	}
 
 	c := &RabbitMQClient{
@@ -68,7 +71,7 @@ func (c *RabbitMQClient) Publish(ctx context.Context, msg Message) error {
 		return ErrInvalidInput
 	}
 
-	_, err := c.Execute(ctx, "Publish", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "Publish", func(ctx context.Context) (interface{}, error) {
 		return nil, c.channel.PublishWithContext(ctx,
 			msg.Exchange,
 			msg.RoutingKey,
@@ -178,7 +181,7 @@ func (c *RabbitMQClient) DeclareQueue(ctx context.Context, name string, durable,
 		return ErrInvalidInput
 	}
 
-	_, err := c.Execute(ctx, "DeclareQueue", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "DeclareQueue", func(context.Context) (interface{}, error) {
 		return c.channel.QueueDeclare(name, durable, autoDelete, exclusive, noWait, args)
 	})
 
@@ -190,7 +193,7 @@ func (c *RabbitMQClient) DeclareExchange(ctx context.Context, name, kind string,
 		return ErrInvalidInput
 	}
 
-	_, err := c.Execute(ctx, "DeclareExchange", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "DeclareExchange", func(context.Context) (interface{}, error) {
 		return nil, c.channel.ExchangeDeclare(name, kind, durable, autoDelete, internal, noWait, args)
 	})
 
@@ -202,7 +205,7 @@ func (c *RabbitMQClient) BindQueue(ctx context.Context, queue, routingKey, excha
 		return ErrInvalidInput
 	}
 
-	_, err := c.Execute(ctx, "BindQueue", func() (interface{}, error) {
+	_, err := c.ExecuteContext(ctx, "BindQueue", func(context.Context) (interface{}, error) {
 		return nil, c.channel.QueueBind(queue, routingKey, exchange, noWait, args)
 	})
 
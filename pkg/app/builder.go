@@ -16,7 +16,6 @@ import (
 type AppBuilder struct {
 	engine        *Engine
 	errors        []error
-	shutdownHooks []func(context.Context) error
 	healthMounted bool
 }
 
@@ -108,12 +107,9 @@ func (b *AppBuilder) WithRouter() *AppBuilder {
 	b.engine = result.Engine
 	b.errors = append(b.errors, b.engine.errors...)
 
-	// Register any shutdown hooks accumulated before router was initialized.
+	// The router stops accepting connections first, then runs the engine lifecycle
 	if b.engine.Router != nil {
-		for _, hook := range b.shutdownHooks {
-			b.engine.Router.RegisterShutdownHook(hook)
-		}
-		b.shutdownHooks = nil
+		b.engine.Router.RegisterShutdownHook(b.engine.GetLifecycle().Shutdown)
 	}
 	b.mountHealthIfReady()
 	return b
@@ -134,8 +130,8 @@ func (b *AppBuilder) WithGracefulShutdown() *AppBuilder {
 	return b
 }
 
-// WithOTEL initializes the OpenTelemetry provider and wires its Shutdown into
-// the router's graceful shutdown sequence. Call after WithDynamicConfig; may be
+// WithOTEL initializes the OpenTelemetry provider and registers its Shutdown on the
+// engine Lifecycle at PriorityTelemetry. Call after WithDynamicConfig; may be
 // called before or after WithRouter.
 func (b *AppBuilder) WithOTEL(cfg pkgotel.OTELConfig) *AppBuilder {
 	if len(b.errors) > 0 {
@@ -155,11 +151,7 @@ func (b *AppBuilder) WithOTEL(cfg pkgotel.OTELConfig) *AppBuilder {
 	}
 	b.engine.Services.OTELProvider = provider
 
-	if b.engine.Router != nil {
-		b.engine.Router.RegisterShutdownHook(provider.Shutdown)
-	} else {
-		b.shutdownHooks = append(b.shutdownHooks, provider.Shutdown)
-	}
+	b.engine.GetLifecycle().Register(PriorityTelemetry, "otel", provider.Shutdown)
 	return b
 }
 
@@ -2,8 +2,11 @@ package app
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/skolldire/go-engine/aws/pkg/clients/cognito"
@@ -67,17 +70,39 @@ type Engine struct {
 	// Kafka clients
 	KafkaProducer kafka.Producer
 	KafkaConsumer kafka.Consumer
+
+	// Lifecycle runs shutdown hooks in priority order; see GetLifecycle
+	Lifecycle     *Lifecycle
+	lifecycleOnce sync.Once
 }
 
 func (e *Engine) GetErrors() []error {
 	return e.errors
 }
 
+// Run serves the router, if any, until SIGINT/SIGTERM and then runs the engine
+// Lifecycle. Without a router it waits for the signal (or for the engine context to
+// end), so workers and consumers get the same ordered shutdown.
 func (e *Engine) Run() error {
-	if e.Router == nil {
-		return fmt.Errorf("router not initialized")
+	var runErr error
+	if e.Router != nil {
+		runErr = e.Router.Run()
+	} else {
+		e.waitForShutdownSignal()
 	}
-	return e.Router.Run()
+	return errors.Join(runErr, e.GetLifecycle().Shutdown(context.Background()))
+}
+
+func (e *Engine) waitForShutdownSignal() {
+	ctx := e.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	<-ctx.Done()
+	logger.OrNoop(e.Log).Info(context.Background(), "shutdown signal received, running lifecycle", nil)
 }
 
 func (e *Engine) GetContext() context.Context {
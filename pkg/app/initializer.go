@@ -19,7 +19,7 @@ func RegisterDefaultClients(log logger.Service) error {
 		if !ok {
 			return nil, fmt.Errorf("invalid configuration for REST client")
 		}
-		return rest.NewClient(restConfig, log), nil
+		return rest.NewClient(restConfig, log)
 	}); err != nil {
 		return err
 	}
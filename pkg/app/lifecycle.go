@@ -0,0 +1,185 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/logger"
+)
+
+// Shutdown priorities used by the engine; hooks run in ascending order, so register
+// custom hooks relative to these (e.g. PriorityConsumers for a worker pool). The HTTP
+// server is not a hook: it stops before the Lifecycle runs.
+const (
+	PriorityConsumers = 100
+	PriorityClients   = 200
+	PriorityTelemetry = 300
+)
+
+// DefaultLifecycleTimeout bounds Lifecycle.Shutdown when no timeout is configured
+const DefaultLifecycleTimeout = 30 * time.Second
+
+// ErrShutdownTimeout is reported for hooks that did not finish, or never started,
+// before the shutdown timeout
+var ErrShutdownTimeout = errors.New("shutdown timeout exceeded")
+
+type shutdownHook struct {
+	priority int
+	name     string
+	fn       func(ctx context.Context) error
+}
+
+// Lifecycle runs shutdown hooks in priority order within a bounded total time.
+// The engine registers its clients and telemetry on it and Engine.Run runs it on
+// SIGINT/SIGTERM, after the HTTP server (if any) has stopped. It is safe for
+// concurrent use.
+type Lifecycle struct {
+	mu      sync.Mutex
+	hooks   []shutdownHook
+	timeout time.Duration
+	log     logger.Service
+
+	once sync.Once
+	err  error
+}
+
+// NewLifecycle creates a Lifecycle whose Shutdown takes at most timeout
+// (DefaultLifecycleTimeout when zero). log may be nil.
+func NewLifecycle(timeout time.Duration, log logger.Service) *Lifecycle {
+	if timeout <= 0 {
+		timeout = DefaultLifecycleTimeout
+	}
+	return &Lifecycle{timeout: timeout, log: logger.OrNoop(log)}
+}
+
+// Register adds a shutdown hook. Lower priorities run first; hooks with the same
+// priority run in registration order.
+func (l *Lifecycle) Register(priority int, name string, fn func(ctx context.Context) error) {
+	if fn == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, shutdownHook{priority: priority, name: name, fn: fn})
+}
+
+// Shutdown runs every hook once, in order, and returns their errors joined. A hook
+// still running when the timeout (or ctx) expires is abandoned with ErrShutdownTimeout,
+// as are the hooks after it. Later calls return the result of the first one.
+func (l *Lifecycle) Shutdown(ctx context.Context) error {
+	l.once.Do(func() {
+		l.err = l.shutdown(ctx)
+	})
+	return l.err
+}
+
+func (l *Lifecycle) shutdown(ctx context.Context) error {
+	l.mu.Lock()
+	hooks := append([]shutdownHook(nil), l.hooks...)
+	l.mu.Unlock()
+	sort.SliceStable(hooks, func(i, j int) bool { return hooks[i].priority < hooks[j].priority })
+
+	ctx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+
+	var errs []error
+	for _, hook := range hooks {
+		if ctx.Err() != nil {
+			errs = append(errs, fmt.Errorf("shutdown %s: %w", hook.name, ErrShutdownTimeout))
+			continue
+		}
+
+		start := time.Now()
+		err := runHook(ctx, hook.fn)
+		fields := map[string]interface{}{"hook": hook.name, "priority": hook.priority, "elapsed_ms": time.Since(start).Milliseconds()}
+		if err != nil {
+			err = fmt.Errorf("shutdown %s: %w", hook.name, err)
+			l.log.Error(ctx, err, fields)
+			errs = append(errs, err)
+			continue
+		}
+		l.log.Debug(ctx, "shutdown hook completed", fields)
+	}
+	return errors.Join(errs...)
+}
+
+// runHook returns when fn does or when ctx ends, so a hook ignoring ctx cannot hold
+// shutdown past its deadline
+func runHook(ctx context.Context, fn func(ctx context.Context) error) error {
+	done := make(chan error, 1)
+	go func() { done <- fn(ctx) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ErrShutdownTimeout
+	}
+}
+
+// GetLifecycle returns the engine's Lifecycle, creating it on first use
+func (e *Engine) GetLifecycle() *Lifecycle {
+	e.lifecycleOnce.Do(func() {
+		if e.Lifecycle == nil {
+			e.Lifecycle = NewLifecycle(DefaultLifecycleTimeout, e.Log)
+		}
+	})
+	return e.Lifecycle
+}
+
+// registerClientShutdowns registers the close of every connection-holding client built
+// by Init: Kafka at PriorityConsumers, databases and brokers at PriorityClients and
+// telemetry at PriorityTelemetry
+func (e *Engine) registerClientShutdowns() {
+	lc := e.GetLifecycle()
+	closeHook := func(closeFn func() error) func(context.Context) error {
+		return func(context.Context) error { return closeFn() }
+	}
+
+	if e.KafkaConsumer != nil {
+		lc.Register(PriorityConsumers, "kafka", closeHook(e.KafkaConsumer.Close))
+	}
+
+	if e.RedisClient != nil {
+		lc.Register(PriorityClients, "redis", closeHook(e.RedisClient.Close))
+	}
+	if e.Services != nil {
+		for _, name := range sortedKeys(e.Services.RedisClients) {
+			if c := e.Services.RedisClients[name]; c != nil {
+				lc.Register(PriorityClients, "redis."+name, closeHook(c.Close))
+			}
+		}
+		for _, name := range sortedKeys(e.Services.MongoDBClients) {
+			if c := e.Services.MongoDBClients[name]; c != nil {
+				lc.Register(PriorityClients, "mongodb."+name, c.Disconnect)
+			}
+		}
+		for _, name := range sortedKeys(e.Services.RabbitMQClients) {
+			if c := e.Services.RabbitMQClients[name]; c != nil {
+				lc.Register(PriorityClients, "rabbitmq."+name, closeHook(c.Close))
+			}
+		}
+		for _, name := range sortedKeys(e.Services.GRPCClients) {
+			if c := e.Services.GRPCClients[name]; c != nil {
+				lc.Register(PriorityClients, "grpc."+name, closeHook(c.Close))
+			}
+		}
+	}
+
+	if e.Telemetry != nil {
+		lc.Register(PriorityTelemetry, "telemetry", e.Telemetry.Shutdown)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
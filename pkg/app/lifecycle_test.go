@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycle_RunsHooksInPriorityOrder(t *testing.T) {
+	lc := NewLifecycle(time.Second, nil)
+
+	var mu sync.Mutex
+	var order []string
+	hook := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	lc.Register(PriorityTelemetry, "otel", hook("otel"))
+	lc.Register(PriorityClients, "redis", hook("redis"))
+	lc.Register(PriorityClients, "db", hook("db"))
+	lc.Register(PriorityConsumers, "sqs-worker", hook("sqs-worker"))
+
+	require.NoError(t, lc.Shutdown(context.Background()))
+	assert.Equal(t, []string{"sqs-worker", "redis", "db", "otel"}, order)
+}
+
+func TestLifecycle_AggregatesErrorsAndRunsEveryHook(t *testing.T) {
+	lc := NewLifecycle(time.Second, nil)
+	errRedis := errors.New("redis close failed")
+	errOtel := errors.New("flush failed")
+
+	dbClosed := false
+	lc.Register(PriorityClients, "redis", func(context.Context) error { return errRedis })
+	lc.Register(PriorityClients, "db", func(context.Context) error { dbClosed = true; return nil })
+	lc.Register(PriorityTelemetry, "otel", func(context.Context) error { return errOtel })
+
+	err := lc.Shutdown(context.Background())
+
+	assert.ErrorIs(t, err, errRedis)
+	assert.ErrorIs(t, err, errOtel)
+	assert.Contains(t, err.Error(), "shutdown redis")
+	assert.True(t, dbClosed)
+}
+
+func TestLifecycle_TimeoutAbandonsSlowHooks(t *testing.T) {
+	lc := NewLifecycle(50*time.Millisecond, nil)
+	release := make(chan struct{})
+	defer close(release)
+
+	telemetryRan := false
+	lc.Register(PriorityConsumers, "stuck-consumer", func(context.Context) error {
+		<-release // ignores ctx
+		return nil
+	})
+	lc.Register(PriorityTelemetry, "otel", func(context.Context) error { telemetryRan = true; return nil })
+
+	start := time.Now()
+	err := lc.Shutdown(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.ErrorIs(t, err, ErrShutdownTimeout)
+	assert.Contains(t, err.Error(), "shutdown stuck-consumer")
+	assert.Contains(t, err.Error(), "shutdown otel")
+	assert.False(t, telemetryRan)
+}
+
+func TestLifecycle_ShutdownRunsOnce(t *testing.T) {
+	lc := NewLifecycle(time.Second, nil)
+	calls := 0
+	lc.Register(PriorityClients, "redis", func(context.Context) error { calls++; return nil })
+
+	require.NoError(t, lc.Shutdown(context.Background()))
+	require.NoError(t, lc.Shutdown(context.Background()))
+	assert.Equal(t, 1, calls)
+}
+
+func TestEngine_GetLifecycle(t *testing.T) {
+	e := &Engine{}
+	lc := e.GetLifecycle()
+
+	require.NotNil(t, lc)
+	assert.Same(t, lc, e.GetLifecycle())
+}
+
+func TestEngine_RunWithoutRouterRunsLifecycle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &Engine{ctx: ctx}
+	errClose := errors.New("close failed")
+	e.GetLifecycle().Register(PriorityClients, "db", func(context.Context) error { return errClose })
+
+	done := make(chan error, 1)
+	go func() { done <- e.Run() }()
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, errClose)
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after the engine context ended")
+	}
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/glebarez/sqlite"
+	"github.com/skolldire/go-engine/aws/pkg/clients/cognito"
+	"github.com/skolldire/go-engine/aws/pkg/clients/s3"
+	"github.com/skolldire/go-engine/aws/pkg/clients/ses"
+	"github.com/skolldire/go-engine/aws/pkg/clients/sns"
+	"github.com/skolldire/go-engine/aws/pkg/clients/sqs"
+	"github.com/skolldire/go-engine/aws/pkg/clients/ssm"
+	"github.com/skolldire/go-engine/aws/pkg/database/dynamo"
+	"github.com/skolldire/go-engine/database/memcached/pkg/database/memcached"
+	"github.com/skolldire/go-engine/database/mongodb/pkg/database/mongodb"
+	"github.com/skolldire/go-engine/database/redis/pkg/database/redis"
+	"github.com/skolldire/go-engine/database/sql/pkg/database/gormsql"
+	grpcClient "github.com/skolldire/go-engine/messaging/pkg/integration/grpc"
+	"github.com/skolldire/go-engine/messaging/pkg/integration/kafka"
+	"github.com/skolldire/go-engine/messaging/pkg/integration/rabbitmq"
+	"github.com/skolldire/go-engine/pkg/clients/rest"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConstructors_NilLogger builds every client with a nil logger; backends are unreachable
+// on purpose so error paths, which log too, are exercised as well
+func TestConstructors_NilLogger(t *testing.T) {
+	acf := aws.Config{Region: "us-east-1"}
+	const unreachable = "127.0.0.1:1"
+
+	tests := []struct {
+		name string
+		new  func()
+	}{
+		{"rest", func() { _, _ = rest.NewClient(rest.Config{BaseURL: "http://localhost", EnableLogging: true}, nil) }},
+		{"sqs", func() { _ = sqs.NewClient(acf, sqs.Config{EnableLogging: true}, nil) }},
+		{"sns", func() { _ = sns.NewClient(acf, sns.Config{EnableLogging: true}, nil) }},
+		{"ssm", func() { _ = ssm.NewClient(acf, ssm.Config{EnableLogging: true}, nil) }},
+		{"ses", func() { _ = ses.NewClient(acf, ses.Config{EnableLogging: true}, nil) }},
+		{"s3", func() { _ = s3.NewClient(acf, s3.Config{EnableLogging: true}, nil) }},
+		{"dynamo", func() { _ = dynamo.NewClient(acf, dynamo.Config{Endpoint: "http://localhost:8000"}, nil) }},
+		{"cognito", func() {
+			_, _ = cognito.NewClient(cognito.Config{Region: "us-east-1", UserPoolID: "us-east-1_pool", ClientID: "client"}, nil)
+		}},
+		{"redis", func() {
+			_, _ = redis.NewClient(redis.Config{Host: "127.0.0.1", Port: 1, Timeout: 100 * time.Millisecond}, nil)
+		}},
+		{"gormsql", func() { _, _ = gormsql.New(gormsql.Config{Type: "sqlite"}, sqlite.Open(":memory:"), nil) }},
+		{"mongodb", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			_, _ = mongodb.NewClient(ctx, mongodb.Config{URI: "mongodb://" + unreachable, Timeout: 100 * time.Millisecond}, nil)
+		}},
+		{"memcached", func() { _, _ = memcached.NewClient(memcached.Config{Servers: []string{unreachable}}, nil) }},
+		{"rabbitmq", func() { _, _ = rabbitmq.NewClient(rabbitmq.Config{URL: "amqp://" + unreachable}, nil) }},
+		{"kafka", func() { _, _ = kafka.NewClient(kafka.Config{Brokers: []string{unreachable}, Topic: "events"}, nil) }},
+		{"grpc", func() { _, _ = grpcClient.NewCliente(grpcClient.Config{Target: unreachable, EnableLogging: true}, nil) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotPanics(t, tt.new)
+		})
+	}
+}
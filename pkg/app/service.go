@@ -151,6 +151,8 @@ func (c *App) Init() *App {
 		c.Engine.FeatureFlags = dynamic.NewFeatureFlags(c.Engine.Conf.FeatureFlags, c.Engine.Log)
 	}
 
+	c.Engine.registerClientShutdowns()
+
 	if len(initializer.errors) > 0 {
 		c.Engine.errors = append(c.Engine.errors, initializer.errors...)
 	}
@@ -192,7 +194,15 @@ func (i *clients) createClientsHttp(configs []map[string]rest.Config) map[string
 	httpClients := make(map[string]rest.Service)
 	for _, v := range configs {
 		for k, cfg := range v {
-			httpClients[k] = rest.NewClient(cfg, i.log)
+			if !cfg.IsEnabled() {
+				continue
+			}
+			client, err := rest.NewClient(cfg, i.log)
+			if err != nil {
+				i.setError(err)
+				continue
+			}
+			httpClients[k] = client
 		}
 	}
 	return httpClients
@@ -214,28 +224,28 @@ func (i *clients) createClientGRPC(configs []map[string]grpcClient.Config) map[s
 }
 
 func (i *clients) createClientSQS(cfg *sqs.Config) sqs.Service {
-	if cfg == nil {
+	if cfg == nil || !cfg.IsEnabled() {
 		return nil
 	}
 	return sqs.NewClient(i.awsConfig, *cfg, i.log)
 }
 
 func (i *clients) createClientSNS(cfg *sns.Config) sns.Service {
-	if cfg == nil {
+	if cfg == nil || !cfg.IsEnabled() {
 		return nil
 	}
 	return sns.NewClient(i.awsConfig, *cfg, i.log)
 }
 
 func (i *clients) createClientDynamo(cfg *dynamo.Config) dynamo.Service {
-	if cfg == nil {
+	if cfg == nil || !cfg.IsEnabled() {
 		return nil
 	}
 	return dynamo.NewClient(i.awsConfig, *cfg, i.log)
 }
 
 func (i *clients) createClientRedis(cfg *redis.Config) *redis.RedisClient {
-	if cfg == nil {
+	if cfg == nil || !cfg.IsEnabled() {
 		return nil
 	}
 
@@ -271,7 +281,7 @@ func (i *clients) createCloudClient(log logger.Service, tel telemetry.Telemetry)
 }
 
 func (i *clients) createClientCognito(cfg *cognito.Config) cognito.Service {
-	if cfg == nil {
+	if cfg == nil || !cfg.IsEnabled() {
 		return nil
 	}
 	client, err := cognito.NewClient(*cfg, i.log)
@@ -286,6 +296,9 @@ func (i *clients) createClientsSQS(configs []map[string]sqs.Config) map[string]s
 	sqsClients := make(map[string]sqs.Service)
 	for _, v := range configs {
 		for k, cfg := range v {
+			if !cfg.IsEnabled() {
+				continue
+			}
 			sqsClients[k] = sqs.NewClient(i.awsConfig, cfg, i.log)
 		}
 	}
@@ -296,6 +309,9 @@ func (i *clients) createClientsSNS(configs []map[string]sns.Config) map[string]s
 	snsClients := make(map[string]sns.Service)
 	for _, v := range configs {
 		for k, cfg := range v {
+			if !cfg.IsEnabled() {
+				continue
+			}
 			snsClients[k] = sns.NewClient(i.awsConfig, cfg, i.log)
 		}
 	}
@@ -306,6 +322,9 @@ func (i *clients) createClientsDynamo(configs []map[string]dynamo.Config) map[st
 	dynamoClients := make(map[string]dynamo.Service)
 	for _, v := range configs {
 		for k, cfg := range v {
+			if !cfg.IsEnabled() {
+				continue
+			}
 			dynamoClients[k] = dynamo.NewClient(i.awsConfig, cfg, i.log)
 		}
 	}
@@ -316,6 +335,9 @@ func (i *clients) createClientsRedis(configs []map[string]redis.Config) map[stri
 	redisClients := make(map[string]*redis.RedisClient)
 	for _, v := range configs {
 		for k, cfg := range v {
+			if !cfg.IsEnabled() {
+				continue
+			}
 			client, err := redis.NewClient(cfg, i.log)
 			if err != nil {
 				i.setError(err)
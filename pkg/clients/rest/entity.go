@@ -3,10 +3,14 @@ package rest
 
 import (
 	"context"
+	"errors"
+	"io"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/skolldire/go-engine/pkg/core/client"
+	"github.com/skolldire/go-engine/pkg/utilities/circuit_breaker"
 	"github.com/skolldire/go-engine/pkg/utilities/resilience"
 )
 
@@ -15,14 +19,73 @@ const (
 	DefaultRetryWaitTime    = 100 * time.Millisecond
 	DefaultRetryMaxWaitTime = 2 * time.Second
 	DefaultTimeout          = 10 * time.Second
+	DefaultMaxPages         = 100
 )
 
+// ErrMaxPagesExceeded is returned by GetAllPages when a rel="next" link remains after MaxPages pages
+var ErrMaxPagesExceeded = errors.New("rest: max pages exceeded")
+
+// ErrForeignNextLink is returned by GetAllPages when a rel="next" link points outside the
+// client's BaseURL origin; following it would send the caller's headers and credentials
+// to another host.
+var ErrForeignNextLink = errors.New("rest: next link outside base URL origin")
+
 type Config struct {
+	// Enabled set to false keeps the block in config but skips building and
+	// validating the client; nil (omitted) means enabled
+	Enabled        *bool             `mapstructure:"enabled" json:"enabled,omitempty"`
 	BaseURL        string            `mapstructure:"base_url" json:"base_url"`
 	TimeOut        time.Duration     `mapstructure:"timeout" json:"time_out"`
 	EnableLogging  bool              `mapstructure:"enable_logging" json:"enable_logging"`
 	WithResilience bool              `mapstructure:"with_resilience" json:"with_resilience"`
 	Resilience     resilience.Config `mapstructure:"resilience" json:"resilience"`
+	SlowThreshold  time.Duration     `mapstructure:"slow_threshold" json:"slow_threshold"`
+	// TLSConfig enables mutual TLS (ClientCertPath, ClientKeyPath, CACertPath)
+	client.TLSConfig `mapstructure:",squash"`
+	// ForceHTTP2 attempts HTTP/2 even with a custom TLS config (h2 over TLS only)
+	ForceHTTP2 bool `mapstructure:"force_http2" json:"force_http2"`
+	// ReadIdleTimeout sends an HTTP/2 health-check ping when no frame is received
+	// for this long on a connection; 0 disables health checks
+	ReadIdleTimeout time.Duration `mapstructure:"read_idle_timeout" json:"read_idle_timeout"`
+	// PingTimeout closes an HTTP/2 connection whose health-check ping is not
+	// answered in time; 0 uses the http2 default (15s)
+	PingTimeout time.Duration `mapstructure:"ping_timeout" json:"ping_timeout"`
+	// CircuitBreakerPerRoute keys the circuit breaker by the route group set with
+	// WithRouteGroup; see WithRouteGroup. Requires WithResilience.
+	CircuitBreakerPerRoute bool `mapstructure:"circuit_breaker_per_route" json:"circuit_breaker_per_route"`
+	// MaxPages caps the pages followed by GetAllPages; 0 uses DefaultMaxPages
+	MaxPages int `mapstructure:"max_pages" json:"max_pages"`
+	// ResponseValidator, when set, is applied to every successful (2xx) response body
+	ResponseValidator ResponseValidator `mapstructure:"-" json:"-"`
+}
+
+// IsEnabled reports whether the client should be built; true unless enabled: false
+func (c Config) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// WithDefaults returns a copy of c with zero-valued fields set to their defaults:
+// TimeOut (DefaultTimeout) and MaxPages (DefaultMaxPages).
+func (c Config) WithDefaults() Config {
+	if c.TimeOut == 0 {
+		c.TimeOut = DefaultTimeout
+	}
+	if c.MaxPages <= 0 {
+		c.MaxPages = DefaultMaxPages
+	}
+	return c
+}
+
+// ResponseValidator checks a response body before it is returned to the caller
+// (e.g. JSON schema validation). A non-nil error rejects the response.
+type ResponseValidator func(body []byte) error
+
+// FormFile is a file part of a PostMultipart request
+type FormFile struct {
+	FieldName   string // Form field name
+	FileName    string // File name sent in Content-Disposition
+	ContentType string // Optional; defaults to application/octet-stream
+	Reader      io.Reader
 }
 
 type Service interface {
@@ -31,11 +94,25 @@ type Service interface {
 	Put(ctx context.Context, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error)
 	Patch(ctx context.Context, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error)
 	Delete(ctx context.Context, endpoint string, headers map[string]string) (*resty.Response, error)
+	GetAllPages(ctx context.Context, path string, headers map[string]string, accumulate func([]byte) error) error
+	GetStream(ctx context.Context, path string, headers map[string]string) (io.ReadCloser, error)
+	PostMultipart(ctx context.Context, path string, fields map[string]string, files []FormFile, headers map[string]string) (*resty.Response, error)
 	WithLogging(enable bool)
 }
 
+// Option is a functional option applied to the REST client during construction
+type Option func(*restClient)
+
 type restClient struct {
 	*client.BaseClient
 	baseURL    string
 	httpClient *resty.Client
+	validator  ResponseValidator
+	maxPages   int
+
+	routeBreakerConfig *circuit_breaker.Config
+	routeResilienceMu  sync.Mutex
+	routeResilience    map[string]*resilience.Service
 }
+
+type responseValidatorKey struct{}
@@ -0,0 +1,63 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// MaxErrorBodyBytes caps the response body kept in an HTTPError
+const MaxErrorBodyBytes = 64 << 10
+
+// errorPreviewBytes bounds the body excerpt included in HTTPError.Error
+const errorPreviewBytes = 200
+
+// ErrBodyTruncated is returned by HTTPError.DecodeBody when the body was cut at MaxErrorBodyBytes
+var ErrBodyTruncated = errors.New("rest: error body truncated")
+
+// HTTPError is returned for non-2xx responses. Use errors.As to inspect the upstream
+// error details:
+//
+//	var httpErr *rest.HTTPError
+//	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusConflict { ... }
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	// Body holds at most MaxErrorBodyBytes of the response body; see Truncated
+	Body      []byte
+	Headers   http.Header
+	Truncated bool
+}
+
+func newHTTPError(statusCode int, status string, headers http.Header, body []byte) *HTTPError {
+	e := &HTTPError{StatusCode: statusCode, Status: status, Headers: headers}
+	if len(body) > MaxErrorBodyBytes {
+		body = body[:MaxErrorBodyBytes]
+		e.Truncated = true
+	}
+	e.Body = append([]byte(nil), body...)
+	return e
+}
+
+func (e *HTTPError) Error() string {
+	preview := string(e.Body)
+	if len(preview) > errorPreviewBytes {
+		preview = preview[:errorPreviewBytes] + "..."
+	}
+	return fmt.Sprintf("HTTP %d: %s - %s", e.StatusCode, e.Status, preview)
+}
+
+// DecodeBody unmarshals a JSON error body into dest
+func (e *HTTPError) DecodeBody(dest interface{}) error {
+	if e.Truncated {
+		return ErrBodyTruncated
+	}
+	if len(e.Body) == 0 {
+		return errors.New("rest: error body is empty")
+	}
+	if err := json.Unmarshal(e.Body, dest); err != nil {
+		return fmt.Errorf("rest: error body is not valid JSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStatusServer(t *testing.T, status int, contentType, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("X-Request-Id", "req-1")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPError_JSONBody(t *testing.T) {
+	server := newStatusServer(t, http.StatusConflict, "application/json", `{"code":"duplicate","message":"order exists"}`)
+	client := newTestClient(t, Config{BaseURL: server.URL}, &mockLogger{})
+
+	_, err := client.Post(context.Background(), "/orders", map[string]string{"id": "o-1"}, nil)
+
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusConflict, httpErr.StatusCode)
+	assert.Equal(t, "req-1", httpErr.Headers.Get("X-Request-Id"))
+
+	var details struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	require.NoError(t, httpErr.DecodeBody(&details))
+	assert.Equal(t, "duplicate", details.Code)
+	assert.Contains(t, err.Error(), "HTTP 409")
+}
+
+func TestHTTPError_PlainTextBody(t *testing.T) {
+	server := newStatusServer(t, http.StatusBadGateway, "text/plain", "upstream timed out")
+	client := newTestClient(t, Config{BaseURL: server.URL}, &mockLogger{})
+
+	_, err := client.Get(context.Background(), "/orders", nil)
+
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, "upstream timed out", string(httpErr.Body))
+	assert.Error(t, httpErr.DecodeBody(&map[string]interface{}{}))
+}
+
+func TestHTTPError_BodyCapped(t *testing.T) {
+	server := newStatusServer(t, http.StatusInternalServerError, "text/plain", strings.Repeat("x", MaxErrorBodyBytes+10))
+
+	for name, call := range map[string]func(Service) error{
+		"buffered": func(c Service) error { _, err := c.Get(context.Background(), "/big", nil); return err },
+		"stream":   func(c Service) error { _, err := c.GetStream(context.Background(), "/big", nil); return err },
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := call(newTestClient(t, Config{BaseURL: server.URL}, &mockLogger{}))
+
+			var httpErr *HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Len(t, httpErr.Body, MaxErrorBodyBytes)
+			assert.True(t, httpErr.Truncated)
+			assert.True(t, errors.Is(httpErr.DecodeBody(&struct{}{}), ErrBodyTruncated))
+		})
+	}
+}
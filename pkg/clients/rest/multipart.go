@@ -0,0 +1,65 @@
+package rest
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+)
+
+// defaultFormFileContentType is sent for a FormFile without ContentType
+const defaultFormFileContentType = "application/octet-stream"
+
+// PostMultipart POSTs a multipart/form-data body built from fields and files; the
+// Content-Type header carries the generated boundary.
+//
+// With WithResilience, files whose Reader implements io.Seeker are rewound before each
+// attempt. Any other reader can only be sent once, so failures are not retried.
+func (c *restClient) PostMultipart(ctx context.Context, path string, fields map[string]string, files []FormFile, headers map[string]string) (*resty.Response, error) {
+	replayable := true
+	for _, f := range files {
+		if _, ok := f.Reader.(io.Seeker); !ok {
+			replayable = false
+		}
+	}
+
+	attempt := 0
+	return c.executeRequest(ctx, "POST "+path, func(ctx context.Context) (*resty.Response, error) {
+		attempt++
+		if attempt > 1 {
+			if err := rewindFormFiles(files); err != nil {
+				return nil, retry_backoff.Permanent(err)
+			}
+		}
+
+		req := c.httpClient.R().
+			SetContext(ctx).
+			SetHeaders(headers).
+			SetFormData(fields)
+		for _, f := range files {
+			req.SetMultipartField(f.FieldName, f.FileName, cmp.Or(f.ContentType, defaultFormFileContentType), f.Reader)
+		}
+
+		resp, err := req.Post(c.baseURL + path)
+		if err == nil && !replayable {
+			err = validateResponse(resp)
+		}
+		if err != nil && !replayable {
+			return nil, retry_backoff.Permanent(err)
+		}
+		return resp, err
+	})
+}
+
+// rewindFormFiles seeks every file reader back to its start for a retry
+func rewindFormFiles(files []FormFile) error {
+	for _, f := range files {
+		if _, err := f.Reader.(io.Seeker).Seek(0, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to rewind form file %q: %w", f.FileName, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/skolldire/go-engine/pkg/utilities/resilience"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// echoedForm is the parsed multipart form returned by newFormEchoServer
+type echoedForm struct {
+	Fields       map[string]string `json:"fields"`
+	Files        map[string]string `json:"files"`
+	FileNames    map[string]string `json:"file_names"`
+	ContentTypes map[string]string `json:"content_types"`
+}
+
+// newFormEchoServer parses multipart requests and echoes the form as JSON; the first
+// failFirst requests get a 503
+func newFormEchoServer(t *testing.T, failFirst int) (*httptest.Server, *int) {
+	t.Helper()
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if calls <= failFirst {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		form := echoedForm{Fields: map[string]string{}, Files: map[string]string{},
+			FileNames: map[string]string{}, ContentTypes: map[string]string{}}
+		for name, values := range r.MultipartForm.Value {
+			form.Fields[name] = values[0]
+		}
+		for name, headers := range r.MultipartForm.File {
+			f, err := headers[0].Open()
+			require.NoError(t, err)
+			content, _ := io.ReadAll(f)
+			_ = f.Close()
+			form.Files[name] = string(content)
+			form.FileNames[name] = headers[0].Filename
+			form.ContentTypes[name] = headers[0].Header.Get("Content-Type")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(form)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestPostMultipart(t *testing.T) {
+	server, _ := newFormEchoServer(t, 0)
+	client := newTestClient(t, Config{BaseURL: server.URL}, &mockLogger{})
+
+	resp, err := client.PostMultipart(context.Background(), "/upload",
+		map[string]string{"title": "Q3 report"},
+		[]FormFile{
+			{FieldName: "document", FileName: "q3.csv", ContentType: "text/csv", Reader: strings.NewReader("a,b\n1,2")},
+			{FieldName: "cover", FileName: "cover.png", ContentType: "image/png", Reader: strings.NewReader("png")},
+		}, nil)
+	require.NoError(t, err)
+
+	var form echoedForm
+	require.NoError(t, json.Unmarshal(resp.Body(), &form))
+	assert.Equal(t, map[string]string{"title": "Q3 report"}, form.Fields)
+	assert.Equal(t, map[string]string{"document": "a,b\n1,2", "cover": "png"}, form.Files)
+	assert.Equal(t, "q3.csv", form.FileNames["document"])
+	assert.Equal(t, "text/csv", form.ContentTypes["document"])
+}
+
+func TestPostMultipart_RetriesSeekableFiles(t *testing.T) {
+	server, calls := newFormEchoServer(t, 1)
+	log := &mockLogger{}
+	log.On("Debug", mock.Anything, mock.Anything, mock.Anything).Return()
+	client := newTestClient(t, Config{
+		BaseURL:        server.URL,
+		WithResilience: true,
+		Resilience: resilience.Config{
+			RetryConfig: &retry_backoff.Config{MaxRetries: 2, InitialWaitTime: 1, MaxWaitTime: 1},
+		},
+	}, log)
+
+	resp, err := client.PostMultipart(context.Background(), "/upload", nil,
+		[]FormFile{{FieldName: "document", FileName: "q3.csv", Reader: strings.NewReader("a,b")}}, nil)
+	require.NoError(t, err)
+
+	var form echoedForm
+	require.NoError(t, json.Unmarshal(resp.Body(), &form))
+	assert.Equal(t, "a,b", form.Files["document"], "the file is rewound for the retry")
+	assert.Equal(t, "application/octet-stream", form.ContentTypes["document"])
+	assert.Equal(t, 2, *calls)
+}
+
+func TestPostMultipart_NonSeekableFilesNotRetried(t *testing.T) {
+	server, calls := newFormEchoServer(t, 1)
+	log := &mockLogger{}
+	log.On("Error", mock.Anything, mock.Anything, mock.Anything).Return()
+	client := newTestClient(t, Config{
+		BaseURL:        server.URL,
+		WithResilience: true,
+		Resilience: resilience.Config{
+			RetryConfig: &retry_backoff.Config{MaxRetries: 2, InitialWaitTime: 1, MaxWaitTime: 1},
+		},
+	}, log)
+
+	_, err := client.PostMultipart(context.Background(), "/upload", nil,
+		[]FormFile{{FieldName: "document", FileName: "q3.csv", Reader: io.MultiReader(strings.NewReader("a,b"))}}, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 503")
+	assert.Equal(t, 1, *calls)
+}
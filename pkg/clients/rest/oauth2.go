@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// oauth2RefreshSkew refreshes tokens this long before they expire
+const oauth2RefreshSkew = 30 * time.Second
+
+// oauth2DefaultExpiry is assumed when the token endpoint omits expires_in
+const oauth2DefaultExpiry = 5 * time.Minute
+
+// WithOAuth2ClientCredentials authenticates every request with an OAuth2 client-credentials token
+// The token is fetched on first use, cached, and refreshed shortly before it expires. It is
+// attached on each attempt, so a retry after a 401 (WithResilience) uses a freshly fetched token.
+// Neither the client secret nor the token is ever logged.
+func WithOAuth2ClientCredentials(tokenURL, clientID, clientSecret string, scopes []string) Option {
+	return func(c *restClient) {
+		source := newClientCredentialsSource(tokenURL, clientID, clientSecret, scopes, c.httpClient.GetClient().Timeout)
+
+		c.httpClient.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+			token, err := source.Token(req.Context())
+			if err != nil {
+				return err
+			}
+			req.SetAuthToken(token)
+			return nil
+		})
+		c.httpClient.OnAfterResponse(func(_ *resty.Client, resp *resty.Response) error {
+			if resp.StatusCode() == http.StatusUnauthorized {
+				source.Invalidate()
+			}
+			return nil
+		})
+	}
+}
+
+// clientCredentialsSource caches a client-credentials access token
+type clientCredentialsSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scopes       []string
+	httpClient   *resty.Client
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func newClientCredentialsSource(tokenURL, clientID, clientSecret string, scopes []string, timeout time.Duration) *clientCredentialsSource {
+	httpClient := resty.New()
+	if timeout > 0 {
+		httpClient.SetTimeout(timeout)
+	}
+	return &clientCredentialsSource{
+		tokenURL:     tokenURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scopes:       scopes,
+		httpClient:   httpClient,
+	}
+}
+
+// Token returns the cached token, fetching a new one if missing or about to expire
+func (s *clientCredentialsSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Add(oauth2RefreshSkew).Before(s.expiresAt) {
+		return s.token, nil
+	}
+
+	token, expiresIn, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+	s.expiresAt = time.Now().Add(expiresIn)
+	return s.token, nil
+}
+
+// Invalidate drops the cached token so the next request fetches a new one
+func (s *clientCredentialsSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = ""
+	s.expiresAt = time.Time{}
+}
+
+func (s *clientCredentialsSource) fetch(ctx context.Context) (string, time.Duration, error) {
+	form := map[string]string{"grant_type": "client_credentials"}
+	if len(s.scopes) > 0 {
+		form["scope"] = strings.Join(s.scopes, " ")
+	}
+
+	resp, err := s.httpClient.R().
+		SetContext(ctx).
+		SetBasicAuth(s.clientID, s.clientSecret).
+		SetFormData(form).
+		Post(s.tokenURL)
+	if err != nil {
+		return "", 0, fmt.Errorf("oauth2 token request failed: %w", err)
+	}
+	if resp.StatusCode() < 200 || resp.StatusCode() > 299 {
+		// The body is not included: token endpoints may echo request parameters
+		return "", 0, fmt.Errorf("oauth2 token request failed: HTTP %d", resp.StatusCode())
+	}
+
+	var tokenResp oauth2TokenResponse
+	if err := json.Unmarshal(resp.Body(), &tokenResp); err != nil {
+		return "", 0, fmt.Errorf("oauth2 token response is not valid JSON: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return "", 0, errors.New("oauth2 token response has no access_token")
+	}
+
+	expiresIn := oauth2DefaultExpiry
+	if tokenResp.ExpiresIn > 0 {
+		expiresIn = time.Duration(tokenResp.ExpiresIn) * time.Second
+	}
+	return tokenResp.AccessToken, expiresIn, nil
+}
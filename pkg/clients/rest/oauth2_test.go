@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTokenServer issues token-1, token-2, ... and records the client credentials it received.
+func newTokenServer(t *testing.T, fetches *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "client-id" || pass != "client-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.FormValue("grant_type") != "client_credentials" || r.FormValue("scope") != "read write" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		n := atomic.AddInt32(fetches, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWithOAuth2ClientCredentials_CachesToken(t *testing.T) {
+	var fetches int32
+	tokenServer := newTokenServer(t, &fetches)
+
+	var seen []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+	}))
+	defer api.Close()
+
+	client := newTestClient(t, Config{BaseURL: api.URL}, &mockLogger{},
+		WithOAuth2ClientCredentials(tokenServer.URL, "client-id", "client-secret", []string{"read", "write"}))
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Get(context.Background(), "/resource", nil)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+	assert.Equal(t, []string{"Bearer token-1", "Bearer token-1"}, seen)
+}
+
+func TestWithOAuth2ClientCredentials_RefreshesAfter401(t *testing.T) {
+	var fetches int32
+	tokenServer := newTokenServer(t, &fetches)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer api.Close()
+
+	client := newTestClient(t, Config{BaseURL: api.URL}, &mockLogger{},
+		WithOAuth2ClientCredentials(tokenServer.URL, "client-id", "client-secret", []string{"read", "write"}))
+
+	_, err := client.Get(context.Background(), "/resource", nil)
+	require.Error(t, err)
+
+	_, err = client.Get(context.Background(), "/resource", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+}
+
+func TestWithOAuth2ClientCredentials_TokenErrorHidesSecret(t *testing.T) {
+	var fetches int32
+	tokenServer := newTokenServer(t, &fetches)
+
+	client := newTestClient(t, Config{BaseURL: "http://127.0.0.1:0"}, &mockLogger{},
+		WithOAuth2ClientCredentials(tokenServer.URL, "client-id", "wrong-secret", nil))
+
+	_, err := client.Get(context.Background(), "/resource", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 401")
+	assert.NotContains(t, err.Error(), "wrong-secret")
+}
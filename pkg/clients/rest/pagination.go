@@ -0,0 +1,129 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// GetAllPages GETs path and follows RFC 5988 rel="next" Link headers until none remain,
+// calling accumulate with each page body. Stops on the first error from a request or
+// from accumulate, on ctx cancellation, and returns ErrMaxPagesExceeded after MaxPages pages.
+// Next links must stay on the scheme and host of BaseURL; others fail with ErrForeignNextLink.
+func (c *restClient) GetAllPages(ctx context.Context, path string, headers map[string]string, accumulate func([]byte) error) error {
+	next := c.baseURL + path
+
+	for page := 0; next != ""; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if page >= c.maxPages {
+			return fmt.Errorf("%w: %d", ErrMaxPagesExceeded, c.maxPages)
+		}
+
+		pageURL := next
+		resp, err := c.executeRequest(ctx, "GET "+path, func(ctx context.Context) (*resty.Response, error) {
+			return c.httpClient.R().
+				SetContext(ctx).
+				SetHeaders(headers).
+				Get(pageURL)
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := accumulate(resp.Body()); err != nil {
+			return err
+		}
+
+		next, err = resolveNextLink(pageURL, resp.Header().Values("Link"))
+		if err != nil {
+			return err
+		}
+		if next != "" && !sameOrigin(c.baseURL, next) {
+			return fmt.Errorf("%w: %s", ErrForeignNextLink, next)
+		}
+	}
+
+	return nil
+}
+
+// resolveNextLink returns the rel="next" target resolved against the current page URL, or ""
+func resolveNextLink(current string, linkHeaders []string) (string, error) {
+	next := parseNextLink(linkHeaders)
+	if next == "" {
+		return "", nil
+	}
+
+	base, err := url.Parse(current)
+	if err != nil {
+		return "", fmt.Errorf("invalid page URL %q: %w", current, err)
+	}
+	ref, err := url.Parse(next)
+	if err != nil {
+		return "", fmt.Errorf("invalid next link %q: %w", next, err)
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+// sameOrigin reports whether target has the scheme and host of base
+func sameOrigin(base, target string) bool {
+	b, err := url.Parse(base)
+	if err != nil {
+		return false
+	}
+	t, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(b.Scheme, t.Scheme) && strings.EqualFold(b.Host, t.Host)
+}
+
+// parseNextLink extracts the rel="next" URI from Link header values
+// e.g. `<https://api.example.com/items?page=2>; rel="next", <...>; rel="last"`
+func parseNextLink(linkHeaders []string) string {
+	for _, header := range linkHeaders {
+		rest := header
+		for {
+			start := strings.Index(rest, "<")
+			if start < 0 {
+				break
+			}
+			end := strings.Index(rest[start:], ">")
+			if end < 0 {
+				break
+			}
+			target := rest[start+1 : start+end]
+			rest = rest[start+end+1:]
+
+			params := rest
+			if nextLink := strings.Index(rest, "<"); nextLink >= 0 {
+				params = rest[:nextLink]
+			}
+			if hasRelNext(params) {
+				return target
+			}
+		}
+	}
+	return ""
+}
+
+// hasRelNext reports whether link params contain rel="next" (rel may list several types)
+func hasRelNext(params string) bool {
+	for _, param := range strings.Split(params, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+			continue
+		}
+		value = strings.Trim(value, `", `)
+		for _, rel := range strings.Fields(value) {
+			if strings.EqualFold(rel, "next") {
+				return true
+			}
+		}
+	}
+	return false
+}
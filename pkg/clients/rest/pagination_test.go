@@ -0,0 +1,139 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPagedServer serves pages 1..total at /items?page=N with a rel="next" Link header.
+func newPagedServer(t *testing.T, total int) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		_, _ = fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page)
+		if page < total {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/items?page=%d>; rel="next", <%s/items?page=%d>; rel="last"`,
+				server.URL, page+1, server.URL, total))
+		}
+		_, _ = fmt.Fprintf(w, "page-%d", page)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetAllPages_FollowsNextLinks(t *testing.T) {
+	server := newPagedServer(t, 3)
+	client := newTestClient(t, Config{BaseURL: server.URL}, &mockLogger{})
+
+	var pages []string
+	err := client.GetAllPages(context.Background(), "/items", nil, func(body []byte) error {
+		pages = append(pages, string(body))
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"page-1", "page-2", "page-3"}, pages)
+}
+
+func TestGetAllPages_RejectsForeignNextLink(t *testing.T) {
+	var foreignCalls int
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		foreignCalls++
+	}))
+	t.Cleanup(foreign.Close)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<%s/steal?page=2>; rel="next"`, foreign.URL))
+		_, _ = fmt.Fprint(w, "page-1")
+	}))
+	t.Cleanup(server.Close)
+	client := newTestClient(t, Config{BaseURL: server.URL}, &mockLogger{})
+
+	var pages int
+	err := client.GetAllPages(context.Background(), "/items", map[string]string{"Authorization": "Bearer secret"},
+		func([]byte) error {
+			pages++
+			return nil
+		})
+
+	assert.ErrorIs(t, err, ErrForeignNextLink)
+	assert.Equal(t, 1, pages)
+	assert.Zero(t, foreignCalls, "credentials are not sent to the foreign host")
+}
+
+func TestGetAllPages_MaxPages(t *testing.T) {
+	server := newPagedServer(t, 5)
+	client := newTestClient(t, Config{BaseURL: server.URL, MaxPages: 2}, &mockLogger{})
+
+	calls := 0
+	err := client.GetAllPages(context.Background(), "/items", nil, func(body []byte) error {
+		calls++
+		return nil
+	})
+
+	assert.ErrorIs(t, err, ErrMaxPagesExceeded)
+	assert.Equal(t, 2, calls)
+}
+
+func TestGetAllPages_AccumulateError(t *testing.T) {
+	server := newPagedServer(t, 3)
+	client := newTestClient(t, Config{BaseURL: server.URL}, &mockLogger{})
+
+	stop := errors.New("stop")
+	err := client.GetAllPages(context.Background(), "/items", nil, func(body []byte) error {
+		return stop
+	})
+
+	assert.ErrorIs(t, err, stop)
+}
+
+func TestGetAllPages_ContextCancelled(t *testing.T) {
+	server := newPagedServer(t, 3)
+	client := newTestClient(t, Config{BaseURL: server.URL}, &mockLogger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := client.GetAllPages(ctx, "/items", nil, func(body []byte) error {
+		calls++
+		cancel()
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestParseNextLink(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		want    string
+	}{
+		{"none", nil, ""},
+		{"next first", []string{`<https://a/x?page=2>; rel="next", <https://a/x?page=9>; rel="last"`}, "https://a/x?page=2"},
+		{"next last", []string{`<https://a/x?page=1>; rel="prev", <https://a/x?page=3>; rel="next"`}, "https://a/x?page=3"},
+		{"unquoted rel", []string{`</x?page=2>; rel=next`}, "/x?page=2"},
+		{"multiple rel types", []string{`</x?page=2>; rel="next last"`}, "/x?page=2"},
+		{"separate headers", []string{`</x?page=1>; rel="prev"`, `</x?page=3>; rel="next"`}, "/x?page=3"},
+		{"no next", []string{`</x?page=1>; rel="prev"`}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseNextLink(tt.headers))
+		})
+	}
+}
+
+func TestResolveNextLink_Relative(t *testing.T) {
+	next, err := resolveNextLink("https://api.example.com/v1/items?page=1", []string{`</v1/items?page=2>; rel="next"`})
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.example.com/v1/items?page=2", next)
+}
@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skolldire/go-engine/pkg/utilities/circuit_breaker"
+	"github.com/skolldire/go-engine/pkg/utilities/resilience"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newPermissiveLogger() *mockLogger {
+	log := &mockLogger{}
+	for _, method := range []string{"Debug", "Info", "Warn", "Error"} {
+		log.On(method, mock.Anything, mock.Anything, mock.Anything).Maybe().Return()
+	}
+	return log
+}
+
+func newRouteBreakerClient(t *testing.T, perRoute bool) Service {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/billing" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return newTestClient(t, Config{
+		BaseURL:                server.URL,
+		WithResilience:         true,
+		CircuitBreakerPerRoute: perRoute,
+		Resilience: resilience.Config{
+			RetryConfig: &retry_backoff.Config{MaxRetries: 1, InitialWaitTime: 1, MaxWaitTime: 1},
+			CircuitBreakerConfig: &circuit_breaker.Config{
+				Name:                 "upstream",
+				RequestThreshold:     2,
+				FailureRateThreshold: 0.5,
+			},
+		},
+	}, newPermissiveLogger())
+}
+
+func TestCircuitBreakerPerRoute_IsolatesFailingRoute(t *testing.T) {
+	client := newRouteBreakerClient(t, true)
+	billing := WithRouteGroup(context.Background(), "billing")
+	users := WithRouteGroup(context.Background(), "users")
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Get(billing, "/billing", nil)
+		require.Error(t, err)
+	}
+
+	_, err := client.Get(billing, "/billing", nil)
+	assert.ErrorIs(t, err, circuit_breaker.ErrCircuitOpen)
+
+	_, err = client.Get(users, "/users", nil)
+	assert.NoError(t, err)
+}
+
+func TestCircuitBreakerShared_TripsForAllRoutes(t *testing.T) {
+	client := newRouteBreakerClient(t, false)
+	billing := WithRouteGroup(context.Background(), "billing")
+	users := WithRouteGroup(context.Background(), "users")
+
+	for i := 0; i < 2; i++ {
+		_, err := client.Get(billing, "/billing", nil)
+		require.Error(t, err)
+	}
+
+	_, err := client.Get(users, "/users", nil)
+	assert.ErrorIs(t, err, circuit_breaker.ErrCircuitOpen)
+}
+
+func TestResilienceForRoute_NamesBreakerPerGroup(t *testing.T) {
+	client := newRouteBreakerClient(t, true).(*restClient)
+
+	_, none := client.resilienceForRoute(context.Background())
+	assert.Nil(t, none)
+
+	group, first := client.resilienceForRoute(WithRouteGroup(context.Background(), "users"))
+	_, again := client.resilienceForRoute(WithRouteGroup(context.Background(), "users"))
+	assert.Equal(t, "users", group)
+	assert.Same(t, first, again)
+}
+
+func TestCircuitBreakerPerRoute_SharesBulkhead(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/billing" {
+			close(started)
+			<-release
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestClient(t, Config{
+		BaseURL:                server.URL,
+		WithResilience:         true,
+		CircuitBreakerPerRoute: true,
+		Resilience: resilience.Config{
+			RetryConfig: &retry_backoff.Config{MaxRetries: 1, InitialWaitTime: 1, MaxWaitTime: 1},
+			Bulkhead:    &resilience.BulkheadConfig{MaxConcurrent: 1},
+		},
+	}, newPermissiveLogger())
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Get(WithRouteGroup(context.Background(), "billing"), "/billing", nil)
+		done <- err
+	}()
+	<-started
+
+	_, err := client.Get(WithRouteGroup(context.Background(), "users"), "/users", nil)
+	assert.ErrorIs(t, err, resilience.ErrBulkheadFull, "both route groups count against one limit")
+	_, err = client.Get(context.Background(), "/users", nil)
+	assert.ErrorIs(t, err, resilience.ErrBulkheadFull, "so do requests without a route group")
+
+	close(release)
+	require.NoError(t, <-done)
+}
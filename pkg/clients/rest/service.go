@@ -4,57 +4,97 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/skolldire/go-engine/pkg/core/client"
+	"github.com/skolldire/go-engine/pkg/utilities/error_handler"
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
+	"github.com/skolldire/go-engine/pkg/utilities/resilience"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
 )
 
-func NewClient(cfg Config, log logger.Service) Service {
+func NewClient(cfg Config, log logger.Service, opts ...Option) (Service, error) {
+	log = logger.OrNoop(log)
+
+	cfg = cfg.WithDefaults()
+	tlsCfg, err := cfg.TLSConfig.Load()
+	if err != nil {
+		return nil, fmt.Errorf("invalid REST client TLS config: %w", err)
+	}
+
 	httpClient := resty.New()
-	timeout := cfg.TimeOut
-	if timeout == 0 {
-		timeout = DefaultTimeout
+	if usesHTTP2Tuning(cfg) {
+		transport, err := newHTTP2Transport(cfg, tlsCfg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid REST client HTTP/2 config: %w", err)
+		}
+		httpClient.SetTransport(transport)
+	} else if tlsCfg != nil {
+		httpClient.SetTLSClientConfig(tlsCfg)
 	}
-	if timeout > 0 {
-		httpClient.SetTimeout(timeout)
+	if cfg.TimeOut > 0 {
+		httpClient.SetTimeout(cfg.TimeOut)
 	}
 
 	baseConfig := client.BaseConfig{
 		EnableLogging:  cfg.EnableLogging,
 		WithResilience: cfg.WithResilience,
 		Resilience:     cfg.Resilience,
-		Timeout:        timeout,
+		Timeout:        cfg.TimeOut,
+		SlowThreshold:  cfg.SlowThreshold,
 	}
 
 	c := &restClient{
 		BaseClient: client.NewBaseClientWithName(baseConfig, log, "REST"),
 		baseURL:    cfg.BaseURL,
 		httpClient: httpClient,
+		validator:  cfg.ResponseValidator,
+		maxPages:   cfg.MaxPages,
+	}
+	if cfg.WithResilience && cfg.CircuitBreakerPerRoute {
+		c.routeBreakerConfig = cfg.Resilience.CircuitBreakerConfig
+		c.routeResilience = make(map[string]*resilience.Service)
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
 
-	return c
+	return c, nil
 }
 
-func (c *restClient) executeRequest(ctx context.Context, operationName string, reqFunc func() (*resty.Response, error)) (*resty.Response, error) {
-	result, err := c.Execute(ctx, operationName, func() (interface{}, error) {
+// executeRequest runs reqFunc through executeOperation; reqFunc receives the attempt
+// context, bounded by resilience.Config.PerAttemptTimeout when set
+func (c *restClient) executeRequest(ctx context.Context, operationName string, reqFunc func(ctx context.Context) (*resty.Response, error)) (*resty.Response, error) {
+	return c.executeOperation(ctx, operationName, func(ctx context.Context) (interface{}, error) {
 		return c.processRequest(ctx, reqFunc)
 	})
+}
 
-	if err != nil {
-		return nil, err
+// executeOperation runs operation through the client's (or the route group's) resilience
+// and returns its *resty.Response
+func (c *restClient) executeOperation(ctx context.Context, operationName string, operation client.ContextOperation) (*resty.Response, error) {
+	var result interface{}
+	var err error
+	if group, rs := c.resilienceForRoute(ctx); rs != nil {
+		result, err = c.executeRoute(ctx, operationName, group, rs, operation)
+	} else {
+		result, err = c.ExecuteContext(ctx, operationName, operation)
 	}
 
-	resp, err := client.SafeTypeAssert[*resty.Response](result)
 	if err != nil {
 		return nil, err
 	}
 
-	return resp, nil
+	return client.SafeTypeAssert[*resty.Response](result)
 }
 
-func (c *restClient) processRequest(ctx context.Context, reqFunc func() (*resty.Response, error)) (*resty.Response, error) {
-	resp, err := reqFunc()
+func (c *restClient) processRequest(ctx context.Context, reqFunc func(ctx context.Context) (*resty.Response, error)) (*resty.Response, error) {
+	resp, err := reqFunc(ctx)
 	if err != nil {
 		if c.IsLoggingEnabled() {
 			c.GetLogger().Warn(ctx, "request_failed",
@@ -73,11 +113,46 @@ func (c *restClient) processRequest(ctx context.Context, reqFunc func() (*resty.
 		return nil, err
 	}
 
+	if err := c.validateBody(ctx, resp); err != nil {
+		if c.IsLoggingEnabled() {
+			c.GetLogger().Warn(ctx, "invalid_response",
+				map[string]interface{}{"event": "invalid_response",
+					"status": resp.StatusCode(),
+					"error":  err.Error()})
+		}
+		return nil, err
+	}
+
 	return resp, nil
 }
 
+// WithResponseValidator returns a context that validates the response of a single call
+// It takes precedence over Config.ResponseValidator for requests made with this context.
+func WithResponseValidator(ctx context.Context, validator ResponseValidator) context.Context {
+	return context.WithValue(ctx, responseValidatorKey{}, validator)
+}
+
+// validateBody applies the per-call or per-client ResponseValidator, if any
+func (c *restClient) validateBody(ctx context.Context, resp *resty.Response) error {
+	validator := c.validator
+	if v, ok := ctx.Value(responseValidatorKey{}).(ResponseValidator); ok && v != nil {
+		validator = v
+	}
+	if validator == nil {
+		return nil
+	}
+
+	if err := validator(resp.Body()); err != nil {
+		return error_handler.NewCommonApiError(error_handler.CodeValidationFailed,
+			"upstream response failed validation", err, http.StatusBadGateway).
+			WithDetail("validation", err.Error()).
+			WithDetail("status", strconv.Itoa(resp.StatusCode()))
+	}
+	return nil
+}
+
 func (c *restClient) Get(ctx context.Context, endpoint string, headers map[string]string) (*resty.Response, error) {
-	return c.executeRequest(ctx, "GET "+endpoint, func() (*resty.Response, error) {
+	return c.executeRequest(ctx, "GET "+endpoint, func(ctx context.Context) (*resty.Response, error) {
 		return c.httpClient.R().
 			SetContext(ctx).
 			SetHeaders(headers).
@@ -86,7 +161,7 @@ func (c *restClient) Get(ctx context.Context, endpoint string, headers map[strin
 }
 
 func (c *restClient) Post(ctx context.Context, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error) {
-	return c.executeRequest(ctx, "POST "+endpoint, func() (*resty.Response, error) {
+	return c.executeRequest(ctx, "POST "+endpoint, func(ctx context.Context) (*resty.Response, error) {
 		return c.httpClient.R().
 			SetBody(body).
 			SetContext(ctx).
@@ -96,7 +171,7 @@ func (c *restClient) Post(ctx context.Context, endpoint string, body interface{}
 }
 
 func (c *restClient) Put(ctx context.Context, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error) {
-	return c.executeRequest(ctx, "PUT "+endpoint, func() (*resty.Response, error) {
+	return c.executeRequest(ctx, "PUT "+endpoint, func(ctx context.Context) (*resty.Response, error) {
 		return c.httpClient.R().
 			SetBody(body).
 			SetContext(ctx).
@@ -106,7 +181,7 @@ func (c *restClient) Put(ctx context.Context, endpoint string, body interface{},
 }
 
 func (c *restClient) Patch(ctx context.Context, endpoint string, body interface{}, headers map[string]string) (*resty.Response, error) {
-	return c.executeRequest(ctx, "PATCH "+endpoint, func() (*resty.Response, error) {
+	return c.executeRequest(ctx, "PATCH "+endpoint, func(ctx context.Context) (*resty.Response, error) {
 		return c.httpClient.R().
 			SetBody(body).
 			SetContext(ctx).
@@ -116,7 +191,7 @@ func (c *restClient) Patch(ctx context.Context, endpoint string, body interface{
 }
 
 func (c *restClient) Delete(ctx context.Context, endpoint string, headers map[string]string) (*resty.Response, error) {
-	return c.executeRequest(ctx, "DELETE "+endpoint, func() (*resty.Response, error) {
+	return c.executeRequest(ctx, "DELETE "+endpoint, func(ctx context.Context) (*resty.Response, error) {
 		return c.httpClient.R().
 			SetContext(ctx).
 			SetHeaders(headers).
@@ -135,13 +210,36 @@ func validateResponse(resp *resty.Response) error {
 	if resp.StatusCode() >= 200 && resp.StatusCode() <= 299 {
 		return nil
 	}
-	bodyPreview := ""
-	if resp.Body() != nil && len(resp.Body()) > 0 {
-		text := string(resp.Body())
-		if len(text) > 200 {
-			text = text[:200] + "..."
+	return withRetryAfter(resp, newHTTPError(resp.StatusCode(), resp.Status(), resp.Header(), resp.Body()))
+}
+
+// withRetryAfter attaches the Retry-After delay of a 429 or 503 response to err
+func withRetryAfter(resp *resty.Response, err error) error {
+	if resp.StatusCode() != http.StatusTooManyRequests && resp.StatusCode() != http.StatusServiceUnavailable {
+		return err
+	}
+	if wait, ok := parseRetryAfter(resp.Header().Get("Retry-After"), time.Now()); ok {
+		return retry_backoff.RetryAfter(err, wait)
+	}
+	return err
+}
+
+// parseRetryAfter reads a Retry-After header value, either delay-seconds or an
+// HTTP-date; a date in the past yields zero
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
 		}
-		bodyPreview = text
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
 	}
-	return fmt.Errorf("HTTP %d: %s - %s", resp.StatusCode(), resp.Status(), bodyPreview)
+	return max(date.Sub(now), 0), true
 }
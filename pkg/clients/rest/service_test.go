@@ -2,17 +2,25 @@ package rest
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/skolldire/go-engine/pkg/core/client"
 	"github.com/skolldire/go-engine/pkg/utilities/circuit_breaker"
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
+	"github.com/skolldire/go-engine/pkg/utilities/error_handler"
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
 	"github.com/skolldire/go-engine/pkg/utilities/resilience"
 	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type mockLogger struct {
@@ -38,6 +46,14 @@ func (m *mockLogger) WithFields(fields map[string]interface{}) logger.Service
 func (m *mockLogger) GetLogLevel() string                                                      { return "info" }
 func (m *mockLogger) SetLogLevel(level string) error                                           { return nil }
 
+// newTestClient builds a client and fails the test on construction errors.
+func newTestClient(t *testing.T, cfg Config, log logger.Service, opts ...Option) Service {
+	t.Helper()
+	client, err := NewClient(cfg, log, opts...)
+	require.NoError(t, err)
+	return client
+}
+
 func TestNewClient(t *testing.T) {
 	cfg := Config{
 		BaseURL:        "https://api.example.com",
@@ -47,8 +63,9 @@ func TestNewClient(t *testing.T) {
 	}
 	log := &mockLogger{}
 
-	client := NewClient(cfg, log)
+	client, err := NewClient(cfg, log)
 
+	assert.NoError(t, err)
 	assert.NotNil(t, client)
 	assert.IsType(t, &restClient{}, client)
 }
@@ -62,7 +79,7 @@ func TestNewClient_DefaultTimeout(t *testing.T) {
 	}
 	log := &mockLogger{}
 
-	client := NewClient(cfg, log)
+	client := newTestClient(t, cfg, log)
 
 	assert.NotNil(t, client)
 	restClient := client.(*restClient)
@@ -71,6 +88,17 @@ func TestNewClient_DefaultTimeout(t *testing.T) {
 	assert.NotNil(t, restClient.baseURL)
 }
 
+func TestConfig_WithDefaults(t *testing.T) {
+	cfg := Config{BaseURL: "https://api.example.com"}.WithDefaults()
+
+	assert.Equal(t, DefaultTimeout, cfg.TimeOut)
+	assert.Equal(t, DefaultMaxPages, cfg.MaxPages)
+
+	custom := Config{TimeOut: 2 * time.Second, MaxPages: 5}.WithDefaults()
+	assert.Equal(t, 2*time.Second, custom.TimeOut)
+	assert.Equal(t, 5, custom.MaxPages)
+}
+
 func TestNewClient_WithResilience(t *testing.T) {
 	cfg := Config{
 		BaseURL:        "https://api.example.com",
@@ -88,7 +116,7 @@ func TestNewClient_WithResilience(t *testing.T) {
 	}
 	log := &mockLogger{}
 
-	client := NewClient(cfg, log)
+	client := newTestClient(t, cfg, log)
 
 	assert.NotNil(t, client)
 }
@@ -101,7 +129,7 @@ func TestRestClient_WithLogging(t *testing.T) {
 	}
 	log := &mockLogger{}
 
-	client := NewClient(cfg, log)
+	client := newTestClient(t, cfg, log)
 	client.WithLogging(true)
 
 	restClient := client.(*restClient)
@@ -124,7 +152,7 @@ func TestRestClient_Get(t *testing.T) {
 	}
 	log := &mockLogger{}
 
-	client := NewClient(cfg, log)
+	client := newTestClient(t, cfg, log)
 	restClient := client.(*restClient)
 
 	// Mock HTTP server would be needed for full integration test
@@ -146,7 +174,7 @@ func TestRestClient_Post(t *testing.T) {
 	}
 	log := &mockLogger{}
 
-	client := NewClient(cfg, log)
+	client := newTestClient(t, cfg, log)
 	restClient := client.(*restClient)
 
 	ctx := context.Background()
@@ -166,7 +194,7 @@ func TestRestClient_Put(t *testing.T) {
 	}
 	log := &mockLogger{}
 
-	client := NewClient(cfg, log)
+	client := newTestClient(t, cfg, log)
 	restClient := client.(*restClient)
 
 	ctx := context.Background()
@@ -185,7 +213,7 @@ func TestRestClient_Patch(t *testing.T) {
 	}
 	log := &mockLogger{}
 
-	client := NewClient(cfg, log)
+	client := newTestClient(t, cfg, log)
 	restClient := client.(*restClient)
 
 	ctx := context.Background()
@@ -204,7 +232,7 @@ func TestRestClient_Delete(t *testing.T) {
 	}
 	log := &mockLogger{}
 
-	client := NewClient(cfg, log)
+	client := newTestClient(t, cfg, log)
 	restClient := client.(*restClient)
 
 	ctx := context.Background()
@@ -223,12 +251,12 @@ func TestRestClient_ProcessRequest_Error(t *testing.T) {
 	log := &mockLogger{}
 	log.On("Warn", mock.Anything, "request_failed", mock.Anything).Return()
 
-	client := NewClient(cfg, log)
+	client := newTestClient(t, cfg, log)
 	restClient := client.(*restClient)
 
 	ctx := context.Background()
 	testErr := errors.New("request failed")
-	reqFunc := func() (*resty.Response, error) {
+	reqFunc := func(context.Context) (*resty.Response, error) {
 		return nil, testErr
 	}
 
@@ -247,12 +275,12 @@ func TestRestClient_ProcessRequest_InvalidResponse(t *testing.T) {
 	log := &mockLogger{}
 	log.On("Warn", mock.Anything, mock.Anything, mock.Anything).Return()
 
-	client := NewClient(cfg, log)
+	client := newTestClient(t, cfg, log)
 	restClient := client.(*restClient)
 
 	ctx := context.Background()
 	// Simular un error en la función de request
-	reqFunc := func() (*resty.Response, error) {
+	reqFunc := func(context.Context) (*resty.Response, error) {
 		return nil, errors.New("request failed")
 	}
 
@@ -260,3 +288,185 @@ func TestRestClient_ProcessRequest_InvalidResponse(t *testing.T) {
 	assert.Error(t, err)
 	log.AssertExpectations(t)
 }
+
+func newJSONServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func requireIDField(body []byte) error {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return err
+	}
+	if _, ok := payload["id"]; !ok {
+		return errors.New("missing field: id")
+	}
+	return nil
+}
+
+func TestRestClient_ResponseValidator_Client(t *testing.T) {
+	server := newJSONServer(t, `{"name":"x"}`)
+	client := newTestClient(t, Config{BaseURL: server.URL, ResponseValidator: requireIDField}, &mockLogger{})
+
+	_, err := client.Get(context.Background(), "/items/1", nil)
+
+	var apiErr *error_handler.CommonApiError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, error_handler.CodeValidationFailed, apiErr.Code)
+	assert.Equal(t, http.StatusBadGateway, apiErr.HttpCode)
+	assert.Equal(t, "missing field: id", apiErr.Details["validation"])
+}
+
+func TestRestClient_ResponseValidator_PerCall(t *testing.T) {
+	server := newJSONServer(t, `{"id":1}`)
+	client := newTestClient(t, Config{BaseURL: server.URL}, &mockLogger{})
+
+	resp, err := client.Get(context.Background(), "/items/1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+
+	ctx := WithResponseValidator(context.Background(), func(body []byte) error {
+		return errors.New("unexpected shape")
+	})
+	_, err = client.Get(ctx, "/items/1", nil)
+
+	var apiErr *error_handler.CommonApiError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "unexpected shape", apiErr.Details["validation"])
+}
+
+func TestRestClient_ResponseValidator_Passes(t *testing.T) {
+	server := newJSONServer(t, `{"id":1}`)
+	client := newTestClient(t, Config{BaseURL: server.URL, ResponseValidator: requireIDField}, &mockLogger{})
+
+	resp, err := client.Get(context.Background(), "/items/1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":1}`, string(resp.Body()))
+}
+
+func TestNewClient_TLSCertWithoutKey(t *testing.T) {
+	cfg := Config{
+		BaseURL:   "https://api.example.com",
+		TLSConfig: client.TLSConfig{ClientCertPath: "/etc/certs/client.crt"},
+	}
+
+	c, err := NewClient(cfg, &mockLogger{})
+
+	assert.Nil(t, c)
+	assert.ErrorIs(t, err, client.ErrTLSCertKeyMismatch)
+}
+
+func TestRestClient_HonorsRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	t.Cleanup(server.Close)
+	log := &mockLogger{}
+	log.On("Debug", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	fake := clock.NewFake(time.Unix(0, 0))
+	client := newTestClient(t, Config{
+		BaseURL:        server.URL,
+		WithResilience: true,
+		Resilience: resilience.Config{
+			RetryConfig: &retry_backoff.Config{MaxRetries: 2, InitialWaitTime: 1, MaxWaitTime: 1},
+			Clock:       fake,
+		},
+	}, log)
+
+	type result struct {
+		resp *resty.Response
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		resp, err := client.Get(context.Background(), "/orders", nil)
+		done <- result{resp, err}
+	}()
+
+	require.Eventually(t, func() bool { return fake.Waiters() == 1 }, time.Second, time.Millisecond)
+	// The 1ms backoff is raised to the 2s Retry-After
+	fake.Advance(time.Second)
+	assert.Equal(t, 1, fake.Waiters(), "still waiting for the Retry-After delay")
+	fake.Advance(time.Second)
+
+	got := <-done
+	require.NoError(t, got.err)
+	assert.Equal(t, http.StatusOK, got.resp.StatusCode())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "seconds", value: "2", want: 2 * time.Second, wantOK: true},
+		{name: "http date", value: "Mon, 01 Jan 2024 12:00:30 GMT", want: 30 * time.Second, wantOK: true},
+		{name: "past date", value: "Mon, 01 Jan 2024 11:00:00 GMT", want: 0, wantOK: true},
+		{name: "empty", value: ""},
+		{name: "negative", value: "-1"},
+		{name: "garbage", value: "soon"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRestClient_PerAttemptTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select { // the first attempt hangs until the client gives up on it
+			case <-r.Context().Done():
+			case <-release:
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	for _, perRoute := range []bool{false, true} {
+		atomic.StoreInt32(&calls, 0)
+		client := newTestClient(t, Config{
+			BaseURL:                server.URL,
+			WithResilience:         true,
+			CircuitBreakerPerRoute: perRoute,
+			Resilience: resilience.Config{
+				RetryConfig:       &retry_backoff.Config{MaxRetries: 1, InitialWaitTime: 1, MaxWaitTime: 1},
+				PerAttemptTimeout: 50 * time.Millisecond,
+			},
+		}, newPermissiveLogger())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		resp, err := client.Get(ctx, "/slow", nil)
+		cancel()
+
+		require.NoError(t, err, "perRoute=%v", perRoute)
+		assert.Equal(t, http.StatusOK, resp.StatusCode())
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls), "the hung attempt is abandoned and retried")
+	}
+}
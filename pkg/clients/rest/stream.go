@@ -0,0 +1,71 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// GetStream GETs path and returns the response body unbuffered, for downloads too large
+// to hold in memory. The caller must close the returned reader.
+//
+// Non-2xx responses are returned as errors, like Get, and their body is closed. The
+// ResponseValidator is not applied. With WithResilience, only failures that happen before
+// the body is returned (connection errors, non-2xx statuses) are retried: a stream the
+// caller has started to consume cannot be replayed, so errors while reading it surface
+// from Read. Config.TimeOut bounds the whole exchange, including reading the body.
+func (c *restClient) GetStream(ctx context.Context, path string, headers map[string]string) (io.ReadCloser, error) {
+	// The body outlives the attempt, so the request uses ctx rather than the attempt
+	// context (which PerAttemptTimeout would cancel once the operation returns)
+	resp, err := c.executeOperation(ctx, "GET "+path, func(context.Context) (interface{}, error) {
+		return c.processStream(ctx, func() (*resty.Response, error) {
+			return c.httpClient.R().
+				SetContext(ctx).
+				SetHeaders(headers).
+				SetDoNotParseResponse(true).
+				Get(c.baseURL + path)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.RawBody(), nil
+}
+
+// processStream is processRequest for unparsed responses: the status is checked without
+// reading a successful body
+func (c *restClient) processStream(ctx context.Context, reqFunc func() (*resty.Response, error)) (*resty.Response, error) {
+	resp, err := reqFunc()
+	if err != nil {
+		if c.IsLoggingEnabled() {
+			c.GetLogger().Warn(ctx, "request_failed",
+				map[string]interface{}{"event": "request_failed", "error": err.Error()})
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode() < http.StatusOK || resp.StatusCode() >= http.StatusMultipleChoices {
+		err := streamStatusError(resp)
+		if c.IsLoggingEnabled() {
+			c.GetLogger().Warn(ctx, "Error HTTP",
+				map[string]interface{}{"event": "http_error",
+					"status": resp.StatusCode(),
+					"error":  err.Error()})
+		}
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// streamStatusError reads up to MaxErrorBodyBytes of a non-2xx streamed body, closes it
+// and builds the same error as validateResponse
+func streamStatusError(resp *resty.Response) error {
+	body := resp.RawBody()
+	defer body.Close()
+
+	captured, _ := io.ReadAll(io.LimitReader(body, MaxErrorBodyBytes+1))
+	return withRetryAfter(resp, newHTTPError(resp.StatusCode(), resp.Status(), resp.Header(), captured))
+}
@@ -0,0 +1,87 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/skolldire/go-engine/pkg/utilities/resilience"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStream_ReturnsUnbufferedBody(t *testing.T) {
+	payload := strings.Repeat("x", 1<<20)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, payload)
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestClient(t, Config{
+		BaseURL:           server.URL,
+		ResponseValidator: func([]byte) error { return errors.New("validator must not run on streams") },
+	}, &mockLogger{})
+
+	body, err := client.GetStream(context.Background(), "/export", nil)
+	require.NoError(t, err)
+	defer body.Close()
+
+	got, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, len(payload), len(got))
+}
+
+func TestGetStream_RetriesErrorStatus(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = io.WriteString(w, "upstream down")
+			return
+		}
+		_, _ = io.WriteString(w, "data")
+	}))
+	t.Cleanup(server.Close)
+	log := &mockLogger{}
+	log.On("Debug", mock.Anything, mock.Anything, mock.Anything).Return()
+
+	client := newTestClient(t, Config{
+		BaseURL:        server.URL,
+		WithResilience: true,
+		Resilience: resilience.Config{
+			RetryConfig: &retry_backoff.Config{MaxRetries: 1, InitialWaitTime: 1, MaxWaitTime: 1},
+		},
+	}, log)
+
+	body, err := client.GetStream(context.Background(), "/export", nil)
+	require.NoError(t, err)
+	defer body.Close()
+
+	got, _ := io.ReadAll(body)
+	assert.Equal(t, "data", string(got))
+	assert.Equal(t, 2, calls)
+}
+
+func TestGetStream_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, strings.Repeat("e", 500))
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestClient(t, Config{BaseURL: server.URL}, &mockLogger{})
+
+	body, err := client.GetStream(context.Background(), "/missing", nil)
+
+	assert.Nil(t, body)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP 404")
+	assert.True(t, strings.HasSuffix(err.Error(), strings.Repeat("e", errorPreviewBytes)+"..."))
+}
@@ -0,0 +1,32 @@
+package rest
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"golang.org/x/net/http2"
+)
+
+// usesHTTP2Tuning reports whether the config requires a custom HTTP/2 transport
+func usesHTTP2Tuning(cfg Config) bool {
+	return cfg.ForceHTTP2 || cfg.ReadIdleTimeout > 0 || cfg.PingTimeout > 0
+}
+
+// newHTTP2Transport clones the default transport and configures its HTTP/2 side
+// The TLS config is set before http2.ConfigureTransports so "h2" is added to NextProtos.
+func newHTTP2Transport(cfg Config, tlsCfg *tls.Config) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ForceAttemptHTTP2 = cfg.ForceHTTP2
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg
+	}
+
+	h2, err := http2.ConfigureTransports(transport)
+	if err != nil {
+		return nil, err
+	}
+	h2.ReadIdleTimeout = cfg.ReadIdleTimeout
+	h2.PingTimeout = cfg.PingTimeout
+
+	return transport, nil
+}
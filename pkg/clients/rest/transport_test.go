@@ -0,0 +1,50 @@
+package rest
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/core/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newH2Server starts a TLS test server with HTTP/2 enabled and returns it with a
+// PEM file containing its certificate, to be used as CACertPath.
+func newH2Server(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caPath, certPEM, 0o600))
+	return server, caPath
+}
+
+func TestNewClient_ForceHTTP2_NegotiatesH2(t *testing.T) {
+	server, caPath := newH2Server(t)
+
+	c := newTestClient(t, Config{
+		BaseURL:         server.URL,
+		TLSConfig:       client.TLSConfig{CACertPath: caPath},
+		ForceHTTP2:      true,
+		ReadIdleTimeout: 30 * time.Second,
+		PingTimeout:     5 * time.Second,
+	}, &mockLogger{})
+
+	resp, err := c.Get(context.Background(), "/", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, resp.RawResponse.ProtoMajor)
+	assert.Equal(t, "HTTP/2.0", string(resp.Body()))
+}
@@ -0,0 +1,237 @@
+package viper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/skolldire/go-engine/aws/pkg/clients/sqs"
+	"github.com/skolldire/go-engine/aws/pkg/database/dynamo"
+	"github.com/skolldire/go-engine/database/redis/pkg/database/redis"
+	"github.com/skolldire/go-engine/pkg/utilities/logger"
+)
+
+// DefaultConnectivityTimeout bounds each probe run by ValidateConnectivity
+const DefaultConnectivityTimeout = 5 * time.Second
+
+// ConnectivityError reports a configured dependency that failed its startup probe
+type ConnectivityError struct {
+	Dependency string
+	Target     string
+	Err        error
+}
+
+func (e *ConnectivityError) Error() string {
+	return fmt.Sprintf("connectivity check failed for '%s' (%s): %v", e.Dependency, e.Target, e.Err)
+}
+
+func (e *ConnectivityError) Unwrap() error {
+	return e.Err
+}
+
+// ErrMissingRegion is reported for an AWS dependency with no endpoint and no region
+var ErrMissingRegion = errors.New("no endpoint and no region configured (set aws.region)")
+
+// connectivityCheck is a single dependency probe
+type connectivityCheck struct {
+	dependency string
+	target     string
+	probe      func(ctx context.Context) error
+}
+
+// sqlPinger is satisfied by *gormsql.DBClient (database/sql sub-module)
+type sqlPinger interface {
+	Ping(ctx context.Context) error
+}
+
+// ConnectivityOption adds probes to ValidateConnectivity
+type ConnectivityOption func(*[]connectivityCheck)
+
+// WithSQLDatabase probes db with Ping as dependency "sql.<name>". SQL clients are built
+// from a gorm.Dialector rather than from Config, so pass the *gormsql.DBClient itself.
+func WithSQLDatabase(name string, db sqlPinger) ConnectivityOption {
+	return func(checks *[]connectivityCheck) {
+		*checks = append(*checks, connectivityCheck{
+			dependency: "sql." + name,
+			target:     name,
+			probe:      db.Ping,
+		})
+	}
+}
+
+// ValidateConnectivity probes every configured redis, dynamo and sqs dependency, plus the
+// SQL databases passed with WithSQLDatabase, and returns one ConnectivityError per
+// unreachable dependency. Unlike ValidateConfig it performs network calls, so it is
+// opt-in: call it at startup to fail fast on wrong hosts or credentials. Each probe is
+// bounded by DefaultConnectivityTimeout.
+func ValidateConnectivity(ctx context.Context, cfg Config, log logger.Service, opts ...ConnectivityOption) []error {
+	return ValidateConnectivityWithTimeout(ctx, cfg, log, DefaultConnectivityTimeout, opts...)
+}
+
+// ValidateConnectivityWithTimeout is ValidateConnectivity with a custom per-check timeout
+func ValidateConnectivityWithTimeout(ctx context.Context, cfg Config, log logger.Service, timeout time.Duration, opts ...ConnectivityOption) []error {
+	if timeout <= 0 {
+		timeout = DefaultConnectivityTimeout
+	}
+
+	checks := connectivityChecks(cfg, opts...)
+	errs := make([]error, len(checks))
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check connectivityCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			if err := check.probe(checkCtx); err != nil {
+				errs[i] = &ConnectivityError{Dependency: check.dependency, Target: check.target, Err: err}
+			}
+		}(i, check)
+	}
+	wg.Wait()
+
+	var failures []error
+	for _, err := range errs {
+		if err != nil {
+			failures = append(failures, err)
+			if log != nil {
+				log.Error(ctx, err, map[string]interface{}{"event": "connectivity_check"})
+			}
+		}
+	}
+
+	if log != nil && len(failures) == 0 {
+		log.Debug(ctx, "connectivity checks passed", map[string]interface{}{"checks": len(checks)})
+	}
+	return failures
+}
+
+// connectivityChecks builds the probes for every enabled dependency and option, sorted by name
+func connectivityChecks(cfg Config, opts ...ConnectivityOption) []connectivityCheck {
+	var checks []connectivityCheck
+	for _, opt := range opts {
+		opt(&checks)
+	}
+
+	if cfg.Redis != nil && cfg.Redis.IsEnabled() {
+		checks = append(checks, redisCheck("redis", *cfg.Redis))
+	}
+	for _, clientMap := range cfg.RedisClients {
+		for name, redisCfg := range clientMap {
+			if !redisCfg.IsEnabled() {
+				continue
+			}
+			checks = append(checks, redisCheck("redis_clients."+name, redisCfg))
+		}
+	}
+
+	if cfg.Dynamo != nil && cfg.Dynamo.IsEnabled() {
+		checks = append(checks, dynamoCheck("dynamo", *cfg.Dynamo, cfg.Aws.Region))
+	}
+	for _, clientMap := range cfg.DynamoClients {
+		for name, dynamoCfg := range clientMap {
+			if !dynamoCfg.IsEnabled() {
+				continue
+			}
+			checks = append(checks, dynamoCheck("dynamo_clients."+name, dynamoCfg, cfg.Aws.Region))
+		}
+	}
+
+	if cfg.SQS != nil && cfg.SQS.IsEnabled() {
+		checks = append(checks, sqsCheck("sqs", *cfg.SQS, cfg.Aws.Region))
+	}
+	for _, clientMap := range cfg.SQSClients {
+		for name, sqsCfg := range clientMap {
+			if !sqsCfg.IsEnabled() {
+				continue
+			}
+			checks = append(checks, sqsCheck("sqs_clients."+name, sqsCfg, cfg.Aws.Region))
+		}
+	}
+
+	sort.SliceStable(checks, func(i, j int) bool { return checks[i].dependency < checks[j].dependency })
+	return checks
+}
+
+// redisCheck authenticates and PINGs with the client's own options (TLS, timeouts), so a
+// wrong password, DB or TLS setting fails here
+func redisCheck(dependency string, cfg redis.Config) connectivityCheck {
+	cfg = cfg.WithDefaults()
+	return connectivityCheck{
+		dependency: dependency,
+		target:     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		probe: func(ctx context.Context) error {
+			options, err := cfg.Options()
+			if err != nil {
+				return err
+			}
+			client := goredis.NewClient(options)
+			defer func() { _ = client.Close() }()
+			return client.Ping(ctx).Err()
+		},
+	}
+}
+
+func dynamoCheck(dependency string, cfg dynamo.Config, region string) connectivityCheck {
+	if cfg.Region != "" {
+		region = cfg.Region
+	}
+	return endpointCheck(dependency, cfg.Endpoint, "dynamodb", region)
+}
+
+func sqsCheck(dependency string, cfg sqs.Config, region string) connectivityCheck {
+	return endpointCheck(dependency, cfg.Endpoint, "sqs", region)
+}
+
+// endpointCheck dials the custom endpoint, or the regional AWS endpoint of service
+// when none is configured. It verifies reachability only; IAM is checked on first use.
+func endpointCheck(dependency, endpoint, service, region string) connectivityCheck {
+	if endpoint == "" && region == "" {
+		return connectivityCheck{
+			dependency: dependency,
+			target:     service,
+			probe:      func(context.Context) error { return ErrMissingRegion },
+		}
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com", service, region)
+	}
+	return connectivityCheck{
+		dependency: dependency,
+		target:     endpoint,
+		probe: func(ctx context.Context) error {
+			addr, err := endpointAddress(endpoint)
+			if err != nil {
+				return err
+			}
+			conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		},
+	}
+}
+
+func endpointAddress(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Hostname() == "" {
+		return "", fmt.Errorf("invalid endpoint %q", endpoint)
+	}
+	if port := u.Port(); port != "" {
+		return net.JoinHostPort(u.Hostname(), port), nil
+	}
+	if u.Scheme == "http" {
+		return net.JoinHostPort(u.Hostname(), "80"), nil
+	}
+	return net.JoinHostPort(u.Hostname(), "443"), nil
+}
@@ -0,0 +1,130 @@
+package viper
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skolldire/go-engine/aws/pkg/clients/sqs"
+	"github.com/skolldire/go-engine/aws/pkg/database/dynamo"
+	"github.com/skolldire/go-engine/database/redis/pkg/database/redis"
+	"github.com/skolldire/go-engine/pkg/core/client"
+)
+
+// closedAddress returns a local address nothing listens on
+func closedAddress(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+	return addr
+}
+
+func TestValidateConnectivity_ReachableEndpoints(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	endpoint := "http://" + ln.Addr().String()
+	cfg := Config{
+		Dynamo:     &dynamo.Config{Endpoint: endpoint},
+		SQSClients: []map[string]sqs.Config{{"orders": {Endpoint: endpoint}}},
+	}
+
+	if errs := ValidateConnectivity(context.Background(), cfg, nil); len(errs) != 0 {
+		t.Errorf("ValidateConnectivity() errors = %v, want none", errs)
+	}
+}
+
+func TestValidateConnectivity_AggregatesFailures(t *testing.T) {
+	addr := closedAddress(t)
+	host, port, _ := net.SplitHostPort(addr)
+	redisPort, _ := strconv.Atoi(port)
+
+	cfg := Config{
+		Redis:  &redis.Config{Host: host, Port: redisPort},
+		Dynamo: &dynamo.Config{Endpoint: "http://" + addr},
+		SQS:    &sqs.Config{Endpoint: "not a url"},
+	}
+
+	errs := ValidateConnectivityWithTimeout(context.Background(), cfg, nil, time.Second)
+	if len(errs) != 3 {
+		t.Fatalf("ValidateConnectivity() returned %d errors, want 3: %v", len(errs), errs)
+	}
+
+	wantDependencies := []string{"dynamo", "redis", "sqs"}
+	for i, err := range errs {
+		var connErr *ConnectivityError
+		if !errors.As(err, &connErr) {
+			t.Fatalf("error %d is %T, want *ConnectivityError", i, err)
+		}
+		if connErr.Dependency != wantDependencies[i] {
+			t.Errorf("error %d dependency = %s, want %s", i, connErr.Dependency, wantDependencies[i])
+		}
+	}
+}
+
+func TestValidateConnectivity_MissingRegion(t *testing.T) {
+	cfg := Config{Dynamo: &dynamo.Config{}}
+
+	errs := ValidateConnectivity(context.Background(), cfg, nil)
+	if len(errs) != 1 || !errors.Is(errs[0], ErrMissingRegion) {
+		t.Fatalf("ValidateConnectivity() errors = %v, want ErrMissingRegion", errs)
+	}
+}
+
+func TestValidateConnectivity_RedisUsesClientTLSSettings(t *testing.T) {
+	cfg := Config{Redis: &redis.Config{
+		Host:      "localhost",
+		Port:      6379,
+		TLSConfig: client.TLSConfig{CACertPath: "/missing/ca.pem"},
+	}}
+
+	errs := ValidateConnectivity(context.Background(), cfg, nil)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "/missing/ca.pem") {
+		t.Fatalf("ValidateConnectivity() errors = %v, want the TLS config error", errs)
+	}
+}
+
+type pingerFunc func(ctx context.Context) error
+
+func (f pingerFunc) Ping(ctx context.Context) error { return f(ctx) }
+
+func TestValidateConnectivity_SQLDatabase(t *testing.T) {
+	down := errors.New("connection refused")
+	errs := ValidateConnectivity(context.Background(), Config{}, nil,
+		WithSQLDatabase("orders", pingerFunc(func(context.Context) error { return down })),
+		WithSQLDatabase("users", pingerFunc(func(context.Context) error { return nil })),
+	)
+
+	if len(errs) != 1 || !errors.Is(errs[0], down) {
+		t.Fatalf("ValidateConnectivity() errors = %v, want the orders failure", errs)
+	}
+	var connErr *ConnectivityError
+	if !errors.As(errs[0], &connErr) || connErr.Dependency != "sql.orders" {
+		t.Errorf("ValidateConnectivity() error = %v, want dependency sql.orders", errs[0])
+	}
+}
+
+func TestEndpointAddress(t *testing.T) {
+	tests := map[string]string{
+		"http://localhost:4566":               "localhost:4566",
+		"https://sqs.us-east-1.amazonaws.com": "sqs.us-east-1.amazonaws.com:443",
+		"http://localstack":                   "localstack:80",
+	}
+	for endpoint, want := range tests {
+		got, err := endpointAddress(endpoint)
+		if err != nil || got != want {
+			t.Errorf("endpointAddress(%q) = %q, %v; want %q", endpoint, got, err, want)
+		}
+	}
+}
@@ -70,6 +70,9 @@ type service struct {
 	propertyFiles []string
 	path          string
 	log           logger.LogWriter
+	// files, when set, replaces the application/profile lookup with an explicit
+	// ordered list of config files merged base-first (see NewServiceWithFiles)
+	files []string
 }
 
 type GracefulShutdownConfig struct {
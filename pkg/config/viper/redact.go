@@ -0,0 +1,172 @@
+package viper
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// RedactedValue replaces secrets in the output of MarshalRedacted
+const RedactedValue = "***"
+
+// secretNameSuffixes mark a field or map key as secret when its lower-cased name ends with one of them
+var secretNameSuffixes = []string{"password", "secret", "token", "apikey", "api_key", "secretkey", "secret_key"}
+
+// MarshalRedacted renders the fully-resolved config as indented JSON, keyed like the YAML
+// (mapstructure tags). Fields tagged json:"-" and known-secret fields (Password, ClientSecret, ...)
+// are replaced by RedactedValue when set, and passwords embedded in URLs are masked.
+// Injected dependencies (mapstructure:"-") and funcs/channels are omitted.
+func (c Config) MarshalRedacted() ([]byte, error) {
+	return json.MarshalIndent(redactValue(reflect.ValueOf(c)), "", "  ")
+}
+
+// RedactedString is MarshalRedacted for logging; it never returns the raw config on error
+func (c Config) RedactedString() string {
+	b, err := c.MarshalRedacted()
+	if err != nil {
+		return fmt.Sprintf("<config redaction failed: %v>", err)
+	}
+	return string(b)
+}
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+// redactValue converts v into plain maps, slices and scalars with secrets masked
+func redactValue(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Type() {
+	case durationType:
+		return time.Duration(v.Int()).String()
+	case timeType:
+		return v.Interface().(time.Time).Format(time.RFC3339)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return redactValue(v.Elem())
+	case reflect.Struct:
+		out := make(map[string]interface{})
+		redactStruct(v, out)
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := fmt.Sprint(iter.Key().Interface())
+			if isSecretName(key) {
+				out[key] = maskSecret(iter.Value())
+				continue
+			}
+			out[key] = redactValue(iter.Value())
+		}
+		return out
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		out := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out[i] = redactValue(v.Index(i))
+		}
+		return out
+	case reflect.String:
+		return redactURL(v.String())
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return nil
+	default:
+		return v.Interface()
+	}
+}
+
+// redactStruct writes the exported fields of v into out, flattening squashed and embedded structs
+func redactStruct(v reflect.Value, out map[string]interface{}) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		ms := field.Tag.Get("mapstructure")
+		if ms == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(ms, ",")
+		fv := v.Field(i)
+
+		if field.Anonymous && name == "" && fv.Kind() == reflect.Struct && (opts == "squash" || ms == "") {
+			redactStruct(fv, out)
+			continue
+		}
+
+		if name == "" {
+			name = jsonName(field)
+		}
+
+		switch fv.Kind() {
+		case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+			continue
+		}
+
+		if field.Tag.Get("json") == "-" || isSecretName(field.Name) || isSecretName(name) {
+			out[name] = maskSecret(fv)
+			continue
+		}
+		out[name] = redactValue(fv)
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+func isSecretName(name string) bool {
+	lower := strings.ToLower(name)
+	for _, suffix := range secretNameSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskSecret hides a secret while keeping "unset" visible, which is what a deploy debug needs
+func maskSecret(v reflect.Value) interface{} {
+	if !v.IsValid() || v.IsZero() {
+		return ""
+	}
+	return RedactedValue
+}
+
+// redactURL masks the password of URLs with credentials (url.Redacted form: user:xxxxx@host)
+func redactURL(s string) string {
+	if !strings.Contains(s, "://") || !strings.Contains(s, "@") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	if _, ok := u.User.Password(); !ok {
+		return s
+	}
+	return u.Redacted()
+}
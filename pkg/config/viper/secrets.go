@@ -0,0 +1,256 @@
+package viper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	ssmsdk "github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/skolldire/go-engine/aws/pkg/clients/ssm"
+)
+
+const (
+	// SecretSchemeSSM resolves ${ssm:/path/to/param} from SSM Parameter Store (decrypted)
+	SecretSchemeSSM = "ssm"
+	// SecretSchemeSecretsManager resolves ${secretsmanager:name} from Secrets Manager;
+	// ${secretsmanager:name#key} picks one key of a JSON secret
+	SecretSchemeSecretsManager = "secretsmanager"
+
+	// DefaultSecretResolutionTimeout bounds the resolution of every reference in one load
+	DefaultSecretResolutionTimeout = 30 * time.Second
+)
+
+// secretReferencePattern matches ${scheme:name}; names starting with "-" are the ${VAR:-default} env syntax
+var secretReferencePattern = regexp.MustCompile(`\$\{([a-z][a-z0-9_]*):([^}\-][^}]*)\}`)
+
+// SecretResolver returns the plaintext value of a secret reference
+type SecretResolver interface {
+	ResolveSecret(ctx context.Context, name string) (string, error)
+}
+
+// SecretResolverFunc adapts a function to SecretResolver
+type SecretResolverFunc func(ctx context.Context, name string) (string, error)
+
+func (f SecretResolverFunc) ResolveSecret(ctx context.Context, name string) (string, error) {
+	return f(ctx, name)
+}
+
+// SecretReferenceError reports a ${scheme:name} reference that could not be resolved
+type SecretReferenceError struct {
+	Key       string
+	Reference string
+	Err       error
+}
+
+func (e *SecretReferenceError) Error() string {
+	return fmt.Sprintf("secret reference '%s' in '%s': %v", e.Reference, e.Key, e.Err)
+}
+
+func (e *SecretReferenceError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	secretResolversMu sync.RWMutex
+	secretResolvers   = map[string]SecretResolver{}
+)
+
+// RegisterSecretResolver installs r for ${scheme:...} references, replacing the built-in
+// AWS resolver for "ssm" / "secretsmanager" or adding a new scheme. Call it before Apply.
+func RegisterSecretResolver(scheme string, r SecretResolver) {
+	secretResolversMu.Lock()
+	defer secretResolversMu.Unlock()
+	if r == nil {
+		delete(secretResolvers, scheme)
+		return
+	}
+	secretResolvers[scheme] = r
+}
+
+// NewSSMSecretResolver resolves references with an existing ssm client, decrypting SecureStrings
+func NewSSMSecretResolver(svc ssm.Service) SecretResolver {
+	return SecretResolverFunc(func(ctx context.Context, name string) (string, error) {
+		param, err := svc.GetParameter(ctx, name, true)
+		if err != nil {
+			return "", err
+		}
+		return param.Value, nil
+	})
+}
+
+// SecretsManagerAPI is the subset of *secretsmanager.Client used to resolve secrets
+type SecretsManagerAPI interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// NewSecretsManagerResolver resolves "name" to the secret string and "name#key" to one key
+// of a JSON secret (the usual shape of RDS / generic key-value secrets)
+func NewSecretsManagerResolver(api SecretsManagerAPI) SecretResolver {
+	return SecretResolverFunc(func(ctx context.Context, name string) (string, error) {
+		secretID, jsonKey, hasKey := strings.Cut(name, "#")
+		out, err := api.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(secretID)})
+		if err != nil {
+			return "", err
+		}
+		if out.SecretString == nil {
+			return "", fmt.Errorf("secret %s has no string value", secretID)
+		}
+		if !hasKey {
+			return *out.SecretString, nil
+		}
+
+		var values map[string]interface{}
+		if err := json.Unmarshal([]byte(*out.SecretString), &values); err != nil {
+			return "", fmt.Errorf("secret %s is not a JSON object: %w", secretID, err)
+		}
+		value, ok := values[jsonKey]
+		if !ok {
+			return "", fmt.Errorf("secret %s has no key %q", secretID, jsonKey)
+		}
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+		return fmt.Sprint(value), nil
+	})
+}
+
+// ssmParameterAPI is the subset of the SSM SDK client used by the default resolver
+type ssmParameterAPI interface {
+	GetParameter(ctx context.Context, params *ssmsdk.GetParameterInput, optFns ...func(*ssmsdk.Options)) (*ssmsdk.GetParameterOutput, error)
+}
+
+func newSSMParameterResolver(api ssmParameterAPI) SecretResolver {
+	return SecretResolverFunc(func(ctx context.Context, name string) (string, error) {
+		out, err := api.GetParameter(ctx, &ssmsdk.GetParameterInput{
+			Name:           aws.String(name),
+			WithDecryption: aws.Bool(true),
+		})
+		if err != nil {
+			return "", err
+		}
+		if out.Parameter == nil || out.Parameter.Value == nil {
+			return "", ssm.ErrParameterNotFound
+		}
+		return *out.Parameter.Value, nil
+	})
+}
+
+// secretResolverSet looks up registered resolvers and lazily builds the AWS defaults,
+// so configs without references never load AWS credentials
+type secretResolverSet struct {
+	region  string
+	awsOnce sync.Once
+	awsCfg  aws.Config
+	awsErr  error
+	cache   map[string]SecretResolver
+}
+
+func newSecretResolverSet(region string) *secretResolverSet {
+	return &secretResolverSet{region: region, cache: map[string]SecretResolver{}}
+}
+
+func (s *secretResolverSet) get(ctx context.Context, scheme string) (SecretResolver, bool, error) {
+	secretResolversMu.RLock()
+	r, ok := secretResolvers[scheme]
+	secretResolversMu.RUnlock()
+	if ok {
+		return r, true, nil
+	}
+	if r, ok := s.cache[scheme]; ok {
+		return r, true, nil
+	}
+	if scheme != SecretSchemeSSM && scheme != SecretSchemeSecretsManager {
+		return nil, false, nil
+	}
+
+	s.awsOnce.Do(func() {
+		var opts []func(*awsconfig.LoadOptions) error
+		if s.region != "" {
+			opts = append(opts, awsconfig.WithRegion(s.region))
+		}
+		s.awsCfg, s.awsErr = awsconfig.LoadDefaultConfig(ctx, opts...)
+	})
+	if s.awsErr != nil {
+		return nil, true, fmt.Errorf("loading AWS config for secret resolution: %w", s.awsErr)
+	}
+
+	if scheme == SecretSchemeSSM {
+		r = newSSMParameterResolver(ssmsdk.NewFromConfig(s.awsCfg))
+	} else {
+		r = NewSecretsManagerResolver(secretsmanager.NewFromConfig(s.awsCfg))
+	}
+	s.cache[scheme] = r
+	return r, true, nil
+}
+
+// resolveSecretReferences replaces every ${scheme:name} string in settings in place. Each
+// distinct reference is fetched once; all failures are returned together, sorted by key.
+func resolveSecretReferences(ctx context.Context, settings map[string]interface{}, resolvers *secretResolverSet) error {
+	resolved := map[string]string{}
+	var failures []*SecretReferenceError
+
+	var walk func(key string, value interface{}) interface{}
+	walk = func(key string, value interface{}) interface{} {
+		switch typed := value.(type) {
+		case map[string]interface{}:
+			for k, v := range typed {
+				typed[k] = walk(joinKey(key, k), v)
+			}
+			return typed
+		case []interface{}:
+			for i, v := range typed {
+				typed[i] = walk(fmt.Sprintf("%s[%d]", key, i), v)
+			}
+			return typed
+		case string:
+			return secretReferencePattern.ReplaceAllStringFunc(typed, func(ref string) string {
+				if value, ok := resolved[ref]; ok {
+					return value
+				}
+				parts := secretReferencePattern.FindStringSubmatch(ref)
+				resolver, known, err := resolvers.get(ctx, parts[1])
+				if !known {
+					return ref
+				}
+				if err == nil {
+					var value string
+					if value, err = resolver.ResolveSecret(ctx, parts[2]); err == nil {
+						resolved[ref] = value
+						return value
+					}
+				}
+				failures = append(failures, &SecretReferenceError{Key: key, Reference: ref, Err: err})
+				return ref
+			})
+		default:
+			return value
+		}
+	}
+	walk("", settings)
+
+	if len(failures) == 0 {
+		return nil
+	}
+	sort.SliceStable(failures, func(i, j int) bool { return failures[i].Key < failures[j].Key })
+	errs := make([]error, len(failures))
+	for i, f := range failures {
+		errs[i] = f
+	}
+	return fmt.Errorf("unresolved secret references (%d): %w", len(errs), errors.Join(errs...))
+}
+
+func joinKey(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
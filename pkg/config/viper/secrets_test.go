@@ -0,0 +1,142 @@
+package viper
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+func registerTestResolver(t *testing.T, scheme string, values map[string]string) {
+	t.Helper()
+	RegisterSecretResolver(scheme, SecretResolverFunc(func(_ context.Context, name string) (string, error) {
+		if v, ok := values[name]; ok {
+			return v, nil
+		}
+		return "", errors.New("not found")
+	}))
+	t.Cleanup(func() { RegisterSecretResolver(scheme, nil) })
+}
+
+func TestResolveSecretReferences_ReplacesNestedValues(t *testing.T) {
+	registerTestResolver(t, SecretSchemeSSM, map[string]string{"/app/redis/password": "s3cret"})
+	registerTestResolver(t, SecretSchemeSecretsManager, map[string]string{"db#host": "db.internal"})
+
+	settings := map[string]interface{}{
+		"redis": map[string]interface{}{"password": "${ssm:/app/redis/password}"},
+		"mongodb_clients": []interface{}{
+			map[string]interface{}{"main": map[string]interface{}{"uri": "mongodb://${secretsmanager:db#host}:27017"}},
+		},
+		"log": map[string]interface{}{"path": "${LOG_PATH:-/tmp}"},
+	}
+
+	if err := resolveSecretReferences(context.Background(), settings, newSecretResolverSet("")); err != nil {
+		t.Fatalf("resolveSecretReferences() error = %v", err)
+	}
+
+	if got := settings["redis"].(map[string]interface{})["password"]; got != "s3cret" {
+		t.Errorf("redis.password = %v, want s3cret", got)
+	}
+	main := settings["mongodb_clients"].([]interface{})[0].(map[string]interface{})["main"].(map[string]interface{})
+	if got := main["uri"]; got != "mongodb://db.internal:27017" {
+		t.Errorf("mongodb uri = %v, want embedded reference resolved", got)
+	}
+	if got := settings["log"].(map[string]interface{})["path"]; got != "${LOG_PATH:-/tmp}" {
+		t.Errorf("log.path = %v, want env syntax left for the env decode hook", got)
+	}
+}
+
+func TestResolveSecretReferences_AggregatesFailures(t *testing.T) {
+	registerTestResolver(t, SecretSchemeSSM, map[string]string{})
+	registerTestResolver(t, SecretSchemeSecretsManager, map[string]string{})
+
+	settings := map[string]interface{}{
+		"cognito": map[string]interface{}{"client_secret": "${secretsmanager:cognito}"},
+		"redis":   map[string]interface{}{"password": "${ssm:/missing}"},
+	}
+
+	err := resolveSecretReferences(context.Background(), settings, newSecretResolverSet(""))
+	if err == nil {
+		t.Fatal("resolveSecretReferences() error = nil, want aggregated error")
+	}
+
+	var refErr *SecretReferenceError
+	if !errors.As(err, &refErr) {
+		t.Fatalf("error %v does not wrap *SecretReferenceError", err)
+	}
+	for _, want := range []string{"(2)", "cognito.client_secret", "${secretsmanager:cognito}", "redis.password", "${ssm:/missing}"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err.Error(), want)
+		}
+	}
+}
+
+type fakeSecretsManager struct {
+	secrets map[string]string
+}
+
+func (f fakeSecretsManager) GetSecretValue(_ context.Context, in *secretsmanager.GetSecretValueInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	v, ok := f.secrets[aws.ToString(in.SecretId)]
+	if !ok {
+		return nil, errors.New("ResourceNotFoundException")
+	}
+	return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(v)}, nil
+}
+
+func TestNewSecretsManagerResolver(t *testing.T) {
+	r := NewSecretsManagerResolver(fakeSecretsManager{secrets: map[string]string{
+		"plain": "value",
+		"rds":   `{"username":"app","password":"pw","port":5432}`,
+	}})
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain", want: "value"},
+		{name: "rds#password", want: "pw"},
+		{name: "rds#port", want: "5432"},
+		{name: "rds#missing", wantErr: true},
+		{name: "plain#key", wantErr: true},
+		{name: "unknown", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.ResolveSecret(ctx, tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveSecret(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveSecret(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply_ResolvesSecretReferences(t *testing.T) {
+	registerTestResolver(t, SecretSchemeSecretsManager, map[string]string{"prod/cognito": "client-secret"})
+
+	dir := t.TempDir()
+	file := writeConfigFile(t, dir, "application.yaml", `
+aws:
+  region: us-east-1
+cognito:
+  region: us-east-1
+  user_pool_id: us-east-1_pool
+  client_id: client
+  client_secret: "${secretsmanager:prod/cognito}"
+`)
+
+	cfg, err := NewServiceWithFiles(discardLogWriter(), file).Apply()
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if cfg.Cognito == nil || cfg.Cognito.ClientSecret != "client-secret" {
+		t.Errorf("cognito.client_secret not resolved: %+v", cfg.Cognito)
+	}
+}
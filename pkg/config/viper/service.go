@@ -1,6 +1,7 @@
 package viper
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,8 +17,16 @@ import (
 	"github.com/spf13/viper"
 )
 
+// ConfigFilesEnv lists config files or directories (comma-separated) that NewService
+// merges instead of application.yaml plus the profile overlay
+const ConfigFilesEnv = "CONF_FILES"
+
 func NewService(log logger.LogWriter) Service {
 	once.Do(func() {
+		if files := os.Getenv(ConfigFilesEnv); files != "" {
+			instance = NewServiceWithFiles(log, strings.Split(files, ",")...)
+			return
+		}
 		instance = &service{
 			propertyFiles: getPropertyFiles(log),
 			path:          getConfigPath(log),
@@ -27,6 +36,23 @@ func NewService(log logger.LogWriter) Service {
 	return instance
 }
 
+// NewServiceWithFiles loads an explicit, ordered list of YAML files: the first is the base
+// and every later file is an overlay. Later files win; nested maps are deep-merged key by key
+// while scalars and lists are replaced whole. A directory entry expands to its *.yaml / *.yml
+// files in lexical order. Environment variables still override every file.
+func NewServiceWithFiles(log logger.LogWriter, files ...string) Service {
+	cleaned := make([]string, 0, len(files))
+	for _, f := range files {
+		if f = strings.TrimSpace(f); f != "" {
+			cleaned = append(cleaned, f)
+		}
+	}
+	return &service{
+		files: cleaned,
+		log:   log,
+	}
+}
+
 func (s *service) Apply() (Config, error) {
 	if err := s.validateRequiredFiles(); err != nil {
 		s.log.Error("error validating configuration files: ", err)
@@ -60,6 +86,11 @@ func (s *service) Apply() (Config, error) {
 }
 
 func (s *service) validateRequiredFiles() error {
+	if s.files != nil {
+		_, err := s.resolveFiles()
+		return err
+	}
+
 	files, err := file_utils.ListFiles(s.path)
 	if err != nil {
 		s.log.Errorf("Error listando archivos en %s: %v", s.path, err)
@@ -77,6 +108,10 @@ func (s *service) validateRequiredFiles() error {
 }
 
 func (s *service) loadAndMergeConfigs() (*viper.Viper, error) {
+	if s.files != nil {
+		return s.loadAndMergeFiles()
+	}
+
 	v := viper.New()
 	v.AddConfigPath(s.path)
 	v.SetConfigType("yaml")
@@ -111,6 +146,84 @@ func (s *service) loadAndMergeConfigs() (*viper.Viper, error) {
 	return v, nil
 }
 
+// loadAndMergeFiles merges s.files in order on top of each other
+func (s *service) loadAndMergeFiles() (*viper.Viper, error) {
+	files, err := s.resolveFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	v.AutomaticEnv()
+
+	for _, file := range files {
+		fileV := viper.New()
+		fileV.SetConfigFile(file)
+		if err := fileV.ReadInConfig(); err != nil {
+			s.log.Errorf("error reading configuration file %s: %v", file, err)
+			return nil, fmt.Errorf("error loading configuration file %s: %w", file, err)
+		}
+		if err := v.MergeConfigMap(fileV.AllSettings()); err != nil {
+			return nil, fmt.Errorf("failed to merge configuration file %s: %w", file, err)
+		}
+		s.log.Infof("configuration file %s loaded successfully", file)
+	}
+
+	if v.GetBool("enable_config_watch") {
+		// Change notifications only; reloads go through ApplyDynamic's file watcher
+		v.SetConfigFile(files[len(files)-1])
+		watchConfig(v, s.log)
+	}
+
+	s.log.Debug("configuration files merged successfully")
+	return v, nil
+}
+
+// resolveFiles expands directories in s.files and fails on missing entries
+func (s *service) resolveFiles() ([]string, error) {
+	if len(s.files) == 0 {
+		return nil, fmt.Errorf("no configuration files provided")
+	}
+
+	var (
+		resolved []string
+		missing  []string
+	)
+	for _, file := range s.files {
+		info, err := os.Stat(file)
+		if err != nil {
+			missing = append(missing, file)
+			continue
+		}
+		if !info.IsDir() {
+			resolved = append(resolved, file)
+			continue
+		}
+
+		entries, err := os.ReadDir(file)
+		if err != nil {
+			return nil, fmt.Errorf("error listing configuration directory %s: %w", file, err)
+		}
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				resolved = append(resolved, filepath.Join(file, entry.Name()))
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		s.log.Errorf("missing configuration files: %v", missing)
+		return nil, fmt.Errorf("missing configuration files: %v", missing)
+	}
+	if len(resolved) == 0 {
+		return nil, fmt.Errorf("no configuration files found in %v", s.files)
+	}
+	return resolved, nil
+}
+
 func (s *service) mapConfigToStruct(v *viper.Viper) (Config, error) {
 	var config Config
 
@@ -138,7 +251,15 @@ func (s *service) mapConfigToStruct(v *viper.Viper) (Config, error) {
 		return Config{}, fmt.Errorf("error creating decoder: %w", err)
 	}
 
-	if err := decoder.Decode(v.AllSettings()); err != nil {
+	settings := v.AllSettings()
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultSecretResolutionTimeout)
+	defer cancel()
+	if err := resolveSecretReferences(ctx, settings, newSecretResolverSet(v.GetString("aws.region"))); err != nil {
+		s.log.Error("error resolving secret references: ", err)
+		return Config{}, err
+	}
+
+	if err := decoder.Decode(settings); err != nil {
 		s.log.Error("error decoding configuration: ", err)
 		return Config{}, err
 	}
@@ -173,14 +294,9 @@ func (s *service) ApplyDynamic(log logger.Service) (*dynamic.DynamicConfig, erro
 	})
 
 	if mergedConfig.GetBool("enable_config_watch") {
-		configPath := s.path
-		configFiles := []string{
-			filepath.Join(configPath, "application.yaml"),
-		}
-
-		envFileName := s.getPropertyFileName()
-		if envFileName != "application" {
-			configFiles = append(configFiles, filepath.Join(configPath, envFileName+".yaml"))
+		configFiles, err := s.watchedFiles()
+		if err != nil {
+			return nil, err
 		}
 
 		fileWatcher, err := dynamic.NewFileWatcher(configFiles, log)
@@ -195,6 +311,22 @@ func (s *service) ApplyDynamic(log logger.Service) (*dynamic.DynamicConfig, erro
 	return dynamicConfig, nil
 }
 
+// watchedFiles lists the files whose changes trigger a dynamic reload
+func (s *service) watchedFiles() ([]string, error) {
+	if s.files != nil {
+		return s.resolveFiles()
+	}
+
+	configFiles := []string{
+		filepath.Join(s.path, "application.yaml"),
+	}
+	envFileName := s.getPropertyFileName()
+	if envFileName != "application" {
+		configFiles = append(configFiles, filepath.Join(s.path, envFileName+".yaml"))
+	}
+	return configFiles, nil
+}
+
 func (s *service) getPropertyFileName() string {
 	scopeFile := fmt.Sprintf("application-%s", app_profile.GetScopeValue())
 	profileFile := fmt.Sprintf("application-%s", app_profile.GetProfileByScope())
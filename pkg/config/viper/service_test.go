@@ -0,0 +1,104 @@
+package viper
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/skolldire/go-engine/pkg/utilities/logger"
+	"github.com/skolldire/go-engine/pkg/utilities/logger/logrusadapter"
+)
+
+func discardLogWriter() logger.LogWriter {
+	l := logrus.New()
+	l.SetOutput(io.Discard)
+	return logrusadapter.New(l)
+}
+
+func writeConfigFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestNewServiceWithFiles_OverlayOverridesNestedField(t *testing.T) {
+	dir := t.TempDir()
+	base := writeConfigFile(t, dir, "base.yaml", `
+aws:
+  region: us-east-1
+router:
+  port: "8080"
+redis:
+  host: localhost
+  port: 6379
+  db: 1
+`)
+	overlay := writeConfigFile(t, dir, "prod.yaml", `
+redis:
+  port: 6380
+router:
+  port: "9090"
+`)
+
+	cfg, err := NewServiceWithFiles(discardLogWriter(), base, overlay).Apply()
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if cfg.Redis == nil {
+		t.Fatal("Apply() lost the redis block")
+	}
+	if cfg.Redis.Port != 6380 {
+		t.Errorf("redis.port = %d, want overlay value 6380", cfg.Redis.Port)
+	}
+	if cfg.Redis.Host != "localhost" || cfg.Redis.DB != 1 {
+		t.Errorf("redis = %+v, want host/db kept from base", *cfg.Redis)
+	}
+	if cfg.Router.Port != "9090" {
+		t.Errorf("router.port = %q, want 9090", cfg.Router.Port)
+	}
+	if cfg.Aws.Region != "us-east-1" {
+		t.Errorf("aws.region = %q, want us-east-1 from base", cfg.Aws.Region)
+	}
+}
+
+func TestNewServiceWithFiles_DirectoryInLexicalOrder(t *testing.T) {
+	dir := t.TempDir()
+	writeConfigFile(t, dir, "00-base.yaml", "aws:\n  region: us-east-1\nredis:\n  host: base\n  port: 6379\n")
+	writeConfigFile(t, dir, "10-env.yml", "redis:\n  host: overlay\n")
+	writeConfigFile(t, dir, "notes.txt", "ignored")
+
+	cfg, err := NewServiceWithFiles(discardLogWriter(), dir).Apply()
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if cfg.Redis == nil || cfg.Redis.Host != "overlay" || cfg.Redis.Port != 6379 {
+		t.Errorf("redis = %+v, want host from 10-env.yml and port from 00-base.yaml", cfg.Redis)
+	}
+}
+
+func TestNewServiceWithFiles_ValidatesMergedResult(t *testing.T) {
+	dir := t.TempDir()
+	base := writeConfigFile(t, dir, "base.yaml", "aws:\n  region: us-east-1\nredis:\n  host: localhost\n")
+	overlay := writeConfigFile(t, dir, "broken.yaml", "redis:\n  host: \"\"\n")
+
+	_, err := NewServiceWithFiles(discardLogWriter(), base, overlay).Apply()
+	if err == nil || !strings.Contains(err.Error(), "redis.host") {
+		t.Errorf("Apply() error = %v, want validation error for the empty redis host", err)
+	}
+}
+
+func TestNewServiceWithFiles_MissingFile(t *testing.T) {
+	dir := t.TempDir()
+	base := writeConfigFile(t, dir, "base.yaml", "redis:\n  host: localhost\n")
+
+	if _, err := NewServiceWithFiles(discardLogWriter(), base, filepath.Join(dir, "missing.yaml")).Apply(); err == nil {
+		t.Error("Apply() error = nil, want missing file error")
+	}
+}
@@ -2,8 +2,10 @@ package viper
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
+	"github.com/skolldire/go-engine/aws/pkg/clients/cognito"
 	"github.com/skolldire/go-engine/aws/pkg/clients/sns"
 	"github.com/skolldire/go-engine/aws/pkg/clients/sqs"
 	"github.com/skolldire/go-engine/aws/pkg/database/dynamo"
@@ -11,6 +13,7 @@ import (
 	grpcClient "github.com/skolldire/go-engine/messaging/pkg/integration/grpc"
 	"github.com/skolldire/go-engine/pkg/app/router"
 	"github.com/skolldire/go-engine/pkg/clients/rest"
+	"github.com/skolldire/go-engine/pkg/utilities/resilience"
 )
 
 // ValidationError represents a configuration validation error
@@ -57,6 +60,11 @@ func ValidateConfig(cfg Config) []error {
 		errors = append(errors, errs...)
 	}
 
+	// Validate Cognito configuration
+	if errs := validateCognitoConfig(cfg.Cognito); len(errs) > 0 {
+		errors = append(errors, errs...)
+	}
+
 	// Validate router configuration
 	if errs := validateRouterConfig(cfg.Router); len(errs) > 0 {
 		errors = append(errors, errs...)
@@ -90,6 +98,10 @@ func validateRESTClients(clients []map[string]rest.Config) []error {
 
 	for i, clientMap := range clients {
 		for name, cfg := range clientMap {
+			if !cfg.IsEnabled() {
+				continue
+			}
+
 			if name == "" {
 				errors = append(errors, &ValidationError{
 					Field:   fmt.Sprintf("rest[%d].name", i),
@@ -115,6 +127,8 @@ func validateRESTClients(clients []map[string]rest.Config) []error {
 					Message: "REST client timeout cannot be negative",
 				})
 			}
+
+			errors = append(errors, validateResilienceConfig(fmt.Sprintf("rest[%d].%s", i, name), cfg.WithResilience, cfg.Resilience)...)
 		}
 	}
 
@@ -147,6 +161,8 @@ func validateGRPCClients(clients []map[string]grpcClient.Config) []error {
 					Message: "gRPC client timeout cannot be negative",
 				})
 			}
+
+			errors = append(errors, validateResilienceConfig(fmt.Sprintf("grpc_client[%d].%s", i, name), cfg.WithResilience, cfg.Resilience)...)
 		}
 	}
 
@@ -158,7 +174,7 @@ func validateSQSConfig(single *sqs.Config, multiple []map[string]sqs.Config) []e
 	var errors []error
 
 	// Validate single SQS client
-	if single != nil {
+	if single != nil && single.IsEnabled() {
 		if single.Endpoint != "" && !isValidURL(single.Endpoint) {
 			errors = append(errors, &ValidationError{
 				Field:   "sqs.endpoint",
@@ -166,12 +182,16 @@ func validateSQSConfig(single *sqs.Config, multiple []map[string]sqs.Config) []e
 			})
 		}
 
-		// SQS Config doesn't have WaitTime field, validation removed
+		errors = append(errors, validateResilienceConfig("sqs", single.WithResilience, single.Resilience)...)
 	}
 
 	// Validate multiple SQS clients
 	for i, clientMap := range multiple {
 		for name, cfg := range clientMap {
+			if !cfg.IsEnabled() {
+				continue
+			}
+
 			if name == "" {
 				errors = append(errors, &ValidationError{
 					Field:   fmt.Sprintf("sqs_clients[%d].name", i),
@@ -186,7 +206,7 @@ func validateSQSConfig(single *sqs.Config, multiple []map[string]sqs.Config) []e
 				})
 			}
 
-			// SQS Config doesn't have WaitTime field, validation removed
+			errors = append(errors, validateResilienceConfig(fmt.Sprintf("sqs_clients[%d].%s", i, name), cfg.WithResilience, cfg.Resilience)...)
 		}
 	}
 
@@ -198,14 +218,19 @@ func validateSNSConfig(single *sns.Config, multiple []map[string]sns.Config) []e
 	var errors []error
 
 	// Validate single SNS client if provided
-	// Note: SNS Config doesn't have Topic/Region fields as they're provided per-operation
-	// Only validate that the config struct exists if needed
-	// SNS config is valid if provided (Topic/Region are operation-specific, not config-level)
-	_ = single // Explicitly acknowledge single parameter to avoid empty branch warning
+	// Note: SNS Config doesn't have Topic/Region fields as they're provided per-operation,
+	// so only its resilience settings are checked
+	if single != nil && single.IsEnabled() {
+		errors = append(errors, validateResilienceConfig("sns", single.WithResilience, single.Resilience)...)
+	}
 
 	// Validate multiple SNS clients
 	for i, clientMap := range multiple {
-		for name := range clientMap {
+		for name, cfg := range clientMap {
+			if !cfg.IsEnabled() {
+				continue
+			}
+
 			if name == "" {
 				errors = append(errors, &ValidationError{
 					Field:   fmt.Sprintf("sns_clients[%d].name", i),
@@ -214,6 +239,8 @@ func validateSNSConfig(single *sns.Config, multiple []map[string]sns.Config) []e
 			}
 			// SNS config doesn't require Topic/Region at config level
 			// These are provided per-operation (Publish, CreateTopic, etc.)
+
+			errors = append(errors, validateResilienceConfig(fmt.Sprintf("sns_clients[%d].%s", i, name), cfg.WithResilience, cfg.Resilience)...)
 		}
 	}
 
@@ -225,14 +252,14 @@ func validateDatabaseConfigs(cfg Config) []error {
 	var errors []error
 
 	// Validate DynamoDB
-	if cfg.Dynamo != nil {
+	if cfg.Dynamo != nil && cfg.Dynamo.IsEnabled() {
 		if errs := validateDynamoConfig(*cfg.Dynamo); len(errs) > 0 {
 			errors = append(errors, errs...)
 		}
 	}
 
 	// Validate Redis
-	if cfg.Redis != nil {
+	if cfg.Redis != nil && cfg.Redis.IsEnabled() {
 		if errs := validateRedisConfig(*cfg.Redis); len(errs) > 0 {
 			errors = append(errors, errs...)
 		}
@@ -241,6 +268,9 @@ func validateDatabaseConfigs(cfg Config) []error {
 	return errors
 }
 
+// dynamoTableNamePattern is the character set DynamoDB allows in table names
+var dynamoTableNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
 // validateDynamoConfig validates DynamoDB configuration
 func validateDynamoConfig(cfg dynamo.Config) []error {
 	var errors []error
@@ -252,6 +282,22 @@ func validateDynamoConfig(cfg dynamo.Config) []error {
 		})
 	}
 
+	if cfg.Region != "" && !isValidAWSRegion(cfg.Region) {
+		errors = append(errors, &ValidationError{
+			Field:   "dynamo.region",
+			Message: fmt.Sprintf("invalid AWS region: %s", cfg.Region),
+		})
+	}
+
+	if cfg.TablePrefix != "" && !dynamoTableNamePattern.MatchString(cfg.TablePrefix) {
+		errors = append(errors, &ValidationError{
+			Field:   "dynamo.table_prefix",
+			Message: "DynamoDB table prefix may only contain letters, digits, '_', '.' and '-'",
+		})
+	}
+
+	errors = append(errors, validateResilienceConfig("dynamo", cfg.WithResilience, cfg.Resilience)...)
+
 	return errors
 }
 
@@ -280,6 +326,94 @@ func validateRedisConfig(cfg redis.Config) []error {
 		})
 	}
 
+	errors = append(errors, validateResilienceConfig("redis", cfg.WithResilience, cfg.Resilience)...)
+
+	return errors
+}
+
+// userPoolIDPattern matches Cognito user pool IDs: "<region>_<id>"
+var userPoolIDPattern = regexp.MustCompile(`^([a-z]{2}(?:-[a-z]+)+-\d+)_[A-Za-z0-9]+$`)
+
+// validateCognitoConfig validates Cognito configuration
+func validateCognitoConfig(cfg *cognito.Config) []error {
+	var errors []error
+
+	if cfg == nil || !cfg.IsEnabled() {
+		return errors
+	}
+
+	if cfg.Region == "" {
+		errors = append(errors, &ValidationError{
+			Field:   "cognito.region",
+			Message: "Cognito region is required",
+		})
+	} else if !isValidAWSRegion(cfg.Region) {
+		errors = append(errors, &ValidationError{
+			Field:   "cognito.region",
+			Message: fmt.Sprintf("invalid AWS region: %s", cfg.Region),
+		})
+	}
+
+	if cfg.UserPoolID == "" {
+		errors = append(errors, &ValidationError{
+			Field:   "cognito.user_pool_id",
+			Message: "Cognito user pool ID is required",
+		})
+	} else if match := userPoolIDPattern.FindStringSubmatch(cfg.UserPoolID); match == nil {
+		errors = append(errors, &ValidationError{
+			Field:   "cognito.user_pool_id",
+			Message: "Cognito user pool ID must look like <region>_<id> (e.g. us-east-1_AbC123)",
+		})
+	} else if cfg.Region != "" && match[1] != cfg.Region {
+		errors = append(errors, &ValidationError{
+			Field:   "cognito.user_pool_id",
+			Message: fmt.Sprintf("Cognito user pool ID region %s does not match region %s", match[1], cfg.Region),
+		})
+	}
+
+	if cfg.ClientID == "" {
+		errors = append(errors, &ValidationError{
+			Field:   "cognito.client_id",
+			Message: "Cognito client ID is required",
+		})
+	}
+
+	if cfg.JWKSUrl != "" && !isValidURL(cfg.JWKSUrl) {
+		errors = append(errors, &ValidationError{
+			Field:   "cognito.jwks_url",
+			Message: "Cognito JWKS URL must be a valid URL",
+		})
+	}
+
+	errors = append(errors, validateResilienceConfig("cognito", cfg.WithResilience, cfg.Resilience)...)
+
+	return errors
+}
+
+// validateResilienceConfig checks that with_resilience comes with a retry or circuit breaker
+// config and that their parameters are in range; settings of disabled resilience are ignored
+func validateResilienceConfig(prefix string, withResilience bool, cfg resilience.Config) []error {
+	if !withResilience {
+		return nil
+	}
+	if !cfg.IsConfigured() {
+		return []error{&ValidationError{
+			Field:   prefix + ".resilience",
+			Message: "with_resilience requires resilience.retry_config or resilience.circuit_breaker_config",
+		}}
+	}
+
+	var errors []error
+	for _, err := range resilience.ValidateConfig(cfg) {
+		if cfgErr, ok := err.(*resilience.ConfigError); ok {
+			errors = append(errors, &ValidationError{
+				Field:   prefix + ".resilience." + cfgErr.Field,
+				Message: cfgErr.Message,
+			})
+			continue
+		}
+		errors = append(errors, err)
+	}
 	return errors
 }
 
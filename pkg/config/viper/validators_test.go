@@ -1,13 +1,20 @@
 package viper
 
 import (
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/skolldire/go-engine/aws/pkg/clients/cognito"
+	"github.com/skolldire/go-engine/aws/pkg/clients/sns"
 	"github.com/skolldire/go-engine/aws/pkg/clients/sqs"
+	"github.com/skolldire/go-engine/aws/pkg/database/dynamo"
+	"github.com/skolldire/go-engine/database/redis/pkg/database/redis"
 	grpcClient "github.com/skolldire/go-engine/messaging/pkg/integration/grpc"
 	"github.com/skolldire/go-engine/pkg/app/router"
 	"github.com/skolldire/go-engine/pkg/clients/rest"
+	"github.com/skolldire/go-engine/pkg/utilities/resilience"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
 )
 
 func TestValidateAWSConfig(t *testing.T) {
@@ -209,6 +216,14 @@ func TestValidateSQSConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "disabled single client skips validation",
+			single: &sqs.Config{
+				Enabled:  boolPtr(false),
+				Endpoint: "not-a-url",
+			},
+			wantErr: false,
+		},
 		{
 			name: "valid multiple SQS clients",
 			multiple: []map[string]sqs.Config{
@@ -245,6 +260,255 @@ func TestValidateSQSConfig(t *testing.T) {
 	}
 }
 
+func TestValidateConfig_DisabledClientsSkipValidation(t *testing.T) {
+	disabled := boolPtr(false)
+	cfg := Config{
+		Aws: AwsConfig{Region: "us-east-1"},
+		Rest: []map[string]rest.Config{
+			{"payments": {Enabled: disabled}},
+		},
+		SQS: &sqs.Config{Enabled: disabled, Endpoint: "not-a-url"},
+		SQSClients: []map[string]sqs.Config{
+			{"orders": {Enabled: disabled, Endpoint: "not-a-url"}},
+		},
+		SNSClients: []map[string]sns.Config{
+			{"": {Enabled: disabled}},
+		},
+		Dynamo: &dynamo.Config{Enabled: disabled, Endpoint: "not-a-url"},
+		Redis:  &redis.Config{Enabled: disabled, Port: -1},
+	}
+
+	if errs := ValidateConfig(cfg); len(errs) > 0 {
+		t.Errorf("ValidateConfig() errors = %v, want none for disabled clients", errs)
+	}
+
+	enabled := boolPtr(true)
+	cfg.Rest[0]["payments"] = rest.Config{Enabled: enabled}
+	cfg.Redis = &redis.Config{Port: -1}
+	if errs := ValidateConfig(cfg); len(errs) != 3 {
+		t.Errorf("ValidateConfig() returned %d errors, want 3 for re-enabled rest and redis: %v", len(errs), errs)
+	}
+}
+
+func TestValidateDynamoConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     dynamo.Config
+		wantFields []string
+	}{
+		{
+			name:   "empty config",
+			config: dynamo.Config{},
+		},
+		{
+			name:   "valid region and prefix",
+			config: dynamo.Config{Region: "eu-west-1", TablePrefix: "orders-prod_v2."},
+		},
+		{
+			name:       "invalid region",
+			config:     dynamo.Config{Region: "moon-1"},
+			wantFields: []string{"dynamo.region"},
+		},
+		{
+			name:       "prefix with illegal characters",
+			config:     dynamo.Config{TablePrefix: "orders/prod:"},
+			wantFields: []string{"dynamo.table_prefix"},
+		},
+		{
+			name:       "invalid endpoint, region and prefix",
+			config:     dynamo.Config{Endpoint: "localhost:8000", Region: "moon-1", TablePrefix: "bad prefix"},
+			wantFields: []string{"dynamo.endpoint", "dynamo.region", "dynamo.table_prefix"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := validateDynamoConfig(tt.config)
+
+			if len(errors) != len(tt.wantFields) {
+				t.Fatalf("validateDynamoConfig() errors = %v, want fields %v", errors, tt.wantFields)
+			}
+			for i, err := range errors {
+				if field := err.(*ValidationError).Field; field != tt.wantFields[i] {
+					t.Errorf("errors[%d].Field = %q, want %q", i, field, tt.wantFields[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCognitoConfig(t *testing.T) {
+	valid := cognito.Config{
+		Region:     "us-east-1",
+		UserPoolID: "us-east-1_AbC123",
+		ClientID:   "client-id",
+	}
+	with := func(mutate func(*cognito.Config)) *cognito.Config {
+		cfg := valid
+		mutate(&cfg)
+		return &cfg
+	}
+
+	tests := []struct {
+		name       string
+		config     *cognito.Config
+		wantFields []string
+	}{
+		{
+			name:   "nil config",
+			config: nil,
+		},
+		{
+			name:   "valid config",
+			config: &valid,
+		},
+		{
+			name: "valid config with JWKS URL",
+			config: with(func(c *cognito.Config) {
+				c.JWKSUrl = "https://cognito-idp.us-east-1.amazonaws.com/us-east-1_AbC123/.well-known/jwks.json"
+			}),
+		},
+		{
+			name:       "empty region",
+			config:     with(func(c *cognito.Config) { c.Region = "" }),
+			wantFields: []string{"cognito.region"},
+		},
+		{
+			name:       "invalid region",
+			config:     with(func(c *cognito.Config) { c.Region = "mars-1" }),
+			wantFields: []string{"cognito.region", "cognito.user_pool_id"},
+		},
+		{
+			name:       "empty user pool ID",
+			config:     with(func(c *cognito.Config) { c.UserPoolID = "" }),
+			wantFields: []string{"cognito.user_pool_id"},
+		},
+		{
+			name:       "malformed user pool ID",
+			config:     with(func(c *cognito.Config) { c.UserPoolID = "AbC123" }),
+			wantFields: []string{"cognito.user_pool_id"},
+		},
+		{
+			name:       "user pool ID from another region",
+			config:     with(func(c *cognito.Config) { c.UserPoolID = "eu-west-1_AbC123" }),
+			wantFields: []string{"cognito.user_pool_id"},
+		},
+		{
+			name:       "empty client ID",
+			config:     with(func(c *cognito.Config) { c.ClientID = "" }),
+			wantFields: []string{"cognito.client_id"},
+		},
+		{
+			name:       "invalid JWKS URL",
+			config:     with(func(c *cognito.Config) { c.JWKSUrl = "cognito-idp/jwks.json" }),
+			wantFields: []string{"cognito.jwks_url"},
+		},
+		{
+			name:   "disabled config skips validation",
+			config: &cognito.Config{Enabled: boolPtr(false)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := validateCognitoConfig(tt.config)
+
+			if len(errors) != len(tt.wantFields) {
+				t.Fatalf("validateCognitoConfig() errors = %v, want fields %v", errors, tt.wantFields)
+			}
+			for i, err := range errors {
+				vErr, ok := err.(*ValidationError)
+				if !ok {
+					t.Fatalf("error %v is %T, want *ValidationError", err, err)
+				}
+				if vErr.Field != tt.wantFields[i] {
+					t.Errorf("errors[%d].Field = %q, want %q", i, vErr.Field, tt.wantFields[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateResilienceConsistency(t *testing.T) {
+	configured := resilience.Config{RetryConfig: &retry_backoff.Config{MaxRetries: 3}}
+
+	tests := []struct {
+		name      string
+		errs      func(res resilience.Config) []error
+		wantField string
+	}{
+		{
+			name: "redis",
+			errs: func(res resilience.Config) []error {
+				return validateRedisConfig(redis.Config{Host: "localhost", WithResilience: true, Resilience: res})
+			},
+			wantField: "redis.resilience",
+		},
+		{
+			name: "cognito",
+			errs: func(res resilience.Config) []error {
+				return validateCognitoConfig(&cognito.Config{
+					Region: "us-east-1", UserPoolID: "us-east-1_AbC123", ClientID: "client",
+					WithResilience: true, Resilience: res,
+				})
+			},
+			wantField: "cognito.resilience",
+		},
+		{
+			name: "rest",
+			errs: func(res resilience.Config) []error {
+				return validateRESTClients([]map[string]rest.Config{
+					{"payments": {BaseURL: "https://payments", WithResilience: true, Resilience: res}},
+				})
+			},
+			wantField: "rest[0].payments.resilience",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" without sub-configs", func(t *testing.T) {
+			errs := tt.errs(resilience.Config{})
+			if len(errs) != 1 {
+				t.Fatalf("errors = %v, want exactly one resilience error", errs)
+			}
+			if field := errs[0].(*ValidationError).Field; field != tt.wantField {
+				t.Errorf("Field = %q, want %q", field, tt.wantField)
+			}
+		})
+		t.Run(tt.name+" with retry config", func(t *testing.T) {
+			if errs := tt.errs(configured); len(errs) > 0 {
+				t.Errorf("errors = %v, want none", errs)
+			}
+		})
+	}
+}
+
+func TestValidateResilienceRanges(t *testing.T) {
+	badRetry := resilience.Config{RetryConfig: &retry_backoff.Config{MaxRetries: -1, JitterFactor: 2}}
+
+	cfg := Config{
+		Aws:        AwsConfig{Region: "us-east-1"},
+		SQS:        &sqs.Config{WithResilience: true, Resilience: badRetry},
+		SNSClients: []map[string]sns.Config{{"events": {WithResilience: true, Resilience: badRetry}}},
+		Dynamo:     &dynamo.Config{WithResilience: false, Resilience: badRetry},
+	}
+
+	var fields []string
+	for _, err := range ValidateConfig(cfg) {
+		fields = append(fields, err.(*ValidationError).Field)
+	}
+
+	want := []string{
+		"sqs.resilience.retry_config.max_retries",
+		"sqs.resilience.retry_config.jitter_factor",
+		"sns_clients[0].events.resilience.retry_config.max_retries",
+		"sns_clients[0].events.resilience.retry_config.jitter_factor",
+	}
+	if strings.Join(fields, ",") != strings.Join(want, ",") {
+		t.Errorf("ValidateConfig() fields = %v, want %v (dynamo has resilience disabled)", fields, want)
+	}
+}
+
 func TestValidateRouterConfig(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -315,3 +579,7 @@ func TestIsValidURL(t *testing.T) {
 		})
 	}
 }
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,47 @@
+// Package auth defines a provider-neutral token validation contract so HTTP
+// middleware can authenticate requests without depending on a specific identity
+// provider (Cognito, Auth0, ...).
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrMalformedToken is returned when the token cannot be parsed to read its issuer
+	ErrMalformedToken = errors.New("malformed token")
+	// ErrUnknownIssuer is returned when no validator is registered for the token's issuer
+	ErrUnknownIssuer = errors.New("no validator registered for token issuer")
+)
+
+// Claims are the verified claims of a token, normalised across providers
+type Claims struct {
+	Sub           string                 `json:"sub"`
+	Email         string                 `json:"email"`
+	EmailVerified bool                   `json:"email_verified"`
+	Username      string                 `json:"username"`
+	Groups        []string               `json:"groups"`
+	CustomClaims  map[string]interface{} `json:"-"`
+
+	// Standard JWT claims
+	Iss      string `json:"iss"`
+	Aud      string `json:"aud"`
+	Exp      int64  `json:"exp"`
+	Iat      int64  `json:"iat"`
+	TokenUse string `json:"token_use"` // "id" or "access" where the provider distinguishes them
+}
+
+// TokenValidator verifies a bearer token (signature, issuer, audience, expiry) and
+// returns its claims. cognito.NewTokenValidator adapts the cognito client to it.
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, token string) (*Claims, error)
+}
+
+// ValidatorFunc adapts a function to TokenValidator
+type ValidatorFunc func(ctx context.Context, token string) (*Claims, error)
+
+// ValidateToken calls f(ctx, token)
+func (f ValidatorFunc) ValidateToken(ctx context.Context, token string) (*Claims, error) {
+	return f(ctx, token)
+}
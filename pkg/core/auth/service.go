@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// IssuerValidators routes tokens to a TokenValidator according to their "iss" claim
+type IssuerValidators struct {
+	byIssuer map[string]TokenValidator
+}
+
+// NewIssuerValidators returns a composite TokenValidator keyed by issuer URL, e.g.
+//
+//	auth.NewIssuerValidators(map[string]auth.TokenValidator{
+//		"https://cognito-idp.us-east-1.amazonaws.com/us-east-1_XXXX": cognito.NewTokenValidator(cognitoClient),
+//		"https://legacy.auth0.com/":                                 auth0Validator,
+//	})
+//
+// Issuers are compared ignoring a trailing slash.
+func NewIssuerValidators(byIssuer map[string]TokenValidator) *IssuerValidators {
+	normalised := make(map[string]TokenValidator, len(byIssuer))
+	for issuer, validator := range byIssuer {
+		normalised[normaliseIssuer(issuer)] = validator
+	}
+	return &IssuerValidators{byIssuer: normalised}
+}
+
+// ValidateToken reads the token's issuer without verifying it and delegates to the
+// validator registered for that issuer, which performs the actual verification.
+func (v *IssuerValidators) ValidateToken(ctx context.Context, token string) (*Claims, error) {
+	issuer, err := unverifiedIssuer(token)
+	if err != nil {
+		return nil, err
+	}
+
+	validator, ok := v.byIssuer[normaliseIssuer(issuer)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownIssuer, issuer)
+	}
+	return validator.ValidateToken(ctx, token)
+}
+
+// unverifiedIssuer returns the "iss" claim; the result must only be used for routing
+func unverifiedIssuer(token string) (string, error) {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrMalformedToken, err)
+	}
+
+	issuer, err := claims.GetIssuer()
+	if err != nil || issuer == "" {
+		return "", fmt.Errorf("%w: missing iss claim", ErrMalformedToken)
+	}
+	return issuer, nil
+}
+
+func normaliseIssuer(issuer string) string {
+	return strings.TrimSuffix(issuer, "/")
+}
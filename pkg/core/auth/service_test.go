@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func unsignedToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	return token
+}
+
+func staticValidator(sub string) ValidatorFunc {
+	return func(ctx context.Context, token string) (*Claims, error) {
+		return &Claims{Sub: sub}, nil
+	}
+}
+
+func TestIssuerValidators_RoutesByIssuer(t *testing.T) {
+	validators := NewIssuerValidators(map[string]TokenValidator{
+		"https://cognito-idp.us-east-1.amazonaws.com/us-east-1_pool": staticValidator("cognito"),
+		"https://legacy.auth0.com/":                                  staticValidator("auth0"),
+	})
+
+	claims, err := validators.ValidateToken(context.Background(),
+		unsignedToken(t, jwt.MapClaims{"iss": "https://cognito-idp.us-east-1.amazonaws.com/us-east-1_pool"}))
+	require.NoError(t, err)
+	assert.Equal(t, "cognito", claims.Sub)
+
+	// Trailing slashes are ignored on both sides
+	claims, err = validators.ValidateToken(context.Background(),
+		unsignedToken(t, jwt.MapClaims{"iss": "https://legacy.auth0.com"}))
+	require.NoError(t, err)
+	assert.Equal(t, "auth0", claims.Sub)
+}
+
+func TestIssuerValidators_UnknownIssuer(t *testing.T) {
+	validators := NewIssuerValidators(map[string]TokenValidator{
+		"https://legacy.auth0.com/": staticValidator("auth0"),
+	})
+
+	_, err := validators.ValidateToken(context.Background(), unsignedToken(t, jwt.MapClaims{"iss": "https://evil.example.com"}))
+	assert.ErrorIs(t, err, ErrUnknownIssuer)
+}
+
+func TestIssuerValidators_MalformedToken(t *testing.T) {
+	validators := NewIssuerValidators(map[string]TokenValidator{"https://legacy.auth0.com/": staticValidator("auth0")})
+
+	_, err := validators.ValidateToken(context.Background(), "not-a-jwt")
+	assert.ErrorIs(t, err, ErrMalformedToken)
+
+	_, err = validators.ValidateToken(context.Background(), unsignedToken(t, jwt.MapClaims{"sub": "no-issuer"}))
+	assert.ErrorIs(t, err, ErrMalformedToken)
+}
+
+func TestIssuerValidators_PropagatesValidatorError(t *testing.T) {
+	validators := NewIssuerValidators(map[string]TokenValidator{
+		"https://legacy.auth0.com/": ValidatorFunc(func(ctx context.Context, token string) (*Claims, error) {
+			return nil, assert.AnError
+		}),
+	})
+
+	_, err := validators.ValidateToken(context.Background(), unsignedToken(t, jwt.MapClaims{"iss": "https://legacy.auth0.com/"}))
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -35,12 +35,21 @@ type BaseConfig struct {
 	// when the caller's context has no deadline; if the context already has
 	// a deadline, that deadline is respected as-is.
 	Timeout time.Duration `mapstructure:"timeout" json:"timeout"`
+
+	// SlowThreshold, when positive, makes Execute log a Warn entry with the
+	// operation name and elapsed time for operations that take longer. It is
+	// independent of EnableLogging.
+	SlowThreshold time.Duration `mapstructure:"slow_threshold" json:"slow_threshold"`
 }
 
 // Operation is a unit of work passed to BaseClient.Execute.
 // It must be idempotent when resilience (retry) is enabled.
 type Operation func() (interface{}, error)
 
+// ContextOperation is an Operation that receives the context to use for its calls,
+// passed to BaseClient.ExecuteContext. With resilience, it is the per-attempt context.
+type ContextOperation func(ctx context.Context) (interface{}, error)
+
 // BaseClient is an embeddable struct that provides logging, timeout management,
 // and optional resilience (retry + circuit breaker) to any client implementation.
 //
@@ -64,12 +73,13 @@ type Operation func() (interface{}, error)
 // BaseClient is safe for concurrent use; its mutable fields are protected by an
 // internal RWMutex.
 type BaseClient struct {
-	logger      logger.Service
-	logging     bool
-	resilience  *resilience.Service
-	timeout     time.Duration
-	serviceName string
-	mu          sync.RWMutex // Protects logging and serviceName fields
+	logger        logger.Service
+	logging       bool
+	resilience    *resilience.Service
+	timeout       time.Duration
+	slowThreshold time.Duration
+	serviceName   string
+	mu            sync.RWMutex // Protects logging and serviceName fields
 }
 
 // NewBaseClient creates a BaseClient with service name "base".
@@ -87,10 +97,11 @@ func NewBaseClient(config BaseConfig, log logger.Service) *BaseClient {
 // config.Resilience; otherwise no retry or circuit-breaker is applied.
 func NewBaseClientWithName(config BaseConfig, log logger.Service, serviceName string) *BaseClient {
 	bc := &BaseClient{
-		logger:      log,
-		logging:     config.EnableLogging,
-		timeout:     config.Timeout,
-		serviceName: serviceName,
+		logger:        log,
+		logging:       config.EnableLogging,
+		timeout:       config.Timeout,
+		slowThreshold: config.SlowThreshold,
+		serviceName:   serviceName,
 	}
 
 	if config.Timeout == 0 {
@@ -104,6 +115,12 @@ func NewBaseClientWithName(config BaseConfig, log logger.Service, serviceName st
 	return bc
 }
 
+// Resilience returns the resilience.Service built from BaseConfig.Resilience, or nil
+// when BaseConfig.WithResilience was false
+func (bc *BaseClient) Resilience() *resilience.Service {
+	return bc.resilience
+}
+
 // Execute runs op under a timeout-bounded context, optionally logging start/end
 // and wrapping op with the resilience layer when configured.
 //
@@ -115,62 +132,54 @@ func NewBaseClientWithName(config BaseConfig, log logger.Service, serviceName st
 // delegates to the resilience.Service (retry + circuit breaker). The operation
 // must be idempotent in that case.
 //
+// Log fields: entries attached to ctx with WithLogFields are merged into every
+// log entry, alongside "operation" and "service".
+//
+// Slow operations: when BaseConfig.SlowThreshold is positive, operations that
+// exceed it are logged at Warn with "elapsed_ms", whether or not logging is enabled.
+//
 // Return value: the raw interface{} returned by op. Use SafeTypeAssert[T] to
 // convert it to a concrete type without a panic.
 func (bc *BaseClient) Execute(ctx context.Context, operationName string, operation Operation) (interface{}, error) {
 	ctx, cancel := bc.ensureContextWithTimeout(ctx)
 	defer cancel()
 
-	logFields := map[string]interface{}{
-		"operation": operationName,
-		"service":   bc.getServiceName(),
-	}
-
-	if bc.resilience != nil {
-		return bc.executeWithResilience(ctx, operationName, operation, logFields)
-	}
-
-	return bc.executeDirectly(ctx, operationName, operation, logFields)
+	return Execute(ctx, bc.executeOptions(ctx, operationName), operation)
 }
 
-func (bc *BaseClient) executeWithResilience(ctx context.Context, operationName string, operation Operation, logFields map[string]interface{}) (interface{}, error) {
-	bc.mu.RLock()
-	logging := bc.logging
-	bc.mu.RUnlock()
-
-	if logging {
-		bc.logger.Debug(ctx, "starting operation with resilience: "+operationName, logFields)
-	}
-
-	result, err := bc.resilience.Execute(ctx, operation)
-
-	if err != nil && logging {
-		bc.logger.Error(ctx, err, logFields)
-	} else if logging {
-		bc.logger.Debug(ctx, "operation completed with resilience: "+operationName, logFields)
-	}
+// ExecuteContext is Execute for operations that take a context: op receives the
+// timeout-bounded context, or with resilience the per-attempt context bounded by
+// resilience.Config.PerAttemptTimeout, and must use it for its calls.
+func (bc *BaseClient) ExecuteContext(ctx context.Context, operationName string, operation ContextOperation) (interface{}, error) {
+	ctx, cancel := bc.ensureContextWithTimeout(ctx)
+	defer cancel()
 
-	return result, err
+	return ExecuteContext(ctx, bc.executeOptions(ctx, operationName), operation)
 }
 
-func (bc *BaseClient) executeDirectly(ctx context.Context, operationName string, operation Operation, logFields map[string]interface{}) (interface{}, error) {
-	bc.mu.RLock()
-	logging := bc.logging
-	bc.mu.RUnlock()
-
-	if logging {
-		bc.logger.Debug(ctx, "starting operation: "+operationName, logFields)
+func (bc *BaseClient) executeOptions(ctx context.Context, operationName string) ExecuteOptions {
+	return ExecuteOptions{
+		Operation:     operationName,
+		Logger:        bc.logger,
+		Logging:       bc.IsLoggingEnabled(),
+		Resilience:    bc.resilience,
+		SlowThreshold: bc.slowThreshold,
+		Fields: func() map[string]interface{} {
+			return bc.logFields(ctx, operationName)
+		},
 	}
+}
 
-	result, err := operation()
-
-	if err != nil && logging {
-		bc.logger.Error(ctx, err, logFields)
-	} else if logging {
-		bc.logger.Debug(ctx, "operation completed: "+operationName, logFields)
+// logFields merges the context log fields with the operation and service names
+func (bc *BaseClient) logFields(ctx context.Context, operationName string) map[string]interface{} {
+	ctxFields := LogFieldsFromContext(ctx)
+	fields := make(map[string]interface{}, len(ctxFields)+2)
+	for k, v := range ctxFields {
+		fields[k] = v
 	}
-
-	return result, err
+	fields["operation"] = operationName
+	fields["service"] = bc.getServiceName()
+	return fields
 }
 
 func (bc *BaseClient) ensureContextWithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
@@ -207,6 +216,11 @@ func (bc *BaseClient) IsLoggingEnabled() bool {
 	return bc.logging
 }
 
+// SlowThreshold returns the BaseConfig.SlowThreshold set at construction time.
+func (bc *BaseClient) SlowThreshold() time.Duration {
+	return bc.slowThreshold
+}
+
 // GetLogger returns the logger.Service injected at construction time.
 func (bc *BaseClient) GetLogger() logger.Service {
 	return bc.logger
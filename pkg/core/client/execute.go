@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/logger"
+	"github.com/skolldire/go-engine/pkg/utilities/resilience"
+)
+
+// ExecuteOptions describes how Execute logs and protects a single operation.
+// Clients that do not embed BaseClient build one per call from their own fields.
+type ExecuteOptions struct {
+	// Service prefixes the log messages ("starting Redis operation: Get").
+	// When empty the messages read "starting operation: Get".
+	Service string
+
+	// Operation is the operation name used in messages and the slow-operation warning.
+	Operation string
+
+	Logger logger.Service
+
+	// Logging enables the error entry and, when the logger is at debug, the start
+	// and completion entries.
+	Logging bool
+
+	// Resilience, when non-nil, wraps the operation with retry and circuit breaker; its
+	// metrics are tagged with Operation.
+	// Errors it returns are wrapped as "error in <Service> operation" when Service is set.
+	Resilience *resilience.Service
+
+	// SlowThreshold, when positive, logs a Warn entry for operations exceeding it,
+	// independently of Logging.
+	SlowThreshold time.Duration
+
+	// Fields builds the log fields; it is only called for entries that are written.
+	// Defaults to {"operation": Operation}.
+	Fields func() map[string]interface{}
+}
+
+// Execute runs operation with the shared logging, slow-operation and resilience
+// behavior of the clients. It does not bound ctx: callers apply their own timeout
+// policy before calling it. resilience.Config.PerAttemptTimeout cannot apply to a
+// context-less operation; use ExecuteContext for that.
+func Execute(ctx context.Context, opts ExecuteOptions, operation Operation) (interface{}, error) {
+	return execute(ctx, opts, func(context.Context) (interface{}, error) {
+		return operation()
+	}, false)
+}
+
+// ExecuteContext is Execute for operations that take a context. With Resilience, each
+// attempt receives its own context (bounded by resilience.Config.PerAttemptTimeout when
+// set), which the operation must use for its calls.
+func ExecuteContext(ctx context.Context, opts ExecuteOptions, operation ContextOperation) (interface{}, error) {
+	return execute(ctx, opts, operation, true)
+}
+
+func execute(ctx context.Context, opts ExecuteOptions, operation ContextOperation, contextAware bool) (interface{}, error) {
+	fields := opts.Fields
+	if fields == nil {
+		fields = func() map[string]interface{} {
+			return map[string]interface{}{"operation": opts.Operation}
+		}
+	}
+
+	// Fields and messages are only built for entries that will be written
+	debug := opts.Logging && logger.Enabled(opts.Logger, logger.LevelDebug)
+
+	start := time.Now()
+	defer func() {
+		WarnIfSlowFunc(ctx, opts.Logger, opts.SlowThreshold, opts.Operation, time.Since(start), fields)
+	}()
+
+	if opts.Resilience != nil {
+		if debug {
+			opts.Logger.Debug(ctx, fmt.Sprintf("starting %s with resilience: %s", opts.label(), opts.Operation), fields())
+		}
+
+		var result interface{}
+		var err error
+		if contextAware {
+			result, err = opts.Resilience.ExecuteNamedContext(ctx, opts.Operation, operation)
+		} else {
+			result, err = opts.Resilience.ExecuteNamed(ctx, opts.Operation, func() (interface{}, error) {
+				return operation(ctx)
+			})
+		}
+
+		if err != nil && opts.Logging {
+			logErr := err
+			if opts.Service != "" {
+				logErr = fmt.Errorf("error in %s: %w", opts.label(), err)
+			}
+			opts.Logger.Error(ctx, logErr, fields())
+		} else if err == nil && debug {
+			opts.Logger.Debug(ctx, fmt.Sprintf("%s completed with resilience: %s", opts.label(), opts.Operation), fields())
+		}
+
+		return result, err
+	}
+
+	if debug {
+		opts.Logger.Debug(ctx, fmt.Sprintf("starting %s: %s", opts.label(), opts.Operation), fields())
+	}
+
+	result, err := operation(ctx)
+
+	if err != nil && opts.Logging {
+		opts.Logger.Error(ctx, err, fields())
+	} else if err == nil && debug {
+		opts.Logger.Debug(ctx, fmt.Sprintf("%s completed: %s", opts.label(), opts.Operation), fields())
+	}
+
+	return result, err
+}
+
+func (o ExecuteOptions) label() string {
+	if o.Service == "" {
+		return "operation"
+	}
+	return o.Service + " operation"
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/circuit_breaker"
+	"github.com/skolldire/go-engine/pkg/utilities/resilience"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// messageLogger records the Debug messages and Error errors written at debug level
+type messageLogger struct {
+	mockLogger
+	messages []string
+	errs     []error
+}
+
+func (m *messageLogger) GetLogLevel() string { return "debug" }
+
+func (m *messageLogger) Debug(ctx context.Context, msg string, fields map[string]interface{}) {
+	m.messages = append(m.messages, msg)
+}
+
+func (m *messageLogger) Error(ctx context.Context, err error, fields map[string]interface{}) {
+	m.errs = append(m.errs, err)
+}
+
+func TestExecute_Messages(t *testing.T) {
+	tests := []struct {
+		name    string
+		service string
+		want    []string
+	}{
+		{name: "with service", service: "Redis", want: []string{"starting Redis operation: Get", "Redis operation completed: Get"}},
+		{name: "without service", want: []string{"starting operation: Get", "operation completed: Get"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := &messageLogger{}
+			result, err := Execute(context.Background(), ExecuteOptions{
+				Service:   tt.service,
+				Operation: "Get",
+				Logger:    log,
+				Logging:   true,
+			}, func() (interface{}, error) { return "v", nil })
+
+			require.NoError(t, err)
+			assert.Equal(t, "v", result)
+			assert.Equal(t, tt.want, log.messages)
+		})
+	}
+}
+
+func TestExecute_LoggingDisabled(t *testing.T) {
+	log := &messageLogger{}
+	_, err := Execute(context.Background(), ExecuteOptions{Service: "Redis", Operation: "Get", Logger: log},
+		func() (interface{}, error) { return nil, errors.New("boom") })
+
+	require.Error(t, err)
+	assert.Empty(t, log.messages)
+	assert.Empty(t, log.errs)
+}
+
+func TestExecute_ResilienceWrapsLoggedError(t *testing.T) {
+	log := &messageLogger{}
+	boom := errors.New("boom")
+	rs := resilience.NewResilienceService(resilience.Config{
+		RetryConfig:          &retry_backoff.Config{MaxRetries: 1, InitialWaitTime: 1, MaxWaitTime: 1},
+		CircuitBreakerConfig: &circuit_breaker.Config{Name: "execute-test"},
+	}, &mockLogger{})
+
+	_, err := Execute(context.Background(), ExecuteOptions{
+		Service:    "DB",
+		Operation:  "Create",
+		Logger:     log,
+		Logging:    true,
+		Resilience: rs,
+	}, func() (interface{}, error) { return nil, retry_backoff.Permanent(boom) })
+
+	require.ErrorIs(t, err, boom)
+	require.Len(t, log.errs, 1)
+	assert.ErrorIs(t, log.errs[0], boom)
+	assert.Contains(t, log.errs[0].Error(), "error in DB operation")
+	assert.Equal(t, []string{"starting DB operation with resilience: Create"}, log.messages)
+}
+
+func TestExecuteContext_PerAttemptTimeout(t *testing.T) {
+	rs := resilience.NewResilienceService(resilience.Config{
+		RetryConfig:       &retry_backoff.Config{MaxRetries: 1, InitialWaitTime: 1, MaxWaitTime: 1},
+		PerAttemptTimeout: 20 * time.Millisecond,
+	}, &mockLogger{})
+
+	var attempts int
+	result, err := ExecuteContext(context.Background(), ExecuteOptions{
+		Operation:  "Get",
+		Logger:     &mockLogger{},
+		Resilience: rs,
+	}, func(attemptCtx context.Context) (interface{}, error) {
+		attempts++
+		if attempts == 1 {
+			<-attemptCtx.Done() // the first attempt hangs until its own deadline
+			return nil, attemptCtx.Err()
+		}
+		return "ok", nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "ok", result)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestExecuteContext_BudgetShareReachesOperation(t *testing.T) {
+	rs := resilience.NewResilienceService(resilience.Config{
+		RetryConfig: &retry_backoff.Config{MaxRetries: 1},
+	}, &mockLogger{})
+	parent, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	parentDeadline, _ := parent.Deadline()
+
+	_, err := ExecuteContext(resilience.WithBudget(parent, 2), ExecuteOptions{
+		Operation:  "Get",
+		Logger:     &mockLogger{},
+		Resilience: rs,
+	}, func(ctx context.Context) (interface{}, error) {
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		assert.True(t, deadline.Before(parentDeadline), "the operation is bounded by its share of the budget")
+		return nil, nil
+	})
+	require.NoError(t, err)
+}
+
+func TestExecute_CustomFields(t *testing.T) {
+	log := &recordingLogger{}
+	_, err := Execute(context.Background(), ExecuteOptions{
+		Operation: "Find",
+		Logger:    log,
+		Logging:   true,
+		Fields: func() map[string]interface{} {
+			return map[string]interface{}{"operation": "Find", "db_type": "postgres"}
+		},
+	}, func() (interface{}, error) { return nil, nil })
+
+	require.NoError(t, err)
+	require.Len(t, log.entries, 2)
+	assert.Equal(t, "postgres", log.entries[0]["db_type"])
+}
@@ -0,0 +1,28 @@
+package client
+
+import "context"
+
+type logFieldsKey struct{}
+
+// WithLogFields returns a copy of ctx carrying fields that BaseClient.Execute merges
+// into every log entry it emits, e.g. tenant or request correlation IDs.
+// Calls accumulate: fields from an outer WithLogFields are kept and later calls
+// override earlier values for the same key. The "operation" and "service" fields
+// set by Execute always take precedence.
+func WithLogFields(ctx context.Context, fields map[string]interface{}) context.Context {
+	merged := make(map[string]interface{}, len(fields))
+	for k, v := range LogFieldsFromContext(ctx) {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, logFieldsKey{}, merged)
+}
+
+// LogFieldsFromContext returns the fields attached with WithLogFields, or nil if none.
+// The returned map must not be modified.
+func LogFieldsFromContext(ctx context.Context) map[string]interface{} {
+	fields, _ := ctx.Value(logFieldsKey{}).(map[string]interface{})
+	return fields
+}
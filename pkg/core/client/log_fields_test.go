@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingLogger captures the fields of every Debug and Error entry
+type recordingLogger struct {
+	mockLogger
+	entries []map[string]interface{}
+}
+
+// GetLogLevel reports debug so Execute emits its Debug entries
+func (r *recordingLogger) GetLogLevel() string { return "debug" }
+
+func (r *recordingLogger) Debug(ctx context.Context, msg string, fields map[string]interface{}) {
+	r.entries = append(r.entries, fields)
+}
+
+func (r *recordingLogger) Error(ctx context.Context, err error, fields map[string]interface{}) {
+	r.entries = append(r.entries, fields)
+}
+
+func TestWithLogFields_Accumulates(t *testing.T) {
+	ctx := WithLogFields(context.Background(), map[string]interface{}{"tenant": "acme", "request_id": "r-1"})
+	ctx = WithLogFields(ctx, map[string]interface{}{"request_id": "r-2"})
+
+	assert.Equal(t, map[string]interface{}{"tenant": "acme", "request_id": "r-2"}, LogFieldsFromContext(ctx))
+	assert.Nil(t, LogFieldsFromContext(context.Background()))
+}
+
+func TestBaseClient_Execute_MergesContextLogFields(t *testing.T) {
+	log := &recordingLogger{}
+	client := NewBaseClientWithName(BaseConfig{EnableLogging: true, Timeout: time.Second}, log, "ssm")
+
+	ctx := WithLogFields(context.Background(), map[string]interface{}{
+		"tenant":     "acme",
+		"request_id": "r-1",
+		"service":    "spoofed",
+	})
+	_, err := client.Execute(ctx, "get-parameter", func() (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+	require.Error(t, err)
+
+	require.Len(t, log.entries, 2) // start + error
+	for _, fields := range log.entries {
+		assert.Equal(t, "acme", fields["tenant"])
+		assert.Equal(t, "r-1", fields["request_id"])
+		assert.Equal(t, "get-parameter", fields["operation"])
+		assert.Equal(t, "ssm", fields["service"])
+	}
+}
+
+// infoLogger records entries like recordingLogger but reports the info level
+type infoLogger struct {
+	recordingLogger
+}
+
+func (l *infoLogger) GetLogLevel() string { return "info" }
+
+func TestBaseClient_Execute_SkipsDebugAboveDebugLevel(t *testing.T) {
+	log := &infoLogger{}
+	client := NewBaseClientWithName(BaseConfig{EnableLogging: true, Timeout: time.Second}, log, "ssm")
+
+	_, err := client.Execute(context.Background(), "get-parameter", func() (interface{}, error) { return "ok", nil })
+	require.NoError(t, err)
+	assert.Empty(t, log.entries)
+
+	_, err = client.Execute(context.Background(), "get-parameter", func() (interface{}, error) { return nil, errors.New("boom") })
+	require.Error(t, err)
+	require.Len(t, log.entries, 1) // error only
+	assert.Equal(t, "get-parameter", log.entries[0]["operation"])
+}
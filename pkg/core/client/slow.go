@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/logger"
+)
+
+// WarnIfSlow logs a Warn entry when elapsed exceeds threshold, adding "elapsed_ms"
+// and "slow_threshold_ms" to fields. A zero or negative threshold disables it.
+// Clients call it after every operation, independently of their EnableLogging
+// flag, so slow calls stay visible without debug logging.
+func WarnIfSlow(ctx context.Context, log logger.Service, threshold time.Duration, operationName string, elapsed time.Duration, fields map[string]interface{}) {
+	if threshold <= 0 || elapsed <= threshold || log == nil {
+		return
+	}
+
+	warnFields := make(map[string]interface{}, len(fields)+2)
+	for k, v := range fields {
+		warnFields[k] = v
+	}
+	warnFields["elapsed_ms"] = elapsed.Milliseconds()
+	warnFields["slow_threshold_ms"] = threshold.Milliseconds()
+
+	log.Warn(ctx, fmt.Sprintf("slow operation: %s took %s", operationName, elapsed), warnFields)
+}
+
+// WarnIfSlowFunc is WarnIfSlow with fields built only when the warning is emitted,
+// so fast operations on hot paths allocate nothing for it
+func WarnIfSlowFunc(ctx context.Context, log logger.Service, threshold time.Duration, operationName string, elapsed time.Duration, fields func() map[string]interface{}) {
+	if threshold <= 0 || elapsed <= threshold || log == nil {
+		return
+	}
+	WarnIfSlow(ctx, log, threshold, operationName, elapsed, fields())
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// warnRecordingLogger captures the message and fields of every Warn entry
+type warnRecordingLogger struct {
+	mockLogger
+	messages []string
+	fields   []map[string]interface{}
+}
+
+func (w *warnRecordingLogger) Warn(ctx context.Context, msg string, fields map[string]interface{}) {
+	w.messages = append(w.messages, msg)
+	w.fields = append(w.fields, fields)
+}
+
+func TestWarnIfSlow(t *testing.T) {
+	log := &warnRecordingLogger{}
+	fields := map[string]interface{}{"operation": "get"}
+
+	WarnIfSlow(context.Background(), log, 0, "get", time.Second, fields)
+	WarnIfSlow(context.Background(), log, time.Second, "get", time.Second, fields)
+	assert.Empty(t, log.messages, "disabled threshold and elapsed equal to the threshold must not warn")
+
+	WarnIfSlow(context.Background(), log, 100*time.Millisecond, "get", 250*time.Millisecond, fields)
+	require.Len(t, log.messages, 1)
+	assert.Equal(t, "slow operation: get took 250ms", log.messages[0])
+	assert.Equal(t, "get", log.fields[0]["operation"])
+	assert.Equal(t, int64(250), log.fields[0]["elapsed_ms"])
+	assert.Equal(t, int64(100), log.fields[0]["slow_threshold_ms"])
+	assert.NotContains(t, fields, "elapsed_ms", "caller fields must not be modified")
+}
+
+func TestBaseClient_Execute_WarnsOnSlowOperation(t *testing.T) {
+	log := &warnRecordingLogger{}
+	client := NewBaseClientWithName(BaseConfig{Timeout: time.Second, SlowThreshold: 5 * time.Millisecond}, log, "ssm")
+
+	_, err := client.Execute(context.Background(), "fast", func() (interface{}, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.Empty(t, log.messages)
+
+	_, err = client.Execute(context.Background(), "slow", func() (interface{}, error) {
+		time.Sleep(20 * time.Millisecond)
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, log.messages, 1, "slow warnings are emitted even with logging disabled")
+	assert.Contains(t, log.messages[0], "slow operation: slow took")
+	assert.Equal(t, "slow", log.fields[0]["operation"])
+	assert.Equal(t, "ssm", log.fields[0]["service"])
+	assert.GreaterOrEqual(t, log.fields[0]["elapsed_ms"], int64(20))
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	// ErrTLSCertKeyMismatch is returned when only one of ClientCertPath/ClientKeyPath is set
+	ErrTLSCertKeyMismatch = errors.New("tls: client_cert_path and client_key_path must be provided together")
+)
+
+// TLSConfig holds the client-side TLS settings shared by the REST and gRPC clients.
+// Embed it in a client Config with `mapstructure:",squash"` to expose the fields at top level.
+type TLSConfig struct {
+	// ClientCertPath is the PEM client certificate presented for mutual TLS.
+	ClientCertPath string `mapstructure:"client_cert_path" json:"client_cert_path"`
+
+	// ClientKeyPath is the PEM private key matching ClientCertPath.
+	ClientKeyPath string `mapstructure:"client_key_path" json:"client_key_path"`
+
+	// CACertPath is a PEM bundle used to verify the server certificate.
+	// If empty, the system root CAs are used.
+	CACertPath string `mapstructure:"ca_cert_path" json:"ca_cert_path"`
+}
+
+// Enabled reports whether any TLS setting is configured.
+func (c TLSConfig) Enabled() bool {
+	return c.ClientCertPath != "" || c.ClientKeyPath != "" || c.CACertPath != ""
+}
+
+// Load validates the settings and builds a *tls.Config.
+// It returns (nil, nil) when no setting is configured, so callers keep their default transport.
+func (c TLSConfig) Load() (*tls.Config, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+	if (c.ClientCertPath == "") != (c.ClientKeyPath == "") {
+		return nil, ErrTLSCertKeyMismatch
+	}
+
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if c.ClientCertPath != "" {
+		cert, err := tls.LoadX509KeyPair(c.ClientCertPath, c.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("tls: loading client certificate %q: %w", c.ClientCertPath, err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if c.CACertPath != "" {
+		pem, err := os.ReadFile(c.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("tls: reading CA certificate %q: %w", c.CACertPath, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("tls: no valid certificates found in %q", c.CACertPath)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	return tlsCfg, nil
+}
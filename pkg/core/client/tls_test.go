@@ -0,0 +1,83 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCert writes a self-signed certificate and key to dir and returns their paths.
+func writeTestCert(t *testing.T, dir string) (certPath, keyPath string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "go-engine-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPath = filepath.Join(dir, "client.crt")
+	keyPath = filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certPath, keyPath
+}
+
+func TestTLSConfig_Load_Disabled(t *testing.T) {
+	tlsCfg, err := TLSConfig{}.Load()
+	assert.NoError(t, err)
+	assert.Nil(t, tlsCfg)
+}
+
+func TestTLSConfig_Load_ClientCertAndCA(t *testing.T) {
+	certPath, keyPath := writeTestCert(t, t.TempDir())
+
+	tlsCfg, err := TLSConfig{ClientCertPath: certPath, ClientKeyPath: keyPath, CACertPath: certPath}.Load()
+
+	require.NoError(t, err)
+	assert.Len(t, tlsCfg.Certificates, 1)
+	assert.NotNil(t, tlsCfg.RootCAs)
+}
+
+func TestTLSConfig_Load_CertWithoutKey(t *testing.T) {
+	certPath, _ := writeTestCert(t, t.TempDir())
+
+	_, err := TLSConfig{ClientCertPath: certPath}.Load()
+	assert.ErrorIs(t, err, ErrTLSCertKeyMismatch)
+}
+
+func TestTLSConfig_Load_InvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := writeTestCert(t, dir)
+	garbage := filepath.Join(dir, "garbage.pem")
+	require.NoError(t, os.WriteFile(garbage, []byte("not a certificate"), 0o600))
+
+	_, err := TLSConfig{ClientCertPath: certPath, ClientKeyPath: garbage}.Load()
+	assert.ErrorContains(t, err, "loading client certificate")
+
+	_, err = TLSConfig{ClientCertPath: certPath, ClientKeyPath: keyPath, CACertPath: garbage}.Load()
+	assert.ErrorContains(t, err, "no valid certificates")
+
+	_, err = TLSConfig{CACertPath: filepath.Join(dir, "missing.pem")}.Load()
+	assert.ErrorContains(t, err, "reading CA certificate")
+}
@@ -0,0 +1,121 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/logger"
+)
+
+const (
+	// DefaultWaitTimeout is used when WaitForDependencies receives a zero timeout
+	DefaultWaitTimeout = time.Minute
+
+	waitInitialBackoff = 200 * time.Millisecond
+	waitMaxBackoff     = 5 * time.Second
+)
+
+// ErrDependenciesNotReady is returned by WaitForDependencies when the timeout elapses
+var ErrDependenciesNotReady = errors.New("dependencies not ready")
+
+// HealthCheck is a named Checker awaited by WaitForDependencies
+type HealthCheck struct {
+	Name    string
+	Checker Checker
+}
+
+// WaitForDependencies blocks until every check passes, retrying the failing ones with
+// exponential backoff (200ms up to 5s). Each attempt is bounded by DefaultTimeout and by
+// the overall timeout, so a hung checker cannot hold startup past it.
+// Use it at startup so the app does not serve before Redis, the database, etc. accept
+// connections, e.g. with NewRedisChecker and NewSQLChecker.
+//
+// It returns an error wrapping ErrDependenciesNotReady and the last error of every
+// dependency still down once timeout (DefaultWaitTimeout when zero) elapses, or ctx.Err()
+// if ctx ends first. Progress is logged at Info and failed attempts at Warn; log may be nil.
+func WaitForDependencies(ctx context.Context, checks []HealthCheck, timeout time.Duration, log logger.Service) error {
+	log = logger.OrNoop(log)
+	if timeout <= 0 {
+		timeout = DefaultWaitTimeout
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pending := checks
+	lastErr := make(map[string]error, len(checks))
+	backoff := waitInitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		var stillDown []HealthCheck
+		for _, hc := range pending {
+			if waitCtx.Err() != nil {
+				// Out of time: the remaining checks keep their last error
+				if lastErr[hc.Name] == nil {
+					lastErr[hc.Name] = waitCtx.Err()
+				}
+				stillDown = append(stillDown, hc)
+				continue
+			}
+			if err := runCheck(waitCtx, hc.Checker); err != nil {
+				lastErr[hc.Name] = err
+				stillDown = append(stillDown, hc)
+				log.Warn(ctx, "dependency not ready", map[string]interface{}{
+					"dependency": hc.Name,
+					"attempt":    attempt,
+					"error":      err.Error(),
+				})
+				continue
+			}
+			delete(lastErr, hc.Name)
+			log.Info(ctx, "dependency ready", map[string]interface{}{"dependency": hc.Name, "attempt": attempt})
+		}
+
+		pending = stillDown
+		if len(pending) == 0 {
+			return nil
+		}
+
+		wait := time.NewTimer(backoff)
+		select {
+		case <-wait.C:
+		case <-waitCtx.Done():
+			wait.Stop()
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return notReadyError(pending, lastErr, timeout)
+		}
+
+		backoff *= 2
+		if backoff > waitMaxBackoff {
+			backoff = waitMaxBackoff
+		}
+	}
+}
+
+// WaitUntilReady is WaitForDependencies over the registered checkers, logging with the
+// service's logger
+func (hs *HealthService) WaitUntilReady(ctx context.Context, timeout time.Duration) error {
+	checks := make([]HealthCheck, len(hs.checkers))
+	for i, nc := range hs.checkers {
+		checks[i] = HealthCheck{Name: nc.name, Checker: nc.checker}
+	}
+	return WaitForDependencies(ctx, checks, timeout, hs.log)
+}
+
+func runCheck(ctx context.Context, c Checker) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+	return c.Check(ctx)
+}
+
+func notReadyError(pending []HealthCheck, lastErr map[string]error, timeout time.Duration) error {
+	errs := make([]error, len(pending))
+	for i, hc := range pending {
+		errs[i] = fmt.Errorf("%s: %w", hc.Name, lastErr[hc.Name])
+	}
+	return fmt.Errorf("%w after %s: %w", ErrDependenciesNotReady, timeout, errors.Join(errs...))
+}
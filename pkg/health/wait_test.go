@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyChecker fails until it has been called failures times
+type flakyChecker struct {
+	failures int32
+	calls    atomic.Int32
+}
+
+func (f *flakyChecker) Check(ctx context.Context) error {
+	if f.calls.Add(1) <= f.failures {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+// hungChecker blocks until its context ends
+type hungChecker struct{}
+
+func (hungChecker) Check(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestWaitForDependencies_RetriesUntilReady(t *testing.T) {
+	redis := &flakyChecker{failures: 2}
+	db := &flakyChecker{}
+
+	err := WaitForDependencies(context.Background(), []HealthCheck{
+		{Name: "redis", Checker: redis},
+		{Name: "db", Checker: db},
+	}, 5*time.Second, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), redis.calls.Load())
+	assert.Equal(t, int32(1), db.calls.Load(), "ready dependencies are not checked again")
+}
+
+func TestWaitForDependencies_Timeout(t *testing.T) {
+	err := WaitForDependencies(context.Background(), []HealthCheck{
+		{Name: "db", Checker: upChecker()},
+		{Name: "redis", Checker: downChecker("connection refused")},
+	}, 300*time.Millisecond, nil)
+
+	require.ErrorIs(t, err, ErrDependenciesNotReady)
+	assert.Contains(t, err.Error(), "redis: connection refused")
+	assert.NotContains(t, err.Error(), "db:")
+}
+
+func TestWaitForDependencies_HungChecksStopAtTheOverallTimeout(t *testing.T) {
+	hung := hungChecker{}
+
+	start := time.Now()
+	err := WaitForDependencies(context.Background(), []HealthCheck{
+		{Name: "redis", Checker: hung},
+		{Name: "db", Checker: hung},
+	}, 100*time.Millisecond, nil)
+
+	require.ErrorIs(t, err, ErrDependenciesNotReady)
+	assert.Contains(t, err.Error(), "redis:")
+	assert.Contains(t, err.Error(), "db:")
+	assert.Less(t, time.Since(start), DefaultTimeout, "checks are bounded by the overall timeout")
+}
+
+func TestWaitForDependencies_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := WaitForDependencies(ctx, []HealthCheck{{Name: "redis", Checker: downChecker("down")}}, time.Minute, nil)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestHealthService_WaitUntilReady(t *testing.T) {
+	log := &mockLogger{}
+	log.On("Info", mock.Anything, "dependency ready", mock.Anything).Return()
+	log.On("Warn", mock.Anything, "dependency not ready", mock.Anything).Return()
+
+	svc := newSvc(log, namedChecker{name: "redis", checker: &flakyChecker{failures: 1}})
+
+	require.NoError(t, svc.WaitUntilReady(context.Background(), 5*time.Second))
+	log.AssertCalled(t, "Warn", mock.Anything, "dependency not ready", mock.Anything)
+	log.AssertCalled(t, "Info", mock.Anything, "dependency ready", mock.Anything)
+}
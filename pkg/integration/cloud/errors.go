@@ -1,6 +1,7 @@
 package cloud
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -43,8 +44,13 @@ const (
 	ErrCodeNotFound               = "aws.not_found"
 	ErrCodeConflict               = "aws.conflict"
 	ErrCodeConditionalCheckFailed = "aws.conditional_check_failed"
+	ErrCodeLambdaFunctionError    = "lambda.invoke.function_error"
 )
 
+// MetadataAWSErrorCode is the Error.Metadata key holding the raw AWS error code
+// (e.g. "ProvisionedThroughputExceededException") alongside the normalized Code
+const MetadataAWSErrorCode = "aws_error_code"
+
 // Error implements error interface
 func (e *Error) Error() string {
 	if e.Cause != nil {
@@ -88,3 +94,13 @@ func NewErrorWithCause(code, message string, cause error) *Error {
 		Cause:   cause,
 	}
 }
+
+// AWSErrorCode returns the raw AWS error code carried by err, or "" if there is none
+func AWSErrorCode(err error) string {
+	var cloudErr *Error
+	if !errors.As(err, &cloudErr) {
+		return ""
+	}
+	code, _ := cloudErr.Metadata[MetadataAWSErrorCode].(string)
+	return code
+}
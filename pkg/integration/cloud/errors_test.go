@@ -2,6 +2,7 @@ package cloud
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -70,3 +71,28 @@ func TestErrorConstants(t *testing.T) {
 		}
 	}
 }
+
+func TestAWSErrorCode(t *testing.T) {
+	err := NewError(ErrCodeThrottling, "slow down").
+		WithMetadata(MetadataAWSErrorCode, "ProvisionedThroughputExceededException")
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"cloud error", err, "ProvisionedThroughputExceededException"},
+		{"wrapped cloud error", fmt.Errorf("wrapped: %w", err), "ProvisionedThroughputExceededException"},
+		{"cloud error without code", NewError(ErrCodeNotFound, "missing"), ""},
+		{"plain error", errors.New("plain"), ""},
+		{"nil", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AWSErrorCode(tt.err); got != tt.want {
+				t.Errorf("AWSErrorCode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package cloud
+
+// PageToken is an opaque continuation token for paginated operations
+// Callers pass back the Next token of the previous page unchanged; the zero value
+// requests the first page. Service-specific names (S3 ContinuationToken, SSM/SQS
+// NextToken, ...) are translated by the adapters.
+type PageToken string
+
+const (
+	// PageTokenParam is the Request.QueryParams key carrying a PageToken
+	// Its presence, even with an empty value, asks the adapter for a single page.
+	PageTokenParam = "cloud.page_token"
+
+	// NextPageTokenHeader is the Response.Headers key carrying the next PageToken
+	// It is empty when the last page has been returned.
+	NextPageTokenHeader = "cloud.next_page_token"
+)
+
+// IsFirst reports whether the token requests the first page
+func (t PageToken) IsFirst() bool {
+	return t == ""
+}
+
+// Page is one page of a paginated listing plus the token for the next one
+type Page[T any] struct {
+	Items []T
+	Next  PageToken
+}
+
+// HasMore reports whether another page can be requested with Next
+func (p *Page[T]) HasMore() bool {
+	return p.Next != ""
+}
+
+// WithPageToken marks the request as paginated and sets the continuation token
+func (r *Request) WithPageToken(token PageToken) *Request {
+	if r.QueryParams == nil {
+		r.QueryParams = make(map[string]string)
+	}
+	r.QueryParams[PageTokenParam] = string(token)
+	return r
+}
+
+// PageToken returns the continuation token of a paginated request and whether the
+// request asked for a single page at all
+func (r *Request) PageToken() (PageToken, bool) {
+	token, ok := r.QueryParams[PageTokenParam]
+	return PageToken(token), ok
+}
+
+// NextPageToken returns the token for the next page, empty on the last page
+func (r *Response) NextPageToken() PageToken {
+	return PageToken(r.Headers[NextPageTokenHeader])
+}
@@ -0,0 +1,42 @@
+package cloud
+
+import "testing"
+
+func TestRequest_WithPageToken(t *testing.T) {
+	req := &Request{Operation: "s3.list_objects"}
+
+	if _, paged := req.PageToken(); paged {
+		t.Error("PageToken() paged = true before WithPageToken")
+	}
+
+	req.WithPageToken("")
+	token, paged := req.PageToken()
+	if !paged || !token.IsFirst() {
+		t.Errorf("PageToken() = %q/%v, want first page of a paged request", token, paged)
+	}
+
+	req.WithPageToken("abc")
+	if token, _ := req.PageToken(); token != "abc" || token.IsFirst() {
+		t.Errorf("PageToken() = %q, want abc", token)
+	}
+}
+
+func TestResponse_NextPageToken(t *testing.T) {
+	if got := (&Response{}).NextPageToken(); got != "" {
+		t.Errorf("NextPageToken() = %q, want empty", got)
+	}
+
+	resp := &Response{Headers: map[string]string{NextPageTokenHeader: "next"}}
+	if got := resp.NextPageToken(); got != "next" {
+		t.Errorf("NextPageToken() = %q, want next", got)
+	}
+}
+
+func TestPage_HasMore(t *testing.T) {
+	if (&Page[string]{Items: []string{"a"}}).HasMore() {
+		t.Error("HasMore() = true for a page without Next")
+	}
+	if !(&Page[string]{Next: "next"}).HasMore() {
+		t.Error("HasMore() = false for a page with Next")
+	}
+}
@@ -1,6 +1,7 @@
 package cloud
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -46,6 +47,11 @@ type Request struct {
 	// If zero, uses client default timeout
 	Timeout time.Duration
 
+	// StreamBody asks for the response payload in Response.BodyStream instead of Body,
+	// for operations that support it (s3.get_object). Middlewares that share responses
+	// between callers pass such requests through.
+	StreamBody bool
+
 	// Method is optional HTTP-like method (mainly for inbound/APIGateway normalization)
 	// For outbound operations, Operation already defines the action
 	// Can be omitted for most outbound operations
@@ -53,6 +59,9 @@ type Request struct {
 }
 
 // WithJSONBody sets Body by JSON-marshaling the given value
+// It uses encoding/json defaults: HTML characters (<, >, &) are escaped.
+// Large 64-bit IDs should be sent as strings (or json.Number), since many
+// consumers decode JSON numbers as float64 and lose precision above 2^53.
 func (r *Request) WithJSONBody(v interface{}) error {
 	body, err := json.Marshal(v)
 	if err != nil {
@@ -62,6 +71,31 @@ func (r *Request) WithJSONBody(v interface{}) error {
 	return nil
 }
 
+// JSONOptions tunes JSON encoding/decoding of request and response bodies
+type JSONOptions struct {
+	// DisableHTMLEscape writes <, > and & verbatim instead of \u003c, \u003e, \u0026
+	DisableHTMLEscape bool
+
+	// UseNumber decodes numbers into json.Number instead of float64 when the target
+	// is an interface{} (Response.UnmarshalBodyOptions), preserving 64-bit IDs.
+	// On encoding, json.Number values are always written verbatim.
+	UseNumber bool
+}
+
+// WithJSONBodyOptions sets Body by JSON-encoding v with the given options
+// The trailing newline added by json.Encoder is stripped, so with zero options
+// Body matches WithJSONBody.
+func (r *Request) WithJSONBodyOptions(v interface{}, opts JSONOptions) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(!opts.DisableHTMLEscape)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to marshal JSON body: %w", err)
+	}
+	r.Body = bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+	return nil
+}
+
 // WithBody sets Body directly from bytes
 func (r *Request) WithBody(body []byte) *Request {
 	r.Body = body
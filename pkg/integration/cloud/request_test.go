@@ -1,6 +1,7 @@
 package cloud
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 )
@@ -43,6 +44,45 @@ func TestRequest_WithJSONBody(t *testing.T) {
 	}
 }
 
+func TestRequest_WithJSONBodyOptions(t *testing.T) {
+	payload := map[string]string{"html": "<b>a & b</b>"}
+
+	escaped := &Request{}
+	if err := escaped.WithJSONBodyOptions(payload, JSONOptions{}); err != nil {
+		t.Fatalf("WithJSONBodyOptions() error = %v", err)
+	}
+	if want := `{"html":"\u003cb\u003ea \u0026 b\u003c/b\u003e"}`; string(escaped.Body) != want {
+		t.Errorf("default options body = %s, want %s", escaped.Body, want)
+	}
+
+	raw := &Request{}
+	if err := raw.WithJSONBodyOptions(payload, JSONOptions{DisableHTMLEscape: true}); err != nil {
+		t.Fatalf("WithJSONBodyOptions() error = %v", err)
+	}
+	if want := `{"html":"<b>a & b</b>"}`; string(raw.Body) != want {
+		t.Errorf("DisableHTMLEscape body = %s, want %s", raw.Body, want)
+	}
+}
+
+func TestRequest_WithJSONBodyOptions_MatchesWithJSONBody(t *testing.T) {
+	payload := map[string]interface{}{"id": json.Number("9007199254740993"), "name": "x"}
+
+	plain := &Request{}
+	withOpts := &Request{}
+	if err := plain.WithJSONBody(payload); err != nil {
+		t.Fatalf("WithJSONBody() error = %v", err)
+	}
+	if err := withOpts.WithJSONBodyOptions(payload, JSONOptions{UseNumber: true}); err != nil {
+		t.Fatalf("WithJSONBodyOptions() error = %v", err)
+	}
+	if string(plain.Body) != string(withOpts.Body) {
+		t.Errorf("bodies differ: %s vs %s", plain.Body, withOpts.Body)
+	}
+	if want := `{"id":9007199254740993,"name":"x"}`; string(withOpts.Body) != want {
+		t.Errorf("body = %s, want %s", withOpts.Body, want)
+	}
+}
+
 func TestRequest_WithBody(t *testing.T) {
 	req := &Request{}
 	body := []byte("test body")
@@ -1,8 +1,10 @@
 package cloud
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 )
 
 // Response represents a normalized AWS operation response
@@ -21,6 +23,10 @@ type Response struct {
 	// Body contains the response payload as raw bytes
 	Body []byte
 
+	// BodyStream, when set, holds the payload unbuffered instead of Body (see
+	// Request.StreamBody). The caller must close it, or call DrainBodyStream.
+	BodyStream io.ReadCloser
+
 	// Headers carry response metadata
 	// Examples:
 	//   - SQS: MessageId, ReceiptHandle
@@ -33,16 +39,53 @@ type Response struct {
 	Metadata map[string]interface{}
 }
 
+// DrainBodyStream reads BodyStream into Body and closes it, so byte-based code can
+// handle streamed responses. It does nothing when BodyStream is nil.
+func (r *Response) DrainBodyStream() error {
+	if r.BodyStream == nil {
+		return nil
+	}
+	stream := r.BodyStream
+	r.BodyStream = nil
+	defer stream.Close()
+
+	body, err := io.ReadAll(stream)
+	if err != nil {
+		return fmt.Errorf("failed to read response body stream: %w", err)
+	}
+	r.Body = body
+	return nil
+}
+
 // UnmarshalBody unmarshals Body as JSON into the given value
 // This is a helper method, not an implementation of json.Unmarshaler
 func (r *Response) UnmarshalBody(v interface{}) error {
+	if err := r.DrainBodyStream(); err != nil {
+		return err
+	}
 	if len(r.Body) == 0 {
 		return fmt.Errorf("response body is empty")
 	}
 	return json.Unmarshal(r.Body, v)
 }
 
-// BodyString returns Body as string
+// UnmarshalBodyOptions unmarshals Body as JSON into v honoring opts.UseNumber
+func (r *Response) UnmarshalBodyOptions(v interface{}, opts JSONOptions) error {
+	if err := r.DrainBodyStream(); err != nil {
+		return err
+	}
+	if len(r.Body) == 0 {
+		return fmt.Errorf("response body is empty")
+	}
+	dec := json.NewDecoder(bytes.NewReader(r.Body))
+	if opts.UseNumber {
+		dec.UseNumber()
+	}
+	return dec.Decode(v)
+}
+
+// BodyString returns Body as string, draining BodyStream first
 func (r *Response) BodyString() string {
+	_ = r.DrainBodyStream()
 	return string(r.Body)
 }
@@ -1,6 +1,9 @@
 package cloud
 
 import (
+	"encoding/json"
+	"io"
+	"strings"
 	"testing"
 )
 
@@ -39,6 +42,31 @@ func TestResponse_UnmarshalBody(t *testing.T) {
 	}
 }
 
+func TestResponse_UnmarshalBodyOptions_UseNumber(t *testing.T) {
+	resp := &Response{Body: []byte(`{"id":9007199254740993}`)}
+
+	var lossy map[string]interface{}
+	if err := resp.UnmarshalBodyOptions(&lossy, JSONOptions{}); err != nil {
+		t.Fatalf("UnmarshalBodyOptions() error = %v", err)
+	}
+	if _, ok := lossy["id"].(float64); !ok {
+		t.Errorf("default decode type = %T, want float64", lossy["id"])
+	}
+
+	var exact map[string]interface{}
+	if err := resp.UnmarshalBodyOptions(&exact, JSONOptions{UseNumber: true}); err != nil {
+		t.Fatalf("UnmarshalBodyOptions() error = %v", err)
+	}
+	if got := exact["id"]; got != json.Number("9007199254740993") {
+		t.Errorf("UseNumber decode = %v (%T), want json.Number 9007199254740993", got, got)
+	}
+
+	empty := &Response{}
+	if err := empty.UnmarshalBodyOptions(&exact, JSONOptions{}); err == nil {
+		t.Error("UnmarshalBodyOptions() on empty body should fail")
+	}
+}
+
 func TestResponse_BodyString(t *testing.T) {
 	resp := &Response{Body: []byte("test body")}
 	if resp.BodyString() != "test body" {
@@ -46,6 +74,33 @@ func TestResponse_BodyString(t *testing.T) {
 	}
 }
 
+func TestResponse_DrainBodyStream(t *testing.T) {
+	stream := &closeRecorder{Reader: strings.NewReader(`{"id":1}`)}
+	resp := &Response{BodyStream: stream}
+
+	var got map[string]int
+	if err := resp.UnmarshalBody(&got); err != nil {
+		t.Fatalf("UnmarshalBody() error = %v", err)
+	}
+	if got["id"] != 1 || resp.BodyStream != nil || !stream.closed {
+		t.Errorf("DrainBodyStream() body = %q, stream = %v, closed = %v", resp.Body, resp.BodyStream, stream.closed)
+	}
+	if err := (&Response{}).DrainBodyStream(); err != nil {
+		t.Errorf("DrainBodyStream() without stream error = %v", err)
+	}
+}
+
+// closeRecorder is an io.ReadCloser that records Close
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
 func TestResponse_Complete(t *testing.T) {
 	resp := &Response{
 		StatusCode: 200,
@@ -0,0 +1,134 @@
+package observability
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand/v2"
+
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+	"github.com/skolldire/go-engine/pkg/utilities/logger"
+)
+
+// DefaultBodyCaptureMaxBytes is used when BodyCaptureConfig.MaxBytes is zero
+const DefaultBodyCaptureMaxBytes = 1024
+
+// RedactedValue replaces the fields scrubbed by RedactJSONFields
+const RedactedValue = "[REDACTED]"
+
+// BodyRedactor scrubs sensitive data from a request or response body before it is
+// logged. It receives the full body and must not modify it in place.
+type BodyRedactor func(operation string, body []byte) []byte
+
+// BodyCaptureConfig controls BodyCapture
+type BodyCaptureConfig struct {
+	SampleRate float64      // Fraction of requests captured, 0 to 1
+	MaxBytes   int          // Bodies are truncated to this size after redaction
+	Redact     BodyRedactor // Optional
+}
+
+// BodyCapture returns a middleware that logs a sampled, truncated copy of request and
+// response bodies at Debug. It is meant for troubleshooting payloads in production:
+// nothing is captured unless the logger has Debug enabled, and bodies go through
+// cfg.Redact before logging. A nil logger or a SampleRate of zero disables it.
+func BodyCapture(log logger.Service, cfg BodyCaptureConfig) cloud.Middleware {
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = DefaultBodyCaptureMaxBytes
+	}
+	return func(next cloud.Client) cloud.Client {
+		if log == nil || cfg.SampleRate <= 0 {
+			return next
+		}
+		return &bodyCaptureMiddleware{next: next, logger: log, cfg: cfg}
+	}
+}
+
+type bodyCaptureMiddleware struct {
+	next   cloud.Client
+	logger logger.Service
+	cfg    BodyCaptureConfig
+}
+
+func (m *bodyCaptureMiddleware) Do(ctx context.Context, req *cloud.Request) (*cloud.Response, error) {
+	if req == nil || !m.sampled() || !logger.Enabled(m.logger, logger.LevelDebug) {
+		return m.next.Do(ctx, req)
+	}
+
+	resp, err := m.next.Do(ctx, req)
+
+	fields := map[string]interface{}{
+		"operation": req.Operation,
+		"path":      req.Path,
+	}
+	m.addBody(fields, "request", req.Operation, req.Body)
+	if resp != nil {
+		fields["status_code"] = resp.StatusCode
+		m.addBody(fields, "response", req.Operation, resp.Body)
+	}
+	if err != nil {
+		fields["error_message"] = err.Error()
+	}
+	m.logger.Debug(ctx, "AWS operation body captured", fields)
+
+	return resp, err
+}
+
+func (m *bodyCaptureMiddleware) sampled() bool {
+	return m.cfg.SampleRate >= 1 || rand.Float64() < m.cfg.SampleRate
+}
+
+// addBody sets <prefix>_body, <prefix>_body_size and, when cut, <prefix>_body_truncated
+func (m *bodyCaptureMiddleware) addBody(fields map[string]interface{}, prefix, operation string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	fields[prefix+"_body_size"] = len(body)
+
+	if m.cfg.Redact != nil {
+		body = m.cfg.Redact(operation, body)
+	}
+	if len(body) > m.cfg.MaxBytes {
+		body = body[:m.cfg.MaxBytes]
+		fields[prefix+"_body_truncated"] = true
+	}
+	fields[prefix+"_body"] = string(body)
+}
+
+// RedactJSONFields returns a BodyRedactor that replaces the value of every object key
+// named in fields, at any depth, with RedactedValue. Bodies that are not JSON are
+// returned unchanged.
+func RedactJSONFields(fields ...string) BodyRedactor {
+	names := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		names[f] = struct{}{}
+	}
+
+	return func(_ string, body []byte) []byte {
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err != nil {
+			return body
+		}
+		redacted, err := json.Marshal(redactJSON(v, names))
+		if err != nil {
+			return body
+		}
+		return redacted
+	}
+}
+
+func redactJSON(v interface{}, names map[string]struct{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if _, ok := names[k]; ok {
+				t[k] = RedactedValue
+				continue
+			}
+			t[k] = redactJSON(child, names)
+		}
+	case []interface{}:
+		for i, child := range t {
+			t[i] = redactJSON(child, names)
+		}
+	}
+	return v
+}
@@ -0,0 +1,117 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/skolldire/go-engine/pkg/integration/cloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyCapture_LogsRedactedTruncatedBodies(t *testing.T) {
+	mockLog := new(mockLogger)
+	mockCli := new(mockClient)
+	ctx := context.Background()
+
+	req := &cloud.Request{
+		Operation: "sqs.send_message",
+		Path:      "orders",
+		Body:      []byte(`{"order_id":"o-1","card":{"number":"4111111111111111"}}`),
+	}
+	resp := &cloud.Response{StatusCode: 200, Body: []byte(`{"MessageId":"abcdefghijklmnopqrstuvwxyz"}`)}
+	mockCli.On("Do", ctx, req).Return(resp, nil)
+
+	var fields map[string]interface{}
+	mockLog.On("GetLogLevel").Return("debug")
+	mockLog.On("Debug", ctx, "AWS operation body captured", mock.Anything).Run(func(args mock.Arguments) {
+		fields = args.Get(2).(map[string]interface{})
+	}).Return()
+
+	client := BodyCapture(mockLog, BodyCaptureConfig{
+		SampleRate: 1,
+		MaxBytes:   64,
+		Redact:     RedactJSONFields("number"),
+	})(mockCli)
+
+	got, err := client.Do(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, resp, got)
+
+	require.NotNil(t, fields)
+	assert.Equal(t, `{"card":{"number":"[REDACTED]"},"order_id":"o-1"}`, fields["request_body"])
+	assert.Equal(t, len(req.Body), fields["request_body_size"])
+	assert.NotContains(t, fields, "request_body_truncated")
+	assert.Equal(t, 200, fields["status_code"])
+	assert.Contains(t, req.Body, byte('4'), "the request body is not modified")
+
+	client = BodyCapture(mockLog, BodyCaptureConfig{SampleRate: 1, MaxBytes: 8})(mockCli)
+	_, err = client.Do(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, `{"order_`, fields["request_body"])
+	assert.Equal(t, true, fields["request_body_truncated"])
+	assert.Equal(t, true, fields["response_body_truncated"])
+}
+
+func TestBodyCapture_Error(t *testing.T) {
+	mockLog := new(mockLogger)
+	mockCli := new(mockClient)
+	ctx := context.Background()
+	req := &cloud.Request{Operation: "sns.publish", Body: []byte("hello")}
+	boom := errors.New("boom")
+
+	mockCli.On("Do", ctx, req).Return(nil, boom)
+	mockLog.On("GetLogLevel").Return("debug")
+	mockLog.On("Debug", ctx, mock.Anything, mock.MatchedBy(func(fields map[string]interface{}) bool {
+		_, hasResponse := fields["response_body"]
+		return fields["request_body"] == "hello" && fields["error_message"] == "boom" && !hasResponse
+	})).Return()
+
+	_, err := BodyCapture(mockLog, BodyCaptureConfig{SampleRate: 1})(mockCli).Do(ctx, req)
+
+	assert.ErrorIs(t, err, boom)
+	mockLog.AssertExpectations(t)
+}
+
+func TestBodyCapture_SkippedWhenDisabled(t *testing.T) {
+	ctx := context.Background()
+	req := &cloud.Request{Operation: "sqs.send_message", Body: []byte("secret")}
+
+	tests := []struct {
+		name  string
+		level string
+		rate  float64
+	}{
+		{name: "info level", level: "info", rate: 1},
+		{name: "zero sample rate", level: "debug", rate: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLog := new(mockLogger)
+			mockCli := new(mockClient)
+			mockCli.On("Do", ctx, req).Return(&cloud.Response{StatusCode: 200}, nil)
+			mockLog.On("GetLogLevel").Return(tt.level)
+
+			_, err := BodyCapture(mockLog, BodyCaptureConfig{SampleRate: tt.rate})(mockCli).Do(ctx, req)
+
+			require.NoError(t, err)
+			mockLog.AssertNotCalled(t, "Debug", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestBodyCapture_NilLoggerReturnsNext(t *testing.T) {
+	mockCli := new(mockClient)
+	assert.Same(t, mockCli, BodyCapture(nil, BodyCaptureConfig{SampleRate: 1})(mockCli))
+}
+
+func TestRedactJSONFields(t *testing.T) {
+	redact := RedactJSONFields("password", "token")
+
+	assert.Equal(t,
+		`{"items":[{"token":"[REDACTED]"}],"password":"[REDACTED]","user":"bob"}`,
+		string(redact("op", []byte(`{"user":"bob","password":"hunter2","items":[{"token":"t"}]}`))))
+	assert.Equal(t, "not json", string(redact("op", []byte("not json"))))
+}
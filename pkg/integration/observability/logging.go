@@ -72,6 +72,9 @@ func (m *loggingMiddleware) Do(ctx context.Context, req *cloud.Request) (*cloud.
 			logFields["error_message"] = cloudErr.Message
 			logFields["retriable"] = cloudErr.Retriable
 			logFields["status_code"] = cloudErr.StatusCode
+			if region, ok := cloudErr.Metadata["aws_region"]; ok {
+				logFields["aws_region"] = region
+			}
 		} else {
 			logFields["error_message"] = err.Error()
 			logFields["status_code"] = 500
@@ -90,6 +93,9 @@ func (m *loggingMiddleware) Do(ctx context.Context, req *cloud.Request) (*cloud.
 		if awsReqID, ok := resp.Metadata["aws_request_id"]; ok {
 			logFields["aws_request_id"] = awsReqID
 		}
+		if region, ok := resp.Metadata["aws_region"]; ok {
+			logFields["aws_region"] = region
+		}
 	}
 
 	m.logger.Info(ctx, fmt.Sprintf("AWS operation completed: %s", req.Operation), logFields)
@@ -16,8 +16,20 @@ type MetricsRecorder interface {
 	RecordThrottle(operation string)
 }
 
+// CacheMetricsRecorder is an optional extension of MetricsRecorder; layers that cache or
+// coalesce requests report through it when the recorder implements it
+type CacheMetricsRecorder interface {
+	// RecordCacheEvent reports whether a caching or coalescing layer served operation
+	// without reaching AWS (hit) or had to call it (miss)
+	RecordCacheEvent(ctx context.Context, operation string, hit bool)
+}
+
 // Metrics returns a middleware that records metrics
+// A nil recorder is replaced by NewNoopMetricsRecorder, so metric emission is skipped.
 func Metrics(recorder MetricsRecorder) cloud.Middleware {
+	if recorder == nil {
+		recorder = NewNoopMetricsRecorder()
+	}
 	return func(next cloud.Client) cloud.Client {
 		return &metricsMiddleware{
 			next:     next,
@@ -64,7 +76,11 @@ type TelemetryMetricsRecorder struct {
 }
 
 // NewTelemetryMetricsRecorder creates a new TelemetryMetricsRecorder
+// Returns a NoopMetricsRecorder when tel is nil.
 func NewTelemetryMetricsRecorder(tel telemetry.Telemetry) MetricsRecorder {
+	if tel == nil {
+		return NewNoopMetricsRecorder()
+	}
 	return &TelemetryMetricsRecorder{
 		telemetry: tel,
 	}
@@ -101,3 +117,31 @@ func (r *TelemetryMetricsRecorder) RecordThrottle(operation string) {
 		attribute.String("operation", operation),
 	)
 }
+
+func (r *TelemetryMetricsRecorder) RecordCacheEvent(ctx context.Context, operation string, hit bool) {
+	name := "aws.cache.miss"
+	if hit {
+		name = "aws.cache.hit"
+	}
+	r.telemetry.Counter(ctx, name, 1,
+		attribute.String("operation", operation),
+	)
+}
+
+// NoopMetricsRecorder implements MetricsRecorder discarding every metric
+// Useful for services that want logging/tracing from WithObservability without running telemetry.
+type NoopMetricsRecorder struct{}
+
+// NewNoopMetricsRecorder creates a MetricsRecorder that records nothing
+func NewNoopMetricsRecorder() MetricsRecorder {
+	return NoopMetricsRecorder{}
+}
+
+func (NoopMetricsRecorder) RecordRequest(operation string, duration time.Duration, statusCode int, errorCode string) {
+}
+
+func (NoopMetricsRecorder) RecordRetry(operation string) {}
+
+func (NoopMetricsRecorder) RecordThrottle(operation string) {}
+
+func (NoopMetricsRecorder) RecordCacheEvent(ctx context.Context, operation string, hit bool) {}
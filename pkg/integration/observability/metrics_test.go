@@ -9,6 +9,7 @@ import (
 	"github.com/skolldire/go-engine/pkg/integration/cloud"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMetricsMiddleware_Success(t *testing.T) {
@@ -170,7 +171,55 @@ func TestTelemetryMetricsRecorder_RecordThrottle(t *testing.T) {
 	tel.AssertExpectations(t)
 }
 
+func TestTelemetryMetricsRecorder_RecordCacheEvent(t *testing.T) {
+	tel := new(mockTelemetry)
+	bg := context.Background()
+
+	tel.On("Counter", bg, "aws.cache.hit", int64(1), mock.Anything).Return().Once()
+	tel.On("Counter", bg, "aws.cache.miss", int64(1), mock.Anything).Return().Once()
+
+	recorder, ok := NewTelemetryMetricsRecorder(tel).(CacheMetricsRecorder)
+	require.True(t, ok, "the telemetry recorder implements CacheMetricsRecorder")
+	recorder.RecordCacheEvent(bg, "s3.get_object", true)
+	recorder.RecordCacheEvent(bg, "s3.get_object", false)
+
+	tel.AssertExpectations(t)
+}
+
 func TestNewTelemetryMetricsRecorder_ReturnsNonNil(t *testing.T) {
 	recorder := NewTelemetryMetricsRecorder(new(mockTelemetry))
 	assert.NotNil(t, recorder)
 }
+
+func TestMetricsMiddleware_NilRecorder(t *testing.T) {
+	mockCli := new(mockClient)
+
+	ctx := context.Background()
+	req := &cloud.Request{Operation: "sqs.send_message"}
+	throttleErr := cloud.NewError(cloud.ErrCodeThrottling, "slow down")
+	mockCli.On("Do", ctx, req).Return(nil, throttleErr).Once()
+	mockCli.On("Do", ctx, req).Return(&cloud.Response{StatusCode: 200}, nil).Once()
+
+	client := Metrics(nil)(mockCli)
+
+	assert.NotPanics(t, func() {
+		_, err := client.Do(ctx, req)
+		assert.ErrorIs(t, err, throttleErr)
+
+		resp, err := client.Do(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+	})
+	mockCli.AssertExpectations(t)
+}
+
+func TestNewTelemetryMetricsRecorder_NilTelemetry(t *testing.T) {
+	recorder := NewTelemetryMetricsRecorder(nil)
+
+	assert.IsType(t, NoopMetricsRecorder{}, recorder)
+	assert.NotPanics(t, func() {
+		recorder.RecordRequest("sqs.send", time.Second, 200, "")
+		recorder.RecordRetry("sqs.send")
+		recorder.RecordThrottle("sqs.send")
+	})
+}
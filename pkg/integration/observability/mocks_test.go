@@ -90,6 +90,10 @@ func (m *mockMetricsRecorder) RecordThrottle(operation string) {
 	m.Called(operation)
 }
 
+func (m *mockMetricsRecorder) RecordCacheEvent(ctx context.Context, operation string, hit bool) {
+	m.Called(ctx, operation, hit)
+}
+
 // mockTracer is a mock implementation of telemetry.Tracer
 type mockTracer struct {
 	mock.Mock
@@ -38,8 +38,8 @@ func (m *tracingMiddleware) Do(ctx context.Context, req *cloud.Request) (*cloud.
 	attrs := []attribute.KeyValue{
 		attribute.String("aws.service", service),
 		attribute.String("aws.operation", operation),
-		attribute.String("aws.path", req.Path),
 	}
+	attrs = append(attrs, resourceAttributes(service, req)...)
 
 	if req.Method != "" {
 		attrs = append(attrs, attribute.String("http.method", req.Method))
@@ -83,3 +83,55 @@ func extractServiceOperation(operation string) (service, op string) {
 	}
 	return operation, ""
 }
+
+// resourceAttributes extracts searchable resource identifiers (queue, topic, bucket, ...)
+// from the request. Only the path and an allow-list of headers are used; bodies are never
+// read, so SSM parameter values and message payloads cannot leak into spans.
+func resourceAttributes(service string, req *cloud.Request) []attribute.KeyValue {
+	if service == "ses" {
+		// SES paths are email addresses (PII)
+		return []attribute.KeyValue{attribute.String("aws.path", redactedValue)}
+	}
+
+	attrs := []attribute.KeyValue{attribute.String("aws.path", req.Path)}
+	if req.Path == "" {
+		return attrs
+	}
+
+	switch service {
+	case "sqs":
+		if strings.Contains(req.Path, "://") {
+			attrs = append(attrs, attribute.String("aws.sqs.queue_url", req.Path))
+		} else {
+			attrs = append(attrs, attribute.String("aws.sqs.queue_name", req.Path))
+		}
+		if groupID := req.Headers["sqs.message_group_id"]; groupID != "" {
+			attrs = append(attrs, attribute.String("aws.sqs.message_group_id", groupID))
+		}
+	case "sns":
+		attrs = append(attrs, attribute.String("aws.sns.topic_arn", req.Path))
+	case "lambda":
+		attrs = append(attrs, attribute.String("aws.lambda.function_name", req.Path))
+		if qualifier := req.Headers["lambda.qualifier"]; qualifier != "" {
+			attrs = append(attrs, attribute.String("aws.lambda.qualifier", qualifier))
+		}
+		if invocationType := req.Headers["lambda.invocation_type"]; invocationType != "" {
+			attrs = append(attrs, attribute.String("aws.lambda.invocation_type", invocationType))
+		}
+	case "s3":
+		bucket, key, _ := strings.Cut(req.Path, "/")
+		attrs = append(attrs, attribute.String("aws.s3.bucket", bucket))
+		if key != "" {
+			attrs = append(attrs, attribute.String("aws.s3.key", key))
+		}
+		if source := req.Headers["s3.source_bucket"]; source != "" {
+			attrs = append(attrs, attribute.String("aws.s3.source_bucket", source))
+		}
+	case "ssm":
+		attrs = append(attrs, attribute.String("aws.ssm.parameter_name", req.Path))
+	}
+	return attrs
+}
+
+// redactedValue replaces sensitive attribute values
+const redactedValue = "[REDACTED]"
@@ -168,3 +168,65 @@ func TestTracingMiddleware_NilTracer(t *testing.T) {
 	assert.Equal(t, resp, result)
 	mockCli.AssertExpectations(t)
 }
+
+func TestResourceAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		service string
+		req     *cloud.Request
+		want    map[string]string
+	}{
+		{
+			name:    "sqs queue url",
+			service: "sqs",
+			req:     &cloud.Request{Path: "https://sqs.us-east-1.amazonaws.com/123/orders", Headers: map[string]string{"sqs.message_group_id": "g1"}},
+			want:    map[string]string{"aws.sqs.queue_url": "https://sqs.us-east-1.amazonaws.com/123/orders", "aws.sqs.message_group_id": "g1"},
+		},
+		{
+			name:    "sns topic",
+			service: "sns",
+			req:     &cloud.Request{Path: "arn:aws:sns:us-east-1:123:alerts"},
+			want:    map[string]string{"aws.sns.topic_arn": "arn:aws:sns:us-east-1:123:alerts"},
+		},
+		{
+			name:    "lambda function",
+			service: "lambda",
+			req:     &cloud.Request{Path: "my-fn", Headers: map[string]string{"lambda.invocation_type": "Event"}},
+			want:    map[string]string{"aws.lambda.function_name": "my-fn", "aws.lambda.invocation_type": "Event"},
+		},
+		{
+			name:    "s3 bucket and key",
+			service: "s3",
+			req:     &cloud.Request{Path: "assets/img/logo.png"},
+			want:    map[string]string{"aws.s3.bucket": "assets", "aws.s3.key": "img/logo.png"},
+		},
+		{
+			name:    "ssm parameter name only",
+			service: "ssm",
+			req:     &cloud.Request{Path: "/app/db-password", Body: []byte(`{"value":"s3cr3t"}`)},
+			want:    map[string]string{"aws.ssm.parameter_name": "/app/db-password"},
+		},
+		{
+			name:    "ses path redacted",
+			service: "ses",
+			req:     &cloud.Request{Path: "john@example.com"},
+			want:    map[string]string{"aws.path": redactedValue},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := map[string]string{}
+			for _, attr := range resourceAttributes(tt.service, tt.req) {
+				got[string(attr.Key)] = attr.Value.AsString()
+			}
+			for k, v := range tt.want {
+				assert.Equal(t, v, got[k], k)
+			}
+			for _, v := range got {
+				assert.NotContains(t, v, "s3cr3t")
+				assert.NotContains(t, v, "john@example.com")
+			}
+		})
+	}
+}
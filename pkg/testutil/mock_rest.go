@@ -2,8 +2,10 @@ package testutil
 
 import (
 	"context"
+	"io"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/skolldire/go-engine/pkg/clients/rest"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -52,6 +54,23 @@ func (m *MockRestClient) Delete(ctx context.Context, endpoint string, headers ma
 	return resp, args.Error(1)
 }
 
+func (m *MockRestClient) GetAllPages(ctx context.Context, path string, headers map[string]string, accumulate func([]byte) error) error {
+	args := m.Called(ctx, path, headers, accumulate)
+	return args.Error(0)
+}
+
+func (m *MockRestClient) GetStream(ctx context.Context, path string, headers map[string]string) (io.ReadCloser, error) {
+	args := m.Called(ctx, path, headers)
+	body, _ := args.Get(0).(io.ReadCloser)
+	return body, args.Error(1)
+}
+
+func (m *MockRestClient) PostMultipart(ctx context.Context, path string, fields map[string]string, files []rest.FormFile, headers map[string]string) (*resty.Response, error) {
+	args := m.Called(ctx, path, fields, files, headers)
+	resp, _ := args.Get(0).(*resty.Response)
+	return resp, args.Error(1)
+}
+
 func (m *MockRestClient) WithLogging(enable bool) {
 	m.Called(enable)
 }
@@ -32,12 +32,23 @@ func (m *MockSQSClient) SendJSON(ctx context.Context, queueURL string, mensaje i
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockSQSClient) SendJSONCompressed(ctx context.Context, queueURL string, mensaje interface{}, atributos map[string]sqstypes.MessageAttributeValue) (string, error) {
+	args := m.Called(ctx, queueURL, mensaje, atributos)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockSQSClient) ReceiveMsj(ctx context.Context, queueURL string, maxMensajes, tiempoEspera int32) ([]sqstypes.Message, error) {
 	args := m.Called(ctx, queueURL, maxMensajes, tiempoEspera)
 	msgs, _ := args.Get(0).([]sqstypes.Message)
 	return msgs, args.Error(1)
 }
 
+func (m *MockSQSClient) ReceiveMsjBlocking(ctx context.Context, queueURL string, maxMensajes int32) ([]sqstypes.Message, error) {
+	args := m.Called(ctx, queueURL, maxMensajes)
+	msgs, _ := args.Get(0).([]sqstypes.Message)
+	return msgs, args.Error(1)
+}
+
 func (m *MockSQSClient) DeleteMsj(ctx context.Context, queueURL, receiptHandle string) error {
 	return m.Called(ctx, queueURL, receiptHandle).Error(0)
 }
@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Outcome is the result of an audited action
+type Outcome string
+
+const (
+	OutcomeSuccess   Outcome = "success"
+	OutcomeFailure   Outcome = "failure"
+	OutcomeChallenge Outcome = "challenge"
+)
+
+// AuditEvent is a single security-relevant event
+// Events must never carry secrets or tokens: Metadata is sanitized by field name
+// before being written, but callers should not put credentials there in the first place.
+type AuditEvent struct {
+	Type      string                 `json:"event_type"`
+	Actor     string                 `json:"actor,omitempty"`
+	Target    string                 `json:"target,omitempty"`
+	Outcome   Outcome                `json:"outcome"`
+	Reason    string                 `json:"reason,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+	Service   string                 `json:"service,omitempty"`
+}
+
+// Config configures the audit sink
+type Config struct {
+	// Service is stamped on every record (e.g. the application name)
+	Service string `mapstructure:"service" json:"service"`
+	// Sink receives one JSON document per line; defaults to os.Stdout
+	Sink io.Writer `mapstructure:"-" json:"-"`
+}
+
+// Service records audit events
+type Service interface {
+	Audit(ctx context.Context, event AuditEvent)
+}
@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/logger"
+)
+
+var _ Service = (*service)(nil)
+
+type actorKey struct{}
+
+type service struct {
+	mu      sync.Mutex
+	sink    io.Writer
+	service string
+}
+
+// NewService creates an audit Service writing JSON lines to cfg.Sink (stdout by default)
+// The sink is kept separate from the application logger so the audit trail can be
+// shipped and retained independently.
+func NewService(cfg Config) Service {
+	sink := cfg.Sink
+	if sink == nil {
+		sink = os.Stdout
+	}
+	return &service{
+		sink:    sink,
+		service: cfg.Service,
+	}
+}
+
+// Audit writes the event as a single JSON line
+// Missing Timestamp/Actor/Service are filled from the clock, context and config.
+func (s *service) Audit(ctx context.Context, event AuditEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+	if event.Actor == "" {
+		event.Actor = ActorFromContext(ctx)
+	}
+	if event.Service == "" {
+		event.Service = s.service
+	}
+	event.Metadata = logger.SanitizeFields(event.Metadata)
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.sink.Write(line)
+}
+
+// WithActor returns a context carrying the identity performing the action
+// Used as AuditEvent.Actor when the emitter does not set one (e.g. admin operations).
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor set by WithActor, or ""
+func ActorFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+type noopService struct{}
+
+// NewNoop returns a Service that discards every event
+func NewNoop() Service {
+	return noopService{}
+}
+
+func (noopService) Audit(ctx context.Context, event AuditEvent) {}
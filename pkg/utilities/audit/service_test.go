@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Audit_WritesJSONLine(t *testing.T) {
+	var buf bytes.Buffer
+	svc := NewService(Config{Service: "accounts", Sink: &buf})
+
+	svc.Audit(context.Background(), AuditEvent{
+		Type:    "cognito.authenticate",
+		Actor:   "john",
+		Outcome: OutcomeSuccess,
+	})
+
+	require.True(t, strings.HasSuffix(buf.String(), "\n"))
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "cognito.authenticate", record["event_type"])
+	assert.Equal(t, "john", record["actor"])
+	assert.Equal(t, "success", record["outcome"])
+	assert.Equal(t, "accounts", record["service"])
+	assert.NotEmpty(t, record["timestamp"])
+}
+
+func TestService_Audit_SanitizesMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	svc := NewService(Config{Sink: &buf})
+
+	svc.Audit(context.Background(), AuditEvent{
+		Type:    "cognito.password_change",
+		Outcome: OutcomeSuccess,
+		Metadata: map[string]interface{}{
+			"password": "Secure1!",
+			"token":    "eyJhbGciOi",
+			"group":    "admins",
+		},
+	})
+
+	assert.NotContains(t, buf.String(), "Secure1!")
+	assert.NotContains(t, buf.String(), "eyJhbGciOi")
+	assert.Contains(t, buf.String(), "admins")
+}
+
+func TestService_Audit_ActorFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	svc := NewService(Config{Sink: &buf})
+
+	ctx := WithActor(context.Background(), "admin-7")
+	svc.Audit(ctx, AuditEvent{Type: "cognito.admin_add_user_to_group", Target: "john", Outcome: OutcomeSuccess})
+
+	var record AuditEvent
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "admin-7", record.Actor)
+	assert.Equal(t, "john", record.Target)
+}
+
+func TestNoop(t *testing.T) {
+	assert.NotPanics(t, func() {
+		NewNoop().Audit(context.Background(), AuditEvent{Type: "x"})
+	})
+}
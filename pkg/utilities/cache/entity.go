@@ -0,0 +1,45 @@
+// Package cache provides a generic in-memory cache with per-entry TTL,
+// optional LRU eviction and deduplication of concurrent loads.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
+)
+
+// DefaultLoadTimeout bounds a GetOrLoad loader when Config.LoadTimeout is zero
+const DefaultLoadTimeout = 30 * time.Second
+
+// Config configures a Cache
+type Config struct {
+	// MaxSize bounds the number of entries; the least recently used entry is evicted
+	// when it is exceeded. Zero means unbounded.
+	MaxSize int `mapstructure:"max_size" json:"max_size"`
+	// DefaultTTL is used by Set and GetOrLoad when ttl is zero. Zero means no expiration.
+	DefaultTTL time.Duration `mapstructure:"default_ttl" json:"default_ttl"`
+	// LoadTimeout bounds a GetOrLoad loader, which runs detached from the callers'
+	// cancellation. Zero uses DefaultLoadTimeout.
+	LoadTimeout time.Duration `mapstructure:"load_timeout" json:"load_timeout"`
+	// Clock is used for expiration; defaults to the real clock
+	Clock clock.Clock `mapstructure:"-" json:"-"`
+}
+
+// Cache is a concurrency-safe key/value cache
+type Cache[K comparable, V any] struct {
+	config Config
+	clock  clock.Clock
+
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	lru     *list.List // front = most recently used
+	loads   Group[K, V]
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time // zero = never
+}
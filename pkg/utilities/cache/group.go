@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrLoaderPanic is wrapped by the error Group.Do returns when fn panics
+var ErrLoaderPanic = errors.New("cache: loader panicked")
+
+// Group deduplicates concurrent calls by key: callers asking for a key while a call for it
+// is in flight share that call's result. The zero value is ready to use.
+type Group[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*load[V]
+}
+
+// load is an in-flight Group call shared by concurrent callers
+type load[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Do runs fn for key unless a call for key is already in flight, in which case it waits for
+// that call; shared reports the latter. fn runs in its own goroutine, so every caller,
+// the one that started it included, stops waiting when its own ctx is done while fn keeps
+// running for the others. A panic in fn is recovered and returned to every caller as an
+// error wrapping ErrLoaderPanic.
+func (g *Group[K, V]) Do(ctx context.Context, key K, fn func() (V, error)) (value V, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*load[V])
+	}
+	call, shared := g.calls[key]
+	if !shared {
+		call = &load[V]{done: make(chan struct{})}
+		g.calls[key] = call
+		go g.run(key, call, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, shared, call.err
+	case <-ctx.Done():
+		var zero V
+		return zero, shared, ctx.Err()
+	}
+}
+
+func (g *Group[K, V]) run(key K, call *load[V], fn func() (V, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			call.err = fmt.Errorf("%w: %v", ErrLoaderPanic, r)
+		}
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.value, call.err = fn()
+}
@@ -0,0 +1,134 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
+)
+
+// New creates an empty Cache
+func New[K comparable, V any](config Config) *Cache[K, V] {
+	return &Cache[K, V]{
+		config:  config,
+		clock:   clock.OrReal(config.Clock),
+		entries: make(map[K]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get returns the value stored for key and whether it was present and unexpired
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.get(key)
+}
+
+// Set stores value for key; ttl zero uses Config.DefaultTTL and a negative ttl never expires
+func (c *Cache[K, V]) Set(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, value, ttl)
+}
+
+// Delete removes key from the cache
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+}
+
+// Clear removes every entry
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[K]*list.Element)
+	c.lru.Init()
+}
+
+// Len returns the number of stored entries, including expired ones not yet evicted
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// GetOrLoad returns the cached value for key, calling loader on a miss
+// Concurrent misses for the same key share a single loader call (see Group). The loader
+// runs detached from the cancellation of the caller that started it, so one caller giving
+// up does not fail the others; it keeps that caller's context values and is bounded by
+// Config.LoadTimeout instead. The loaded value is stored with ttl (see Set); loader
+// errors, including a recovered panic, are returned to every waiter and not cached.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, ttl time.Duration, loader func(ctx context.Context) (V, error)) (V, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+	shared := context.WithoutCancel(ctx)
+	value, _, err := c.loads.Do(ctx, key, func() (V, error) {
+		// a load that finished since the miss above has already stored the value
+		if value, ok := c.Get(key); ok {
+			return value, nil
+		}
+		loadCtx, cancel := context.WithTimeout(shared, c.loadTimeout())
+		defer cancel()
+		value, err := loader(loadCtx)
+		if err == nil {
+			c.Set(key, value, ttl)
+		}
+		return value, err
+	})
+	return value, err
+}
+
+func (c *Cache[K, V]) loadTimeout() time.Duration {
+	if c.config.LoadTimeout > 0 {
+		return c.config.LoadTimeout
+	}
+	return DefaultLoadTimeout
+}
+
+func (c *Cache[K, V]) get(key K) (V, bool) {
+	var zero V
+	elem, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+	e := elem.Value.(*entry[K, V])
+	if !e.expiresAt.IsZero() && !c.clock.Now().Before(e.expiresAt) {
+		c.remove(elem)
+		return zero, false
+	}
+	c.lru.MoveToFront(elem)
+	return e.value, true
+}
+
+func (c *Cache[K, V]) set(key K, value V, ttl time.Duration) {
+	if ttl == 0 {
+		ttl = c.config.DefaultTTL
+	}
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.clock.Now().Add(ttl)
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*entry[K, V])
+		e.value = value
+		e.expiresAt = expiresAt
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	if c.config.MaxSize > 0 && c.lru.Len() > c.config.MaxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *Cache[K, V]) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,295 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_SetGetDelete(t *testing.T) {
+	c := New[string, int](Config{})
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	c.Set("a", 1, 0)
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	c.Set("a", 2, 0)
+	v, _ = c.Get("a")
+	assert.Equal(t, 2, v)
+	assert.Equal(t, 1, c.Len())
+
+	c.Delete("a")
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCache_TTL(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	c := New[string, string](Config{DefaultTTL: time.Minute, Clock: fake})
+
+	c.Set("default", "x", 0)
+	c.Set("short", "y", time.Second)
+	c.Set("forever", "z", -1)
+
+	fake.Advance(time.Second)
+	_, ok := c.Get("short")
+	assert.False(t, ok)
+	_, ok = c.Get("default")
+	assert.True(t, ok)
+
+	fake.Advance(time.Minute)
+	_, ok = c.Get("default")
+	assert.False(t, ok)
+	_, ok = c.Get("forever")
+	assert.True(t, ok)
+}
+
+func TestCache_LRUEviction(t *testing.T) {
+	c := New[string, int](Config{MaxSize: 2})
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 0)
+	_, _ = c.Get("a") // "b" becomes least recently used
+	c.Set("c", 3, 0)
+
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("a")
+	assert.True(t, ok)
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestCache_Clear(t *testing.T) {
+	c := New[int, int](Config{})
+	c.Set(1, 1, 0)
+	c.Set(2, 2, 0)
+
+	c.Clear()
+
+	assert.Equal(t, 0, c.Len())
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+}
+
+func TestCache_GetOrLoad_DeduplicatesConcurrentMisses(t *testing.T) {
+	c := New[string, int](Config{})
+
+	var calls int32
+	release := make(chan struct{})
+	loader := func(ctx context.Context) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 42, nil
+	}
+
+	const callers = 50
+	var wg sync.WaitGroup
+	results := make([]int, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := c.GetOrLoad(context.Background(), "k", 0, loader)
+			assert.NoError(t, err)
+			results[i] = v
+		}(i)
+	}
+
+	// Give the callers time to pile up on the in-flight load
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, v := range results {
+		assert.Equal(t, 42, v)
+	}
+
+	v, ok := c.Get("k")
+	assert.True(t, ok)
+	assert.Equal(t, 42, v)
+}
+
+func TestCache_GetOrLoad_ErrorNotCached(t *testing.T) {
+	c := New[string, int](Config{})
+	loadErr := errors.New("backend down")
+
+	_, err := c.GetOrLoad(context.Background(), "k", 0, func(ctx context.Context) (int, error) {
+		return 0, loadErr
+	})
+	assert.ErrorIs(t, err, loadErr)
+
+	v, err := c.GetOrLoad(context.Background(), "k", 0, func(ctx context.Context) (int, error) {
+		return 7, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 7, v)
+}
+
+func TestCache_GetOrLoad_WaiterContextCancelled(t *testing.T) {
+	c := New[string, int](Config{})
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	go func() {
+		_, _ = c.GetOrLoad(context.Background(), "k", 0, func(ctx context.Context) (int, error) {
+			close(started)
+			<-release
+			return 1, nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.GetOrLoad(ctx, "k", 0, func(ctx context.Context) (int, error) {
+		t.Fatal("second loader must not run while the first is in flight")
+		return 0, nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCache_GetOrLoad_FirstCallerCancelDoesNotFailWaiters(t *testing.T) {
+	c := New[string, int](Config{})
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var loaderErr error
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstDone := make(chan error, 1)
+	go func() {
+		_, err := c.GetOrLoad(firstCtx, "k", 0, func(ctx context.Context) (int, error) {
+			close(started)
+			<-release
+			loaderErr = ctx.Err()
+			return 9, nil
+		})
+		firstDone <- err
+	}()
+	<-started
+
+	waiterDone := make(chan int, 1)
+	go func() {
+		v, err := c.GetOrLoad(context.Background(), "k", 0, func(ctx context.Context) (int, error) {
+			t.Error("second loader must not run while the first is in flight")
+			return 0, nil
+		})
+		assert.NoError(t, err)
+		waiterDone <- v
+	}()
+
+	cancelFirst()
+	assert.ErrorIs(t, <-firstDone, context.Canceled)
+	close(release)
+
+	assert.Equal(t, 9, <-waiterDone)
+	assert.NoError(t, loaderErr, "the loader context is not cancelled with the first caller")
+}
+
+func TestCache_GetOrLoad_LoadTimeout(t *testing.T) {
+	c := New[string, int](Config{LoadTimeout: 10 * time.Millisecond})
+
+	_, err := c.GetOrLoad(context.Background(), "k", 0, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestCache_ConcurrentAccess(t *testing.T) {
+	c := New[string, int](Config{MaxSize: 64, DefaultTTL: time.Minute})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := fmt.Sprintf("k%d", (g*i)%100)
+				switch i % 4 {
+				case 0:
+					c.Set(key, i, 0)
+				case 1:
+					c.Get(key)
+				case 2:
+					_, _ = c.GetOrLoad(context.Background(), key, 0, func(ctx context.Context) (int, error) { return i, nil })
+				default:
+					c.Delete(key)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, c.Len(), 64)
+}
+
+func TestCache_GetOrLoad_LoaderPanic(t *testing.T) {
+	c := New[string, int](Config{})
+
+	_, err := c.GetOrLoad(context.Background(), "k", 0, func(ctx context.Context) (int, error) {
+		panic("boom")
+	})
+	assert.ErrorIs(t, err, ErrLoaderPanic)
+	assert.ErrorContains(t, err, "boom")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	v, err := c.GetOrLoad(ctx, "k", 0, func(ctx context.Context) (int, error) {
+		return 3, nil
+	})
+	require.NoError(t, err, "the key is not left locked by the panicked load")
+	assert.Equal(t, 3, v)
+}
+
+func TestGroup_Do_SharesInFlightCall(t *testing.T) {
+	var g Group[string, int]
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	first := make(chan int, 1)
+	go func() {
+		v, shared, _ := g.Do(context.Background(), "k", func() (int, error) {
+			close(started)
+			<-release
+			return 5, nil
+		})
+		assert.False(t, shared)
+		first <- v
+	}()
+	<-started
+
+	// a caller that gives up still joined the in-flight call instead of starting another
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, shared, err := g.Do(ctx, "k", func() (int, error) {
+		t.Error("a second call must not run while the first is in flight")
+		return 0, nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.True(t, shared)
+
+	close(release)
+	assert.Equal(t, 5, <-first)
+
+	v, shared, err := g.Do(context.Background(), "k", func() (int, error) { return 6, nil })
+	assert.NoError(t, err)
+	assert.False(t, shared, "a finished call is not shared")
+	assert.Equal(t, 6, v)
+}
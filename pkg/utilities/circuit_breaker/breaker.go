@@ -0,0 +1,191 @@
+package circuit_breaker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
+	"github.com/sony/gobreaker"
+)
+
+// gobreakerDefaultTimeout mirrors gobreaker's open-state duration when Timeout is not positive
+const gobreakerDefaultTimeout = 60 * time.Second
+
+// breaker is the gobreaker state machine driven by an injectable clock.Clock.
+// gobreaker reads time.Now directly, which makes open → half-open transitions
+// untestable without real sleeps; the semantics (generations, counts, expiry)
+// are kept identical and gobreaker's State/Counts/errors are reused.
+type breaker struct {
+	name          string
+	maxRequests   uint32
+	interval      time.Duration
+	timeout       time.Duration
+	readyToTrip   func(counts gobreaker.Counts) bool
+	onStateChange func(name string, from gobreaker.State, to gobreaker.State)
+	isSuccessful  func(err error) bool
+	clock         clock.Clock
+
+	mu         sync.Mutex
+	state      gobreaker.State
+	generation uint64
+	counts     gobreaker.Counts
+	expiry     time.Time
+}
+
+func newBreaker(st gobreaker.Settings, clk clock.Clock) *breaker {
+	b := &breaker{
+		name:          st.Name,
+		maxRequests:   st.MaxRequests,
+		interval:      st.Interval,
+		timeout:       st.Timeout,
+		readyToTrip:   st.ReadyToTrip,
+		onStateChange: st.OnStateChange,
+		isSuccessful:  st.IsSuccessful,
+		clock:         clock.OrReal(clk),
+	}
+	if b.isSuccessful == nil {
+		b.isSuccessful = func(err error) bool { return err == nil }
+	}
+	if b.maxRequests == 0 {
+		b.maxRequests = 1
+	}
+	if b.interval < 0 {
+		b.interval = 0
+	}
+	if b.timeout <= 0 {
+		b.timeout = gobreakerDefaultTimeout
+	}
+	b.toNewGeneration(b.clock.Now())
+	return b
+}
+
+func (b *breaker) State() gobreaker.State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, _ := b.currentState(b.clock.Now())
+	return state
+}
+
+func (b *breaker) Execute(req func() (interface{}, error)) (interface{}, error) {
+	generation, err := b.beforeRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if e := recover(); e != nil {
+			b.afterRequest(generation, false)
+			panic(e)
+		}
+	}()
+
+	result, err := req()
+	b.afterRequest(generation, b.isSuccessful(err))
+	return result, err
+}
+
+func (b *breaker) beforeRequest() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, generation := b.currentState(b.clock.Now())
+	if state == gobreaker.StateOpen {
+		return generation, gobreaker.ErrOpenState
+	}
+	if state == gobreaker.StateHalfOpen && b.counts.Requests >= b.maxRequests {
+		return generation, gobreaker.ErrTooManyRequests
+	}
+
+	b.counts.Requests++
+	return generation, nil
+}
+
+func (b *breaker) afterRequest(before uint64, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+	state, generation := b.currentState(now)
+	if generation != before {
+		return
+	}
+
+	if success {
+		b.onSuccess(state, now)
+	} else {
+		b.onFailure(state, now)
+	}
+}
+
+func (b *breaker) onSuccess(state gobreaker.State, now time.Time) {
+	if state == gobreaker.StateOpen {
+		return
+	}
+	b.counts.TotalSuccesses++
+	b.counts.ConsecutiveSuccesses++
+	b.counts.ConsecutiveFailures = 0
+	if state == gobreaker.StateHalfOpen && b.counts.ConsecutiveSuccesses >= b.maxRequests {
+		b.setState(gobreaker.StateClosed, now)
+	}
+}
+
+func (b *breaker) onFailure(state gobreaker.State, now time.Time) {
+	switch state {
+	case gobreaker.StateClosed:
+		b.counts.TotalFailures++
+		b.counts.ConsecutiveFailures++
+		b.counts.ConsecutiveSuccesses = 0
+		if b.readyToTrip(b.counts) {
+			b.setState(gobreaker.StateOpen, now)
+		}
+	case gobreaker.StateHalfOpen:
+		b.setState(gobreaker.StateOpen, now)
+	}
+}
+
+func (b *breaker) currentState(now time.Time) (gobreaker.State, uint64) {
+	switch b.state {
+	case gobreaker.StateClosed:
+		if !b.expiry.IsZero() && b.expiry.Before(now) {
+			b.toNewGeneration(now)
+		}
+	case gobreaker.StateOpen:
+		if b.expiry.Before(now) {
+			b.setState(gobreaker.StateHalfOpen, now)
+		}
+	}
+	return b.state, b.generation
+}
+
+func (b *breaker) setState(state gobreaker.State, now time.Time) {
+	if b.state == state {
+		return
+	}
+
+	prev := b.state
+	b.state = state
+	b.toNewGeneration(now)
+
+	if b.onStateChange != nil {
+		b.onStateChange(b.name, prev, state)
+	}
+}
+
+func (b *breaker) toNewGeneration(now time.Time) {
+	b.generation++
+	b.counts = gobreaker.Counts{}
+
+	switch b.state {
+	case gobreaker.StateClosed:
+		if b.interval == 0 {
+			b.expiry = time.Time{}
+		} else {
+			b.expiry = now.Add(b.interval)
+		}
+	case gobreaker.StateOpen:
+		b.expiry = now.Add(b.timeout)
+	default:
+		b.expiry = time.Time{}
+	}
+}
@@ -0,0 +1,159 @@
+package circuit_breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errBoom = errors.New("boom")
+
+func succeed() (interface{}, error) { return "ok", nil }
+
+func fail() (interface{}, error) { return nil, errBoom }
+
+// newTestBreaker trips after three consecutive failures and records state changes
+func newTestBreaker(st gobreaker.Settings, clk clock.Clock) (*breaker, *[]string) {
+	var changes []string
+	st.Name = "test"
+	st.ReadyToTrip = func(counts gobreaker.Counts) bool { return counts.ConsecutiveFailures >= 3 }
+	st.OnStateChange = func(name string, from, to gobreaker.State) {
+		changes = append(changes, from.String()+"->"+to.String())
+	}
+	return newBreaker(st, clk), &changes
+}
+
+// trip opens b with three failures
+func trip(b *breaker) {
+	for i := 0; i < 3; i++ {
+		_, _ = b.Execute(fail)
+	}
+}
+
+func TestBreaker_HalfOpenAllowsMaxRequests(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	b, changes := newTestBreaker(gobreaker.Settings{MaxRequests: 3, Timeout: time.Second}, fake)
+
+	trip(b)
+	fake.Advance(2 * time.Second)
+	require.Equal(t, gobreaker.StateHalfOpen, b.State())
+
+	for i := 0; i < 2; i++ {
+		_, err := b.Execute(succeed)
+		require.NoError(t, err)
+		assert.Equal(t, gobreaker.StateHalfOpen, b.State(), "closes only after MaxRequests consecutive successes")
+	}
+	_, err := b.Execute(succeed)
+	require.NoError(t, err)
+	assert.Equal(t, gobreaker.StateClosed, b.State())
+	assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->closed"}, *changes)
+}
+
+func TestBreaker_HalfOpenRejectsBeyondMaxRequests(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	b, _ := newTestBreaker(gobreaker.Settings{MaxRequests: 1, Timeout: time.Second}, fake)
+
+	trip(b)
+	fake.Advance(2 * time.Second)
+
+	_, err := b.Execute(func() (interface{}, error) {
+		// the probe is still in flight
+		_, err := b.Execute(succeed)
+		assert.ErrorIs(t, err, gobreaker.ErrTooManyRequests)
+		return "ok", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, gobreaker.StateClosed, b.State())
+}
+
+func TestBreaker_OpenRejectsUntilTimeout(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	b, _ := newTestBreaker(gobreaker.Settings{Timeout: 5 * time.Second}, fake)
+
+	trip(b)
+	_, err := b.Execute(succeed)
+	assert.ErrorIs(t, err, gobreaker.ErrOpenState)
+
+	fake.Advance(5 * time.Second)
+	assert.Equal(t, gobreaker.StateOpen, b.State(), "expiry is exclusive")
+	fake.Advance(time.Millisecond)
+	assert.Equal(t, gobreaker.StateHalfOpen, b.State())
+}
+
+func TestBreaker_DefaultTimeout(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	b, _ := newTestBreaker(gobreaker.Settings{}, fake)
+
+	trip(b)
+	fake.Advance(gobreakerDefaultTimeout)
+	assert.Equal(t, gobreaker.StateOpen, b.State())
+	fake.Advance(time.Second)
+	assert.Equal(t, gobreaker.StateHalfOpen, b.State())
+}
+
+func TestBreaker_IntervalStartsNewGeneration(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	b, _ := newTestBreaker(gobreaker.Settings{Interval: 10 * time.Second}, fake)
+
+	for i := 0; i < 2; i++ {
+		_, _ = b.Execute(fail)
+	}
+	assert.Equal(t, uint32(2), b.counts.ConsecutiveFailures)
+
+	fake.Advance(11 * time.Second)
+	_, _ = b.Execute(fail)
+	assert.Equal(t, gobreaker.StateClosed, b.State(), "the failures before the interval were cleared")
+	assert.Equal(t, uint32(1), b.counts.ConsecutiveFailures)
+}
+
+func TestBreaker_ZeroIntervalNeverClearsCounts(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	b, _ := newTestBreaker(gobreaker.Settings{}, fake)
+
+	for i := 0; i < 2; i++ {
+		_, _ = b.Execute(fail)
+		fake.Advance(time.Hour)
+	}
+	_, _ = b.Execute(fail)
+	assert.Equal(t, gobreaker.StateOpen, b.State())
+}
+
+func TestBreaker_IgnoresResultsFromAnOldGeneration(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	b, _ := newTestBreaker(gobreaker.Settings{Interval: 10 * time.Second}, fake)
+
+	_, _ = b.Execute(func() (interface{}, error) {
+		fake.Advance(11 * time.Second) // the interval rolls over while the request runs
+		return nil, errBoom
+	})
+
+	assert.Equal(t, gobreaker.Counts{}, b.counts)
+}
+
+func TestBreaker_IsSuccessful(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	b, _ := newTestBreaker(gobreaker.Settings{
+		IsSuccessful: func(err error) bool { return err == nil || errors.Is(err, errBoom) },
+	}, fake)
+
+	trip(b)
+	assert.Equal(t, gobreaker.StateClosed, b.State())
+	assert.Equal(t, uint32(3), b.counts.TotalSuccesses)
+}
+
+func TestBreaker_PanicCountsAsFailure(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	b, _ := newTestBreaker(gobreaker.Settings{}, fake)
+
+	for i := 0; i < 3; i++ {
+		assert.Panics(t, func() {
+			_, _ = b.Execute(func() (interface{}, error) { panic("boom") })
+		})
+	}
+	assert.Equal(t, gobreaker.StateOpen, b.State())
+}
@@ -4,8 +4,8 @@ import (
 	"errors"
 	"time"
 
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
-	"github.com/sony/gobreaker"
 )
 
 const (
@@ -29,10 +29,13 @@ type Config struct {
 	Timeout              time.Duration `mapstructure:"timeout" json:"timeout"`
 	RequestThreshold     uint32        `mapstructure:"request_threshold" json:"request_threshold"`
 	FailureRateThreshold float64       `mapstructure:"failure_rate_threshold" json:"failure_rate_threshold"`
+	// Clock drives the open → half-open cooldown; nil uses real time.
+	// Inject clock.NewFake in tests to assert transitions without sleeping.
+	Clock clock.Clock `mapstructure:"-" json:"-"`
 }
 
 type CircuitBreaker struct {
-	cb     *gobreaker.CircuitBreaker
+	cb     *breaker
 	config *Config
 	log    logger.Service
 }
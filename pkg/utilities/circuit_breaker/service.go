@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
 	"github.com/sony/gobreaker"
 )
 
@@ -17,10 +18,11 @@ func NewCircuitBreaker(d Dependencies) *CircuitBreaker {
 		Timeout:       d.Config.Timeout * time.Second,
 		ReadyToTrip:   createReadyToTripFunc(d.Config, d.Log),
 		OnStateChange: createOnStateChangeFunc(d.Config, d.Log),
+		IsSuccessful:  isSuccessful,
 	}
 
 	return &CircuitBreaker{
-		cb:     gobreaker.NewCircuitBreaker(settings),
+		cb:     newBreaker(settings, d.Config.Clock),
 		config: d.Config,
 		log:    d.Log,
 	}
@@ -56,6 +58,13 @@ func (cb *CircuitBreaker) StateAsString() string {
 	return stateToString(cb.cb.State())
 }
 
+// isSuccessful keeps permanent errors (validation, not found, conditional
+// check failures) from counting against the breaker: they mean the dependency
+// answered, so tripping on them would reject healthy traffic.
+func isSuccessful(err error) bool {
+	return err == nil || retry_backoff.IsPermanent(err)
+}
+
 func createReadyToTripFunc(config *Config, log logger.Service) func(counts gobreaker.Counts) bool {
 	return func(counts gobreaker.Counts) bool {
 		if counts.Requests >= config.RequestThreshold {
@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
 	"github.com/sony/gobreaker"
 	"github.com/stretchr/testify/assert"
 )
@@ -97,6 +99,108 @@ func TestCircuitBreaker_StateAsString(t *testing.T) {
 	assert.Equal(t, "closed", stateStr) // Initially closed
 }
 
+func TestCircuitBreaker_StateTransitions_FakeClock(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	cb := NewCircuitBreaker(Dependencies{
+		Config: &Config{
+			Name:                 "test",
+			MaxRequests:          1,
+			Timeout:              5, // seconds
+			RequestThreshold:     2,
+			FailureRateThreshold: 0.5,
+			Clock:                fake,
+		},
+	})
+	fail := func() (interface{}, error) { return nil, errors.New("boom") }
+	succeed := func() (interface{}, error) { return "ok", nil }
+
+	for i := 0; i < 2; i++ {
+		_, _ = cb.Execute(context.Background(), fail)
+	}
+	assert.Equal(t, gobreaker.StateOpen, cb.State())
+
+	_, err := cb.Execute(context.Background(), succeed)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	fake.Advance(4 * time.Second)
+	assert.Equal(t, gobreaker.StateOpen, cb.State())
+
+	fake.Advance(2 * time.Second)
+	assert.Equal(t, gobreaker.StateHalfOpen, cb.State())
+
+	// A failure in half-open reopens the breaker for a new cooldown
+	_, _ = cb.Execute(context.Background(), fail)
+	assert.Equal(t, gobreaker.StateOpen, cb.State())
+
+	fake.Advance(6 * time.Second)
+	result, err := cb.Execute(context.Background(), succeed)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", result)
+	assert.Equal(t, gobreaker.StateClosed, cb.State())
+}
+
+func TestCircuitBreaker_PermanentErrorsDoNotTrip(t *testing.T) {
+	cb := NewCircuitBreaker(Dependencies{
+		Config: &Config{
+			Name:                 "test",
+			MaxRequests:          1,
+			Timeout:              5,
+			RequestThreshold:     2,
+			FailureRateThreshold: 0.5,
+			Clock:                clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+	})
+	notFound := retry_backoff.Permanent(errors.New("not found"))
+
+	for i := 0; i < 5; i++ {
+		_, err := cb.Execute(context.Background(), func() (interface{}, error) { return nil, notFound })
+		assert.ErrorIs(t, err, notFound)
+	}
+	assert.Equal(t, gobreaker.StateClosed, cb.State())
+
+	for i := 0; i < 10; i++ {
+		_, _ = cb.Execute(context.Background(), func() (interface{}, error) { return nil, errors.New("boom") })
+	}
+	assert.Equal(t, gobreaker.StateOpen, cb.State())
+}
+
+func TestCircuitBreaker_HalfOpen_LimitsRequests(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	cb := NewCircuitBreaker(Dependencies{
+		Config: &Config{
+			Name:                 "test",
+			MaxRequests:          1,
+			Timeout:              1,
+			RequestThreshold:     1,
+			FailureRateThreshold: 1,
+			Clock:                fake,
+		},
+	})
+
+	_, _ = cb.Execute(context.Background(), func() (interface{}, error) { return nil, errors.New("boom") })
+	fake.Advance(2 * time.Second)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = cb.Execute(context.Background(), func() (interface{}, error) {
+			close(started)
+			<-release
+			return "ok", nil
+		})
+	}()
+	<-started
+
+	_, err := cb.Execute(context.Background(), func() (interface{}, error) { return "ok", nil })
+	assert.ErrorIs(t, err, ErrTooManyCalls)
+
+	close(release)
+	<-done
+	assert.Equal(t, gobreaker.StateClosed, cb.State())
+}
+
 func TestStateToString(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,44 @@
+package circuit_breaker
+
+import (
+	"context"
+	"sync"
+)
+
+// named holds the breakers created by Named, Wrap and WrapTyped, keyed by name
+var named sync.Map
+
+// Named returns the process-wide breaker registered under name, creating it from cfg on
+// first use. Later calls with the same name return the same breaker and ignore cfg, so
+// every call site guarding one dependency shares its state. cfg.Name is set to name.
+func Named(name string, cfg Config) *CircuitBreaker {
+	if cb, ok := named.Load(name); ok {
+		return cb.(*CircuitBreaker)
+	}
+	cfg.Name = name
+	cb, _ := named.LoadOrStore(name, NewCircuitBreaker(Dependencies{Config: &cfg}))
+	return cb.(*CircuitBreaker)
+}
+
+// Wrap runs fn through the breaker named name (see Named), for dependencies that are not
+// behind a framework client, such as a third-party SDK. It returns ErrCircuitOpen or
+// ErrTooManyCalls without calling fn while the breaker rejects calls. cfg only applies on
+// the first call for name; later calls reuse that breaker whatever cfg they pass, so
+// declare one Config per dependency and share it between call sites.
+func Wrap(name string, cfg Config, fn func() (interface{}, error)) (interface{}, error) {
+	return Named(name, cfg).Execute(context.Background(), fn)
+}
+
+// WrapTyped is Wrap for a function returning T. A nil result (fn returning a nil
+// interface or pointer) comes back as T's zero value.
+func WrapTyped[T any](name string, cfg Config, fn func() (T, error)) (T, error) {
+	result, err := Wrap(name, cfg, func() (interface{}, error) {
+		return fn()
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	value, _ := result.(T)
+	return value, nil
+}
@@ -0,0 +1,66 @@
+package circuit_breaker
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
+	"github.com/sony/gobreaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrap_TripsAndRecovers(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	cfg := Config{
+		MaxRequests:          1,
+		Timeout:              5, // seconds
+		RequestThreshold:     2,
+		FailureRateThreshold: 0.5,
+		Clock:                fake,
+	}
+	calls := 0
+	fail := func() (interface{}, error) { calls++; return nil, errors.New("sdk unavailable") }
+
+	for i := 0; i < 2; i++ {
+		_, _ = Wrap("payments-sdk-trip", cfg, fail)
+	}
+	_, err := Wrap("payments-sdk-trip", cfg, fail)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 2, calls, "fn is not called while the breaker is open")
+	assert.Equal(t, gobreaker.StateOpen, Named("payments-sdk-trip", cfg).State())
+
+	fake.Advance(6 * time.Second)
+	got, err := WrapTyped("payments-sdk-trip", cfg, func() (string, error) { return "charged", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "charged", got)
+	assert.Equal(t, gobreaker.StateClosed, Named("payments-sdk-trip", cfg).State())
+}
+
+func TestNamed_SharesBreakerByName(t *testing.T) {
+	a := Named("shared-dependency", Config{RequestThreshold: 3})
+	b := Named("shared-dependency", Config{RequestThreshold: 10})
+
+	assert.Same(t, a, b)
+	assert.Equal(t, "shared-dependency", a.config.Name)
+	assert.Equal(t, uint32(3), a.config.RequestThreshold, "the first config wins")
+	assert.NotSame(t, a, Named("other-dependency", Config{}))
+}
+
+func TestWrapTyped_Error(t *testing.T) {
+	boom := errors.New("boom")
+
+	got, err := WrapTyped("typed-error", Config{}, func() (int, error) { return 42, boom })
+
+	assert.ErrorIs(t, err, boom)
+	assert.Zero(t, got)
+}
+
+func TestWrapTyped_NilInterfaceResult(t *testing.T) {
+	got, err := WrapTyped("typed-nil", Config{}, func() (fmt.Stringer, error) { return nil, nil })
+
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,29 @@
+// Package clock abstracts time so that time-dependent components (circuit breaker
+// cooldowns, retry backoff) can be driven by a fake clock in tests.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// Fake is a manually advanced Clock for tests; it is safe for concurrent use.
+// Timers returned by After fire when Advance moves the clock to or past their deadline.
+type Fake struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+type realClock struct{}
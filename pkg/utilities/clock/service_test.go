@@ -0,0 +1,54 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake_NowAndAdvance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+
+	assert.Equal(t, start, f.Now())
+	f.Advance(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), f.Now())
+}
+
+func TestFake_AfterFiresOnAdvance(t *testing.T) {
+	f := NewFake(time.Unix(0, 0))
+	ch := f.After(10 * time.Second)
+	assert.Equal(t, 1, f.Waiters())
+
+	f.Advance(9 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("timer fired before its deadline")
+	default:
+	}
+
+	f.Advance(time.Second)
+	select {
+	case fired := <-ch:
+		assert.Equal(t, time.Unix(10, 0), fired)
+	default:
+		t.Fatal("timer did not fire at its deadline")
+	}
+	assert.Equal(t, 0, f.Waiters())
+}
+
+func TestFake_AfterNonPositive(t *testing.T) {
+	f := NewFake(time.Unix(0, 0))
+	select {
+	case <-f.After(0):
+	default:
+		t.Fatal("After(0) should fire immediately")
+	}
+}
+
+func TestOrReal(t *testing.T) {
+	assert.Equal(t, Real(), OrReal(nil))
+	f := NewFake(time.Unix(0, 0))
+	assert.Same(t, f, OrReal(f))
+}
@@ -0,0 +1,39 @@
+package logger
+
+import "github.com/sirupsen/logrus"
+
+// Level names accepted by Config.Level, SetLogLevel and Enabled
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+// LevelEnabler is implemented by loggers that answer level checks directly;
+// Enabled falls back to comparing GetLogLevel for the others
+type LevelEnabler interface {
+	Enabled(level string) bool
+}
+
+// Enabled reports whether log would emit an entry at level. Hot paths use it to skip
+// building fields and messages for entries that would be discarded. Unknown levels
+// count as enabled so nothing is dropped by mistake.
+func Enabled(log Service, level string) bool {
+	if log == nil {
+		return false
+	}
+	if e, ok := log.(LevelEnabler); ok {
+		return e.Enabled(level)
+	}
+
+	want, err := logrus.ParseLevel(level)
+	if err != nil {
+		return true
+	}
+	current, err := logrus.ParseLevel(log.GetLogLevel())
+	if err != nil {
+		return true
+	}
+	return current >= want
+}
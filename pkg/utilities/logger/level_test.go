@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"io"
+	"testing"
+)
+
+// levelOnly implements Service through GetLogLevel only, exercising the Enabled fallback
+type levelOnly struct {
+	Service
+	level string
+}
+
+func (l levelOnly) GetLogLevel() string { return l.level }
+
+func TestEnabled(t *testing.T) {
+	info := NewService(Config{Level: LevelInfo, OutputWriters: []io.Writer{io.Discard}}, nil)
+
+	tests := []struct {
+		name  string
+		log   Service
+		level string
+		want  bool
+	}{
+		{name: "nil logger", log: nil, level: LevelError, want: false},
+		{name: "noop logger", log: NewNoop(), level: LevelError, want: false},
+		{name: "service at info, debug", log: info, level: LevelDebug, want: false},
+		{name: "service at info, info", log: info, level: LevelInfo, want: true},
+		{name: "service at info, error", log: info, level: LevelError, want: true},
+		{name: "fallback at warn, info", log: levelOnly{level: "warn"}, level: LevelInfo, want: false},
+		{name: "fallback at debug, debug", log: levelOnly{level: "debug"}, level: LevelDebug, want: true},
+		{name: "fallback with unknown level", log: levelOnly{level: "verbose"}, level: LevelDebug, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Enabled(tt.log, tt.level); got != tt.want {
+				t.Errorf("Enabled(%q) = %v, want %v", tt.level, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"context"
+	"errors"
+)
+
+// noop discards every entry and never fails
+type noop struct{}
+
+// NewNoop returns a Service where every method is a no-op: WrapError returns the error
+// unchanged, WithField/WithFields return the same logger and GetLogLevel reports "info".
+// Use it in tests and simple programs instead of a mock.
+func NewNoop() Service {
+	return noop{}
+}
+
+// OrNoop returns log, or a no-op Service when log is nil. Constructors call it so callers
+// that don't care about logging can pass nil.
+func OrNoop(log Service) Service {
+	if log == nil {
+		return NewNoop()
+	}
+	return log
+}
+
+func (noop) Info(context.Context, string, map[string]interface{})      {}
+func (noop) Error(context.Context, error, map[string]interface{})      {}
+func (noop) Debug(context.Context, string, map[string]interface{})     {}
+func (noop) Warn(context.Context, string, map[string]interface{})      {}
+func (noop) FatalError(context.Context, error, map[string]interface{}) {}
+
+// WrapError returns err unchanged; with a nil err it still returns msg as an error,
+// like the real logger, so a failure is never turned into success
+func (noop) WrapError(err error, msg string) error {
+	if err == nil {
+		return errors.New(msg)
+	}
+	return err
+}
+
+func (n noop) WithField(string, interface{}) Service     { return n }
+func (n noop) WithFields(map[string]interface{}) Service { return n }
+func (noop) GetLogLevel() string                         { return "info" }
+func (noop) SetLogLevel(string) error                    { return nil }
+
+// Enabled is always false: nothing is ever written
+func (noop) Enabled(string) bool { return false }
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNoop(t *testing.T) {
+	log := NewNoop()
+	ctx := context.Background()
+
+	log.Info(ctx, "info", map[string]interface{}{"k": "v"})
+	log.Debug(ctx, "debug", nil)
+	log.Warn(ctx, "warn", nil)
+	log.Error(ctx, errors.New("boom"), nil)
+	log.FatalError(ctx, errors.New("fatal"), nil)
+
+	err := errors.New("original")
+	if got := log.WrapError(err, "context"); got != err {
+		t.Errorf("WrapError() = %v, want the original error unchanged", got)
+	}
+	if got := log.WrapError(nil, "context"); got == nil || got.Error() != "context" {
+		t.Errorf("WrapError(nil) = %v, want error with the message", got)
+	}
+
+	if log.WithField("k", "v") != log || log.WithFields(map[string]interface{}{"k": "v"}) != log {
+		t.Error("WithField/WithFields should return the same logger")
+	}
+	if err := log.SetLogLevel("debug"); err != nil {
+		t.Errorf("SetLogLevel() error = %v", err)
+	}
+	if got := log.GetLogLevel(); got != "info" {
+		t.Errorf("GetLogLevel() = %q, want info", got)
+	}
+}
+
+func TestOrNoop(t *testing.T) {
+	if OrNoop(nil) == nil {
+		t.Fatal("OrNoop(nil) returned nil")
+	}
+
+	log := NewNoop()
+	if OrNoop(log) != log {
+		t.Error("OrNoop should return a non-nil logger unchanged")
+	}
+}
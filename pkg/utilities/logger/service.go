@@ -226,6 +226,11 @@ func (l *service) GetLogLevel() string {
 	return l.Log.GetLevel().String()
 }
 
+// Enabled reports whether an entry at level would be written
+func (l *service) Enabled(level string) bool {
+	return l.Log.IsLevelEnabled(loggerLevel(level))
+}
+
 func (l *service) SetLogLevel(level string) error {
 	lvl, err := logrus.ParseLevel(level)
 	if err != nil {
@@ -0,0 +1,75 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
+)
+
+// ErrBudgetExhausted is returned by Execute, without running the operation, when the
+// deadline of a WithBudget context has already passed
+var ErrBudgetExhausted = errors.New("deadline budget exhausted")
+
+type budgetKey struct{}
+
+// budget splits the time left before a deadline across the calls still expected
+type budget struct {
+	mu       sync.Mutex
+	deadline time.Time
+	calls    int
+}
+
+// WithBudget shares ctx's deadline across the next calls resilience-wrapped calls made
+// with the returned context, so a handler calling several dependencies in sequence stays
+// within its total deadline. Each Execute takes an even share of the time left at its
+// start, (deadline - now) / calls remaining, as its own deadline (retries included); time
+// a call does not use carries over to the later ones. Calls beyond the expected number
+// get all the time left. ctx is returned unchanged when it has no deadline or calls < 1.
+//
+// Operations run with ExecuteContext are bounded by their share, as are the calls of the
+// built-in clients, which pass the attempt context to their SDK calls; with Execute the
+// share stops retries but the operation must use its own context.
+func WithBudget(ctx context.Context, calls int) context.Context {
+	deadline, ok := ctx.Deadline()
+	if !ok || calls < 1 {
+		return ctx
+	}
+	return context.WithValue(ctx, budgetKey{}, &budget{deadline: deadline, calls: calls})
+}
+
+// withBudgetShare bounds ctx by the next share of its budget, if it has one, measuring the
+// time left with clk. Nested calls made inside the operation run within that share instead
+// of taking their own.
+func withBudgetShare(ctx context.Context, clk clock.Clock) (context.Context, context.CancelFunc, error) {
+	b, _ := ctx.Value(budgetKey{}).(*budget)
+	if b == nil {
+		return ctx, func() {}, nil
+	}
+
+	now := clk.Now()
+	share, ok := b.take(now)
+	if !ok {
+		return ctx, func() {}, ErrBudgetExhausted
+	}
+	ctx, cancel := context.WithDeadline(ctx, now.Add(share))
+	return context.WithValue(ctx, budgetKey{}, (*budget)(nil)), cancel, nil
+}
+
+// take returns the next call's share of the time left at now, false once the deadline has passed
+func (b *budget) take(now time.Time) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	left := b.deadline.Sub(now)
+	if left <= 0 {
+		return 0, false
+	}
+	share := left / time.Duration(max(b.calls, 1))
+	if b.calls > 1 {
+		b.calls--
+	}
+	return share, true
+}
@@ -0,0 +1,129 @@
+package resilience
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// attemptDeadline returns an operation that records the time left, on clk, before its
+// context's deadline
+func attemptDeadline(clk clock.Clock, left *time.Duration) func(ctx context.Context) (interface{}, error) {
+	return func(ctx context.Context) (interface{}, error) {
+		deadline, _ := ctx.Deadline()
+		*left = deadline.Sub(clk.Now())
+		return nil, nil
+	}
+}
+
+// newBudgetTest returns a service and a parent context whose deadline is timeout after the
+// fake clock's start. The fake clock starts an hour from now so the real deadline of the
+// parent context does not expire during the test.
+func newBudgetTest(t *testing.T, timeout time.Duration) (*Service, *clock.Fake, context.Context) {
+	t.Helper()
+	fake := clock.NewFake(time.Now().Add(time.Hour))
+	service := NewResilienceService(Config{RetryConfig: &retry_backoff.Config{MaxRetries: 1}, Clock: fake}, nil)
+	parent, cancel := context.WithDeadline(context.Background(), fake.Now().Add(timeout))
+	t.Cleanup(cancel)
+	return service, fake, parent
+}
+
+func TestWithBudget_SharesDeadlineAcrossCalls(t *testing.T) {
+	service, fake, parent := newBudgetTest(t, 900*time.Millisecond)
+	ctx := WithBudget(parent, 3)
+
+	var first, second, third time.Duration
+	_, err := service.ExecuteContext(ctx, attemptDeadline(fake, &first))
+	require.NoError(t, err)
+	fake.Advance(100 * time.Millisecond)
+	_, err = service.ExecuteContext(ctx, attemptDeadline(fake, &second))
+	require.NoError(t, err)
+	_, err = service.ExecuteContext(ctx, attemptDeadline(fake, &third))
+	require.NoError(t, err)
+
+	assert.Equal(t, 300*time.Millisecond, first)
+	// the first call took 100ms of its 300ms share; the rest is split by the remaining two
+	assert.Equal(t, 400*time.Millisecond, second)
+	// the last expected call gets all the time left
+	assert.Equal(t, 800*time.Millisecond, third)
+}
+
+func TestWithBudget_Depletion(t *testing.T) {
+	service, fake, parent := newBudgetTest(t, 100*time.Millisecond)
+	ctx := WithBudget(parent, 2)
+
+	// a slow dependency uses up its share and no more
+	var first, second time.Duration
+	_, err := service.ExecuteContext(ctx, func(ctx context.Context) (interface{}, error) {
+		_, _ = attemptDeadline(fake, &first)(ctx)
+		fake.Advance(first)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 50*time.Millisecond, first, "the first call is held to half the budget")
+
+	// the second call consumes the rest of the budget
+	_, err = service.ExecuteContext(ctx, func(ctx context.Context) (interface{}, error) {
+		_, _ = attemptDeadline(fake, &second)(ctx)
+		fake.Advance(second)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 50*time.Millisecond, second)
+
+	var ran bool
+	_, err = service.Execute(ctx, func() (interface{}, error) {
+		ran = true
+		return nil, nil
+	})
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+	assert.False(t, ran, "an exhausted budget does not run the operation")
+}
+
+func TestWithBudget_HungCallIsCancelledAtItsShare(t *testing.T) {
+	service := NewResilienceService(Config{RetryConfig: &retry_backoff.Config{MaxRetries: 1, InitialWaitTime: 1, MaxWaitTime: 1}}, nil)
+	parent, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	ctx := WithBudget(parent, 2)
+
+	_, err := service.ExecuteContext(ctx, func(ctx context.Context) (interface{}, error) {
+		deadline, _ := ctx.Deadline()
+		parentDeadline, _ := parent.Deadline()
+		assert.True(t, deadline.Before(parentDeadline), "the operation context carries the share")
+		return nil, retry_backoff.Permanent(context.DeadlineExceeded)
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWithBudget_NestedCallsShareTheParentShare(t *testing.T) {
+	service, fake, parent := newBudgetTest(t, time.Second)
+	ctx := WithBudget(parent, 2)
+
+	var outer, inner time.Duration
+	_, err := service.ExecuteContext(ctx, func(ctx context.Context) (interface{}, error) {
+		_, _ = attemptDeadline(fake, &outer)(ctx)
+		return service.ExecuteContext(ctx, attemptDeadline(fake, &inner))
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, outer, inner, "the nested call does not split the share again")
+
+	var next time.Duration
+	_, err = service.ExecuteContext(ctx, attemptDeadline(fake, &next))
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, next, "only the outer call counted against the budget")
+}
+
+func TestWithBudget_NoDeadline(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, WithBudget(ctx, 3))
+
+	withDeadline, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	assert.Equal(t, withDeadline, WithBudget(withDeadline, 0))
+}
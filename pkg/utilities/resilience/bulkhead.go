@@ -0,0 +1,63 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrBulkheadFull is returned by Execute when MaxConcurrent calls are in flight and
+// MaxQueue calls are already waiting
+var ErrBulkheadFull = errors.New("bulkhead is full")
+
+// BulkheadConfig caps the calls in flight through a Service
+type BulkheadConfig struct {
+	// MaxConcurrent is the number of calls allowed in flight; 0 disables the bulkhead
+	MaxConcurrent int `mapstructure:"max_concurrent" json:"max_concurrent"`
+	// MaxQueue is the number of calls allowed to wait for a slot; 0 rejects immediately
+	MaxQueue int `mapstructure:"max_queue" json:"max_queue"`
+}
+
+// bulkhead is a semaphore with a bounded wait queue
+type bulkhead struct {
+	slots    chan struct{}
+	waiting  atomic.Int64
+	maxQueue int64
+}
+
+// newBulkhead returns nil when cfg does not enable a bulkhead
+func newBulkhead(cfg *BulkheadConfig) *bulkhead {
+	if cfg == nil || cfg.MaxConcurrent <= 0 {
+		return nil
+	}
+	return &bulkhead{
+		slots:    make(chan struct{}, cfg.MaxConcurrent),
+		maxQueue: int64(max(cfg.MaxQueue, 0)),
+	}
+}
+
+// acquire takes a slot, waiting in the queue if there is room; release must follow a nil error
+func (b *bulkhead) acquire(ctx context.Context) error {
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	if b.waiting.Add(1) > b.maxQueue {
+		b.waiting.Add(-1)
+		return ErrBulkheadFull
+	}
+	defer b.waiting.Add(-1)
+
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *bulkhead) release() {
+	<-b.slots
+}
@@ -0,0 +1,120 @@
+package resilience
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/circuit_breaker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitFor polls cond until it holds or the test times out
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		require.True(t, time.Now().Before(deadline), "condition not met in time")
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestService_Execute_BulkheadLimitsConcurrency(t *testing.T) {
+	service := NewResilienceService(Config{Bulkhead: &BulkheadConfig{MaxConcurrent: 3, MaxQueue: 2}}, nil)
+
+	release := make(chan struct{})
+	var inFlight, peak, started atomic.Int32
+	operation := func() (interface{}, error) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		started.Add(1)
+		<-release
+		inFlight.Add(-1)
+		return "ok", nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.Execute(context.Background(), operation)
+			errs <- err
+		}()
+	}
+	// 3 calls run, 2 wait in the queue
+	waitFor(t, func() bool { return started.Load() == 3 && service.bulkhead.waiting.Load() == 2 })
+
+	_, err := service.Execute(context.Background(), operation)
+	assert.ErrorIs(t, err, ErrBulkheadFull)
+
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(3), peak.Load())
+	assert.Equal(t, int32(5), started.Load())
+	assert.False(t, service.IsCircuitOpen(), "rejections are not counted by the breaker")
+}
+
+func TestService_WithCircuitBreaker_SharesBulkhead(t *testing.T) {
+	service := NewResilienceService(Config{Bulkhead: &BulkheadConfig{MaxConcurrent: 1}}, nil)
+	routed := service.WithCircuitBreaker(circuit_breaker.Config{Name: "routed"})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		_, err := service.Execute(context.Background(), func() (interface{}, error) {
+			close(started)
+			<-release
+			return "ok", nil
+		})
+		done <- err
+	}()
+	<-started
+
+	_, err := routed.Execute(context.Background(), func() (interface{}, error) { return "ok", nil })
+	assert.ErrorIs(t, err, ErrBulkheadFull)
+	assert.NotSame(t, service.circuitBreaker, routed.circuitBreaker)
+
+	close(release)
+	require.NoError(t, <-done)
+}
+
+func TestService_Execute_BulkheadQueueHonorsContext(t *testing.T) {
+	service := NewResilienceService(Config{Bulkhead: &BulkheadConfig{MaxConcurrent: 1, MaxQueue: 1}}, nil)
+
+	release := make(chan struct{})
+	go func() {
+		_, _ = service.Execute(context.Background(), func() (interface{}, error) {
+			<-release
+			return nil, nil
+		})
+	}()
+	waitFor(t, func() bool { return len(service.bulkhead.slots) == 1 })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := service.Execute(ctx, func() (interface{}, error) { return "never", nil })
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int64(0), service.bulkhead.waiting.Load())
+	close(release)
+}
+
+func TestNewBulkhead_Disabled(t *testing.T) {
+	assert.Nil(t, newBulkhead(nil))
+	assert.Nil(t, newBulkhead(&BulkheadConfig{MaxQueue: 5}))
+}
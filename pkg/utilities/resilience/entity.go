@@ -1,7 +1,11 @@
 package resilience
 
 import (
+	"sync"
+	"time"
+
 	"github.com/skolldire/go-engine/pkg/utilities/circuit_breaker"
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
 	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
 )
@@ -9,10 +13,39 @@ import (
 type Config struct {
 	RetryConfig          *retry_backoff.Config   `mapstructure:"retry_config" json:"retry_config"`
 	CircuitBreakerConfig *circuit_breaker.Config `mapstructure:"circuit_breaker_config" json:"circuit_breaker_config"`
+	// Bulkhead, when set with MaxConcurrent > 0, caps the calls in flight (see BulkheadConfig)
+	Bulkhead *BulkheadConfig `mapstructure:"bulkhead" json:"bulkhead,omitempty"`
+	// RetryBudget, when set with MaxRetries > 0, caps the retries across calls (see
+	// RetryBudgetConfig and WithRetryBudget)
+	RetryBudget *RetryBudgetConfig `mapstructure:"retry_budget" json:"retry_budget,omitempty"`
+	// PerAttemptTimeout, when > 0, bounds each attempt made through ExecuteContext (or ExecuteNamedContext) with its
+	// own deadline derived from the caller's context, so a hung attempt leaves time to retry
+	PerAttemptTimeout time.Duration `mapstructure:"per_attempt_timeout" json:"per_attempt_timeout,omitempty"`
+	// Metrics, when set, receives attempt, retry, success, failure and rejection counts
+	// (see MetricsRecorder and NewTelemetryMetrics); nil records nothing.
+	Metrics MetricsRecorder `mapstructure:"-" json:"-"`
+	// Clock is propagated to the retryer and circuit breaker when they do not set their own;
+	// nil uses real time.
+	Clock clock.Clock `mapstructure:"-" json:"-"`
+}
+
+// IsConfigured reports whether a retry, circuit breaker, bulkhead or retry budget config is set. A missing
+// retry or breaker config falls back to package defaults; with_resilience with none set is
+// treated as a config mistake.
+func (c Config) IsConfigured() bool {
+	return c.RetryConfig != nil || c.CircuitBreakerConfig != nil || c.Bulkhead != nil || c.RetryBudget != nil
 }
 
 type Service struct {
-	retryer        *retry_backoff.Retryer
-	circuitBreaker *circuit_breaker.CircuitBreaker
-	logger         logger.Service
+	retryer           *retry_backoff.Retryer
+	circuitBreaker    *circuit_breaker.CircuitBreaker
+	bulkhead          *bulkhead
+	retryBudget       *retryBudget
+	maxRetries        int
+	perAttemptTimeout time.Duration
+	clock             clock.Clock
+	metrics           MetricsRecorder
+	logger            logger.Service
+
+	perAttemptWarnOnce sync.Once
 }
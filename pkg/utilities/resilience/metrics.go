@@ -0,0 +1,79 @@
+package resilience
+
+import (
+	"context"
+
+	"github.com/skolldire/go-engine/pkg/utilities/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Rejection reasons passed to MetricsRecorder.RecordRejection
+const (
+	RejectionCircuitOpen  = "circuit_open"
+	RejectionBulkheadFull = "bulkhead_full"
+	// RejectionTooManyCalls is a half-open circuit breaker refusing calls beyond its probes
+	RejectionTooManyCalls = "too_many_calls"
+)
+
+// MetricsRecorder receives resilience metrics tagged with the operation name given to
+// ExecuteNamed ("" for Execute). RecordAttempt is called for every try of the operation
+// and RecordRetry for every try after the first; each call then ends with exactly one of
+// RecordSuccess, RecordFailure (retries exhausted, a permanent error or the context ended)
+// or RecordRejection (refused by the circuit breaker or the bulkhead without running).
+type MetricsRecorder interface {
+	RecordAttempt(ctx context.Context, operation string)
+	RecordRetry(ctx context.Context, operation string)
+	RecordSuccess(ctx context.Context, operation string)
+	RecordFailure(ctx context.Context, operation string)
+	RecordRejection(ctx context.Context, operation string, reason string)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) RecordAttempt(context.Context, string) {}
+
+func (noopMetrics) RecordRetry(context.Context, string) {}
+
+func (noopMetrics) RecordSuccess(context.Context, string) {}
+
+func (noopMetrics) RecordFailure(context.Context, string) {}
+
+func (noopMetrics) RecordRejection(context.Context, string, string) {}
+
+// telemetryMetrics emits resilience.* counters
+type telemetryMetrics struct {
+	telemetry telemetry.Metrics
+}
+
+// NewTelemetryMetrics records resilience metrics as the counters
+// resilience.{attempts,retries,successes,failures,rejections}, all with an operation
+// attribute; rejections also carry a reason attribute. A nil tel records nothing.
+func NewTelemetryMetrics(tel telemetry.Metrics) MetricsRecorder {
+	if tel == nil {
+		return noopMetrics{}
+	}
+	return &telemetryMetrics{telemetry: tel}
+}
+
+func (r *telemetryMetrics) RecordAttempt(ctx context.Context, operation string) {
+	r.telemetry.Counter(ctx, "resilience.attempts", 1, attribute.String("operation", operation))
+}
+
+func (r *telemetryMetrics) RecordRetry(ctx context.Context, operation string) {
+	r.telemetry.Counter(ctx, "resilience.retries", 1, attribute.String("operation", operation))
+}
+
+func (r *telemetryMetrics) RecordSuccess(ctx context.Context, operation string) {
+	r.telemetry.Counter(ctx, "resilience.successes", 1, attribute.String("operation", operation))
+}
+
+func (r *telemetryMetrics) RecordFailure(ctx context.Context, operation string) {
+	r.telemetry.Counter(ctx, "resilience.failures", 1, attribute.String("operation", operation))
+}
+
+func (r *telemetryMetrics) RecordRejection(ctx context.Context, operation string, reason string) {
+	r.telemetry.Counter(ctx, "resilience.rejections", 1,
+		attribute.String("operation", operation),
+		attribute.String("reason", reason),
+	)
+}
@@ -0,0 +1,166 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/skolldire/go-engine/pkg/utilities/circuit_breaker"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// recordingMetrics counts MetricsRecorder calls as "event:operation[:reason]"
+type recordingMetrics struct {
+	mu     sync.Mutex
+	events map[string]int
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{events: map[string]int{}}
+}
+
+func (r *recordingMetrics) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events[event]++
+}
+
+func (r *recordingMetrics) RecordAttempt(_ context.Context, op string) { r.add("attempt:" + op) }
+
+func (r *recordingMetrics) RecordRetry(_ context.Context, op string) { r.add("retry:" + op) }
+
+func (r *recordingMetrics) RecordSuccess(_ context.Context, op string) { r.add("success:" + op) }
+
+func (r *recordingMetrics) RecordFailure(_ context.Context, op string) { r.add("failure:" + op) }
+
+func (r *recordingMetrics) RecordRejection(_ context.Context, op, reason string) {
+	r.add("rejection:" + op + ":" + reason)
+}
+
+func TestService_ExecuteNamed_RecordsRetriesAndSuccess(t *testing.T) {
+	metrics := newRecordingMetrics()
+	service := NewResilienceService(Config{
+		RetryConfig: &retry_backoff.Config{MaxRetries: 3, InitialWaitTime: 1, MaxWaitTime: 1},
+		Metrics:     metrics,
+	}, nil)
+
+	var calls int
+	_, err := service.ExecuteNamed(context.Background(), "orders.get", func() (interface{}, error) {
+		calls++
+		if calls < 3 {
+			return nil, errors.New("transient")
+		}
+		return "ok", nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		"attempt:orders.get": 3,
+		"retry:orders.get":   2,
+		"success:orders.get": 1,
+	}, metrics.events)
+}
+
+func TestService_ExecuteNamed_RecordsFailure(t *testing.T) {
+	metrics := newRecordingMetrics()
+	service := NewResilienceService(Config{
+		RetryConfig: &retry_backoff.Config{MaxRetries: 1, InitialWaitTime: 1, MaxWaitTime: 1},
+		Metrics:     metrics,
+	}, nil)
+
+	_, err := service.ExecuteNamed(context.Background(), "orders.put", func() (interface{}, error) {
+		return nil, retry_backoff.Permanent(errors.New("invalid"))
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, map[string]int{"attempt:orders.put": 1, "failure:orders.put": 1}, metrics.events)
+}
+
+func TestService_ExecuteNamed_RecordsRejections(t *testing.T) {
+	metrics := newRecordingMetrics()
+	service := NewResilienceService(Config{
+		RetryConfig: &retry_backoff.Config{MaxRetries: 1, InitialWaitTime: 1, MaxWaitTime: 1},
+		CircuitBreakerConfig: &circuit_breaker.Config{
+			Name:                 "metrics",
+			Timeout:              60,
+			RequestThreshold:     1,
+			FailureRateThreshold: 1,
+		},
+		Bulkhead: &BulkheadConfig{MaxConcurrent: 1},
+		Metrics:  metrics,
+	}, nil)
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = service.ExecuteNamed(context.Background(), "slow", func() (interface{}, error) {
+			<-release
+			return nil, errors.New("down")
+		})
+	}()
+	waitFor(t, func() bool { return len(service.bulkhead.slots) == 1 })
+
+	_, err := service.ExecuteNamed(context.Background(), "fast", func() (interface{}, error) { return nil, nil })
+	assert.ErrorIs(t, err, ErrBulkheadFull)
+
+	close(release)
+	<-done
+	assert.True(t, service.IsCircuitOpen())
+
+	_, err = service.ExecuteNamed(context.Background(), "fast", func() (interface{}, error) { return nil, nil })
+	assert.ErrorIs(t, err, circuit_breaker.ErrCircuitOpen)
+
+	assert.Equal(t, 1, metrics.events["rejection:fast:"+RejectionBulkheadFull])
+	assert.Equal(t, 1, metrics.events["rejection:fast:"+RejectionCircuitOpen])
+	assert.Equal(t, 1, metrics.events["failure:slow"])
+	assert.Zero(t, metrics.events["attempt:fast"], "rejected calls make no attempts")
+}
+
+func TestService_Execute_NoMetricsRecorder(t *testing.T) {
+	service := NewResilienceService(Config{RetryConfig: &retry_backoff.Config{MaxRetries: 1}}, nil)
+
+	result, err := service.Execute(context.Background(), func() (interface{}, error) { return "ok", nil })
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", result)
+}
+
+// counterTelemetry records Counter names and attributes
+type counterTelemetry struct {
+	counters []string
+	attrs    [][]attribute.KeyValue
+}
+
+func (c *counterTelemetry) Counter(_ context.Context, name string, _ int64, attrs ...attribute.KeyValue) {
+	c.counters = append(c.counters, name)
+	c.attrs = append(c.attrs, attrs)
+}
+
+func (c *counterTelemetry) Gauge(context.Context, string, float64, ...attribute.KeyValue) {}
+
+func (c *counterTelemetry) Histogram(context.Context, string, float64, ...attribute.KeyValue) {}
+
+func TestNewTelemetryMetrics(t *testing.T) {
+	tel := &counterTelemetry{}
+	recorder := NewTelemetryMetrics(tel)
+
+	ctx := context.Background()
+	recorder.RecordAttempt(ctx, "op")
+	recorder.RecordRetry(ctx, "op")
+	recorder.RecordSuccess(ctx, "op")
+	recorder.RecordFailure(ctx, "op")
+	recorder.RecordRejection(ctx, "op", RejectionCircuitOpen)
+
+	assert.Equal(t, []string{
+		"resilience.attempts", "resilience.retries", "resilience.successes",
+		"resilience.failures", "resilience.rejections",
+	}, tel.counters)
+	assert.Contains(t, tel.attrs[4], attribute.String("reason", RejectionCircuitOpen))
+	assert.Contains(t, tel.attrs[0], attribute.String("operation", "op"))
+
+	assert.NotPanics(t, func() { NewTelemetryMetrics(nil).RecordAttempt(ctx, "op") })
+}
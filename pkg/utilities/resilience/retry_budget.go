@@ -0,0 +1,85 @@
+package resilience
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
+)
+
+// DefaultRetryBudgetWindow is the RetryBudgetConfig.Window used when it is zero
+const DefaultRetryBudgetWindow = time.Minute
+
+// ErrRetryBudgetExhausted is returned by Execute, wrapping the last operation error, when a
+// retry is needed but the retry budget has no retries left in the current window
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// RetryBudgetConfig caps the retries made across Execute calls, so retries do not amplify
+// a downstream outage. First attempts are never limited.
+type RetryBudgetConfig struct {
+	// Name shares one budget between every Service configured with it, such as the
+	// clients of one dependency; empty gives the Service a budget of its own. The first
+	// config registered under a name wins.
+	Name string `mapstructure:"name" json:"name,omitempty"`
+	// MaxRetries is the number of retries allowed per Window; 0 disables the budget
+	MaxRetries int `mapstructure:"max_retries" json:"max_retries"`
+	// Window is the length of the fixed window MaxRetries applies to; 0 uses
+	// DefaultRetryBudgetWindow
+	Window time.Duration `mapstructure:"window" json:"window"`
+}
+
+// WithRetryBudget returns a copy of c with a per-Service retry budget of
+// maxRetriesPerWindow retries every window
+func (c Config) WithRetryBudget(maxRetriesPerWindow int, window time.Duration) Config {
+	c.RetryBudget = &RetryBudgetConfig{MaxRetries: maxRetriesPerWindow, Window: window}
+	return c
+}
+
+// namedRetryBudgets holds the budgets of RetryBudgetConfigs with a Name
+var namedRetryBudgets sync.Map
+
+// retryBudget counts retries in fixed windows
+type retryBudget struct {
+	mu          sync.Mutex
+	clock       clock.Clock
+	max         int
+	window      time.Duration
+	windowStart time.Time
+	used        int
+}
+
+// newRetryBudget returns nil when cfg does not enable a budget, and the registered budget
+// when cfg has a Name
+func newRetryBudget(cfg *RetryBudgetConfig, clk clock.Clock) *retryBudget {
+	if cfg == nil || cfg.MaxRetries <= 0 {
+		return nil
+	}
+	window := cfg.Window
+	if window <= 0 {
+		window = DefaultRetryBudgetWindow
+	}
+	b := &retryBudget{clock: clock.OrReal(clk), max: cfg.MaxRetries, window: window}
+	if cfg.Name == "" {
+		return b
+	}
+	registered, _ := namedRetryBudgets.LoadOrStore(cfg.Name, b)
+	return registered.(*retryBudget)
+}
+
+// take spends one retry, false when the current window has none left
+func (b *retryBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+	if now.Sub(b.windowStart) >= b.window {
+		b.windowStart = now
+		b.used = 0
+	}
+	if b.used >= b.max {
+		return false
+	}
+	b.used++
+	return true
+}
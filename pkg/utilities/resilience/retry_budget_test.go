@@ -0,0 +1,92 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+	"github.com/stretchr/testify/assert"
+)
+
+func failingOperation(calls *int) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		*calls++
+		return nil, errors.New("downstream unavailable")
+	}
+}
+
+func TestService_Execute_RetryBudgetCapsRetries(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	service := NewResilienceService(Config{
+		RetryConfig: &retry_backoff.Config{MaxRetries: 3, InitialWaitTime: 1, MaxWaitTime: 1},
+	}.WithRetryBudget(2, time.Minute), nil)
+	service.retryBudget.clock = fake // only the window; the retry waits stay on real time
+
+	var calls int
+	_, err := service.Execute(context.Background(), failingOperation(&calls))
+	assert.ErrorIs(t, err, ErrRetryBudgetExhausted)
+	assert.ErrorContains(t, err, "downstream unavailable")
+	assert.Equal(t, 3, calls, "the first attempt and the 2 budgeted retries")
+
+	calls = 0
+	_, err = service.Execute(context.Background(), failingOperation(&calls))
+	assert.ErrorIs(t, err, ErrRetryBudgetExhausted)
+	assert.Equal(t, 1, calls, "first attempts are not limited, retries are")
+
+	fake.Advance(time.Minute)
+	calls = 0
+	var succeeded bool
+	_, err = service.Execute(context.Background(), func() (interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("transient")
+		}
+		succeeded = true
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, succeeded, "the budget is refilled in the next window")
+}
+
+func TestService_Execute_RetryBudgetNotSpentOnLastAttempt(t *testing.T) {
+	service := NewResilienceService(Config{
+		RetryConfig: &retry_backoff.Config{MaxRetries: 1, InitialWaitTime: 1, MaxWaitTime: 1},
+	}.WithRetryBudget(1, time.Minute), nil)
+
+	var calls int
+	_, err := service.Execute(context.Background(), failingOperation(&calls))
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrRetryBudgetExhausted, "MaxRetries ran out before the budget")
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 1, service.retryBudget.used)
+}
+
+func TestService_Execute_NamedRetryBudgetShared(t *testing.T) {
+	cfg := Config{
+		RetryConfig: &retry_backoff.Config{MaxRetries: 2, InitialWaitTime: 1, MaxWaitTime: 1},
+		RetryBudget: &RetryBudgetConfig{Name: t.Name(), MaxRetries: 1},
+	}
+	users := NewResilienceService(cfg, nil)
+	orders := NewResilienceService(cfg, nil)
+	assert.Same(t, users.retryBudget, orders.retryBudget)
+
+	var calls int
+	_, err := users.Execute(context.Background(), failingOperation(&calls))
+	assert.ErrorIs(t, err, ErrRetryBudgetExhausted)
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	_, err = orders.Execute(context.Background(), failingOperation(&calls))
+	assert.ErrorIs(t, err, ErrRetryBudgetExhausted)
+	assert.Equal(t, 1, calls, "the other service spent the shared budget")
+}
+
+func TestNewRetryBudget_Disabled(t *testing.T) {
+	assert.Nil(t, newRetryBudget(nil, nil))
+	assert.Nil(t, newRetryBudget(&RetryBudgetConfig{Window: time.Second}, nil))
+	assert.Equal(t, DefaultRetryBudgetWindow, newRetryBudget(&RetryBudgetConfig{MaxRetries: 1}, nil).window)
+}
@@ -3,8 +3,10 @@ package resilience
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/skolldire/go-engine/pkg/utilities/circuit_breaker"
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
 	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
 	"github.com/sony/gobreaker"
@@ -15,6 +17,8 @@ var (
 )
 
 func NewResilienceService(config Config, log logger.Service) *Service {
+	config = withDefaultSubConfigs(config)
+	config = propagateClock(config)
 	return &Service{
 		retryer: retry_backoff.NewRetryer(retry_backoff.Dependencies{
 			RetryConfig: config.RetryConfig,
@@ -24,18 +28,165 @@ func NewResilienceService(config Config, log logger.Service) *Service {
 			Config: config.CircuitBreakerConfig,
 			Log:    log,
 		}),
-		logger: log,
+		bulkhead:          newBulkhead(config.Bulkhead),
+		retryBudget:       newRetryBudget(config.RetryBudget, config.Clock),
+		maxRetries:        retry_backoff.MaxRetriesOrDefault(config.RetryConfig.MaxRetries),
+		perAttemptTimeout: config.PerAttemptTimeout,
+		clock:             clock.OrReal(config.Clock),
+		metrics:           metricsOrNoop(config.Metrics),
+		logger:            log,
 	}
 }
 
+// WithCircuitBreaker returns a Service that runs through its own circuit breaker built from
+// cfg but shares everything else with rs, including the bulkhead and the retry budget, so
+// calls through either count against the same concurrency and retry limits.
+func (rs *Service) WithCircuitBreaker(cfg circuit_breaker.Config) *Service {
+	if cfg.Clock == nil {
+		cfg.Clock = rs.clock
+	}
+	return &Service{
+		retryer: rs.retryer,
+		circuitBreaker: circuit_breaker.NewCircuitBreaker(circuit_breaker.Dependencies{
+			Config: &cfg,
+			Log:    rs.logger,
+		}),
+		bulkhead:          rs.bulkhead,
+		retryBudget:       rs.retryBudget,
+		maxRetries:        rs.maxRetries,
+		perAttemptTimeout: rs.perAttemptTimeout,
+		clock:             rs.clock,
+		metrics:           rs.metrics,
+		logger:            rs.logger,
+	}
+}
+
+// metricsOrNoop replaces a nil recorder with one that records nothing
+func metricsOrNoop(recorder MetricsRecorder) MetricsRecorder {
+	if recorder == nil {
+		return noopMetrics{}
+	}
+	return recorder
+}
+
+// withDefaultSubConfigs fills a missing retry or circuit breaker config with an empty one,
+// which the retryer and breaker complete with their package defaults
+func withDefaultSubConfigs(config Config) Config {
+	if config.RetryConfig == nil {
+		config.RetryConfig = &retry_backoff.Config{}
+	}
+	if config.CircuitBreakerConfig == nil {
+		config.CircuitBreakerConfig = &circuit_breaker.Config{}
+	}
+	return config
+}
+
+// propagateClock copies config.Clock into the retry and circuit breaker configs
+// that have none, without mutating the caller's configs
+func propagateClock(config Config) Config {
+	if config.Clock == nil {
+		return config
+	}
+	if config.RetryConfig != nil && config.RetryConfig.Clock == nil {
+		retryCfg := *config.RetryConfig
+		retryCfg.Clock = config.Clock
+		config.RetryConfig = &retryCfg
+	}
+	if config.CircuitBreakerConfig != nil && config.CircuitBreakerConfig.Clock == nil {
+		cbCfg := *config.CircuitBreakerConfig
+		cbCfg.Clock = config.Clock
+		config.CircuitBreakerConfig = &cbCfg
+	}
+	return config
+}
+
+// Execute runs operation with retries inside the circuit breaker. With a bulkhead, the call
+// first takes a slot (held across its retries) or fails with ErrBulkheadFull; rejected
+// calls are not counted by the circuit breaker. Config.PerAttemptTimeout does not apply
+// because operation has no context to cancel (a warning is logged once); use
+// ExecuteContext for that.
 func (rs *Service) Execute(ctx context.Context,
 	operation func() (interface{}, error)) (interface{}, error) {
+	return rs.ExecuteNamed(ctx, "", operation)
+}
+
+// ExecuteContext is Execute for operations that take a context. Each attempt receives a
+// context derived from ctx, bounded by Config.PerAttemptTimeout when set: an attempt that
+// times out is retried while ctx is still live.
+func (rs *Service) ExecuteContext(ctx context.Context,
+	operation func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	return rs.execute(ctx, "", operation)
+}
+
+// ExecuteNamed is Execute with an operation name that tags the Config.Metrics metrics
+func (rs *Service) ExecuteNamed(ctx context.Context, name string,
+	operation func() (interface{}, error)) (interface{}, error) {
+	rs.warnPerAttemptTimeoutIgnored(ctx)
+	return rs.execute(ctx, name, func(context.Context) (interface{}, error) {
+		return operation()
+	})
+}
+
+// ExecuteNamedContext is ExecuteContext with an operation name that tags the Config.Metrics metrics
+func (rs *Service) ExecuteNamedContext(ctx context.Context, name string,
+	operation func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	return rs.execute(ctx, name, operation)
+}
+
+// warnPerAttemptTimeoutIgnored logs once that PerAttemptTimeout is set but the
+// context-less Execute/ExecuteNamed cannot apply it
+func (rs *Service) warnPerAttemptTimeoutIgnored(ctx context.Context) {
+	if rs.perAttemptTimeout <= 0 || rs.logger == nil {
+		return
+	}
+	rs.perAttemptWarnOnce.Do(func() {
+		rs.logger.Warn(ctx, "per_attempt_timeout is ignored by Execute/ExecuteNamed; use ExecuteContext or ExecuteNamedContext",
+			map[string]interface{}{"per_attempt_timeout": rs.perAttemptTimeout.String()})
+	})
+}
+
+func (rs *Service) execute(ctx context.Context, name string,
+	operation func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	ctx, cancel, err := withBudgetShare(ctx, rs.clock)
+	if err != nil {
+		rs.metrics.RecordFailure(ctx, name)
+		return nil, err
+	}
+	defer cancel()
+
+	if rs.bulkhead != nil {
+		if err := rs.bulkhead.acquire(ctx); err != nil {
+			if errors.Is(err, ErrBulkheadFull) {
+				rs.metrics.RecordRejection(ctx, name, RejectionBulkheadFull)
+				if rs.logger != nil {
+					rs.logger.Warn(ctx, "bulkhead full, rejecting request", nil)
+				}
+			} else {
+				rs.metrics.RecordFailure(ctx, name)
+			}
+			return nil, err
+		}
+		defer rs.bulkhead.release()
+	}
+
 	result, err := rs.circuitBreaker.Execute(ctx, func() (interface{}, error) {
 		var opResult interface{}
+		var attempts int
 
 		retryErr := rs.retryer.Do(ctx, func() error {
+			attempts++
+			rs.metrics.RecordAttempt(ctx, name)
+			if attempts > 1 {
+				rs.metrics.RecordRetry(ctx, name)
+			}
+
 			var err error
-			opResult, err = operation()
+			opResult, err = rs.attempt(ctx, operation)
+			if err != nil && attempts <= rs.maxRetries && rs.retryBudget != nil &&
+				ctx.Err() == nil && !retry_backoff.IsPermanent(err) && !rs.retryBudget.take() {
+				// a retry would follow; fail now rather than spend it
+				return retry_backoff.Permanent(fmt.Errorf("%w: %w", ErrRetryBudgetExhausted, err))
+			}
 			return err
 		})
 
@@ -50,18 +201,36 @@ func (rs *Service) Execute(ctx context.Context,
 	})
 
 	if err != nil {
-		if errors.Is(err, circuit_breaker.ErrCircuitOpen) {
+		switch {
+		case errors.Is(err, circuit_breaker.ErrCircuitOpen):
+			rs.metrics.RecordRejection(ctx, name, RejectionCircuitOpen)
 			if rs.logger != nil {
 				rs.logger.Warn(ctx, "circuit breaker open, rejecting request", nil)
 			}
+		case errors.Is(err, circuit_breaker.ErrTooManyCalls):
+			rs.metrics.RecordRejection(ctx, name, RejectionTooManyCalls)
+		default:
+			rs.metrics.RecordFailure(ctx, name)
 		}
 
 		return nil, err
 	}
 
+	rs.metrics.RecordSuccess(ctx, name)
 	return result, nil
 }
 
+// attempt runs one try of operation under its own PerAttemptTimeout deadline, if any
+func (rs *Service) attempt(ctx context.Context,
+	operation func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	if rs.perAttemptTimeout <= 0 {
+		return operation(ctx)
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, rs.perAttemptTimeout)
+	defer cancel()
+	return operation(attemptCtx)
+}
+
 func (rs *Service) CircuitBreakerState() string {
 	return rs.circuitBreaker.StateAsString()
 }
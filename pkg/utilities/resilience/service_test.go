@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/skolldire/go-engine/pkg/utilities/circuit_breaker"
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
+	"github.com/skolldire/go-engine/pkg/utilities/logger"
 	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
 	"github.com/stretchr/testify/assert"
 )
@@ -24,6 +27,24 @@ func TestNewResilienceService(t *testing.T) {
 	assert.NotNil(t, service)
 }
 
+func TestNewResilienceService_MissingSubConfigUsesDefaults(t *testing.T) {
+	retryOnly := NewResilienceService(Config{RetryConfig: &retry_backoff.Config{MaxRetries: 1}}, nil)
+	result, err := retryOnly.Execute(context.Background(), func() (interface{}, error) { return "ok", nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", result)
+
+	breakerOnly := NewResilienceService(Config{CircuitBreakerConfig: &circuit_breaker.Config{Name: "cb"}}, nil)
+	assert.NotNil(t, breakerOnly)
+}
+
+func TestConfig_IsConfigured(t *testing.T) {
+	assert.False(t, Config{}.IsConfigured())
+	assert.True(t, Config{RetryConfig: &retry_backoff.Config{}}.IsConfigured())
+	assert.True(t, Config{CircuitBreakerConfig: &circuit_breaker.Config{}}.IsConfigured())
+	assert.True(t, Config{Bulkhead: &BulkheadConfig{MaxConcurrent: 10}}.IsConfigured())
+	assert.True(t, Config{}.WithRetryBudget(10, time.Minute).IsConfigured())
+}
+
 func TestService_Execute_Success(t *testing.T) {
 	config := Config{
 		RetryConfig: &retry_backoff.Config{
@@ -94,3 +115,135 @@ func TestService_IsCircuitOpen(t *testing.T) {
 	isOpen := service.IsCircuitOpen()
 	assert.False(t, isOpen) // Initially closed
 }
+
+func TestService_CircuitRecovery_FakeClock(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	service := NewResilienceService(Config{
+		RetryConfig: &retry_backoff.Config{MaxRetries: 1, InitialWaitTime: 10, MaxWaitTime: 1},
+		CircuitBreakerConfig: &circuit_breaker.Config{
+			Name:                 "test",
+			MaxRequests:          1,
+			Timeout:              30, // seconds
+			RequestThreshold:     1,
+			FailureRateThreshold: 1,
+		},
+		Clock: fake,
+	}, nil)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := service.Execute(context.Background(), func() (interface{}, error) {
+			return nil, errors.New("down")
+		})
+		done <- err
+	}()
+	for fake.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	fake.Advance(10 * time.Millisecond) // release the single retry wait
+	assert.Error(t, <-done)
+	assert.True(t, service.IsCircuitOpen())
+
+	fake.Advance(31 * time.Second)
+	assert.Equal(t, "half-open", service.CircuitBreakerState())
+
+	result, err := service.Execute(context.Background(), func() (interface{}, error) {
+		return "up", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "up", result)
+	assert.Equal(t, "closed", service.CircuitBreakerState())
+}
+
+func TestPropagateClock_DoesNotMutateCallerConfig(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	retryCfg := &retry_backoff.Config{MaxRetries: 1}
+	cbCfg := &circuit_breaker.Config{Name: "test"}
+
+	cfg := propagateClock(Config{RetryConfig: retryCfg, CircuitBreakerConfig: cbCfg, Clock: fake})
+
+	assert.Same(t, fake, cfg.RetryConfig.Clock)
+	assert.Same(t, fake, cfg.CircuitBreakerConfig.Clock)
+	assert.Nil(t, retryCfg.Clock)
+	assert.Nil(t, cbCfg.Clock)
+}
+
+func TestService_ExecuteContext_PerAttemptTimeout(t *testing.T) {
+	service := NewResilienceService(Config{
+		RetryConfig:       &retry_backoff.Config{MaxRetries: 1, InitialWaitTime: 1, MaxWaitTime: 1},
+		PerAttemptTimeout: 20 * time.Millisecond,
+	}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var attempts int
+	result, err := service.ExecuteContext(ctx, func(attemptCtx context.Context) (interface{}, error) {
+		attempts++
+		if attempts == 1 {
+			<-attemptCtx.Done() // hangs until its own deadline, well before ctx's
+			return nil, attemptCtx.Err()
+		}
+		return "ok", nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", result)
+	assert.Equal(t, 2, attempts)
+	assert.NoError(t, ctx.Err(), "the overall deadline was not consumed by the first attempt")
+}
+
+func TestService_ExecuteNamedContext_PerAttemptTimeout(t *testing.T) {
+	service := NewResilienceService(Config{
+		RetryConfig:       &retry_backoff.Config{MaxRetries: 1},
+		PerAttemptTimeout: time.Second,
+	}, nil)
+
+	_, err := service.ExecuteNamedContext(context.Background(), "op", func(attemptCtx context.Context) (interface{}, error) {
+		_, ok := attemptCtx.Deadline()
+		assert.True(t, ok, "the attempt is bounded by PerAttemptTimeout")
+		return nil, nil
+	})
+	assert.NoError(t, err)
+}
+
+// warnCounter counts Warn calls and discards everything else
+type warnCounter struct {
+	logger.Service
+	warns int
+}
+
+func (w *warnCounter) Warn(context.Context, string, map[string]interface{}) { w.warns++ }
+
+func TestService_ExecuteNamed_WarnsOncePerAttemptTimeoutIgnored(t *testing.T) {
+	log := &warnCounter{Service: logger.NewNoop()}
+	service := NewResilienceService(Config{
+		RetryConfig:       &retry_backoff.Config{MaxRetries: 1},
+		PerAttemptTimeout: time.Second,
+	}, log)
+
+	for i := 0; i < 3; i++ {
+		_, err := service.ExecuteNamed(context.Background(), "op", func() (interface{}, error) { return nil, nil })
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, log.warns)
+
+	_, err := service.ExecuteNamedContext(context.Background(), "op", func(context.Context) (interface{}, error) { return nil, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, 1, log.warns, "context-aware calls do not warn")
+}
+
+func TestService_ExecuteContext_NoPerAttemptTimeout(t *testing.T) {
+	service := NewResilienceService(Config{RetryConfig: &retry_backoff.Config{MaxRetries: 1}}, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	_, err := service.ExecuteContext(ctx, func(attemptCtx context.Context) (interface{}, error) {
+		deadline, _ := attemptCtx.Deadline()
+		want, _ := ctx.Deadline()
+		assert.Equal(t, want, deadline)
+		return nil, nil
+	})
+	assert.NoError(t, err)
+}
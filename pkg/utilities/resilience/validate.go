@@ -0,0 +1,87 @@
+package resilience
+
+import "fmt"
+
+// ConfigError reports an out-of-range resilience setting; Field is the YAML path
+// relative to the resilience block (e.g. "retry_config.max_retries")
+type ConfigError struct {
+	Field   string
+	Message string
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid resilience setting '%s': %s", e.Field, e.Message)
+}
+
+// ValidateConfig checks retry, circuit breaker, bulkhead, retry budget and timeout parameter
+// ranges. Zero values are accepted because the retryer and breaker replace them with their
+// defaults; negative or out-of-range values are reported instead of being silently defaulted.
+func ValidateConfig(cfg Config) []error {
+	var errors []error
+
+	if r := cfg.RetryConfig; r != nil {
+		if r.MaxRetries < 0 {
+			errors = append(errors, &ConfigError{Field: "retry_config.max_retries", Message: "must be >= 0"})
+		}
+		if r.InitialWaitTime < 0 {
+			errors = append(errors, &ConfigError{Field: "retry_config.initial_wait_time", Message: "must be >= 0"})
+		}
+		if r.MaxWaitTime < 0 {
+			errors = append(errors, &ConfigError{Field: "retry_config.max_wait_time", Message: "must be >= 0"})
+		}
+		if r.BackoffFactor < 0 || (r.BackoffFactor > 0 && r.BackoffFactor < 1) {
+			errors = append(errors, &ConfigError{Field: "retry_config.backoff_factor", Message: "must be >= 1 (0 uses the default)"})
+		}
+		if r.JitterFactor < 0 || r.JitterFactor > 1 {
+			errors = append(errors, &ConfigError{Field: "retry_config.jitter_factor", Message: "must be between 0 and 1"})
+		}
+		if r.InitialInterval < 0 {
+			errors = append(errors, &ConfigError{Field: "retry_config.initial_interval", Message: "must be >= 0"})
+		}
+		if r.MaxInterval < 0 {
+			errors = append(errors, &ConfigError{Field: "retry_config.max_interval", Message: "must be >= 0"})
+		}
+		if r.Multiplier < 0 || (r.Multiplier > 0 && r.Multiplier < 1) {
+			errors = append(errors, &ConfigError{Field: "retry_config.multiplier", Message: "must be >= 1 (0 uses backoff_factor)"})
+		}
+		if r.Jitter < 0 || r.Jitter > 1 {
+			errors = append(errors, &ConfigError{Field: "retry_config.jitter", Message: "must be between 0 and 1"})
+		}
+	}
+
+	if cb := cfg.CircuitBreakerConfig; cb != nil {
+		if cb.Interval < 0 {
+			errors = append(errors, &ConfigError{Field: "circuit_breaker_config.interval", Message: "must be >= 0"})
+		}
+		if cb.Timeout < 0 {
+			errors = append(errors, &ConfigError{Field: "circuit_breaker_config.timeout", Message: "must be >= 0"})
+		}
+		if cb.FailureRateThreshold < 0 || cb.FailureRateThreshold > 1 {
+			errors = append(errors, &ConfigError{Field: "circuit_breaker_config.failure_rate_threshold", Message: "must be between 0 and 1"})
+		}
+	}
+
+	if b := cfg.Bulkhead; b != nil {
+		if b.MaxConcurrent < 0 {
+			errors = append(errors, &ConfigError{Field: "bulkhead.max_concurrent", Message: "must be >= 0"})
+		}
+		if b.MaxQueue < 0 {
+			errors = append(errors, &ConfigError{Field: "bulkhead.max_queue", Message: "must be >= 0"})
+		}
+	}
+
+	if rb := cfg.RetryBudget; rb != nil {
+		if rb.MaxRetries < 0 {
+			errors = append(errors, &ConfigError{Field: "retry_budget.max_retries", Message: "must be >= 0"})
+		}
+		if rb.Window < 0 {
+			errors = append(errors, &ConfigError{Field: "retry_budget.window", Message: "must be >= 0"})
+		}
+	}
+
+	if cfg.PerAttemptTimeout < 0 {
+		errors = append(errors, &ConfigError{Field: "per_attempt_timeout", Message: "must be >= 0"})
+	}
+
+	return errors
+}
@@ -0,0 +1,113 @@
+package resilience
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/circuit_breaker"
+	"github.com/skolldire/go-engine/pkg/utilities/retry_backoff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     Config
+		wantFields []string
+	}{
+		{
+			name:   "empty config",
+			config: Config{},
+		},
+		{
+			name: "zero values use defaults",
+			config: Config{
+				RetryConfig:          &retry_backoff.Config{},
+				CircuitBreakerConfig: &circuit_breaker.Config{},
+			},
+		},
+		{
+			name: "valid ranges",
+			config: Config{
+				RetryConfig:          &retry_backoff.Config{MaxRetries: 5, InitialWaitTime: 100, MaxWaitTime: 5, BackoffFactor: 1.5, JitterFactor: 0.3},
+				CircuitBreakerConfig: &circuit_breaker.Config{Interval: 60, Timeout: 30, FailureRateThreshold: 0.5},
+			},
+		},
+		{
+			name: "negative retry settings",
+			config: Config{
+				RetryConfig: &retry_backoff.Config{MaxRetries: -1, InitialWaitTime: -1, MaxWaitTime: -1, BackoffFactor: -2, JitterFactor: -0.1},
+			},
+			wantFields: []string{
+				"retry_config.max_retries",
+				"retry_config.initial_wait_time",
+				"retry_config.max_wait_time",
+				"retry_config.backoff_factor",
+				"retry_config.jitter_factor",
+			},
+		},
+		{
+			name:       "shrinking backoff factor",
+			config:     Config{RetryConfig: &retry_backoff.Config{BackoffFactor: 0.5}},
+			wantFields: []string{"retry_config.backoff_factor"},
+		},
+		{
+			name:       "jitter above one",
+			config:     Config{RetryConfig: &retry_backoff.Config{JitterFactor: 1.5}},
+			wantFields: []string{"retry_config.jitter_factor"},
+		},
+		{
+			name: "invalid backoff curve",
+			config: Config{RetryConfig: &retry_backoff.Config{
+				InitialInterval: -1, MaxInterval: -1, Multiplier: 0.5, Jitter: 1.2,
+			}},
+			wantFields: []string{
+				"retry_config.initial_interval",
+				"retry_config.max_interval",
+				"retry_config.multiplier",
+				"retry_config.jitter",
+			},
+		},
+		{
+			name: "breaker out of range",
+			config: Config{
+				CircuitBreakerConfig: &circuit_breaker.Config{Interval: -1, Timeout: -1, FailureRateThreshold: 1.2},
+			},
+			wantFields: []string{
+				"circuit_breaker_config.interval",
+				"circuit_breaker_config.timeout",
+				"circuit_breaker_config.failure_rate_threshold",
+			},
+		},
+		{
+			name:       "negative bulkhead",
+			config:     Config{Bulkhead: &BulkheadConfig{MaxConcurrent: -1, MaxQueue: -1}},
+			wantFields: []string{"bulkhead.max_concurrent", "bulkhead.max_queue"},
+		},
+		{
+			name:       "negative retry budget",
+			config:     Config{RetryBudget: &RetryBudgetConfig{MaxRetries: -1, Window: -time.Second}},
+			wantFields: []string{"retry_budget.max_retries", "retry_budget.window"},
+		},
+		{
+			name:       "negative per-attempt timeout",
+			config:     Config{PerAttemptTimeout: -time.Second},
+			wantFields: []string{"per_attempt_timeout"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateConfig(tt.config)
+
+			var fields []string
+			for _, err := range errs {
+				cfgErr, ok := err.(*ConfigError)
+				if assert.True(t, ok, "error %v is %T", err, err) {
+					fields = append(fields, cfgErr.Field)
+				}
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
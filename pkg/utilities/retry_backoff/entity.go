@@ -3,6 +3,7 @@ package retry_backoff
 import (
 	"time"
 
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
 )
 
@@ -12,6 +13,8 @@ const (
 	DefaultMaxRetries      = 3
 	DefaultBackoffFactor   = 2.0
 	DefaultJitterFactor    = 0.2
+	// DefaultMaxRetryAfter caps a server-requested RetryAfter delay when MaxRetryAfter is unset
+	DefaultMaxRetryAfter = 5 * time.Minute
 )
 
 type Retryer struct {
@@ -25,6 +28,30 @@ type Config struct {
 	MaxRetries      int           `mapstructure:"max_retries" json:"max_retries"`
 	BackoffFactor   float64       `mapstructure:"backoff_factor" json:"backoff_factor"`
 	JitterFactor    float64       `mapstructure:"jitter_factor" json:"jitter_factor"`
+	// InitialInterval, Multiplier and MaxInterval override InitialWaitTime, BackoffFactor
+	// and MaxWaitTime when set. The intervals are plain durations ("250ms", "30s"), not
+	// counts of milliseconds and seconds.
+	InitialInterval time.Duration `mapstructure:"initial_interval" json:"initial_interval,omitempty"`
+	Multiplier      float64       `mapstructure:"multiplier" json:"multiplier,omitempty"`
+	MaxInterval     time.Duration `mapstructure:"max_interval" json:"max_interval,omitempty"`
+	// Jitter, between 0 and 1, switches to full jitter: each wait is drawn from
+	// [(1-Jitter)*d, d] for the capped exponential delay d, so Jitter 1 spreads retries
+	// over [0, d]. It replaces JitterFactor, which adds up to JitterFactor*d on top of d.
+	Jitter float64 `mapstructure:"jitter" json:"jitter,omitempty"`
+	// MaxRetryAfter caps a server-requested delay (see RetryAfter), a plain duration;
+	// 0 uses DefaultMaxRetryAfter
+	MaxRetryAfter time.Duration `mapstructure:"max_retry_after" json:"max_retry_after,omitempty"`
+	// JitterSource provides the random values used for jitter; nil uses math/rand.
+	// Inject NewSeededJitterSource in tests to make the backoff sequence deterministic.
+	JitterSource JitterSource `mapstructure:"-" json:"-"`
+	// Clock times the waits between attempts; nil uses real time.
+	Clock clock.Clock `mapstructure:"-" json:"-"`
+}
+
+// JitterSource returns pseudo-random values in [0.0, 1.0); *rand.Rand satisfies it
+// but is not safe for concurrent use (see NewSeededJitterSource).
+type JitterSource interface {
+	Float64() float64
 }
 
 type Dependencies struct {
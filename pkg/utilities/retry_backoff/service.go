@@ -2,10 +2,14 @@ package retry_backoff
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
+	"sync"
 	"time"
+
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
 )
 
 func NewRetryer(d Dependencies) *Retryer {
@@ -16,6 +20,22 @@ func NewRetryer(d Dependencies) *Retryer {
 		MaxRetries:      d.RetryConfig.MaxRetries,
 		BackoffFactor:   d.RetryConfig.BackoffFactor,
 		JitterFactor:    d.RetryConfig.JitterFactor,
+		Jitter:          min(max(d.RetryConfig.Jitter, 0), 1),
+		JitterSource:    d.RetryConfig.JitterSource,
+		MaxRetryAfter:   d.RetryConfig.MaxRetryAfter,
+		Clock:           clock.OrReal(d.RetryConfig.Clock),
+	}
+	if settings.MaxRetryAfter <= 0 {
+		settings.MaxRetryAfter = DefaultMaxRetryAfter
+	}
+	if d.RetryConfig.InitialInterval > 0 {
+		settings.InitialWaitTime = d.RetryConfig.InitialInterval
+	}
+	if d.RetryConfig.Multiplier > 0 {
+		settings.BackoffFactor = d.RetryConfig.Multiplier
+	}
+	if d.RetryConfig.MaxInterval > 0 {
+		settings.MaxWaitTime = d.RetryConfig.MaxInterval
 	}
 	return &Retryer{
 		config: settings,
@@ -45,13 +65,21 @@ func validateConfig(cfg *Config) {
 	}
 }
 
+// MaxRetriesOrDefault returns the MaxRetries a Retryer uses for a configured value
+func MaxRetriesOrDefault(maxRetries int) int {
+	if maxRetries <= 0 {
+		return DefaultMaxRetries
+	}
+	return maxRetries
+}
+
 func (r *Retryer) Do(ctx context.Context, operation func() error) error {
 	var err error
 
 	for attempt := 0; attempt <= r.config.MaxRetries; attempt++ {
 		err = operation()
 
-		if err == nil || ctx.Err() != nil {
+		if err == nil || ctx.Err() != nil || IsPermanent(err) {
 			return err
 		}
 
@@ -60,6 +88,13 @@ func (r *Retryer) Do(ctx context.Context, operation func() error) error {
 		}
 
 		waitTime := r.calculateWaitTime(attempt)
+		if hint, ok := RetryAfterHint(err); ok && hint > waitTime {
+			waitTime = max(min(hint, r.config.MaxRetryAfter), waitTime)
+			if deadline, ok := ctx.Deadline(); ok && r.config.Clock.Now().Add(waitTime).After(deadline) {
+				// the server asks for more time than is left: fail now rather than wait it out
+				return fmt.Errorf("retry after %s exceeds the context deadline: %w", waitTime, err)
+			}
+		}
 
 		if r.logger != nil {
 			r.logger.Debug(ctx, "retrying operation after error",
@@ -70,7 +105,7 @@ func (r *Retryer) Do(ctx context.Context, operation func() error) error {
 		}
 
 		select {
-		case <-time.After(waitTime):
+		case <-r.config.Clock.After(waitTime):
 		case <-ctx.Done():
 			return ctx.Err()
 		}
@@ -85,10 +120,72 @@ func (r *Retryer) Do(ctx context.Context, operation func() error) error {
 	return wrappedErr
 }
 
+// Permanent marks err as not worth retrying: Retryer.Do returns it without further
+// attempts. errors.Is and errors.As still see the wrapped error. Permanent(nil) is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err, or any error it wraps, was marked with Permanent
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+type permanentError struct {
+	err error
+}
+
+func (p *permanentError) Error() string { return p.err.Error() }
+
+func (p *permanentError) Unwrap() error { return p.err }
+
+// RetryAfter attaches a server-requested delay (e.g. an HTTP Retry-After header) to err.
+// Retryer.Do waits at least d before the next attempt, even above MaxWaitTime, up to
+// Config.MaxRetryAfter; when the wait would pass the context deadline it returns err at
+// once instead. RetryAfter(nil, d) is nil.
+func RetryAfter(err error, d time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &retryAfterError{err: err, after: d}
+}
+
+// RetryAfterHint returns the delay attached with RetryAfter to err or any error it wraps
+func RetryAfterHint(err error) (time.Duration, bool) {
+	var r *retryAfterError
+	if errors.As(err, &r) {
+		return r.after, true
+	}
+	return 0, false
+}
+
+type retryAfterError struct {
+	err   error
+	after time.Duration
+}
+
+func (r *retryAfterError) Error() string { return r.err.Error() }
+
+func (r *retryAfterError) Unwrap() error { return r.err }
+
 func (r *Retryer) calculateWaitTime(attempt int) time.Duration {
-	baseWaitTime := r.config.InitialWaitTime * time.Duration(math.Pow(r.config.BackoffFactor, float64(attempt)))
+	// computed in float64 so fractional factors (1.5) are not truncated; a delay past
+	// MaxWaitTime (or that overflows) is capped before converting back to a Duration
+	baseWaitTime := r.config.MaxWaitTime
+	if base := float64(r.config.InitialWaitTime) * math.Pow(r.config.BackoffFactor, float64(attempt)); base < float64(r.config.MaxWaitTime) {
+		baseWaitTime = time.Duration(base)
+	}
+
+	if r.config.Jitter > 0 {
+		// full jitter: a random wait within the top Jitter fraction of the capped delay
+		return baseWaitTime - time.Duration(r.jitter()*r.config.Jitter*float64(baseWaitTime))
+	}
 
-	jitter := time.Duration(rand.Float64() * r.config.JitterFactor * float64(baseWaitTime))
+	jitter := time.Duration(r.jitter() * r.config.JitterFactor * float64(baseWaitTime))
 	waitTime := baseWaitTime + jitter
 
 	if waitTime > r.config.MaxWaitTime {
@@ -97,3 +194,26 @@ func (r *Retryer) calculateWaitTime(attempt int) time.Duration {
 
 	return waitTime
 }
+
+func (r *Retryer) jitter() float64 {
+	if r.config.JitterSource != nil {
+		return r.config.JitterSource.Float64()
+	}
+	return rand.Float64()
+}
+
+// NewSeededJitterSource returns a deterministic JitterSource safe for concurrent use
+func NewSeededJitterSource(seed int64) JitterSource {
+	return &seededJitterSource{rnd: rand.New(rand.NewSource(seed))}
+}
+
+type seededJitterSource struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func (s *seededJitterSource) Float64() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rnd.Float64()
+}
@@ -3,9 +3,11 @@ package retry_backoff
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/skolldire/go-engine/pkg/utilities/clock"
 	"github.com/skolldire/go-engine/pkg/utilities/logger"
 	"github.com/stretchr/testify/assert"
 )
@@ -74,28 +76,169 @@ func TestRetryer_Do_Error(t *testing.T) {
 }
 
 func TestRetryer_Do_ContextCancelled(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
 	retryer := NewRetryer(Dependencies{
-		RetryConfig: &Config{MaxRetries: 3, InitialWaitTime: 200},
+		RetryConfig: &Config{MaxRetries: 3, InitialWaitTime: 200, MaxWaitTime: 10, Clock: fake},
 		Logger:      nil,
 	})
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Cancel context during wait to test cancellation during retry wait
+	// Cancel the context once the retryer is blocked waiting on the (never advanced) clock
 	go func() {
-		time.Sleep(100 * time.Millisecond)
+		for fake.Waiters() == 0 {
+			time.Sleep(time.Millisecond)
+		}
 		cancel()
 	}()
 
+	attempts := 0
 	err := retryer.Do(ctx, func() error {
+		attempts++
 		return errors.New("test")
 	})
 
-	assert.Error(t, err)
-	// The retryer may return either the operation error or ctx.Err() depending on timing
-	// Both are valid behaviors - we just verify that an error is returned
-	assert.True(t, err != nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryer_Do_FakeClockAdvancesBackoff(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	retryer := NewRetryer(Dependencies{
+		RetryConfig: &Config{MaxRetries: 2, InitialWaitTime: 100, MaxWaitTime: 10, JitterFactor: 0, Clock: fake},
+	})
+
+	done := make(chan error, 1)
+	attempts := 0
+	go func() {
+		done <- retryer.Do(context.Background(), func() error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("transient")
+			}
+			return nil
+		})
+	}()
+
+	// Backoff is 100ms then 200ms; advancing the fake clock releases each wait
+	for _, wait := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond} {
+		for fake.Waiters() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		fake.Advance(wait)
+	}
+
+	assert.NoError(t, <-done)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetryer_Do_PermanentErrorStopsRetries(t *testing.T) {
+	retryer := NewRetryer(Dependencies{
+		RetryConfig: &Config{MaxRetries: 3, InitialWaitTime: 1, MaxWaitTime: 1},
+	})
+
+	sentinel := errors.New("condition failed")
+	attempts := 0
+	err := retryer.Do(context.Background(), func() error {
+		attempts++
+		return fmt.Errorf("put item: %w", Permanent(sentinel))
+	})
+
+	assert.ErrorIs(t, err, sentinel)
+	assert.True(t, IsPermanent(err))
+	assert.Equal(t, 1, attempts)
+}
+
+func TestPermanent(t *testing.T) {
+	assert.Nil(t, Permanent(nil))
+	assert.False(t, IsPermanent(errors.New("transient")))
+	assert.Equal(t, "boom", Permanent(errors.New("boom")).Error())
+}
+
+func TestRetryer_Do_RetryAfterRaisesWait(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	retryer := NewRetryer(Dependencies{
+		RetryConfig: &Config{MaxRetries: 1, InitialWaitTime: 100, MaxWaitTime: 1, JitterFactor: 0, Clock: fake},
+	})
+
+	done := make(chan error, 1)
+	attempts := 0
+	go func() {
+		done <- retryer.Do(context.Background(), func() error {
+			attempts++
+			if attempts == 1 {
+				return RetryAfter(errors.New("throttled"), 5*time.Second)
+			}
+			return nil
+		})
+	}()
+
+	for fake.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// The 100ms backoff is raised to the 5s hint, above MaxWaitTime
+	fake.Advance(time.Second)
+	assert.Equal(t, 1, fake.Waiters())
+	fake.Advance(4 * time.Second)
+
+	assert.NoError(t, <-done)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRetryer_Do_RetryAfterIsCapped(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	retryer := NewRetryer(Dependencies{
+		RetryConfig: &Config{MaxRetries: 1, InitialWaitTime: 100, MaxWaitTime: 1, MaxRetryAfter: 2 * time.Second, Clock: fake},
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		attempts := 0
+		done <- retryer.Do(context.Background(), func() error {
+			attempts++
+			if attempts == 1 {
+				return RetryAfter(errors.New("throttled"), time.Hour)
+			}
+			return nil
+		})
+	}()
+
+	for fake.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// The one-hour hint is held to MaxRetryAfter
+	fake.Advance(2 * time.Second)
+	assert.NoError(t, <-done)
+}
+
+func TestRetryer_Do_RetryAfterPastDeadlineFailsFast(t *testing.T) {
+	retryer := NewRetryer(Dependencies{RetryConfig: &Config{MaxRetries: 3}})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	throttled := errors.New("throttled")
+	attempts := 0
+	err := retryer.Do(ctx, func() error {
+		attempts++
+		return RetryAfter(throttled, 2*time.Minute)
+	})
+
+	assert.ErrorIs(t, err, throttled)
+	assert.Equal(t, 1, attempts, "no attempt is made after a wait that would pass the deadline")
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Nil(t, RetryAfter(nil, time.Second))
+
+	err := fmt.Errorf("get: %w", RetryAfter(errors.New("429"), 2*time.Second))
+	hint, ok := RetryAfterHint(err)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, hint)
+	assert.Equal(t, "get: 429", err.Error())
+
+	_, ok = RetryAfterHint(errors.New("transient"))
+	assert.False(t, ok)
 }
 
 func TestRetryer_Do_WithLogger(t *testing.T) {
@@ -172,3 +315,106 @@ func TestRetryer_CalculateWaitTime(t *testing.T) {
 	assert.Greater(t, waitTime, time.Duration(0))
 	assert.LessOrEqual(t, waitTime, retryer.config.MaxWaitTime)
 }
+
+func TestRetryer_CalculateWaitTime_SeededJitter(t *testing.T) {
+	newSeeded := func() *Retryer {
+		return NewRetryer(Dependencies{
+			RetryConfig: &Config{
+				InitialWaitTime: 100,
+				MaxWaitTime:     10,
+				BackoffFactor:   2.0,
+				JitterFactor:    0.5,
+				JitterSource:    NewSeededJitterSource(42),
+			},
+		})
+	}
+
+	// base wait 100ms, 200ms, 400ms, 800ms plus up to 50% seeded jitter
+	want := []time.Duration{
+		118651418 * time.Nanosecond,
+		206600049 * time.Nanosecond,
+		520818770 * time.Nanosecond,
+		883527481 * time.Nanosecond,
+	}
+
+	for _, retryer := range []*Retryer{newSeeded(), newSeeded()} {
+		for attempt, expected := range want {
+			assert.Equal(t, expected, retryer.calculateWaitTime(attempt))
+		}
+	}
+}
+
+func TestRetryer_CalculateWaitTime_IntervalsOverrideLegacyFields(t *testing.T) {
+	retryer := NewRetryer(Dependencies{RetryConfig: &Config{
+		InitialWaitTime: 100,
+		MaxWaitTime:     10,
+		BackoffFactor:   2,
+		InitialInterval: 50 * time.Millisecond,
+		Multiplier:      3,
+		MaxInterval:     time.Second,
+	}})
+
+	assert.Equal(t, 50*time.Millisecond, retryer.calculateWaitTime(0))
+	assert.Equal(t, 150*time.Millisecond, retryer.calculateWaitTime(1))
+	assert.Equal(t, 450*time.Millisecond, retryer.calculateWaitTime(2))
+	assert.Equal(t, time.Second, retryer.calculateWaitTime(3), "capped at MaxInterval")
+}
+
+func TestRetryer_CalculateWaitTime_FractionalMultiplier(t *testing.T) {
+	retryer := NewRetryer(Dependencies{RetryConfig: &Config{
+		InitialInterval: 100 * time.Millisecond,
+		Multiplier:      1.5,
+		MaxInterval:     time.Second,
+	}})
+
+	assert.Equal(t, 100*time.Millisecond, retryer.calculateWaitTime(0))
+	assert.Equal(t, 150*time.Millisecond, retryer.calculateWaitTime(1))
+	assert.Equal(t, 225*time.Millisecond, retryer.calculateWaitTime(2))
+	assert.Equal(t, 337500*time.Microsecond, retryer.calculateWaitTime(3))
+	assert.Equal(t, time.Second, retryer.calculateWaitTime(6), "capped at MaxInterval")
+	assert.Equal(t, time.Second, retryer.calculateWaitTime(200), "an overflowing delay is capped")
+}
+
+func TestRetryer_CalculateWaitTime_FullJitter(t *testing.T) {
+	retryer := NewRetryer(Dependencies{RetryConfig: &Config{
+		InitialInterval: 100 * time.Millisecond,
+		Multiplier:      2,
+		MaxInterval:     time.Second,
+		Jitter:          0.5,
+		JitterFactor:    0.9, // ignored once Jitter is set
+		JitterSource:    NewSeededJitterSource(7),
+	}})
+
+	for attempt, base := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		base *= time.Millisecond
+		wait := retryer.calculateWaitTime(attempt)
+		assert.GreaterOrEqual(t, wait, base/2, "attempt %d", attempt)
+		assert.LessOrEqual(t, wait, base, "attempt %d", attempt)
+	}
+}
+
+func TestRetryer_CalculateWaitTime_ConcurrentJitteredSchedulesDiffer(t *testing.T) {
+	newJittered := func() *Retryer {
+		return NewRetryer(Dependencies{RetryConfig: &Config{
+			InitialInterval: 100 * time.Millisecond,
+			Multiplier:      2,
+			MaxInterval:     10 * time.Second,
+			Jitter:          1,
+		}})
+	}
+
+	schedules := make([][]time.Duration, 2)
+	done := make(chan struct{})
+	for i := range schedules {
+		go func(retryer *Retryer) {
+			defer func() { done <- struct{}{} }()
+			for attempt := 0; attempt < 5; attempt++ {
+				schedules[i] = append(schedules[i], retryer.calculateWaitTime(attempt))
+			}
+		}(newJittered())
+	}
+	<-done
+	<-done
+
+	assert.NotEqual(t, schedules[0], schedules[1], "jitter spreads the retries of concurrent callers")
+}
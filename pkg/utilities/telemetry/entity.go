@@ -18,6 +18,10 @@ type Config struct {
 	OtelEndpoint   string  `json:"otel_endpoint"`
 	SampleRate     float64 `json:"sample_rate"`
 	Enabled        bool    `json:"enabled"`
+	// RequireExporter makes NewTelemetry fail when the exporter cannot be initialized.
+	// When false, NewTelemetry logs a warning and returns a no-op Telemetry instead,
+	// so a collector outage does not prevent the application from starting.
+	RequireExporter bool `json:"require_exporter"`
 }
 
 type Metrics interface {
@@ -24,6 +24,24 @@ func NewTelemetry(ctx context.Context, config Config) (Telemetry, error) {
 		return &noopTelemetry{}, nil
 	}
 
+	tel, err := newTelemetry(ctx, config)
+	if err != nil {
+		return degrade(config, err)
+	}
+	return tel, nil
+}
+
+// degrade returns a no-op Telemetry when the exporter is optional, or the init error otherwise
+func degrade(config Config, err error) (Telemetry, error) {
+	if config.RequireExporter {
+		return nil, err
+	}
+	log.Printf("[telemetry] warning: exporter initialization failed, telemetry disabled: %v", err)
+	return &noopTelemetry{}, nil
+}
+
+func newTelemetry(ctx context.Context, config Config) (Telemetry, error) {
+
 	res, err := resource.New(ctx,
 		resource.WithAttributes(
 			semconv.ServiceNameKey.String(config.ServiceName),
@@ -54,6 +72,7 @@ func NewTelemetry(ctx context.Context, config Config) (Telemetry, error) {
 		otlpmetricgrpc.WithInsecure(),
 	)
 	if err != nil {
+		_ = traceProvider.Shutdown(ctx)
 		return nil, err
 	}
 
@@ -77,3 +77,16 @@ func TestOperation_Execute_Error(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, testErr, err)
 }
+
+func TestDegrade_ExporterOptional(t *testing.T) {
+	tel, err := degrade(Config{RequireExporter: false}, errors.New("collector unreachable"))
+	assert.NoError(t, err)
+	assert.IsType(t, &noopTelemetry{}, tel)
+}
+
+func TestDegrade_ExporterRequired(t *testing.T) {
+	initErr := errors.New("collector unreachable")
+	tel, err := degrade(Config{RequireExporter: true}, initErr)
+	assert.ErrorIs(t, err, initErr)
+	assert.Nil(t, tel)
+}